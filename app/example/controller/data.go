@@ -15,7 +15,7 @@ import (
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-event/event"
     "github.com/deatil/go-datebin/datebin"
-    "github.com/deatil/go-encoding/encoding"
+    "github.com/deatil/go-encoding/v2/encoding"
     "github.com/deatil/go-pipeline/pipeline"
     "github.com/deatil/go-exception/exception"
     "github.com/deatil/lakego-filesystem/filesystem"
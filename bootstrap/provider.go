@@ -17,6 +17,9 @@ import (
     _ "github.com/deatil/lakego-admin/swagger"
     _ "github.com/deatil/lakego-doak-swagger/swagger/bootstrap"
 
+    // 证书签发
+    _ "github.com/deatil/lakego-doak-ca/ca/bootstrap"
+
     // 静态文件代理模块
     _ "github.com/deatil/lakego-doak-statics/statics/bootstrap"
 
@@ -13,7 +13,7 @@ replace (
 	github.com/deatil/go-crc8 => ./pkg/lakego-pkg/go-crc8
 	github.com/deatil/go-cryptobin => ./pkg/lakego-pkg/go-cryptobin
 	github.com/deatil/go-datebin => ./pkg/lakego-pkg/go-datebin
-	github.com/deatil/go-encoding => ./pkg/lakego-pkg/go-encoding
+	github.com/deatil/go-encoding/v2 => ./pkg/lakego-pkg/go-encoding
 	github.com/deatil/go-event => ./pkg/lakego-pkg/go-event
 	github.com/deatil/go-exception => ./pkg/lakego-pkg/go-exception
 	github.com/deatil/go-filesystem => ./pkg/lakego-pkg/go-filesystem
@@ -26,6 +26,7 @@ replace (
 	github.com/deatil/lakego-doak => ./pkg/lakego-pkg/lakego-doak
 	github.com/deatil/lakego-doak-action-log => ./pkg/lakego-app/doak-action-log
 	github.com/deatil/lakego-doak-admin => ./pkg/lakego-app/doak-admin
+	github.com/deatil/lakego-doak-ca => ./pkg/lakego-app/doak-ca
 	github.com/deatil/lakego-doak-database => ./pkg/lakego-app/doak-database
 	github.com/deatil/lakego-doak-monitor => ./pkg/lakego-app/doak-monitor
 	github.com/deatil/lakego-doak-statics => ./pkg/lakego-app/doak-statics
@@ -39,6 +40,7 @@ require (
 	github.com/deatil/lakego-doak v1.0.1001
 	github.com/deatil/lakego-doak-action-log v0.0.3
 	github.com/deatil/lakego-doak-admin v1.0.0
+	github.com/deatil/lakego-doak-ca v0.0.3
 	github.com/deatil/lakego-doak-database v0.0.3
 	github.com/deatil/lakego-doak-monitor v0.0.0-00010101000000-000000000000
 	github.com/deatil/lakego-doak-statics v0.0.0-00010101000000-000000000000
@@ -78,7 +80,7 @@ require (
 	github.com/deatil/go-crc8 v1.0.10005 // indirect
 	github.com/deatil/go-cryptobin v1.0.1042 // indirect
 	github.com/deatil/go-datebin v1.0.1011 // indirect
-	github.com/deatil/go-encoding v1.0.1009 // indirect
+	github.com/deatil/go-encoding/v2 v2.0.0 // indirect
 	github.com/deatil/go-event v1.0.1007 // indirect
 	github.com/deatil/go-exception v1.0.1002 // indirect
 	github.com/deatil/go-filesystem v1.0.5 // indirect
@@ -1,16 +1,30 @@
 package controller
 
 import (
-    "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
 
     "github.com/deatil/lakego-doak/lakego/router"
 
     adminController "github.com/deatil/lakego-doak-admin/admin/controller"
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
 
     "github.com/deatil/lakego-doak-action-log/action-log/model"
 )
 
+// 操作日志列表配置
+var actionLogResource = supportController.NewResource(supportController.ResourceConfig{
+    Model: model.NewActionLog,
+    Sortable: []string{"id", "time"},
+    DefaultOrder: "time__DESC",
+    Searchable: []string{"name", "url"},
+    Filters: []supportController.Filter{
+        {Field: "time", Param: "time", Type: supportController.FilterRange, Format: supportController.FormatDate},
+        {Field: "method"},
+        {Field: "status", Format: supportController.FormatStatus},
+        {Field: "request_id"},
+    },
+})
+
 /**
  * 操作日志
  *
@@ -33,96 +47,22 @@ type ActionLog struct {
 // @Param end_time   query string false "结束时间"
 // @Param method     query string false "请求方法"
 // @Param status     query string false "状态"
+// @Param request_id query string false "请求ID"
 // @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
 // @Param limit      query string false "每页数量"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /action-log [get]
 // @Security Bearer
 // @x-lakego {"slug": "lakego-admin.action-log.index"}
 func (this *ActionLog) Index(ctx *router.Context) {
-    // 模型
-    logModel := model.NewActionLog()
-
-    // 排序
-    order := ctx.DefaultQuery("order", "time__DESC")
-    orders := this.FormatOrderBy(order)
-    if orders[0] == "" ||
-        (orders[0] != "id" &&
-        orders[0] != "time") {
-        orders[0] = "time"
-    }
-
-    logModel = logModel.Order(orders[0] + " " + orders[1])
-
-    // 搜索条件
-    searchword := ctx.DefaultQuery("searchword", "")
-    if searchword != "" {
-        searchword = "%" + searchword + "%"
-
-        logModel = logModel.Where(
-            model.NewDB().
-                Where("name LIKE ?", searchword).
-                Or("url LIKE ?", searchword),
-        )
-    }
-
-    // 时间条件
-    startTime := ctx.DefaultQuery("start_time", "")
-    if startTime != "" {
-        logModel = logModel.Where("time >= ?", this.FormatDate(startTime))
-    }
-
-    endTime := ctx.DefaultQuery("end_time", "")
-    if endTime != "" {
-        logModel = logModel.Where("time <= ?", this.FormatDate(endTime))
-    }
-
-    // 请求方式
-    method := ctx.DefaultQuery("method", "")
-    if method != "" {
-        logModel = logModel.Where("method = ?", method)
-    }
-
-    status := this.SwitchStatus(ctx.DefaultQuery("status", ""))
-    if status != -1 {
-        logModel = logModel.Where("status = ?", status)
-    }
-
-    // 分页相关
-    start := ctx.DefaultQuery("start", "0")
-    limit := ctx.DefaultQuery("limit", "10")
-
-    newStart := goch.ToInt(start)
-    newLimit := goch.ToInt(limit)
-
-    logModel = logModel.
-        Offset(newStart).
-        Limit(newLimit)
-
-    list := make([]map[string]any, 0)
-
-    // 列表
-    logModel = logModel.Find(&list)
-
-    var total int64
-
-    // 总数
-    err := logModel.
-        Offset(-1).
-        Limit(-1).
-        Count(&total).
-        Error
+    page, err := actionLogResource.List(ctx, model.NewActionLog())
     if err != nil {
         this.Error(ctx, "获取失败")
         return
     }
 
-    this.SuccessWithData(ctx, "获取成功", router.H{
-        "start": start,
-        "limit": limit,
-        "total": total,
-        "list": list,
-    })
+    this.SuccessWithPage(ctx, "获取成功", page)
 }
 
 // 清除 30 天前的数据
@@ -6,8 +6,10 @@ import (
 
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-doak/lakego/gmq"
+    "github.com/deatil/lakego-doak/lakego/trace"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/http/request"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
 
     "github.com/deatil/lakego-doak-action-log/action-log/model"
 )
@@ -90,8 +92,11 @@ func recordLog(ctx *router.Context) {
         name = "操作账号[" + adminId.(string) + "]"
     }
 
-    // 记录数据
-    model.NewDB().Create(&model.ActionLog{
+    // 请求ID
+    requestId := trace.RequestIDFromContext(ctx.Request.Context())
+
+    // 记录数据，数据表缺少字段等错误时记录到日志
+    err := model.NewDB().WithContext(ctx.Request.Context()).Create(&model.ActionLog{
         Name: name,
         Url: path,
         Method: method,
@@ -100,5 +105,9 @@ func recordLog(ctx *router.Context) {
         Time: int(datebin.NowTime()),
         Ip: ip,
         Status: status,
-    })
+        RequestId: requestId,
+    }).Error
+    if err != nil {
+        logger.WithContext(ctx.Request.Context()).Error("[action-log]" + err.Error())
+    }
 }
@@ -17,6 +17,7 @@ type ActionLog struct {
     Time      int    `gorm:"column:time;type:int(10);" json:"time"`
     Ip        string `gorm:"column:ip;type:varchar(50);" json:"ip"`
     Status    string `gorm:"column:status;type:char(3);" json:"status"`
+    RequestId string `gorm:"column:request_id;type:varchar(128);" json:"request_id"`
 }
 
 /*
@@ -3,6 +3,7 @@ package provider
 import (
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
 
     admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
 
@@ -44,6 +45,9 @@ func (this *ActionLog) Register() {
 func (this *ActionLog) Boot() {
     // 路由
     this.loadRoute()
+
+    // 语言包
+    this.loadTranslations()
 }
 
 /**
@@ -75,3 +79,17 @@ func (this *ActionLog) loadRoute() {
     })
 }
 
+/**
+ * 导入语言包
+ */
+func (this *ActionLog) loadTranslations() {
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-action-log/resources/lang")
+
+    this.LoadTranslationsFrom(path, "lakego-action-log")
+
+    // 推送语言包
+    // > go run main.go lakego:publish --tag=action-log-lang --force
+    this.Publishes(this, map[string]string{
+        path: pathTool.ResourcesPath("/lang/lakego-action-log"),
+    }, "action-log-lang")
+}
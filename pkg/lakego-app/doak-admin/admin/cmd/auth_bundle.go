@@ -0,0 +1,188 @@
+package cmd
+
+import (
+    "os"
+    "strings"
+    "path/filepath"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-admin/admin/permission"
+    "github.com/deatil/lakego-doak-admin/admin/repository/bundle"
+)
+
+/**
+ * 导出权限包
+ *
+ * > ./main lakego-admin:export-auth [--output=auth.yaml] [--format=yaml]
+ * > main.exe lakego-admin:export-auth [--output=auth.yaml] [--format=yaml]
+ * > go run main.go lakego-admin:export-auth [--output=auth.yaml] [--format=yaml]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ExportAuthCmd = &command.Command{
+    Use: "lakego-admin:export-auth",
+    Short: "lakego-admin export auth rules and groups.",
+    Example: "{execfile} lakego-admin:export-auth --output=auth.yaml",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        ExportAuth()
+    },
+}
+
+/**
+ * 导入权限包
+ *
+ * > ./main lakego-admin:import-auth [file] [--dry-run] [--strategy=skip|overwrite]
+ * > main.exe lakego-admin:import-auth [file] [--dry-run] [--strategy=skip|overwrite]
+ * > go run main.go lakego-admin:import-auth [file] [--dry-run] [--strategy=skip|overwrite]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ImportAuthCmd = &command.Command{
+    Use: "lakego-admin:import-auth",
+    Short: "lakego-admin import auth rules and groups.",
+    Example: "{execfile} lakego-admin:import-auth auth.yaml --dry-run",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        ImportAuth(args[0])
+    },
+}
+
+var bundleOutput string
+var bundleFormat string
+var bundleDryRun bool
+var bundleStrategy string
+
+func init() {
+    ef := ExportAuthCmd.Flags()
+    ef.StringVarP(&bundleOutput, "output", "o", "", "导出文件，为空时输出到终端")
+    ef.StringVarP(&bundleFormat, "format", "f", "", "格式，可选：json | yaml，默认根据导出文件后缀判断")
+
+    pf := ImportAuthCmd.Flags()
+    pf.StringVarP(&bundleFormat, "format", "f", "", "格式，可选：json | yaml，默认根据文件后缀判断")
+    pf.BoolVarP(&bundleDryRun, "dry-run", "", false, "只检测不写入")
+    pf.StringVarP(&bundleStrategy, "strategy", "s", bundle.StrategySkip, "冲突处理，可选：skip | overwrite")
+}
+
+// 导出权限包
+func ExportAuth() {
+    format := bundleFormat
+    if format == "" {
+        format = bundleFileFormat(bundleOutput)
+    }
+    if format == "" {
+        format = bundle.FormatJSON
+    }
+
+    data, err := bundle.Export()
+    if err != nil {
+        color.Redln("导出失败！原因为：" + err.Error())
+        return
+    }
+
+    content, err := bundle.Encode(data, format)
+    if err != nil {
+        color.Redln("导出失败！原因为：" + err.Error())
+        return
+    }
+
+    if bundleOutput == "" {
+        os.Stdout.Write(content)
+        return
+    }
+
+    if err := os.WriteFile(bundleOutput, content, 0644); err != nil {
+        color.Redln("导出失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("导出成功！" + bundleOutput)
+}
+
+// 导入权限包
+func ImportAuth(file string) {
+    content, err := os.ReadFile(file)
+    if err != nil {
+        color.Redln("读取文件失败！原因为：" + err.Error())
+        return
+    }
+
+    format := bundleFormat
+    if format == "" {
+        format = bundleFileFormat(file)
+    }
+
+    data, err := bundle.Decode(content, format)
+    if err != nil {
+        color.Redln("权限包格式错误！原因为：" + err.Error())
+        return
+    }
+
+    report, err := bundle.Import(data, bundle.Options{
+        DryRun:   bundleDryRun,
+        Strategy: bundleStrategy,
+    })
+
+    for _, change := range report.Changes {
+        line := "[" + change.Action + "] " + change.Type + " " + change.Key
+        if len(change.Fields) > 0 {
+            line += " (" + strings.Join(change.Fields, ",") + ")"
+        }
+        if change.Message != "" {
+            line += " " + change.Message
+        }
+
+        switch change.Action {
+            case bundle.ActionConflict:
+                color.Yellowln(line)
+            case bundle.ActionCreate, bundle.ActionUpdate:
+                color.Greenln(line)
+        }
+    }
+
+    if err != nil {
+        color.Redln("导入失败！原因为：" + err.Error())
+        return
+    }
+
+    summary := "新增 " + goch.ToString(report.Created) +
+        "，更新 " + goch.ToString(report.Updated) +
+        "，未变化 " + goch.ToString(report.Unchanged) +
+        "，冲突 " + goch.ToString(report.Conflicts)
+
+    if report.DryRun {
+        color.Yellowln("检测完成，未写入数据。" + summary)
+        return
+    }
+
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    color.Greenln("导入成功！" + summary)
+}
+
+// 根据文件后缀获取格式
+func bundleFileFormat(file string) string {
+    switch strings.ToLower(filepath.Ext(file)) {
+        case ".json":
+            return bundle.FormatJSON
+        case ".yaml", ".yml":
+            return bundle.FormatYAML
+    }
+
+    return ""
+}
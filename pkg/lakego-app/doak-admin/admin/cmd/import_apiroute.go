@@ -6,7 +6,7 @@ import (
 
     "github.com/deatil/go-hash/hash"
     "github.com/deatil/go-datebin/datebin"
-    "github.com/deatil/go-encoding/encoding"
+    "github.com/deatil/go-encoding/v2/encoding"
     "github.com/deatil/lakego-filesystem/filesystem"
 
     "github.com/deatil/lakego-doak/lakego/array"
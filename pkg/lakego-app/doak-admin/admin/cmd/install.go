@@ -4,10 +4,13 @@ import (
     "os"
     "fmt"
     "strings"
+    "crypto/rand"
+    "encoding/hex"
 
     "github.com/deatil/lakego-filesystem/filesystem"
     "github.com/deatil/lakego-doak/lakego/path"
     "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
 )
@@ -79,8 +82,63 @@ func runInsatll() {
         }
     }
 
+    // 生成签名链接密钥
+    if err := makeSignKey("./.env"); err != nil {
+        fmt.Println("签名链接密钥生成失败：", err)
+        os.Exit(1)
+    }
+
     installFile, _ := os.OpenFile("./install.lock", os.O_RDWR|os.O_CREATE, os.ModePerm)
     installFile.WriteString("")
 
     fmt.Println("\n安装成功。\n")
 }
+
+// 签名链接密钥环境变量
+const signKeyEnv = "LAKEGO_SERVER_SIGNED_URL_KEY"
+
+// 未设置签名链接密钥时生成并写入 env 文件
+func makeSignKey(envFile string) error {
+    if config.New("server").GetString("signed-url.key") != "" {
+        return nil
+    }
+
+    key := make([]byte, 32)
+    if _, err := rand.Read(key); err != nil {
+        return err
+    }
+
+    line := signKeyEnv + "=\"" + hex.EncodeToString(key) + "\""
+
+    data, err := os.ReadFile(envFile)
+    if err != nil && !os.IsNotExist(err) {
+        return err
+    }
+
+    // 替换已有的空配置，没有时添加到结尾
+    lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+    replaced := false
+    for i, l := range lines {
+        name, _, _ := strings.Cut(strings.TrimSpace(l), "=")
+        if name == signKeyEnv {
+            lines[i] = line
+            replaced = true
+        }
+    }
+
+    if !replaced {
+        lines = append(lines, line)
+    }
+
+    content := strings.TrimLeft(strings.Join(lines, "\n"), "\n") + "\n"
+    if err := os.WriteFile(envFile, []byte(content), 0600); err != nil {
+        return err
+    }
+
+    os.Setenv(signKeyEnv, hex.EncodeToString(key))
+
+    fmt.Println("签名链接密钥已写入 [" + envFile + "]")
+
+    return nil
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-admin/admin/stubs"
+)
+
+/**
+ * 生成模块
+ *
+ * > ./main lakego-admin:make-module [name] [--force]
+ * > main.exe lakego-admin:make-module [name] [--force]
+ * > go run main.go lakego-admin:make-module [name] [--force]
+ *
+ * > go run main.go make:module blog
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeModuleCmd = &command.Command{
+    Use: "lakego-admin:make-module",
+    Aliases: []string{"make:module"},
+    Short: "lakego-admin make module.",
+    Example: "{execfile} lakego-admin:make-module [name]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeModule(args[0])
+    },
+}
+
+/**
+ * 生成控制器
+ *
+ * > go run main.go make:controller HotBook [--dir=app/admin] [--force]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeControllerCmd = &command.Command{
+    Use: "lakego-admin:make-controller",
+    Aliases: []string{"make:controller"},
+    Short: "lakego-admin make controller.",
+    Example: "{execfile} lakego-admin:make-controller [name] --dir=[dir]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeController(args[0])
+    },
+}
+
+/**
+ * 生成模型
+ *
+ * > go run main.go make:model HotBook [--dir=app/admin] [--force]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeModelCmd = &command.Command{
+    Use: "lakego-admin:make-model",
+    Aliases: []string{"make:model"},
+    Short: "lakego-admin make model.",
+    Example: "{execfile} lakego-admin:make-model [name] --dir=[dir]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeModel(args[0])
+    },
+}
+
+/**
+ * 生成中间件
+ *
+ * > go run main.go make:middleware CheckIp [--dir=app/admin] [--force]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeMiddlewareCmd = &command.Command{
+    Use: "lakego-admin:make-middleware",
+    Aliases: []string{"make:middleware"},
+    Short: "lakego-admin make middleware.",
+    Example: "{execfile} lakego-admin:make-middleware [name] --dir=[dir]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeMiddleware(args[0])
+    },
+}
+
+/**
+ * 生成脚本
+ *
+ * > go run main.go make:command SyncData [--use=app:sync-data] [--dir=app/admin] [--force]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeCommandCmd = &command.Command{
+    Use: "lakego-admin:make-command",
+    Aliases: []string{"make:command"},
+    Short: "lakego-admin make command.",
+    Example: "{execfile} lakego-admin:make-command [name] --use=[use] --dir=[dir]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeCommand(args[0])
+    },
+}
+
+var makeDir string
+var makeUse string
+var makeForce bool
+
+func init() {
+    for _, c := range []*command.Command{
+        MakeControllerCmd,
+        MakeModelCmd,
+        MakeMiddlewareCmd,
+        MakeCommandCmd,
+    } {
+        c.Flags().StringVarP(&makeDir, "dir", "d", "app/admin", "生成目录，相对程序根目录")
+    }
+
+    for _, c := range []*command.Command{
+        MakeModuleCmd,
+        MakeControllerCmd,
+        MakeModelCmd,
+        MakeMiddlewareCmd,
+        MakeCommandCmd,
+    } {
+        c.Flags().BoolVarP(&makeForce, "force", "f", false, "是否覆盖")
+    }
+
+    MakeCommandCmd.Flags().StringVarP(&makeUse, "use", "u", "", "脚本名称，默认为 app:[name]")
+}
+
+// 生成模块
+func MakeModule(name string) {
+    files, err := stubs.New().MakeModule(name, makeForce)
+    if err != nil {
+        color.Redln("生成模块失败！原因为：" + err.Error())
+        return
+    }
+
+    for _, file := range files {
+        color.Greenln("生成文件：" + file)
+    }
+
+    kebab := str.Kebab(name)
+
+    color.Greenln("生成模块成功！")
+    color.Yellowln("请在 go.work 的 use 中添加 ./pkg/lakego-app/doak-" + kebab)
+    color.Yellowln("请在 go.mod 中添加 replace github.com/deatil/lakego-doak-" + kebab + " => ./pkg/lakego-app/doak-" + kebab)
+    color.Yellowln("请在 bootstrap/provider.go 中引入 _ \"github.com/deatil/lakego-doak-" + kebab + "/" + kebab + "/bootstrap\"")
+}
+
+// 生成控制器
+func MakeController(name string) {
+    data := map[string]string{
+        "controllerName": str.Camel(name),
+        "controllerLowerName": str.LowerCamel(name),
+        "controllerPath": str.Kebab(name),
+    }
+
+    dst := makeDir + "/controller/" + str.Snake(name) + ".go"
+
+    err := stubs.New().Make("controller", dst, data, makeForce)
+    if err != nil {
+        color.Redln("生成控制器失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("生成控制器成功！" + dst)
+}
+
+// 生成模型
+func MakeModel(name string) {
+    data := map[string]string{
+        "modelName": str.Camel(name),
+    }
+
+    dst := makeDir + "/model/" + str.Snake(name) + ".go"
+
+    err := stubs.New().Make("model", dst, data, makeForce)
+    if err != nil {
+        color.Redln("生成模型失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("生成模型成功！" + dst)
+}
+
+// 生成中间件
+func MakeMiddleware(name string) {
+    dst, err := stubs.New().MakeMiddleware(makeDir, name, makeForce)
+    if err != nil {
+        color.Redln("生成中间件失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("生成中间件成功！" + dst)
+}
+
+// 生成脚本
+func MakeCommand(name string) {
+    dst, err := stubs.New().MakeCommand(makeDir, name, makeUse, makeForce)
+    if err != nil {
+        color.Redln("生成脚本失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("生成脚本成功！" + dst)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+    "os"
+    "fmt"
+    "sort"
+    "strings"
+    "text/tabwriter"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+)
+
+/**
+ * 路由列表
+ *
+ * > ./main lakego-admin:route-list [--name=admin.attachment] [--path=/attachment]
+ * > main.exe lakego-admin:route-list [--name=admin.attachment] [--path=/attachment]
+ * > go run main.go lakego-admin:route-list [--name=admin.attachment] [--path=/attachment]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var RouteListCmd = &command.Command{
+    Use: "lakego-admin:route-list",
+    Aliases: []string{"route:list"},
+    Short: "lakego-admin show route list.",
+    Example: "{execfile} lakego-admin:route-list",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        RouteList()
+    },
+}
+
+// 筛选名称
+var routeListName string
+
+// 筛选路径
+var routeListPath string
+
+func init() {
+    pf := RouteListCmd.Flags()
+    pf.StringVarP(&routeListName, "name", "n", "", "筛选路由名称")
+    pf.StringVarP(&routeListPath, "path", "p", "", "筛选路由路径")
+}
+
+// 显示路由列表
+func RouteList() {
+    routes := router.NewRoute().GetRoutes()
+
+    sort.Slice(routes, func(i, j int) bool {
+        if routes[i].Path == routes[j].Path {
+            return routes[i].Method < routes[j].Method
+        }
+
+        return routes[i].Path < routes[j].Path
+    })
+
+    // 路由前缀
+    group := "/" + config.New("admin").GetString("Route.Prefix")
+
+    slugs := routeSlugs()
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "NAME\tMETHOD\tPATH\tMIDDLEWARES\tSLUG")
+
+    for _, v := range routes {
+        info, _ := router.NewName().FindRoute(v.Method, v.Path)
+
+        if routeListName != "" && !strings.Contains(info.Name, routeListName) {
+            continue
+        }
+
+        if routeListPath != "" && !strings.Contains(v.Path, routeListPath) {
+            continue
+        }
+
+        middlewares := make([]string, 0)
+        for _, m := range info.Middlewares {
+            middlewares = append(middlewares, shortHandlerName(router.HandlerName(m)))
+        }
+
+        slug := ""
+        if strings.HasPrefix(v.Path, group + "/") {
+            slug = slugs[v.Method + ":" + strings.TrimPrefix(v.Path, group)]
+        }
+
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+            orDash(info.Name),
+            v.Method,
+            v.Path,
+            orDash(strings.Join(middlewares, ",")),
+            orDash(slug),
+        )
+    }
+
+    w.Flush()
+}
+
+// 权限标识
+func routeSlugs() map[string]string {
+    slugs := make(map[string]string)
+
+    rules := make([]model.AuthRule, 0)
+    err := model.NewAuthRule().
+        Select("url", "method", "slug").
+        Find(&rules).
+        Error
+    if err != nil {
+        return slugs
+    }
+
+    for _, rule := range rules {
+        slugs[strings.ToUpper(rule.Method) + ":" + rule.Url] = rule.Slug
+    }
+
+    return slugs
+}
+
+// 简短函数名称
+func shortHandlerName(name string) string {
+    if i := strings.LastIndex(name, "/"); i >= 0 {
+        name = name[i+1:]
+    }
+
+    return name
+}
+
+// 空值显示
+func orDash(s string) string {
+    if s == "" {
+        return "-"
+    }
+
+    return s
+}
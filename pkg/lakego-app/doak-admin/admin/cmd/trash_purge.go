@@ -0,0 +1,66 @@
+package cmd
+
+import (
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-admin/admin/permission"
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
+)
+
+/**
+ * 彻底删除回收站过期数据
+ *
+ * > ./main lakego-admin:trash-purge [--days=30]
+ * > main.exe lakego-admin:trash-purge [--days=30]
+ * > go run main.go lakego-admin:trash-purge [--days=30]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var TrashPurgeCmd = &command.Command{
+    Use: "lakego-admin:trash-purge",
+    Short: "lakego-admin purge expired trash data.",
+    Example: "{execfile} lakego-admin:trash-purge --days=30",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        TrashPurge()
+    },
+}
+
+var trashDays int
+
+func init() {
+    pf := TrashPurgeCmd.Flags()
+    pf.IntVarP(&trashDays, "days", "d", -1, "保留天数，默认使用配置 trash.retention")
+}
+
+// 彻底删除回收站过期数据
+func TrashPurge() {
+    days := trashDays
+    if days < 0 {
+        days = trash.Retention()
+    }
+
+    if days <= 0 {
+        color.Yellowln("保留天数为 0，不删除数据")
+        return
+    }
+
+    count, err := trash.Clean(days)
+    if err != nil {
+        color.Redln("删除回收站数据失败！原因为：" + err.Error())
+        return
+    }
+
+    if count > 0 {
+        permission.ResetPermission()
+    }
+
+    color.Greenln("删除回收站数据成功！共删除 " + goch.ToString(count) + " 条数据")
+}
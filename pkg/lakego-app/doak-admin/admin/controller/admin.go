@@ -1,9 +1,12 @@
 package controller
 
 import (
+    "errors"
     "strings"
     "encoding/json"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-hash/hash"
     "github.com/deatil/go-tree/tree"
@@ -23,8 +26,28 @@ import (
     authPassword "github.com/deatil/lakego-doak/lakego/auth/password"
     adminValidate "github.com/deatil/lakego-doak-admin/admin/validate/admin"
     adminRepository "github.com/deatil/lakego-doak-admin/admin/repository/admin"
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
 )
 
+// 账号回收站列表配置
+var adminTrashResource = supportController.NewResource(supportController.ResourceConfig{
+    Sortable: []string{"id", "name", "add_time", "deleted_at"},
+    DefaultOrder: "deleted_at__DESC",
+    Searchable: []string{"name", "nickname", "email"},
+    Fields: []string{
+        "id", "name", "nickname",
+        "email", "avatar",
+        "is_root", "status",
+        "add_time", "deleted_at",
+    },
+    Format: func(item map[string]any) map[string]any {
+        item["avatar_url"] = model.AttachmentUrl(goch.ToString(item["avatar"]))
+
+        return item
+    },
+})
+
 /**
  * 管理员
  *
@@ -350,7 +373,7 @@ func (this *Admin) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := adminValidate.Create(post)
+    validateErr := adminValidate.Create(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -449,7 +472,7 @@ func (this *Admin) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := adminValidate.Update(post)
+    validateErr := adminValidate.Update(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -554,9 +577,131 @@ func (this *Admin) Delete(ctx *router.Context) {
         return
     }
 
+    // 重设权限
+    permission.ResetPermission()
+
     this.Success(ctx, "账号删除成功")
 }
 
+// 账号回收站
+// @Summary 账号回收站
+// @Description 已删除的管理员账号列表
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param order      query string false "排序，示例：deleted_at__DESC"
+// @Param searchword query string false "搜索关键字"
+// @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/trash [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.trash"}
+func (this *Admin) Trash(ctx *router.Context) {
+    // 授权数据
+    gadb := model.NewAuthGroupAccess()
+
+    page, err := adminTrashResource.List(ctx, trash.Admin.Query().Scopes(scope.AdminWithAccess(ctx, gadb)))
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 恢复账号
+// @Summary 恢复账号
+// @Description 从回收站恢复管理员账号
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "管理员ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/{id}/restore [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.restore"}
+func (this *Admin) Restore(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "账号ID不能为空")
+        return
+    }
+
+    if !this.inTrash(ctx, id) {
+        this.Error(ctx, "账号信息不存在")
+        return
+    }
+
+    err := trash.Admin.Restore(id)
+    if err != nil {
+        switch err {
+            case trash.ErrDuplicate:
+                this.Error(ctx, "管理员账号或者邮箱已经存在")
+            default:
+                this.Error(ctx, "账号恢复失败")
+        }
+
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "账号恢复成功")
+}
+
+// 彻底删除账号
+// @Summary 彻底删除账号
+// @Description 彻底删除回收站中的管理员账号及其分组授权
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "管理员ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/{id}/purge [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.purge"}
+func (this *Admin) Purge(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "账号ID不能为空")
+        return
+    }
+
+    if !this.inTrash(ctx, id) {
+        this.Error(ctx, "账号信息不存在")
+        return
+    }
+
+    err := trash.Admin.Purge(id)
+    if err != nil {
+        this.Error(ctx, "账号删除失败")
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "账号删除成功")
+}
+
+// 当前账号可管理的回收站账号
+func (this *Admin) inTrash(ctx *router.Context, id string) bool {
+    // 授权数据
+    gadb := model.NewAuthGroupAccess()
+
+    var total int64
+    err := trash.Admin.Query().
+        Scopes(scope.AdminWithAccess(ctx, gadb)).
+        Where("id = ?", id).
+        Count(&total).
+        Error
+
+    return err == nil && total > 0
+}
+
 // 修改账号头像
 // @Summary 修改账号头像
 // @Description 修改管理员账号头像
@@ -601,7 +746,7 @@ func (this *Admin) UpdateAvatar(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := adminValidate.UpdateAvatar(post)
+    validateErr := adminValidate.UpdateAvatar(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -817,6 +962,135 @@ func (this *Admin) Disable(ctx *router.Context) {
     this.Success(ctx, "禁用账号成功")
 }
 
+// 批量启用账号
+// @Summary 批量启用账号
+// @Description 批量启用管理员账号
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "管理员ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/batch/enable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.batch-enable"}
+func (this *Admin) BatchEnable(ctx *router.Context) {
+    this.batchStatus(ctx, 1)
+}
+
+// 批量禁用账号
+// @Summary 批量禁用账号
+// @Description 批量禁用管理员账号
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "管理员ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/batch/disable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.batch-disable"}
+func (this *Admin) BatchDisable(ctx *router.Context) {
+    this.batchStatus(ctx, 0)
+}
+
+// 批量删除账号
+// @Summary 批量删除账号
+// @Description 批量删除管理员账号，删除后进入回收站
+// @Tags 管理员
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "管理员ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /admin/batch [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.admin.batch-delete"}
+func (this *Admin) BatchDelete(ctx *router.Context) {
+    ids, post := this.BatchPost(ctx)
+
+    allowed := this.batchAllowed(ctx, ids)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if err := this.batchCheck(ctx, allowed, id); err != nil {
+                return err
+            }
+
+            return tx.Delete(&model.Admin{
+                ID: id,
+            }).Error
+        })
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 批量修改账号状态
+func (this *Admin) batchStatus(ctx *router.Context, status int) {
+    ids, post := this.BatchPost(ctx)
+
+    allowed := this.batchAllowed(ctx, ids)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if err := this.batchCheck(ctx, allowed, id); err != nil {
+                return err
+            }
+
+            return tx.Model(&model.Admin{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "status": status,
+                }).
+                Error
+        })
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 当前账号可管理的账号
+func (this *Admin) batchAllowed(ctx *router.Context, ids []string) map[string]bool {
+    // 授权数据
+    gadb := model.NewAuthGroupAccess()
+
+    adminIds := make([]string, 0)
+    model.NewAdmin().
+        Scopes(scope.AdminWithAccess(ctx, gadb)).
+        Where("id IN ?", ids).
+        Pluck("id", &adminIds)
+
+    allowed := make(map[string]bool, len(adminIds))
+    for _, id := range adminIds {
+        allowed[id] = true
+    }
+
+    return allowed
+}
+
+// 批量操作检测
+func (this *Admin) batchCheck(ctx *router.Context, allowed map[string]bool, id string) error {
+    adminId, _ := ctx.Get("admin_id")
+    if id == adminId.(string) {
+        return errors.New("你不能修改自己的账号")
+    }
+
+    authAdminId := config.New("auth").GetString("auth.admin-id")
+    if authAdminId == id {
+        return errors.New("当前账号不能被修改")
+    }
+
+    if !allowed[id] {
+        return errors.New("账号信息不存在")
+    }
+
+    return nil
+}
+
 // 账号退出
 // @Summary 账号退出
 // @Description 管理员账号退出
@@ -1,6 +1,8 @@
 package controller
 
 import (
+    "time"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-hash/hash"
     "github.com/deatil/go-datebin/datebin"
@@ -8,13 +10,43 @@ import (
 
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/random"
-    "github.com/deatil/lakego-doak/lakego/facade/storage"
     "github.com/deatil/lakego-doak/lakego/facade/cache"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
     "github.com/deatil/lakego-doak-admin/admin/support/url"
+    "github.com/deatil/lakego-doak-admin/admin/support/route"
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
 )
 
+// 附件回收站列表配置
+var attachmentTrashResource = supportController.NewResource(supportController.ResourceConfig{
+    Sortable: []string{"id", "name", "add_time", "deleted_at"},
+    DefaultOrder: "deleted_at__DESC",
+    Searchable: []string{"name", "extension", "disk"},
+    Format: func(item map[string]any) map[string]any {
+        item["url"] = url.AttachmentUrl(goch.ToString(item["path"]), goch.ToString(item["disk"]))
+
+        return item
+    },
+})
+
+// 附件列表配置
+var attachmentResource = supportController.NewResource(supportController.ResourceConfig{
+    Model: model.NewAttachment,
+    Sortable: []string{"id", "name", "update_time", "add_time"},
+    Searchable: []string{"name", "extension", "disk"},
+    Filters: []supportController.Filter{
+        {Field: "create_time", Param: "time", Type: supportController.FilterRange, Format: supportController.FormatDate},
+        {Field: "status", Format: supportController.FormatStatus},
+    },
+    Format: func(item map[string]any) map[string]any {
+        item["url"] = url.AttachmentUrl(goch.ToString(item["path"]), goch.ToString(item["disk"]))
+
+        return item
+    },
+})
+
 /**
  * 附件
  *
@@ -37,95 +69,21 @@ type Attachment struct {
 // @Param end_time   query string false "结束时间"
 // @Param status     query string false "状态"
 // @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
 // @Param limit      query string false "每页数量"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /attachment [get]
 // @Security Bearer
 // @x-lakego {"slug": "lakego-admin.attachment.index","sort":"151"}
 func (this *Attachment) Index(ctx *router.Context) {
-    // 附件模型
-    attachModel := model.NewAttachment()
-
-    // 排序
-    order := ctx.DefaultQuery("order", "add_time__DESC")
-    orders := this.FormatOrderBy(order)
-    if orders[0] == "" ||
-        (orders[0] != "id" &&
-        orders[0] != "name" &&
-        orders[0] != "update_time" &&
-        orders[0] != "add_time") {
-        orders[0] = "add_time"
-    }
-
-    attachModel = attachModel.Order(orders[0] + " " + orders[1])
-
-    // 搜索条件
-    searchword := ctx.DefaultQuery("searchword", "")
-    if searchword != "" {
-        searchword = "%" + searchword + "%"
-
-        attachModel = attachModel.Where(
-            model.NewDB().
-                Where("name LIKE ?", searchword).
-                Or("extension LIKE ?", searchword).
-                Or("disk LIKE ?", searchword),
-        )
-    }
-
-    // 时间条件
-    startTime := ctx.DefaultQuery("start_time", "")
-    if startTime != "" {
-        attachModel = attachModel.Where("create_time >= ?", this.FormatDate(startTime))
-    }
-
-    endTime := ctx.DefaultQuery("end_time", "")
-    if endTime != "" {
-        attachModel = attachModel.Where("create_time <= ?", this.FormatDate(endTime))
-    }
-
-    status := this.SwitchStatus(ctx.DefaultQuery("status", ""))
-    if status != -1 {
-        attachModel = attachModel.Where("status = ?", status)
-    }
-
-    // 分页相关
-    start := ctx.DefaultQuery("start", "0")
-    limit := ctx.DefaultQuery("limit", "10")
-
-    newStart := goch.ToInt(start)
-    newLimit := goch.ToInt(limit)
-
-    attachModel = attachModel.
-        Offset(newStart).
-        Limit(newLimit)
-
-    list := make([]map[string]any, 0)
-
-    // 列表
-    attachModel = attachModel.Find(&list)
-
-    var total int64
-
-    // 总数
-    err := attachModel.Offset(-1).Limit(-1).Count(&total).Error
+    page, err := attachmentResource.List(ctx, model.NewAttachment())
     if err != nil {
         this.Error(ctx, "获取失败")
         return
     }
 
-    newList := make([]map[string]any, 0)
-    for _, v := range list {
-        v["url"] = url.AttachmentUrl(v["path"].(string), v["disk"].(string))
-        newList = append(newList, v)
-    }
-
     // 数据输出
-    this.SuccessWithData(ctx, "获取成功", router.H{
-        "start": start,
-        "limit": limit,
-        "total": total,
-        "list": newList,
-    })
+    this.SuccessWithPage(ctx, "获取成功", page)
 }
 
 // 附件详情
@@ -197,7 +155,7 @@ func (this *Attachment) Delete(ctx *router.Context) {
         return
     }
 
-    // 附件模型
+    // 附件模型，文件在彻底删除时删除
     err2 := model.NewAttachment().
         Delete(&model.Attachment{
             ID: id,
@@ -208,9 +166,97 @@ func (this *Attachment) Delete(ctx *router.Context) {
         return
     }
 
-    // 删除具体文件
-    storage.NewWithDisk(result["disk"].(string)).
-        Delete(result["path"].(string))
+    // 数据输出
+    this.Success(ctx, "文件删除成功")
+}
+
+// 附件回收站
+// @Summary 附件回收站
+// @Description 已删除的附件列表
+// @Tags 附件
+// @Accept  application/json
+// @Produce application/json
+// @Param order      query string false "排序，示例：deleted_at__DESC"
+// @Param searchword query string false "搜索关键字"
+// @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /attachment/trash [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.attachment.trash","sort":"158"}
+func (this *Attachment) Trash(ctx *router.Context) {
+    page, err := attachmentTrashResource.List(ctx, trash.Attachment.Query())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    // 数据输出
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 附件恢复
+// @Summary 附件恢复
+// @Description 从回收站恢复附件
+// @Tags 附件
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "附件ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /attachment/{id}/restore [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.attachment.restore","sort":"159"}
+func (this *Attachment) Restore(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "文件ID不能为空")
+        return
+    }
+
+    err := trash.Attachment.Restore(id)
+    if err != nil {
+        if err == trash.ErrNotFound {
+            this.Error(ctx, "文件信息不存在")
+        } else {
+            this.Error(ctx, "文件恢复失败")
+        }
+
+        return
+    }
+
+    // 数据输出
+    this.Success(ctx, "文件恢复成功")
+}
+
+// 附件彻底删除
+// @Summary 附件彻底删除
+// @Description 彻底删除回收站中的附件及文件
+// @Tags 附件
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "附件ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /attachment/{id}/purge [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.attachment.purge","sort":"160"}
+func (this *Attachment) Purge(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "文件ID不能为空")
+        return
+    }
+
+    err := trash.Attachment.Purge(id)
+    if err != nil {
+        if err == trash.ErrNotFound {
+            this.Error(ctx, "文件信息不存在")
+        } else {
+            this.Error(ctx, "文件删除失败")
+        }
+
+        return
+    }
 
     // 数据输出
     this.Success(ctx, "文件删除成功")
@@ -350,9 +396,19 @@ func (this *Attachment) DownloadCode(ctx *router.Context) {
     code := hash.MD5(goch.ToString(datebin.NowTime()) + random.String(10))
     cache.New().Put(code, result["id"].(string), 300)
 
+    // 签名下载链接，签名的是下载码
+    downloadUrl, err := route.SignedURL("admin.attachment.download", map[string]any{
+        "code": code,
+    }, nil, 300 * time.Second)
+    if err != nil {
+        this.Error(ctx, "下载链接生成失败")
+        return
+    }
+
     // 数据输出
     this.SuccessWithData(ctx, "获取成功", router.H{
         "code": code,
+        "url":  downloadUrl,
     })
 }
 
@@ -374,7 +430,15 @@ func (this *Attachment) Download(ctx *router.Context) {
         return
     }
 
-    fileId, _ := cache.New().Pull(code)
+    // 下载链接需要有效签名，没有签名或者已过期时拒绝
+    if err := router.CheckSignature(ctx.Request.URL); err != nil {
+        this.ReturnString(ctx, "下载链接已失效")
+        return
+    }
+
+    data, _ := cache.New().Pull(code)
+    fileId := goch.ToString(data)
+
     if fileId == "" {
         this.ReturnString(ctx, "文件ID错误")
         return
@@ -0,0 +1,69 @@
+package controller
+
+import (
+    "time"
+    "testing"
+    "strconv"
+    "strings"
+    "net/url"
+    "net/http"
+    "net/http/httptest"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+var testSignKey = []byte("0123456789abcdef0123456789abcdef")
+
+// 按 router 签名规则生成指定过期时间的链接
+func signDownloadURL(path string, expires int64) string {
+    values := url.Values{}
+    values.Set(router.SignatureExpiresKey, strconv.FormatInt(expires, 10))
+
+    h := hmac.New(sha256.New, testSignKey)
+    h.Write([]byte(path))
+    h.Write([]byte("?"))
+    h.Write([]byte(values.Encode()))
+
+    values.Set(router.SignatureKey, hex.EncodeToString(h.Sum(nil)))
+
+    return path + "?" + values.Encode()
+}
+
+func TestAttachmentDownloadSignature(t *testing.T) {
+    router.SetMode(router.TestMode)
+    router.WithSignKey(testSignKey)
+
+    engine := router.New()
+    engine.GET("/attachment/download/:code", new(Attachment).Download)
+
+    path := "/attachment/download/abc"
+
+    tests := []struct {
+        name string
+        link string
+    }{
+        {"unsigned", path},
+        {"expired", signDownloadURL(path, time.Now().Add(-time.Minute).Unix())},
+        {"tampered", strings.Replace(signDownloadURL("/attachment/download/other", time.Now().Add(time.Minute).Unix()), "other", "abc", 1)},
+    }
+
+    for _, test := range tests {
+        w := httptest.NewRecorder()
+        req := httptest.NewRequest(http.MethodGet, test.link, nil)
+        engine.ServeHTTP(w, req)
+
+        if w.Body.String() != "下载链接已失效" {
+            t.Errorf("%s: got %q", test.name, w.Body.String())
+        }
+    }
+
+    // 签名链接可以通过验证
+    link := signDownloadURL(path, time.Now().Add(time.Minute).Unix())
+    u, _ := url.Parse(link)
+    if err := router.CheckSignature(u); err != nil {
+        t.Errorf("signed link got %v", err)
+    }
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+    "io"
+    "net/http"
+    "path/filepath"
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-admin/admin/permission"
+    "github.com/deatil/lakego-doak-admin/admin/repository/bundle"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
+)
+
+/**
+ * 权限包
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type AuthBundle struct {
+    Base
+}
+
+// 权限包导出
+// @Summary 权限包导出
+// @Description 导出权限菜单、权限分组及分组授权，使用 slug 作为标识
+// @Tags 权限包
+// @Accept  application/json
+// @Produce application/json
+// @Param download query string false "是否下载文件"
+// @Param format   query string false "下载格式，可选：json | yaml。默认：json"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/bundle/export [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-bundle.export"}
+func (this *AuthBundle) Export(ctx *router.Context) {
+    data, err := bundle.Export()
+    if err != nil {
+        this.Error(ctx, "导出失败")
+        return
+    }
+
+    if !goch.ToBool(ctx.DefaultQuery("download", "0")) {
+        this.SuccessWithData(ctx, "获取成功", data)
+        return
+    }
+
+    format := ctx.DefaultQuery("format", bundle.FormatJSON)
+
+    content, err := bundle.Encode(data, format)
+    if err != nil {
+        this.Error(ctx, "导出格式错误")
+        return
+    }
+
+    contentType := "application/json; charset=utf-8"
+    if format != bundle.FormatJSON {
+        contentType = "application/x-yaml; charset=utf-8"
+    }
+
+    filename := "lakego-auth-" + datebin.Now().Format("YmdHis") + "." + format
+
+    ctx.Header("Content-Disposition", "attachment; filename=" + filename)
+    ctx.Data(http.StatusOK, contentType, content)
+}
+
+// 权限包导入
+// @Summary 权限包导入
+// @Description 按标识合并导入权限包，可以上传文件或者直接提交内容
+// @Tags 权限包
+// @Accept  application/json
+// @Produce application/json
+// @Param file     formData file   false "权限包文件"
+// @Param format   query    string false "格式，可选：json | yaml。默认根据内容判断"
+// @Param dry_run  query    string false "只检测不写入"
+// @Param strategy query    string false "冲突处理，可选：skip | overwrite。默认：skip"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/bundle/import [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-bundle.import"}
+func (this *AuthBundle) Import(ctx *router.Context) {
+    format := ctx.Query("format")
+
+    var content []byte
+
+    file, err := ctx.FormFile("file")
+    if err == nil {
+        if format == "" {
+            format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+        }
+
+        f, err := file.Open()
+        if err != nil {
+            this.Error(ctx, "读取文件失败")
+            return
+        }
+        defer f.Close()
+
+        content, err = io.ReadAll(f)
+        if err != nil {
+            this.Error(ctx, "读取文件失败")
+            return
+        }
+    } else {
+        content, err = ctx.GetRawData()
+        if err != nil {
+            this.Error(ctx, "读取数据失败")
+            return
+        }
+    }
+
+    if len(content) == 0 {
+        this.Error(ctx, "权限包不能为空")
+        return
+    }
+
+    data, err := bundle.Decode(content, format)
+    if err != nil {
+        this.Error(ctx, "权限包格式错误")
+        return
+    }
+
+    report, err := bundle.Import(data, bundle.Options{
+        DryRun:   goch.ToBool(ctx.DefaultQuery("dry_run", "0")),
+        Strategy: ctx.DefaultQuery("strategy", bundle.StrategySkip),
+        Ip:       router.GetRequestIp(ctx),
+    })
+    if err != nil {
+        this.ErrorWithData(ctx, "导入失败", code.StatusError, report)
+        return
+    }
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.SuccessWithData(ctx, "导入成功", report)
+}
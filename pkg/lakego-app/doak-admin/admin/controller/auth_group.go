@@ -1,8 +1,11 @@
 package controller
 
 import (
+    "errors"
     "strings"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-tree/tree"
     "github.com/deatil/go-datebin/datebin"
@@ -13,8 +16,19 @@ import (
     "github.com/deatil/lakego-doak-admin/admin/model"
     authGroupValidate "github.com/deatil/lakego-doak-admin/admin/validate/authgroup"
     authGroupRepository "github.com/deatil/lakego-doak-admin/admin/repository/authgroup"
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
+    "github.com/deatil/lakego-doak-admin/admin/permission"
+    "github.com/deatil/lakego-doak-admin/admin/support/batch"
 )
 
+// 权限分组回收站列表配置
+var authGroupTrashResource = supportController.NewResource(supportController.ResourceConfig{
+    Sortable: []string{"id", "title", "add_time", "deleted_at"},
+    DefaultOrder: "deleted_at__DESC",
+    Searchable: []string{"title", "description"},
+})
+
 /**
  * 权限分组
  *
@@ -272,7 +286,7 @@ func (this *AuthGroup) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authGroupValidate.Create(post)
+    validateErr := authGroupValidate.Create(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -348,7 +362,7 @@ func (this *AuthGroup) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authGroupValidate.Update(post)
+    validateErr := authGroupValidate.Update(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -434,6 +448,110 @@ func (this *AuthGroup) Delete(ctx *router.Context) {
         return
     }
 
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "信息删除成功")
+}
+
+// 权限分组回收站
+// @Summary 权限分组回收站
+// @Description 已删除的权限分组列表
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param order      query string false "排序，示例：deleted_at__DESC"
+// @Param searchword query string false "搜索关键字"
+// @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/trash [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.trash"}
+func (this *AuthGroup) Trash(ctx *router.Context) {
+    page, err := authGroupTrashResource.List(ctx, trash.AuthGroup.Query())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 权限分组恢复
+// @Summary 权限分组恢复
+// @Description 从回收站恢复权限分组，上级需要存在
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "权限分组ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/{id}/restore [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.restore"}
+func (this *AuthGroup) Restore(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    err := trash.AuthGroup.Restore(id)
+    if err != nil {
+        switch err {
+            case trash.ErrNotFound:
+                this.Error(ctx, "信息不存在")
+            case trash.ErrParentDeleted:
+                this.Error(ctx, "请先恢复上级分组")
+            default:
+                this.Error(ctx, "信息恢复失败")
+        }
+
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "信息恢复成功")
+}
+
+// 权限分组彻底删除
+// @Summary 权限分组彻底删除
+// @Description 彻底删除回收站中的权限分组及其授权数据
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "权限分组ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/{id}/purge [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.purge"}
+func (this *AuthGroup) Purge(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    err := trash.AuthGroup.Purge(id)
+    if err != nil {
+        switch err {
+            case trash.ErrNotFound:
+                this.Error(ctx, "信息不存在")
+            case trash.ErrHasChildren:
+                this.Error(ctx, "请删除子分组后再操作")
+            default:
+                this.Error(ctx, "信息删除失败")
+        }
+
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
     this.Success(ctx, "信息删除成功")
 }
 
@@ -597,6 +715,243 @@ func (this *AuthGroup) Disable(ctx *router.Context) {
     this.Success(ctx, "禁用成功")
 }
 
+// 权限分组批量启用
+// @Summary 权限分组批量启用
+// @Description 权限分组批量启用
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限分组ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/batch/enable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.batch-enable"}
+func (this *AuthGroup) BatchEnable(ctx *router.Context) {
+    this.batchStatus(ctx, 1)
+}
+
+// 权限分组批量禁用
+// @Summary 权限分组批量禁用
+// @Description 权限分组批量禁用
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限分组ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/batch/disable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.batch-disable"}
+func (this *AuthGroup) BatchDisable(ctx *router.Context) {
+    this.batchStatus(ctx, 0)
+}
+
+// 权限分组批量删除
+// @Summary 权限分组批量删除
+// @Description 权限分组批量删除，子级会先于上级删除，删除后进入回收站
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限分组ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/batch [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.batch-delete"}
+func (this *AuthGroup) BatchDelete(ctx *router.Context) {
+    ids, post := this.BatchPost(ctx)
+
+    parents, err := batch.LoadParents(model.NewAuthGroup())
+    if err != nil {
+        this.Error(ctx, "批量操作失败")
+        return
+    }
+
+    // 子级在前
+    ids = parents.SortByDepth(ids)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if !parents.Has(id) {
+                return errors.New("信息不存在")
+            }
+
+            // 子级
+            var total int64
+            err := tx.Model(&model.AuthGroup{}).
+                Where("parentid = ?", id).
+                Count(&total).
+                Error
+            if err != nil {
+                return err
+            }
+            if total > 0 {
+                return errors.New("请删除子分组后再操作")
+            }
+
+            return tx.Delete(&model.AuthGroup{
+                ID: id,
+            }).Error
+        })
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 权限分组批量移动
+// @Summary 权限分组批量移动
+// @Description 权限分组批量修改上级
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param ids      formData string true  "权限分组ID列表，数组或者逗号分隔"
+// @Param parentid formData string true  "上级ID，顶级为 0"
+// @Param partial  formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/batch/parent [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.batch-parent"}
+func (this *AuthGroup) BatchParent(ctx *router.Context) {
+    ids, post := this.BatchPost(ctx)
+
+    parentid := goch.ToString(post["parentid"])
+    if parentid == "" {
+        this.Error(ctx, "上级ID不能为空")
+        return
+    }
+
+    parents, err := batch.LoadParents(model.NewAuthGroup())
+    if err != nil {
+        this.Error(ctx, "批量操作失败")
+        return
+    }
+
+    if parentid != "0" && !parents.Has(parentid) {
+        this.Error(ctx, "上级分组不存在")
+        return
+    }
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if !parents.Has(id) {
+                return errors.New("信息不存在")
+            }
+
+            if parents.IsDescendant(parentid, id) {
+                return errors.New("上级不能为自身或者子级")
+            }
+
+            return tx.Model(&model.AuthGroup{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "parentid": parentid,
+                }).
+                Error
+        })
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 权限分组批量排序
+// @Summary 权限分组批量排序
+// @Description 权限分组批量排序，items 格式为 [{"id": "ID", "listorder": 100}]
+// @Tags 权限分组
+// @Accept  application/json
+// @Produce application/json
+// @Param items   formData string true  "排序列表"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/group/batch/sort [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-group.batch-listorder"}
+func (this *AuthGroup) BatchListorder(ctx *router.Context) {
+    _, post := this.BatchPost(ctx)
+
+    ids, listorders := this.batchListorders(post["items"])
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            var total int64
+            tx.Model(&model.AuthGroup{}).
+                Where("id = ?", id).
+                Count(&total)
+            if total == 0 {
+                return errors.New("信息不存在")
+            }
+
+            return tx.Model(&model.AuthGroup{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "listorder": listorders[id],
+                }).
+                Error
+        })
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 批量修改状态
+func (this *AuthGroup) batchStatus(ctx *router.Context, status int) {
+    ids, post := this.BatchPost(ctx)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            var total int64
+            tx.Model(&model.AuthGroup{}).
+                Where("id = ?", id).
+                Count(&total)
+            if total == 0 {
+                return errors.New("信息不存在")
+            }
+
+            return tx.Model(&model.AuthGroup{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "status": status,
+                }).
+                Error
+        })
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 格式化排序列表
+func (this *AuthGroup) batchListorders(data any) ([]string, map[string]int) {
+    ids := make([]string, 0)
+    listorders := make(map[string]int)
+
+    items, _ := data.([]any)
+    for _, item := range items {
+        v, ok := item.(map[string]any)
+        if !ok {
+            continue
+        }
+
+        id := goch.ToString(v["id"])
+        if id == "" {
+            continue
+        }
+
+        if _, exists := listorders[id]; !exists {
+            ids = append(ids, id)
+        }
+
+        listorders[id] = goch.ToInt(v["listorder"])
+    }
+
+    return ids, listorders
+}
+
 // 权限分组授权
 // @Summary 权限分组授权
 // @Description 权限分组授权
@@ -1,8 +1,11 @@
 package controller
 
 import (
+    "errors"
     "strings"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-tree/tree"
     "github.com/deatil/go-datebin/datebin"
@@ -12,8 +15,19 @@ import (
     "github.com/deatil/lakego-doak-admin/admin/model"
     authRuleValidate "github.com/deatil/lakego-doak-admin/admin/validate/authrule"
     authRuleRepository "github.com/deatil/lakego-doak-admin/admin/repository/authrule"
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
+    "github.com/deatil/lakego-doak-admin/admin/permission"
+    "github.com/deatil/lakego-doak-admin/admin/support/batch"
 )
 
+// 权限菜单回收站列表配置
+var authRuleTrashResource = supportController.NewResource(supportController.ResourceConfig{
+    Sortable: []string{"id", "title", "url", "add_time", "deleted_at"},
+    DefaultOrder: "deleted_at__DESC",
+    Searchable: []string{"title", "url", "method", "slug"},
+})
+
 /**
  * 权限菜单
  *
@@ -252,7 +266,7 @@ func (this *AuthRule) Create(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authRuleValidate.Create(post)
+    validateErr := authRuleValidate.Create(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -342,7 +356,7 @@ func (this *AuthRule) Update(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := authRuleValidate.Update(post)
+    validateErr := authRuleValidate.Update(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -434,6 +448,110 @@ func (this *AuthRule) Delete(ctx *router.Context) {
         return
     }
 
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "信息删除成功")
+}
+
+// 权限菜单回收站
+// @Summary 权限菜单回收站
+// @Description 已删除的权限菜单列表
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param order      query string false "排序，示例：deleted_at__DESC"
+// @Param searchword query string false "搜索关键字"
+// @Param start      query string false "开始数据量"
+// @Param cursor     query string false "游标，使用游标分页时传入上次返回的 next_cursor 或 prev_cursor"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/trash [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.trash"}
+func (this *AuthRule) Trash(ctx *router.Context) {
+    page, err := authRuleTrashResource.List(ctx, trash.AuthRule.Query())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 权限菜单恢复
+// @Summary 权限菜单恢复
+// @Description 从回收站恢复权限菜单，上级需要存在
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "权限菜单ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/{id}/restore [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.restore"}
+func (this *AuthRule) Restore(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    err := trash.AuthRule.Restore(id)
+    if err != nil {
+        switch err {
+            case trash.ErrNotFound:
+                this.Error(ctx, "信息不存在")
+            case trash.ErrParentDeleted:
+                this.Error(ctx, "请先恢复上级权限")
+            default:
+                this.Error(ctx, "信息恢复失败")
+        }
+
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
+    this.Success(ctx, "信息恢复成功")
+}
+
+// 权限菜单彻底删除
+// @Summary 权限菜单彻底删除
+// @Description 彻底删除回收站中的权限菜单及其授权数据
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "权限菜单ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/{id}/purge [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.purge"}
+func (this *AuthRule) Purge(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    err := trash.AuthRule.Purge(id)
+    if err != nil {
+        switch err {
+            case trash.ErrNotFound:
+                this.Error(ctx, "信息不存在")
+            case trash.ErrHasChildren:
+                this.Error(ctx, "请删除子权限后再操作")
+            default:
+                this.Error(ctx, "信息删除失败")
+        }
+
+        return
+    }
+
+    // 重设权限
+    permission.ResetPermission()
+
     this.Success(ctx, "信息删除成功")
 }
 
@@ -597,6 +715,243 @@ func (this *AuthRule) Disable(ctx *router.Context) {
     this.Success(ctx, "禁用成功")
 }
 
+// 权限菜单批量启用
+// @Summary 权限菜单批量启用
+// @Description 权限菜单批量启用
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限菜单ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/batch/enable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.batch-enable"}
+func (this *AuthRule) BatchEnable(ctx *router.Context) {
+    this.batchStatus(ctx, 1)
+}
+
+// 权限菜单批量禁用
+// @Summary 权限菜单批量禁用
+// @Description 权限菜单批量禁用
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限菜单ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/batch/disable [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.batch-disable"}
+func (this *AuthRule) BatchDisable(ctx *router.Context) {
+    this.batchStatus(ctx, 0)
+}
+
+// 权限菜单批量删除
+// @Summary 权限菜单批量删除
+// @Description 权限菜单批量删除，子级会先于上级删除，删除后进入回收站
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param ids     formData string true  "权限菜单ID列表，数组或者逗号分隔"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/batch [delete]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.batch-delete"}
+func (this *AuthRule) BatchDelete(ctx *router.Context) {
+    ids, post := this.BatchPost(ctx)
+
+    parents, err := batch.LoadParents(model.NewAuthRule())
+    if err != nil {
+        this.Error(ctx, "批量操作失败")
+        return
+    }
+
+    // 子级在前
+    ids = parents.SortByDepth(ids)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if !parents.Has(id) {
+                return errors.New("信息不存在")
+            }
+
+            // 子级
+            var total int64
+            err := tx.Model(&model.AuthRule{}).
+                Where("parentid = ?", id).
+                Count(&total).
+                Error
+            if err != nil {
+                return err
+            }
+            if total > 0 {
+                return errors.New("请删除子权限后再操作")
+            }
+
+            return tx.Delete(&model.AuthRule{
+                ID: id,
+            }).Error
+        })
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 权限菜单批量移动
+// @Summary 权限菜单批量移动
+// @Description 权限菜单批量修改上级
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param ids      formData string true  "权限菜单ID列表，数组或者逗号分隔"
+// @Param parentid formData string true  "上级ID，顶级为 0"
+// @Param partial  formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/batch/parent [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.batch-parent"}
+func (this *AuthRule) BatchParent(ctx *router.Context) {
+    ids, post := this.BatchPost(ctx)
+
+    parentid := goch.ToString(post["parentid"])
+    if parentid == "" {
+        this.Error(ctx, "上级ID不能为空")
+        return
+    }
+
+    parents, err := batch.LoadParents(model.NewAuthRule())
+    if err != nil {
+        this.Error(ctx, "批量操作失败")
+        return
+    }
+
+    if parentid != "0" && !parents.Has(parentid) {
+        this.Error(ctx, "上级权限不存在")
+        return
+    }
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            if !parents.Has(id) {
+                return errors.New("信息不存在")
+            }
+
+            if parents.IsDescendant(parentid, id) {
+                return errors.New("上级不能为自身或者子级")
+            }
+
+            return tx.Model(&model.AuthRule{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "parentid": parentid,
+                }).
+                Error
+        })
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 权限菜单批量排序
+// @Summary 权限菜单批量排序
+// @Description 权限菜单批量排序，items 格式为 [{"id": "ID", "listorder": 100}]
+// @Tags 权限菜单
+// @Accept  application/json
+// @Produce application/json
+// @Param items   formData string true  "排序列表"
+// @Param partial formData string false "是否允许部分成功"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /auth/rule/batch/sort [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.auth-rule.batch-listorder"}
+func (this *AuthRule) BatchListorder(ctx *router.Context) {
+    _, post := this.BatchPost(ctx)
+
+    ids, listorders := this.batchListorders(post["items"])
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            var total int64
+            tx.Model(&model.AuthRule{}).
+                Where("id = ?", id).
+                Count(&total)
+            if total == 0 {
+                return errors.New("信息不存在")
+            }
+
+            return tx.Model(&model.AuthRule{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "listorder": listorders[id],
+                }).
+                Error
+        })
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 批量修改状态
+func (this *AuthRule) batchStatus(ctx *router.Context, status int) {
+    ids, post := this.BatchPost(ctx)
+
+    report, err := this.NewBatch(model.NewDB(), post).
+        Run(ids, func(tx *gorm.DB, id string) error {
+            var total int64
+            tx.Model(&model.AuthRule{}).
+                Where("id = ?", id).
+                Count(&total)
+            if total == 0 {
+                return errors.New("信息不存在")
+            }
+
+            return tx.Model(&model.AuthRule{}).
+                Where("id = ?", id).
+                Updates(map[string]any{
+                    "status": status,
+                }).
+                Error
+        })
+
+    // 重设权限
+    if report.Changed() {
+        permission.ResetPermission()
+    }
+
+    this.BatchResponse(ctx, report, err)
+}
+
+// 格式化排序列表
+func (this *AuthRule) batchListorders(data any) ([]string, map[string]int) {
+    ids := make([]string, 0)
+    listorders := make(map[string]int)
+
+    items, _ := data.([]any)
+    for _, item := range items {
+        v, ok := item.(map[string]any)
+        if !ok {
+            continue
+        }
+
+        id := goch.ToString(v["id"])
+        if id == "" {
+            continue
+        }
+
+        if _, exists := listorders[id]; !exists {
+            ids = append(ids, id)
+        }
+
+        listorders[id] = goch.ToInt(v["listorder"])
+    }
+
+    return ids, listorders
+}
+
 // 清空特定ID权限
 // @Summary 清空特定ID权限
 // @Description 清空特定ID权限
@@ -3,11 +3,16 @@ package controller
 import (
     "strings"
 
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
 
     "github.com/deatil/lakego-doak/lakego/router"
 
+    "github.com/deatil/lakego-doak-admin/admin/support/batch"
     "github.com/deatil/lakego-doak-admin/admin/support/controller"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
 )
 
 /**
@@ -68,3 +73,38 @@ func (this *Base) FormatOrderBy(order string, def ...string) []string {
     return orders
 }
 
+
+// 批量操作请求数据，ids 支持数组及逗号分隔的字符
+func (this *Base) BatchPost(ctx *router.Context) ([]string, map[string]any) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    return batch.ParseIds(post["ids"]), post
+}
+
+// 批量操作，partial 为 true 时允许部分成功
+func (this *Base) NewBatch(db *gorm.DB, post map[string]any) *batch.Batch {
+    return batch.New(db).Partial(goch.ToBool(post["partial"]))
+}
+
+// 批量操作结果输出
+func (this *Base) BatchResponse(ctx *router.Context, report batch.Report, err error) {
+    switch err {
+        case nil:
+            if report.Failed > 0 {
+                this.SuccessWithData(ctx, "批量操作部分成功", report)
+            } else {
+                this.SuccessWithData(ctx, "批量操作成功", report)
+            }
+        case batch.ErrEmptyIds:
+            this.Error(ctx, "ID列表不能为空")
+        case batch.ErrTooManyIds:
+            this.Error(ctx, this.Trans(ctx, "ID数量不能超过 :num", map[string]any{
+                "num": batch.MaxItems,
+            }))
+        case batch.ErrRollback:
+            this.ErrorWithData(ctx, "批量操作失败，已全部回滚", code.StatusError, report)
+        default:
+            this.ErrorWithData(ctx, "批量操作失败", code.StatusError, report)
+    }
+}
@@ -1,6 +1,9 @@
 package controller
 
 import (
+    "fmt"
+
+    "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-hash/hash"
     "github.com/deatil/go-datebin/datebin"
 
@@ -39,10 +42,16 @@ type Passport struct {
 // @Router /passport/captcha [get]
 // @x-lakego {"slug": "lakego-admin.passport.captcha"}
 func (this *Passport) Captcha(ctx *router.Context) {
+    if !captcha.AllowIssue(router.GetRequestIp(ctx)) {
+        this.Error(ctx, "验证码获取过于频繁", code.StatusError)
+        return
+    }
+
     c := captcha.New()
     id, b64s, err := c.Make()
     if err != nil {
         this.Error(ctx, "error", code.StatusError)
+        return
     }
 
     key := config.New("auth").GetString("passport.header-captcha-key")
@@ -50,6 +59,7 @@ func (this *Passport) Captcha(ctx *router.Context) {
     this.SetHeader(ctx, key, id)
     this.SuccessWithData(ctx, "获取成功", router.H{
         "captcha": b64s,
+        "required": this.needCaptcha(ctx),
     })
 }
 
@@ -62,7 +72,7 @@ func (this *Passport) Captcha(ctx *router.Context) {
 // @Param Lakego-Admin-Captcha-Id header string true "验证码字段"
 // @Param name formData string true "账号"
 // @Param password formData string true "密码"
-// @Param captcha formData string true "验证码"
+// @Param captcha formData string false "验证码，登录失败次数达到配置值后必填"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /passport/login [post]
 // @x-lakego {"slug": "lakego-admin.passport.login"}
@@ -71,7 +81,9 @@ func (this *Passport) Login(ctx *router.Context) {
     post := make(map[string]any)
     this.ShouldBindJSON(ctx, &post)
 
-    validateErr := passportValidate.Login(post)
+    needCaptcha := this.needCaptcha(ctx)
+
+    validateErr := passportValidate.Login(this.Validator(ctx), post, needCaptcha)
     if validateErr != "" {
         this.Error(ctx, validateErr, code.LoginError)
         return
@@ -79,16 +91,19 @@ func (this *Passport) Login(ctx *router.Context) {
 
     name := post["name"].(string)
     password := post["password"].(string)
-    captchaCode := post["captcha"].(string)
 
     // 验证码检测
-    key := config.New("auth").GetString("passport.header-captcha-key")
-    captchaId := ctx.GetHeader(key)
+    if needCaptcha {
+        captchaCode := fmt.Sprintf("%v", post["captcha"])
 
-    ok := captcha.New().Verify(captchaId, captchaCode, true)
-    if !ok {
-        this.Error(ctx, "验证码错误", code.LoginError)
-        return
+        key := config.New("auth").GetString("passport.header-captcha-key")
+        captchaId := ctx.GetHeader(key)
+
+        ok := captcha.New().Verify(captchaId, captchaCode, true)
+        if !ok {
+            this.Error(ctx, "验证码错误", code.LoginError)
+            return
+        }
     }
 
     // 用户信息
@@ -98,6 +113,8 @@ func (this *Passport) Login(ctx *router.Context) {
         First(&admin).
         Error
     if err != nil {
+        this.addLoginFailed(ctx)
+
         this.Error(ctx, "账号或者密码错误", code.LoginError)
         return
     }
@@ -105,10 +122,15 @@ func (this *Passport) Login(ctx *router.Context) {
     // 验证密码
     checkStatus := authPassword.CheckPassword(admin["password"].(string), password, admin["password_salt"].(string))
     if !checkStatus {
+        this.addLoginFailed(ctx)
+
         this.Error(ctx, "账号或者密码错误", code.LoginError)
         return
     }
 
+    // 清除登录失败次数
+    cache.New().Forget(this.loginFailedKey(ctx))
+
     // 生成 token
     aud := jwt.GetJwtAud(ctx)
     jwter := auth.NewWithAud(aud)
@@ -124,7 +146,7 @@ func (this *Passport) Login(ctx *router.Context) {
     // 授权 token
     accessToken, err := jwter.MakeAccessToken(tokenData)
     if err != nil {
-        logger.New().Error("[login]" + err.Error())
+        logger.WithContext(ctx).Error("[login]" + err.Error())
 
         this.Error(ctx, "授权token生成失败", code.LoginError)
         return
@@ -133,7 +155,7 @@ func (this *Passport) Login(ctx *router.Context) {
     // 刷新 token
     refreshToken, err := jwter.MakeRefreshToken(tokenData)
     if err != nil {
-        logger.New().Error("[login]" + err.Error())
+        logger.WithContext(ctx).Error("[login]" + err.Error())
 
         this.Error(ctx, "刷新token生成失败", code.LoginError)
         return
@@ -208,7 +230,7 @@ func (this *Passport) RefreshToken(ctx *router.Context) {
     // 授权 token
     accessToken, err := jwter.MakeAccessToken(tokenData)
     if err != nil {
-        logger.New().Error("[login]" + err.Error())
+        logger.WithContext(ctx).Error("[login]" + err.Error())
 
         this.Error(ctx, "生成 access_token 失败", code.JwtRefreshTokenFail)
         return
@@ -292,3 +314,40 @@ func (this *Passport) Logout(ctx *router.Context) {
     // 数据输出
     this.Success(ctx, "退出成功")
 }
+
+// 是否需要验证码
+func (this *Passport) needCaptcha(ctx *router.Context) bool {
+    afterFailed := config.New("auth").GetInt("passport.captcha-after-failed")
+    if afterFailed <= 0 {
+        return true
+    }
+
+    failed, err := cache.New().Get(this.loginFailedKey(ctx))
+    if err != nil || failed == nil {
+        return false
+    }
+
+    return goch.New(failed).ToInt() >= afterFailed
+}
+
+// 记录登录失败次数
+func (this *Passport) addLoginFailed(ctx *router.Context) {
+    if config.New("auth").GetInt("passport.captcha-after-failed") <= 0 {
+        return
+    }
+
+    c := cache.New()
+    key := this.loginFailedKey(ctx)
+
+    if !c.Has(key) {
+        c.Put(key, 1, config.New("auth").GetInt("passport.failed-ttl"))
+        return
+    }
+
+    c.Increment(key)
+}
+
+// 登录失败次数缓存 key
+func (this *Passport) loginFailedKey(ctx *router.Context) string {
+    return "passport-failed:" + hash.MD5(router.GetRequestIp(ctx))
+}
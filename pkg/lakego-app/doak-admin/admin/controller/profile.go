@@ -62,7 +62,7 @@ func (this *Profile) Update(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profileValidate.Update(post)
+    validateErr := profileValidate.Update(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -113,7 +113,7 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profileValidate.UpdateAvatar(post)
+    validateErr := profileValidate.UpdateAvatar(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
@@ -164,7 +164,7 @@ func (this *Profile) UpdatePasssword(ctx *router.Context) {
     this.ShouldBindJSON(ctx, &post)
 
     // 检测
-    validateErr := profileValidate.UpdatePasssword(post)
+    validateErr := profileValidate.UpdatePasssword(this.Validator(ctx), post)
     if validateErr != "" {
         this.Error(ctx, validateErr)
         return
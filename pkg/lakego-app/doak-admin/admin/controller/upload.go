@@ -55,7 +55,9 @@ func (this *Upload) File(ctx *router.Context) {
 
     file, err := ctx.FormFile(conf.GetString("Upload.Field"))
     if err != nil {
-        this.Error(ctx, "上传文件失败，原因：" + err.Error())
+        this.Error(ctx, this.Trans(ctx, "上传文件失败，原因：:reason", map[string]any{
+            "reason": err.Error(),
+        }))
         return
     }
 
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+    "sync"
+    "math"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    lakegoRatelimit "github.com/deatil/lakego-doak/lakego/ratelimit"
+    "github.com/deatil/lakego-doak/lakego/facade/ratelimit"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/response"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
+)
+
+/**
+ * 请求限流
+ *
+ * name 为 `config/ratelimit.yml` 中的限流配置名称
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(name string) router.HandlerFunc {
+    var once sync.Once
+    var handler router.HandlerFunc
+
+    return func(ctx *router.Context) {
+        // 配置在使用时导入
+        once.Do(func() {
+            handler = ratelimit.Handler(name, denied)
+        })
+
+        handler(ctx)
+    }
+}
+
+// 超出限制
+func denied(ctx *router.Context, result lakegoRatelimit.Result) {
+    response.ReturnJsonWithAbort(
+        ctx,
+        false,
+        code.StatusTooManyRequests,
+        "请求过于频繁，请稍后再试",
+        router.H{
+            "retry_after": int(math.Ceil(result.Reset.Seconds())),
+        },
+        http.StatusTooManyRequests,
+    )
+}
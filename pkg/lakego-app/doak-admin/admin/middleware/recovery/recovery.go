@@ -102,7 +102,7 @@ func Handler() router.HandlerFunc {
                 }
 
                 // 记录日志
-                logger.New().Error(logData)
+                logger.WithContext(ctx).Error(logData)
 
                 if brokenPipe {
                     responseData(ctx, "服务器内部异常", responsedata)
@@ -26,6 +26,7 @@ type Admin struct {
     UpdateIp     string `gorm:"column:update_ip;type:varchar(50);" json:"update_ip"`
     AddTime      int    `gorm:"column:add_time;type:int(10);" json:"add_time"`
     AddIp        string `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`
+    DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index;" json:"deleted_at"`
 
     Groups []AuthGroup `gorm:"many2many:auth_group_access;foreignKey:ID;joinForeignKey:AdminId;References:ID;JoinReferences:GroupId"`
     Attachments []Attachment `gorm:"polymorphic:Owner;polymorphicValue:admin;"`
@@ -27,6 +27,7 @@ type Attachment struct {
     CreateTime int    `gorm:"column:create_time;size:10;" json:"create_time"`
     AddTime    int    `gorm:"column:add_time;size:10;" json:"add_time"`
     AddIp      string `gorm:"column:add_ip;size:50;" json:"add_ip"`
+    DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index;" json:"deleted_at"`
 }
 
 func (this *Attachment) BeforeCreate(tx *gorm.DB) error {
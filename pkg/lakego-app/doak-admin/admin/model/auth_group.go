@@ -19,6 +19,7 @@ type AuthGroup struct {
     UpdateIp    string `gorm:"column:update_ip;type:varchar(50);" json:"update_ip"`
     AddTime     int    `gorm:"column:add_time;type:int(10);" json:"add_time"`
     AddIp       string `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`
+    DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index;" json:"deleted_at"`
 
     Admins []Admin `gorm:"many2many:auth_group_access;foreignKey:ID;joinForeignKey:GroupId;References:ID;JoinReferences:AdminId"`
     Rules []AuthRule `gorm:"many2many:auth_rule_access;foreignKey:ID;joinForeignKey:GroupId;References:ID;JoinReferences:RuleId"`
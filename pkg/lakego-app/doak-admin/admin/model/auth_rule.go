@@ -22,6 +22,7 @@ type AuthRule struct {
     UpdateIp    string `gorm:"column:update_ip;size:50;" json:"update_ip"`
     AddTime     int    `gorm:"column:add_time;size:10;" json:"add_time"`
     AddIp       string `gorm:"column:add_ip;size:50;" json:"add_ip"`
+    DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index;" json:"deleted_at"`
 
     RuleAccesses []AuthRuleAccess `gorm:"foreignKey:RuleId;references:ID"`
 }
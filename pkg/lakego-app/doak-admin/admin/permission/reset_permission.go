@@ -32,6 +32,7 @@ func ResetPermission() bool {
     groupList := make([]model.AuthGroupAccess, 0)
     err2 := model.NewAuthGroupAccess().
         Preload("Group", "status = ?", 1).
+        Preload("Admin").
         Find(&groupList).
         Error
     if err2 != nil {
@@ -45,6 +46,11 @@ func ResetPermission() bool {
         for _, rv := range ruleListMap {
             rule := rv["Rule"].(map[string]any)
 
+            // 权限已禁用或者已删除
+            if rule["id"] == "" {
+                continue
+            }
+
             permission.New().AddPolicy(rv["group_id"].(string), rule["url"].(string), rule["method"].(string))
         }
     }
@@ -52,6 +58,13 @@ func ResetPermission() bool {
     // 添加权限
     if len(groupListMap) > 0 {
         for _, gv := range groupListMap {
+            // 分组或者账号已禁用、已删除
+            group := gv["Group"].(map[string]any)
+            admin := gv["Admin"].(map[string]any)
+            if group["id"] == "" || admin["id"] == "" {
+                continue
+            }
+
             permission.New().AddRoleForUser(gv["admin_id"].(string), gv["group_id"].(string))
         }
     }
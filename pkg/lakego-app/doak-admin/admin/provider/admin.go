@@ -4,9 +4,11 @@ import (
     "os"
     "fmt"
 
+    "github.com/deatil/go-goch/goch"
     "github.com/deatil/lakego-filesystem/filesystem"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     pathTool "github.com/deatil/lakego-doak/lakego/path"
 
@@ -20,12 +22,17 @@ import (
     "github.com/deatil/lakego-doak-admin/admin/middleware/cors"
     "github.com/deatil/lakego-doak-admin/admin/middleware/permission"
     "github.com/deatil/lakego-doak-admin/admin/middleware/admincheck"
+    "github.com/deatil/lakego-doak-admin/admin/middleware/ratelimit"
 
     // 路由
     adminRoute "github.com/deatil/lakego-doak-admin/admin/route"
 
     // 脚本
     "github.com/deatil/lakego-doak-admin/admin/cmd"
+
+    // 回收站
+    "github.com/deatil/lakego-doak-admin/admin/repository/trash"
+    adminPermission "github.com/deatil/lakego-doak-admin/admin/permission"
 )
 
 // 全局中间件
@@ -81,6 +88,9 @@ func (this *Admin) Boot() {
     // 路由
     this.loadRoute()
 
+    // 语言包
+    this.loadTranslations()
+
     // 推送配置
     this.publishConfig()
 
@@ -88,6 +98,22 @@ func (this *Admin) Boot() {
     this.putSock()
 }
 
+// 计划任务
+func (this *Admin) Schedule(s *schedule.Schedule) {
+    at := config.New("admin").GetString("trash.purge-at")
+    if at == "" {
+        at = "03:00"
+    }
+
+    // 彻底删除回收站过期数据
+    s.AddFunc(func() {
+        count, _ := trash.Clean(trash.Retention())
+        if count > 0 {
+            adminPermission.ResetPermission()
+        }
+    }).DailyAt(at).WithName("lakego-admin.trash-purge")
+}
+
 /**
  * 导入脚本
  */
@@ -104,15 +130,32 @@ func (this *Admin) loadCommand() {
     // 导入 api 路由信息
     this.AddCommand(cmd.ImportApiRouteCmd)
 
+    // 路由列表
+    this.AddCommand(cmd.RouteListCmd)
+
     // 强制将 jwt 的 refreshToken 放入黑名单
     this.AddCommand(cmd.PassportLogoutCmd)
 
     // 重置密码
     this.AddCommand(cmd.ResetPasswordCmd)
 
+    // 彻底删除回收站过期数据
+    this.AddCommand(cmd.TrashPurgeCmd)
+
+    // 权限包导入导出
+    this.AddCommand(cmd.ExportAuthCmd)
+    this.AddCommand(cmd.ImportAuthCmd)
+
     // 脚手架
     this.AddCommand(cmd.AppAdminCmd)
 
+    // 代码生成
+    this.AddCommand(cmd.MakeModuleCmd)
+    this.AddCommand(cmd.MakeControllerCmd)
+    this.AddCommand(cmd.MakeModelCmd)
+    this.AddCommand(cmd.MakeMiddlewareCmd)
+    this.AddCommand(cmd.MakeCommandCmd)
+
     // 系统信息
     this.AddCommand(cmd.VersionCmd)
 
@@ -191,6 +234,25 @@ func (this *Admin) loadMiddleware() {
             m.PushMiddlewareToGroup(groupName, middleware)
         }
     }
+
+    // 限流中间件
+    limits := config.New("admin").Get("ratelimit.middlewares")
+    for _, limit := range goch.ToSlice(limits) {
+        limitConf := goch.ToStringMap(limit)
+
+        alias := goch.ToString(limitConf["alias"])
+        limiter := goch.ToString(limitConf["limiter"])
+        if alias == "" || limiter == "" {
+            continue
+        }
+
+        m.AliasMiddleware(alias, ratelimit.Handler(limiter))
+
+        // 限流需要在验证之前
+        for _, groupName := range goch.ToStringSlice(limitConf["groups"]) {
+            m.PrependMiddlewareToGroup(groupName, alias)
+        }
+    }
 }
 
 /**
@@ -208,6 +270,21 @@ func (this *Admin) publishConfig() {
     }, "admin-config")
 }
 
+/**
+ * 导入语言包
+ */
+func (this *Admin) loadTranslations() {
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-admin/resources/lang")
+
+    this.LoadTranslationsFrom(path, "lakego-admin")
+
+    // 推送语言包
+    // > go run main.go lakego:publish --tag=admin-lang --force
+    this.Publishes(this, map[string]string{
+        path: pathTool.ResourcesPath("/lang/lakego-admin"),
+    }, "admin-lang")
+}
+
 /**
  * 记录 pid 信息
  */
@@ -0,0 +1,360 @@
+package bundle
+
+import (
+    "bytes"
+    "errors"
+    "strings"
+    "encoding/json"
+
+    "gopkg.in/yaml.v3"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+)
+
+// 当前版本
+const Version = 1
+
+// 格式
+const (
+    FormatJSON = "json"
+    FormatYAML = "yaml"
+)
+
+var (
+    // 不支持的格式
+    ErrFormat = errors.New("bundle: unsupported format")
+
+    // 不支持的版本
+    ErrVersion = errors.New("bundle: unsupported version")
+)
+
+/**
+ * 权限包
+ *
+ * 权限菜单使用 slug 作为标识，slug 为空或者为 # 时使用 "请求类型 链接 标题"
+ * 权限分组使用标题路径作为标识，比如 "超级管理员组/编辑组"
+ * 不包括账号的分组授权
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Bundle struct {
+    // 版本
+    Version int `json:"version" yaml:"version"`
+
+    // 导出时间
+    ExportedAt string `json:"exported_at" yaml:"exported_at"`
+
+    // 权限菜单
+    Rules []Rule `json:"rules" yaml:"rules"`
+
+    // 权限分组
+    Groups []Group `json:"groups" yaml:"groups"`
+}
+
+// 权限菜单
+type Rule struct {
+    Key         string `json:"key" yaml:"key"`
+    Parent      string `json:"parent" yaml:"parent"`
+    Title       string `json:"title" yaml:"title"`
+    Url         string `json:"url" yaml:"url"`
+    Method      string `json:"method" yaml:"method"`
+    Slug        string `json:"slug" yaml:"slug"`
+    Description string `json:"description" yaml:"description"`
+    Listorder   int    `json:"listorder" yaml:"listorder"`
+    Status      int    `json:"status" yaml:"status"`
+}
+
+// 权限分组
+type Group struct {
+    Key         string   `json:"key" yaml:"key"`
+    Parent      string   `json:"parent" yaml:"parent"`
+    Title       string   `json:"title" yaml:"title"`
+    Description string   `json:"description" yaml:"description"`
+    Listorder   int      `json:"listorder" yaml:"listorder"`
+    Status      int      `json:"status" yaml:"status"`
+    Rules       []string `json:"rules" yaml:"rules"`
+}
+
+// 权限菜单标识
+func RuleKey(slug string, method string, url string, title string) string {
+    if slug != "" && slug != "#" {
+        return slug
+    }
+
+    return strings.ToUpper(method) + " " + url + " " + title
+}
+
+// 权限分组标识
+func GroupKey(parent string, title string) string {
+    if parent == "" {
+        return title
+    }
+
+    return parent + "/" + title
+}
+
+// 导出
+func Export() (Bundle, error) {
+    rules, err := loadRules()
+    if err != nil {
+        return Bundle{}, err
+    }
+
+    groups, err := loadGroups(rules)
+    if err != nil {
+        return Bundle{}, err
+    }
+
+    bundle := Bundle{
+        Version:    Version,
+        ExportedAt: datebin.NowDatetimeString(),
+        Rules:      make([]Rule, 0, len(rules.list)),
+        Groups:     make([]Group, 0, len(groups.list)),
+    }
+
+    for _, item := range rules.list {
+        bundle.Rules = append(bundle.Rules, item.Rule)
+    }
+
+    for _, item := range groups.list {
+        bundle.Groups = append(bundle.Groups, item.Group)
+    }
+
+    return bundle, nil
+}
+
+// 编码
+func Encode(bundle Bundle, format string) ([]byte, error) {
+    switch format {
+        case FormatJSON, "":
+            return json.MarshalIndent(bundle, "", "    ")
+        case FormatYAML, "yml":
+            return yaml.Marshal(bundle)
+    }
+
+    return nil, ErrFormat
+}
+
+// 解析，format 为空时根据内容判断
+func Decode(data []byte, format string) (Bundle, error) {
+    if format == "" {
+        format = FormatYAML
+
+        if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+            format = FormatJSON
+        }
+    }
+
+    var bundle Bundle
+    var err error
+
+    switch format {
+        case FormatJSON:
+            err = json.Unmarshal(data, &bundle)
+        case FormatYAML, "yml":
+            err = yaml.Unmarshal(data, &bundle)
+        default:
+            return Bundle{}, ErrFormat
+    }
+
+    if err != nil {
+        return Bundle{}, err
+    }
+
+    if bundle.Version < 1 || bundle.Version > Version {
+        return Bundle{}, ErrVersion
+    }
+
+    return bundle, nil
+}
+
+// 数据库中的权限菜单
+type ruleItem struct {
+    Rule
+
+    ID string
+}
+
+type ruleSet struct {
+    list []ruleItem
+
+    // 标识 => 索引，标识重复时为多个
+    keys map[string][]int
+
+    // ID => 标识
+    ids map[string]string
+}
+
+// 数据库中的权限分组
+type groupItem struct {
+    Group
+
+    ID string
+}
+
+type groupSet struct {
+    list []groupItem
+
+    keys map[string][]int
+
+    ids map[string]string
+}
+
+// 读取权限菜单，上级在前
+func loadRules() (ruleSet, error) {
+    rows := make([]map[string]any, 0)
+    err := model.NewAuthRule().
+        Order("listorder ASC").
+        Order("add_time ASC").
+        Find(&rows).
+        Error
+    if err != nil {
+        return ruleSet{}, err
+    }
+
+    set := ruleSet{
+        list: make([]ruleItem, 0, len(rows)),
+        keys: make(map[string][]int),
+        ids:  make(map[string]string),
+    }
+
+    parents := make(map[string]string)
+    for _, row := range sortTree(rows) {
+        id := goch.ToString(row["id"])
+
+        rule := Rule{
+            Title:       goch.ToString(row["title"]),
+            Url:         goch.ToString(row["url"]),
+            Method:      strings.ToUpper(goch.ToString(row["method"])),
+            Slug:        goch.ToString(row["slug"]),
+            Description: goch.ToString(row["description"]),
+            Listorder:   goch.ToInt(row["listorder"]),
+            Status:      goch.ToInt(row["status"]),
+        }
+        rule.Key = RuleKey(rule.Slug, rule.Method, rule.Url, rule.Title)
+        rule.Parent = parents[goch.ToString(row["parentid"])]
+
+        parents[id] = rule.Key
+
+        set.keys[rule.Key] = append(set.keys[rule.Key], len(set.list))
+        set.ids[id] = rule.Key
+        set.list = append(set.list, ruleItem{Rule: rule, ID: id})
+    }
+
+    return set, nil
+}
+
+// 读取权限分组及授权，上级在前
+func loadGroups(rules ruleSet) (groupSet, error) {
+    rows := make([]map[string]any, 0)
+    err := model.NewAuthGroup().
+        Order("listorder ASC").
+        Order("add_time ASC").
+        Find(&rows).
+        Error
+    if err != nil {
+        return groupSet{}, err
+    }
+
+    accesses := make([]map[string]any, 0)
+    err = model.NewAuthRuleAccess().
+        Find(&accesses).
+        Error
+    if err != nil {
+        return groupSet{}, err
+    }
+
+    groupRules := make(map[string][]string)
+    for _, access := range accesses {
+        ruleKey, ok := rules.ids[goch.ToString(access["rule_id"])]
+        if !ok {
+            continue
+        }
+
+        groupId := goch.ToString(access["group_id"])
+        groupRules[groupId] = append(groupRules[groupId], ruleKey)
+    }
+
+    set := groupSet{
+        list: make([]groupItem, 0, len(rows)),
+        keys: make(map[string][]int),
+        ids:  make(map[string]string),
+    }
+
+    parents := make(map[string]string)
+    for _, row := range sortTree(rows) {
+        id := goch.ToString(row["id"])
+
+        group := Group{
+            Title:       goch.ToString(row["title"]),
+            Description: goch.ToString(row["description"]),
+            Listorder:   goch.ToInt(row["listorder"]),
+            Status:      goch.ToInt(row["status"]),
+            Rules:       groupRules[id],
+        }
+        group.Parent = parents[goch.ToString(row["parentid"])]
+        group.Key = GroupKey(group.Parent, group.Title)
+
+        if group.Rules == nil {
+            group.Rules = make([]string, 0)
+        }
+
+        parents[id] = group.Key
+
+        set.keys[group.Key] = append(set.keys[group.Key], len(set.list))
+        set.ids[id] = group.Key
+        set.list = append(set.list, groupItem{Group: group, ID: id})
+    }
+
+    return set, nil
+}
+
+// 树形排序，上级在前，上级不存在的作为顶级
+func sortTree(rows []map[string]any) []map[string]any {
+    ids := make(map[string]bool, len(rows))
+    for _, row := range rows {
+        ids[goch.ToString(row["id"])] = true
+    }
+
+    children := make(map[string][]map[string]any)
+    for _, row := range rows {
+        parentid := goch.ToString(row["parentid"])
+        if !ids[parentid] {
+            parentid = ""
+        }
+
+        children[parentid] = append(children[parentid], row)
+    }
+
+    sorted := make([]map[string]any, 0, len(rows))
+    visited := make(map[string]bool, len(rows))
+
+    var walk func(parentid string)
+    walk = func(parentid string) {
+        for _, row := range children[parentid] {
+            id := goch.ToString(row["id"])
+            if visited[id] {
+                continue
+            }
+
+            visited[id] = true
+            sorted = append(sorted, row)
+
+            walk(id)
+        }
+    }
+    walk("")
+
+    // 循环引用的数据放在最后
+    for _, row := range rows {
+        if !visited[goch.ToString(row["id"])] {
+            sorted = append(sorted, row)
+        }
+    }
+
+    return sorted
+}
@@ -0,0 +1,647 @@
+package bundle
+
+import (
+    "sort"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+)
+
+// 冲突处理方式
+const (
+    // 跳过冲突数据
+    StrategySkip = "skip"
+
+    // 使用导入数据覆盖，分组授权同时删除导入数据中没有的权限
+    StrategyOverwrite = "overwrite"
+)
+
+// 数据类型
+const (
+    TypeRule   = "rule"
+    TypeGroup  = "group"
+    TypeAccess = "access"
+)
+
+// 操作
+const (
+    ActionCreate    = "create"
+    ActionUpdate    = "update"
+    ActionUnchanged = "unchanged"
+    ActionConflict  = "conflict"
+)
+
+// 导入设置
+type Options struct {
+    // 只检测不写入
+    DryRun bool
+
+    // 冲突处理方式
+    Strategy string
+
+    // 操作 ip
+    Ip string
+}
+
+// 单条变动
+type Change struct {
+    Type    string   `json:"type"`
+    Key     string   `json:"key"`
+    Action  string   `json:"action"`
+    Fields  []string `json:"fields,omitempty"`
+    Message string   `json:"message,omitempty"`
+}
+
+/**
+ * 导入结果
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Report struct {
+    DryRun    bool     `json:"dry_run"`
+    Strategy  string   `json:"strategy"`
+    Created   int      `json:"created"`
+    Updated   int      `json:"updated"`
+    Unchanged int      `json:"unchanged"`
+    Conflicts int      `json:"conflicts"`
+    Changes   []Change `json:"changes"`
+}
+
+// 是否有数据变动
+func (this Report) Changed() bool {
+    return !this.DryRun && (this.Created > 0 || this.Updated > 0)
+}
+
+// 添加变动
+func (this *Report) add(change Change) {
+    switch change.Action {
+        case ActionCreate:
+            this.Created++
+        case ActionUpdate:
+            this.Updated++
+        case ActionUnchanged:
+            this.Unchanged++
+        case ActionConflict:
+            this.Conflicts++
+    }
+
+    this.Changes = append(this.Changes, change)
+}
+
+// 权限菜单操作
+type rulePlan struct {
+    action string
+    rule   Rule
+    id     string
+    fields []string
+}
+
+// 权限分组操作
+type groupPlan struct {
+    action string
+    group  Group
+    id     string
+    fields []string
+
+    // 新增及删除的权限
+    addRules    []string
+    removeRules []string
+}
+
+/**
+ * 导入
+ *
+ * 按标识合并数据，先生成导入计划，DryRun 时只返回计划
+ * 写入时在同一事务中执行
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Import(bundle Bundle, opts Options) (Report, error) {
+    if opts.Strategy != StrategyOverwrite {
+        opts.Strategy = StrategySkip
+    }
+    if opts.Ip == "" {
+        opts.Ip = "127.0.0.1"
+    }
+
+    report := Report{
+        DryRun:   opts.DryRun,
+        Strategy: opts.Strategy,
+        Changes:  make([]Change, 0),
+    }
+
+    rules, err := loadRules()
+    if err != nil {
+        return report, err
+    }
+
+    groups, err := loadGroups(rules)
+    if err != nil {
+        return report, err
+    }
+
+    rulePlans, ruleKeys := planRules(bundle.Rules, rules, opts, &report)
+    groupPlans := planGroups(bundle.Groups, groups, rules, ruleKeys, opts, &report)
+
+    if opts.DryRun {
+        return report, nil
+    }
+
+    err = model.NewDB().Transaction(func(tx *gorm.DB) error {
+        ruleIds, err := applyRules(tx, rulePlans, rules, opts)
+        if err != nil {
+            return err
+        }
+
+        return applyGroups(tx, groupPlans, groups, ruleIds, opts)
+    })
+
+    return report, err
+}
+
+// 权限菜单导入计划，返回可以使用的权限标识
+func planRules(list []Rule, rules ruleSet, opts Options, report *Report) ([]rulePlan, map[string]bool) {
+    plans := make([]rulePlan, 0, len(list))
+
+    // 导入成功的标识
+    usable := make(map[string]bool)
+    for key, idx := range rules.keys {
+        if len(idx) == 1 {
+            usable[key] = true
+        }
+    }
+
+    seen := make(map[string]bool)
+    for _, rule := range sortRules(list) {
+        rule.Method = strings.ToUpper(rule.Method)
+        if rule.Key == "" {
+            rule.Key = RuleKey(rule.Slug, rule.Method, rule.Url, rule.Title)
+        }
+
+        if seen[rule.Key] {
+            report.add(conflict(TypeRule, rule.Key, "导入数据中标识重复"))
+            continue
+        }
+        seen[rule.Key] = true
+
+        if rule.Parent != "" && !usable[rule.Parent] {
+            delete(usable, rule.Key)
+            report.add(conflict(TypeRule, rule.Key, "上级权限不存在：" + rule.Parent))
+            continue
+        }
+
+        idx := rules.keys[rule.Key]
+        if len(idx) > 1 {
+            report.add(conflict(TypeRule, rule.Key, "存在多个相同标识的权限"))
+            continue
+        }
+
+        if len(idx) == 0 {
+            usable[rule.Key] = true
+
+            plans = append(plans, rulePlan{action: ActionCreate, rule: rule})
+            report.add(Change{Type: TypeRule, Key: rule.Key, Action: ActionCreate})
+            continue
+        }
+
+        current := rules.list[idx[0]]
+        fields := diffRule(current.Rule, rule)
+
+        switch {
+            case len(fields) == 0:
+                report.add(Change{Type: TypeRule, Key: rule.Key, Action: ActionUnchanged})
+            case opts.Strategy == StrategyOverwrite:
+                plans = append(plans, rulePlan{action: ActionUpdate, rule: rule, id: current.ID, fields: fields})
+                report.add(Change{Type: TypeRule, Key: rule.Key, Action: ActionUpdate, Fields: fields})
+            default:
+                report.add(Change{Type: TypeRule, Key: rule.Key, Action: ActionConflict, Fields: fields, Message: "数据不一致"})
+        }
+    }
+
+    return plans, usable
+}
+
+// 权限分组导入计划
+func planGroups(list []Group, groups groupSet, rules ruleSet, ruleKeys map[string]bool, opts Options, report *Report) []groupPlan {
+    plans := make([]groupPlan, 0, len(list))
+
+    usable := make(map[string]bool)
+    for key, idx := range groups.keys {
+        if len(idx) == 1 {
+            usable[key] = true
+        }
+    }
+
+    seen := make(map[string]bool)
+    for _, group := range sortGroups(list) {
+        // 标识使用标题路径
+        group.Key = GroupKey(group.Parent, group.Title)
+
+        if seen[group.Key] {
+            report.add(conflict(TypeGroup, group.Key, "导入数据中标识重复"))
+            continue
+        }
+        seen[group.Key] = true
+
+        if group.Parent != "" && !usable[group.Parent] {
+            delete(usable, group.Key)
+            report.add(conflict(TypeGroup, group.Key, "上级分组不存在：" + group.Parent))
+            continue
+        }
+
+        // 授权的权限
+        wantRules := make([]string, 0, len(group.Rules))
+        for _, ruleKey := range uniqueStrings(group.Rules) {
+            if !ruleKeys[ruleKey] {
+                report.add(conflict(TypeAccess, group.Key, "权限不存在：" + ruleKey))
+                continue
+            }
+
+            wantRules = append(wantRules, ruleKey)
+        }
+
+        idx := groups.keys[group.Key]
+        if len(idx) > 1 {
+            report.add(conflict(TypeGroup, group.Key, "存在多个相同标识的分组"))
+            continue
+        }
+
+        if len(idx) == 0 {
+            usable[group.Key] = true
+
+            plans = append(plans, groupPlan{action: ActionCreate, group: group, addRules: wantRules})
+            report.add(Change{Type: TypeGroup, Key: group.Key, Action: ActionCreate})
+
+            if len(wantRules) > 0 {
+                report.add(accessChange(group.Key, ActionCreate, len(wantRules), 0))
+            }
+
+            continue
+        }
+
+        current := groups.list[idx[0]]
+        fields := diffGroup(current.Group, group)
+
+        plan := groupPlan{action: ActionUnchanged, group: group, id: current.ID}
+
+        switch {
+            case len(fields) == 0:
+                report.add(Change{Type: TypeGroup, Key: group.Key, Action: ActionUnchanged})
+            case opts.Strategy == StrategyOverwrite:
+                plan.action = ActionUpdate
+                plan.fields = fields
+                report.add(Change{Type: TypeGroup, Key: group.Key, Action: ActionUpdate, Fields: fields})
+            default:
+                // 冲突的分组不合并授权
+                report.add(Change{Type: TypeGroup, Key: group.Key, Action: ActionConflict, Fields: fields, Message: "数据不一致"})
+                continue
+        }
+
+        // 授权合并
+        currentRules := make(map[string]bool, len(current.Rules))
+        for _, ruleKey := range current.Rules {
+            currentRules[ruleKey] = true
+        }
+
+        wanted := make(map[string]bool, len(wantRules))
+        for _, ruleKey := range wantRules {
+            wanted[ruleKey] = true
+
+            if !currentRules[ruleKey] {
+                plan.addRules = append(plan.addRules, ruleKey)
+            }
+        }
+
+        if opts.Strategy == StrategyOverwrite {
+            for _, ruleKey := range current.Rules {
+                if !wanted[ruleKey] {
+                    plan.removeRules = append(plan.removeRules, ruleKey)
+                }
+            }
+        }
+
+        if len(plan.addRules) > 0 || len(plan.removeRules) > 0 {
+            report.add(accessChange(group.Key, ActionUpdate, len(plan.addRules), len(plan.removeRules)))
+        }
+
+        plans = append(plans, plan)
+    }
+
+    return plans
+}
+
+// 写入权限菜单，返回标识 => ID
+func applyRules(tx *gorm.DB, plans []rulePlan, rules ruleSet, opts Options) (map[string]string, error) {
+    ids := make(map[string]string)
+    for key, idx := range rules.keys {
+        if len(idx) == 1 {
+            ids[key] = rules.list[idx[0]].ID
+        }
+    }
+
+    for _, plan := range plans {
+        rule := plan.rule
+
+        parentid := "0"
+        if rule.Parent != "" {
+            parentid = ids[rule.Parent]
+        }
+
+        if plan.action == ActionCreate {
+            insertData := model.AuthRule{
+                Parentid:    parentid,
+                Title:       rule.Title,
+                Url:         rule.Url,
+                Method:      rule.Method,
+                Slug:        rule.Slug,
+                Description: rule.Description,
+                Listorder:   goch.ToString(rule.Listorder),
+                Status:      rule.Status,
+                AddTime:     int(datebin.NowTime()),
+                AddIp:       opts.Ip,
+            }
+
+            if err := tx.Create(&insertData).Error; err != nil {
+                return nil, err
+            }
+
+            ids[rule.Key] = insertData.ID
+            continue
+        }
+
+        data := map[string]any{
+            "update_time": int(datebin.NowTime()),
+            "update_ip":   opts.Ip,
+        }
+        for _, field := range plan.fields {
+            switch field {
+                case "parent":
+                    data["parentid"] = parentid
+                case "title":
+                    data["title"] = rule.Title
+                case "url":
+                    data["url"] = rule.Url
+                case "method":
+                    data["method"] = rule.Method
+                case "description":
+                    data["description"] = rule.Description
+                case "listorder":
+                    data["listorder"] = rule.Listorder
+                case "status":
+                    data["status"] = rule.Status
+            }
+        }
+
+        err := tx.Model(&model.AuthRule{}).
+            Where("id = ?", plan.id).
+            Updates(data).
+            Error
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    return ids, nil
+}
+
+// 写入权限分组及授权
+func applyGroups(tx *gorm.DB, plans []groupPlan, groups groupSet, ruleIds map[string]string, opts Options) error {
+    ids := make(map[string]string)
+    for key, idx := range groups.keys {
+        if len(idx) == 1 {
+            ids[key] = groups.list[idx[0]].ID
+        }
+    }
+
+    for _, plan := range plans {
+        group := plan.group
+
+        parentid := "0"
+        if group.Parent != "" {
+            parentid = ids[group.Parent]
+        }
+
+        groupId := plan.id
+
+        switch plan.action {
+            case ActionCreate:
+                insertData := model.AuthGroup{
+                    Parentid:    parentid,
+                    Title:       group.Title,
+                    Description: group.Description,
+                    Listorder:   goch.ToString(group.Listorder),
+                    Status:      group.Status,
+                    AddTime:     int(datebin.NowTime()),
+                    AddIp:       opts.Ip,
+                }
+
+                if err := tx.Create(&insertData).Error; err != nil {
+                    return err
+                }
+
+                groupId = insertData.ID
+                ids[group.Key] = groupId
+
+            case ActionUpdate:
+                data := map[string]any{
+                    "update_time": int(datebin.NowTime()),
+                    "update_ip":   opts.Ip,
+                }
+                for _, field := range plan.fields {
+                    switch field {
+                        case "description":
+                            data["description"] = group.Description
+                        case "listorder":
+                            data["listorder"] = group.Listorder
+                        case "status":
+                            data["status"] = group.Status
+                    }
+                }
+
+                err := tx.Model(&model.AuthGroup{}).
+                    Where("id = ?", groupId).
+                    Updates(data).
+                    Error
+                if err != nil {
+                    return err
+                }
+        }
+
+        for _, ruleKey := range plan.addRules {
+            err := tx.Create(&model.AuthRuleAccess{
+                GroupId: groupId,
+                RuleId:  ruleIds[ruleKey],
+            }).Error
+            if err != nil {
+                return err
+            }
+        }
+
+        if len(plan.removeRules) > 0 {
+            removeIds := make([]string, 0, len(plan.removeRules))
+            for _, ruleKey := range plan.removeRules {
+                removeIds = append(removeIds, ruleIds[ruleKey])
+            }
+
+            err := tx.Where("group_id = ?", groupId).
+                Where("rule_id IN ?", removeIds).
+                Delete(&model.AuthRuleAccess{}).
+                Error
+            if err != nil {
+                return err
+            }
+        }
+    }
+
+    return nil
+}
+
+// 权限菜单不同的字段
+func diffRule(current Rule, rule Rule) []string {
+    fields := make([]string, 0)
+
+    if current.Parent != rule.Parent {
+        fields = append(fields, "parent")
+    }
+    if current.Title != rule.Title {
+        fields = append(fields, "title")
+    }
+    if current.Url != rule.Url {
+        fields = append(fields, "url")
+    }
+    if current.Method != rule.Method {
+        fields = append(fields, "method")
+    }
+    if current.Description != rule.Description {
+        fields = append(fields, "description")
+    }
+    if current.Listorder != rule.Listorder {
+        fields = append(fields, "listorder")
+    }
+    if current.Status != rule.Status {
+        fields = append(fields, "status")
+    }
+
+    return fields
+}
+
+// 权限分组不同的字段
+func diffGroup(current Group, group Group) []string {
+    fields := make([]string, 0)
+
+    if current.Description != group.Description {
+        fields = append(fields, "description")
+    }
+    if current.Listorder != group.Listorder {
+        fields = append(fields, "listorder")
+    }
+    if current.Status != group.Status {
+        fields = append(fields, "status")
+    }
+
+    return fields
+}
+
+// 冲突
+func conflict(typ string, key string, message string) Change {
+    return Change{
+        Type:    typ,
+        Key:     key,
+        Action:  ActionConflict,
+        Message: message,
+    }
+}
+
+// 授权变动
+func accessChange(key string, action string, added int, removed int) Change {
+    return Change{
+        Type:    TypeAccess,
+        Key:     key,
+        Action:  action,
+        Message: "新增 " + goch.ToString(added) + " 条，删除 " + goch.ToString(removed) + " 条",
+    }
+}
+
+// 上级在前，上级不在导入数据中的保持原来顺序
+func sortRules(list []Rule) []Rule {
+    depth := make(map[string]int, len(list))
+    parents := make(map[string]string, len(list))
+    for _, rule := range list {
+        if rule.Key != "" {
+            parents[rule.Key] = rule.Parent
+        }
+    }
+
+    sorted := make([]Rule, len(list))
+    copy(sorted, list)
+
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return keyDepth(parents, depth, sorted[i].Key) < keyDepth(parents, depth, sorted[j].Key)
+    })
+
+    return sorted
+}
+
+// 上级在前
+func sortGroups(list []Group) []Group {
+    depth := make(map[string]int, len(list))
+    parents := make(map[string]string, len(list))
+    for _, group := range list {
+        parents[GroupKey(group.Parent, group.Title)] = group.Parent
+    }
+
+    sorted := make([]Group, len(list))
+    copy(sorted, list)
+
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return keyDepth(parents, depth, GroupKey(sorted[i].Parent, sorted[i].Title)) <
+            keyDepth(parents, depth, GroupKey(sorted[j].Parent, sorted[j].Title))
+    })
+
+    return sorted
+}
+
+// 导入数据中的层级
+func keyDepth(parents map[string]string, cache map[string]int, key string) int {
+    if d, ok := cache[key]; ok {
+        return d
+    }
+
+    d := 0
+    for current := key; d <= len(parents); d++ {
+        parent, ok := parents[current]
+        if !ok || parent == "" {
+            break
+        }
+
+        current = parent
+    }
+
+    cache[key] = d
+
+    return d
+}
+
+// 去重
+func uniqueStrings(items []string) []string {
+    seen := make(map[string]bool, len(items))
+
+    newItems := make([]string, 0, len(items))
+    for _, item := range items {
+        if item == "" || seen[item] {
+            continue
+        }
+
+        seen[item] = true
+        newItems = append(newItems, item)
+    }
+
+    return newItems
+}
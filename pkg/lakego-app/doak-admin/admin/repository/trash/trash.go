@@ -0,0 +1,330 @@
+package trash
+
+import (
+    "time"
+    "errors"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/storage"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+)
+
+var (
+    // 回收站中没有该数据
+    ErrNotFound = errors.New("trash: record not found")
+
+    // 上级数据已删除
+    ErrParentDeleted = errors.New("trash: parent is deleted")
+
+    // 存在子级数据
+    ErrHasChildren = errors.New("trash: record has children")
+
+    // 唯一数据已被使用
+    ErrDuplicate = errors.New("trash: record is duplicate")
+)
+
+// 管理员
+var Admin = Trash{
+    Model: &model.Admin{},
+    Restoring: func(info map[string]any) error {
+        return checkUnique(model.NewAdmin(), info, "name", "email")
+    },
+    Purging: func(tx *gorm.DB, info map[string]any) error {
+        // 删除分组授权
+        return tx.Where("admin_id = ?", info["id"]).
+            Delete(&model.AuthGroupAccess{}).
+            Error
+    },
+}
+
+// 权限分组
+var AuthGroup = Trash{
+    Model: &model.AuthGroup{},
+    Restoring: func(info map[string]any) error {
+        return checkParent(model.NewAuthGroup(), info)
+    },
+    Purging: func(tx *gorm.DB, info map[string]any) error {
+        if err := checkChildren(tx.Model(&model.AuthGroup{}), info); err != nil {
+            return err
+        }
+
+        // 删除账号授权
+        err := tx.Where("group_id = ?", info["id"]).
+            Delete(&model.AuthGroupAccess{}).
+            Error
+        if err != nil {
+            return err
+        }
+
+        // 删除权限授权
+        return tx.Where("group_id = ?", info["id"]).
+            Delete(&model.AuthRuleAccess{}).
+            Error
+    },
+}
+
+// 权限菜单
+var AuthRule = Trash{
+    Model: &model.AuthRule{},
+    Restoring: func(info map[string]any) error {
+        return checkParent(model.NewAuthRule(), info)
+    },
+    Purging: func(tx *gorm.DB, info map[string]any) error {
+        if err := checkChildren(tx.Model(&model.AuthRule{}), info); err != nil {
+            return err
+        }
+
+        // 删除权限授权
+        return tx.Where("rule_id = ?", info["id"]).
+            Delete(&model.AuthRuleAccess{}).
+            Error
+    },
+}
+
+// 附件
+var Attachment = Trash{
+    Model: &model.Attachment{},
+    Purged: func(info map[string]any) {
+        disk := goch.ToString(info["disk"])
+        path := goch.ToString(info["path"])
+
+        // 其他附件使用同一文件时不删除
+        var total int64
+        model.NewAttachment().
+            Where("disk = ?", disk).
+            Where("path = ?", path).
+            Count(&total)
+        if total > 0 {
+            return
+        }
+
+        storage.NewWithDisk(disk).Delete(path)
+    },
+}
+
+// 全部回收站，彻底删除过期数据时使用
+func All() map[string]Trash {
+    return map[string]Trash{
+        "admin":      Admin,
+        "auth-group": AuthGroup,
+        "auth-rule":  AuthRule,
+        "attachment": Attachment,
+    }
+}
+
+// 保留天数，为 0 时不自动彻底删除
+func Retention() int {
+    return config.New("admin").GetInt("trash.retention")
+}
+
+// 彻底删除全部回收站中超过保留天数的数据，返回删除数量
+func Clean(days int) (int, error) {
+    if days <= 0 {
+        return 0, nil
+    }
+
+    before := time.Now().AddDate(0, 0, -days)
+
+    count := 0
+    for _, t := range All() {
+        n, err := t.PurgeExpired(before)
+        if err != nil {
+            return count, err
+        }
+
+        count += n
+    }
+
+    return count, nil
+}
+
+/**
+ * 回收站
+ *
+ * 模型使用 gorm 软删除，删除后进入回收站，可以恢复或者彻底删除
+ * 彻底删除时在事务中删除关联数据
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Trash struct {
+    // 模型结构体
+    Model any
+
+    // 恢复前检测
+    Restoring func(info map[string]any) error
+
+    // 彻底删除前检测及删除关联数据，在事务中执行
+    Purging func(tx *gorm.DB, info map[string]any) error
+
+    // 彻底删除后
+    Purged func(info map[string]any)
+}
+
+// 回收站查询
+func (this Trash) Query() *gorm.DB {
+    return model.NewDB().
+        Model(this.Model).
+        Unscoped().
+        Where("deleted_at IS NOT NULL")
+}
+
+// 回收站数据详情
+func (this Trash) Find(id string) (map[string]any, error) {
+    info := map[string]any{}
+
+    err := this.Query().
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil || len(info) == 0 {
+        return nil, ErrNotFound
+    }
+
+    return info, nil
+}
+
+// 恢复
+func (this Trash) Restore(id string) error {
+    info, err := this.Find(id)
+    if err != nil {
+        return err
+    }
+
+    if this.Restoring != nil {
+        if err := this.Restoring(info); err != nil {
+            return err
+        }
+    }
+
+    return this.Query().
+        Where("id = ?", id).
+        Update("deleted_at", nil).
+        Error
+}
+
+// 彻底删除
+func (this Trash) Purge(id string) error {
+    info, err := this.Find(id)
+    if err != nil {
+        return err
+    }
+
+    err = model.NewDB().Transaction(func(tx *gorm.DB) error {
+        if this.Purging != nil {
+            if err := this.Purging(tx, info); err != nil {
+                return err
+            }
+        }
+
+        return tx.Unscoped().
+            Where("id = ?", id).
+            Where("deleted_at IS NOT NULL").
+            Delete(this.Model).
+            Error
+    })
+    if err != nil {
+        return err
+    }
+
+    if this.Purged != nil {
+        this.Purged(info)
+    }
+
+    return nil
+}
+
+// 删除时间早于 before 的数据 ID，按删除时间排序
+func (this Trash) Expired(before time.Time) ([]string, error) {
+    ids := make([]string, 0)
+
+    err := this.Query().
+        Where("deleted_at < ?", before).
+        Order("deleted_at ASC").
+        Pluck("id", &ids).
+        Error
+
+    return ids, err
+}
+
+// 彻底删除过期数据，返回删除数量
+func (this Trash) PurgeExpired(before time.Time) (int, error) {
+    ids, err := this.Expired(before)
+    if err != nil {
+        return 0, err
+    }
+
+    // 存在子级等情况时跳过
+    count := 0
+    for _, id := range ids {
+        if this.Purge(id) == nil {
+            count++
+        }
+    }
+
+    return count, nil
+}
+
+// 上级数据需要存在
+func checkParent(db *gorm.DB, info map[string]any) error {
+    parentid := goch.ToString(info["parentid"])
+    if parentid == "" || parentid == "0" {
+        return nil
+    }
+
+    var total int64
+    err := db.Where("id = ?", parentid).
+        Count(&total).
+        Error
+    if err != nil || total == 0 {
+        return ErrParentDeleted
+    }
+
+    return nil
+}
+
+// 未删除的数据中唯一字段不能重复
+func checkUnique(db *gorm.DB, info map[string]any, fields ...string) error {
+    query := model.NewDB()
+    for _, field := range fields {
+        query = query.Or(field + " = ?", info[field])
+    }
+
+    var total int64
+    err := db.Where("id != ?", info["id"]).
+        Where(query).
+        Count(&total).
+        Error
+    if err != nil {
+        return err
+    }
+
+    if total > 0 {
+        return ErrDuplicate
+    }
+
+    return nil
+}
+
+// 包括回收站中的数据都不能有子级
+func checkChildren(db *gorm.DB, info map[string]any) error {
+    var total int64
+    err := db.Unscoped().
+        Where("parentid = ?", info["id"]).
+        Count(&total).
+        Error
+    if err != nil {
+        return err
+    }
+
+    if total > 0 {
+        return ErrHasChildren
+    }
+
+    return nil
+}
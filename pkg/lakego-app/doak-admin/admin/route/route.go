@@ -12,54 +12,66 @@ import (
 func Route(engine router.IRouter) {
     // 登陆
     passportController := new(controller.Passport)
-    engine.GET("/passport/captcha", passportController.Captcha)
-    engine.POST("/passport/login", passportController.Login)
-    engine.PUT("/passport/refresh-token", passportController.RefreshToken)
-    engine.DELETE("/passport/logout", passportController.Logout)
+    router.Named(engine, "admin.passport.captcha").GET("/passport/captcha", passportController.Captcha)
+    router.Named(engine, "admin.passport.login").POST("/passport/login", append(
+        router.GetMiddlewares("lakego-admin.ratelimit-login"),
+        passportController.Login,
+    )...)
+    router.Named(engine, "admin.passport.refresh-token").PUT("/passport/refresh-token", passportController.RefreshToken)
+    router.Named(engine, "admin.passport.logout").DELETE("/passport/logout", passportController.Logout)
 
     // 个人信息
     profileController := new(controller.Profile)
-    engine.GET("/profile", profileController.Index)
-    engine.PUT("/profile", profileController.Update)
-    engine.PATCH("/profile/avatar", profileController.UpdateAvatar)
-    engine.PATCH("/profile/password", profileController.UpdatePasssword)
-    engine.GET("/profile/rules", profileController.Rules)
+    router.Named(engine, "admin.profile.index").GET("/profile", profileController.Index)
+    router.Named(engine, "admin.profile.update").PUT("/profile", profileController.Update)
+    router.Named(engine, "admin.profile.update-avatar").PATCH("/profile/avatar", profileController.UpdateAvatar)
+    router.Named(engine, "admin.profile.update-passsword").PATCH("/profile/password", profileController.UpdatePasssword)
+    router.Named(engine, "admin.profile.rules").GET("/profile/rules", profileController.Rules)
 
     // 上传
     uploadController := new(controller.Upload)
-    engine.POST("/upload/file", uploadController.File)
+    router.Named(engine, "admin.upload.file").POST("/upload/file", uploadController.File)
 
     // 附件
     attachmentController := new(controller.Attachment)
-    engine.GET("/attachment", attachmentController.Index)
-    engine.GET("/attachment/:id", attachmentController.Detail)
-    engine.PATCH("/attachment/:id/enable", attachmentController.Enable)
-    engine.PATCH("/attachment/:id/disable", attachmentController.Disable)
-    engine.DELETE("/attachment/:id", attachmentController.Delete)
-    engine.GET("/attachment/downcode/:id", attachmentController.DownloadCode)
-    engine.GET("/attachment/download/:code", attachmentController.Download)
+    router.Named(engine, "admin.attachment.index").GET("/attachment", attachmentController.Index)
+    router.Named(engine, "admin.attachment.trash").GET("/attachment/trash", attachmentController.Trash)
+    router.Named(engine, "admin.attachment.detail").GET("/attachment/:id", attachmentController.Detail)
+    router.Named(engine, "admin.attachment.enable").PATCH("/attachment/:id/enable", attachmentController.Enable)
+    router.Named(engine, "admin.attachment.disable").PATCH("/attachment/:id/disable", attachmentController.Disable)
+    router.Named(engine, "admin.attachment.delete").DELETE("/attachment/:id", attachmentController.Delete)
+    router.Named(engine, "admin.attachment.restore").PATCH("/attachment/:id/restore", attachmentController.Restore)
+    router.Named(engine, "admin.attachment.purge").DELETE("/attachment/:id/purge", attachmentController.Purge)
+    router.Named(engine, "admin.attachment.download-code").GET("/attachment/downcode/:id", attachmentController.DownloadCode)
+    router.Named(engine, "admin.attachment.download").GET("/attachment/download/:code", attachmentController.Download)
 
     // 管理员
     adminController := new(controller.Admin)
-    engine.GET("/admin", adminController.Index)
-    engine.GET("/admin/groups", adminController.Groups)
-    engine.GET("/admin/:id", adminController.Detail)
-    engine.GET("/admin/:id/rules", adminController.Rules)
-    engine.POST("/admin", adminController.Create)
-    engine.PUT("/admin/:id", adminController.Update)
-    engine.DELETE("/admin/:id", adminController.Delete)
-    engine.PATCH("/admin/:id/enable", adminController.Enable)
-    engine.PATCH("/admin/:id/disable", adminController.Disable)
-    engine.PATCH("/admin/:id/avatar", adminController.UpdateAvatar)
-    engine.PATCH("/admin/:id/password", adminController.UpdatePasssword)
-    engine.PATCH("/admin/:id/access", adminController.Access)
-    engine.DELETE("/admin/logout/:refreshToken", adminController.Logout)
-    engine.PUT("/admin/reset-permission", adminController.ResetPermission)
+    router.Named(engine, "admin.admin.index").GET("/admin", adminController.Index)
+    router.Named(engine, "admin.admin.groups").GET("/admin/groups", adminController.Groups)
+    router.Named(engine, "admin.admin.batch-enable").PATCH("/admin/batch/enable", adminController.BatchEnable)
+    router.Named(engine, "admin.admin.batch-disable").PATCH("/admin/batch/disable", adminController.BatchDisable)
+    router.Named(engine, "admin.admin.batch-delete").DELETE("/admin/batch", adminController.BatchDelete)
+    router.Named(engine, "admin.admin.trash").GET("/admin/trash", adminController.Trash)
+    router.Named(engine, "admin.admin.detail").GET("/admin/:id", adminController.Detail)
+    router.Named(engine, "admin.admin.rules").GET("/admin/:id/rules", adminController.Rules)
+    router.Named(engine, "admin.admin.create").POST("/admin", adminController.Create)
+    router.Named(engine, "admin.admin.update").PUT("/admin/:id", adminController.Update)
+    router.Named(engine, "admin.admin.delete").DELETE("/admin/:id", adminController.Delete)
+    router.Named(engine, "admin.admin.restore").PATCH("/admin/:id/restore", adminController.Restore)
+    router.Named(engine, "admin.admin.purge").DELETE("/admin/:id/purge", adminController.Purge)
+    router.Named(engine, "admin.admin.enable").PATCH("/admin/:id/enable", adminController.Enable)
+    router.Named(engine, "admin.admin.disable").PATCH("/admin/:id/disable", adminController.Disable)
+    router.Named(engine, "admin.admin.update-avatar").PATCH("/admin/:id/avatar", adminController.UpdateAvatar)
+    router.Named(engine, "admin.admin.update-passsword").PATCH("/admin/:id/password", adminController.UpdatePasssword)
+    router.Named(engine, "admin.admin.access").PATCH("/admin/:id/access", adminController.Access)
+    router.Named(engine, "admin.admin.logout").DELETE("/admin/logout/:refreshToken", adminController.Logout)
+    router.Named(engine, "admin.admin.reset-permission").PUT("/admin/reset-permission", adminController.ResetPermission)
 
     // 系统信息
     systemController := new(controller.System)
-    engine.GET("/system/info", systemController.Info)
-    engine.GET("/system/rules", systemController.Rules)
+    router.Named(engine, "admin.system.info").GET("/system/info", systemController.Info)
+    router.Named(engine, "admin.system.rules").GET("/system/rules", systemController.Rules)
 }
 
 /**
@@ -68,29 +80,50 @@ func Route(engine router.IRouter) {
 func AdminRoute(engine router.IRouter) {
     // 权限菜单
     authRuleController := new(controller.AuthRule)
-    engine.GET("/auth/rule", authRuleController.Index)
-    engine.GET("/auth/rule/tree", authRuleController.IndexTree)
-    engine.GET("/auth/rule/children", authRuleController.IndexChildren)
-    engine.GET("/auth/rule/:id", authRuleController.Detail)
-    engine.POST("/auth/rule", authRuleController.Create)
-    engine.PUT("/auth/rule/:id", authRuleController.Update)
-    engine.DELETE("/auth/rule/clear", authRuleController.Clear)
-    engine.DELETE("/auth/rule/:id", authRuleController.Delete)
-    engine.PATCH("/auth/rule/:id/sort", authRuleController.Listorder)
-    engine.PATCH("/auth/rule/:id/enable", authRuleController.Enable)
-    engine.PATCH("/auth/rule/:id/disable", authRuleController.Disable)
+    router.Named(engine, "admin.auth-rule.index").GET("/auth/rule", authRuleController.Index)
+    router.Named(engine, "admin.auth-rule.index-tree").GET("/auth/rule/tree", authRuleController.IndexTree)
+    router.Named(engine, "admin.auth-rule.index-children").GET("/auth/rule/children", authRuleController.IndexChildren)
+    router.Named(engine, "admin.auth-rule.trash").GET("/auth/rule/trash", authRuleController.Trash)
+    router.Named(engine, "admin.auth-rule.batch-enable").PATCH("/auth/rule/batch/enable", authRuleController.BatchEnable)
+    router.Named(engine, "admin.auth-rule.batch-disable").PATCH("/auth/rule/batch/disable", authRuleController.BatchDisable)
+    router.Named(engine, "admin.auth-rule.batch-delete").DELETE("/auth/rule/batch", authRuleController.BatchDelete)
+    router.Named(engine, "admin.auth-rule.batch-parent").PATCH("/auth/rule/batch/parent", authRuleController.BatchParent)
+    router.Named(engine, "admin.auth-rule.batch-listorder").PATCH("/auth/rule/batch/sort", authRuleController.BatchListorder)
+    router.Named(engine, "admin.auth-rule.detail").GET("/auth/rule/:id", authRuleController.Detail)
+    router.Named(engine, "admin.auth-rule.create").POST("/auth/rule", authRuleController.Create)
+    router.Named(engine, "admin.auth-rule.update").PUT("/auth/rule/:id", authRuleController.Update)
+    router.Named(engine, "admin.auth-rule.clear").DELETE("/auth/rule/clear", authRuleController.Clear)
+    router.Named(engine, "admin.auth-rule.delete").DELETE("/auth/rule/:id", authRuleController.Delete)
+    router.Named(engine, "admin.auth-rule.restore").PATCH("/auth/rule/:id/restore", authRuleController.Restore)
+    router.Named(engine, "admin.auth-rule.purge").DELETE("/auth/rule/:id/purge", authRuleController.Purge)
+    router.Named(engine, "admin.auth-rule.listorder").PATCH("/auth/rule/:id/sort", authRuleController.Listorder)
+    router.Named(engine, "admin.auth-rule.enable").PATCH("/auth/rule/:id/enable", authRuleController.Enable)
+    router.Named(engine, "admin.auth-rule.disable").PATCH("/auth/rule/:id/disable", authRuleController.Disable)
 
     // 权限分组
     authGroupController := new(controller.AuthGroup)
-    engine.GET("/auth/group", authGroupController.Index)
-    engine.GET("/auth/group/tree", authGroupController.IndexTree)
-    engine.GET("/auth/group/children", authGroupController.IndexChildren)
-    engine.GET("/auth/group/:id", authGroupController.Detail)
-    engine.POST("/auth/group", authGroupController.Create)
-    engine.PUT("/auth/group/:id", authGroupController.Update)
-    engine.DELETE("/auth/group/:id", authGroupController.Delete)
-    engine.PATCH("/auth/group/:id/sort", authGroupController.Listorder)
-    engine.PATCH("/auth/group/:id/enable", authGroupController.Enable)
-    engine.PATCH("/auth/group/:id/disable", authGroupController.Disable)
-    engine.PATCH("/auth/group/:id/access", authGroupController.Access)
+    router.Named(engine, "admin.auth-group.index").GET("/auth/group", authGroupController.Index)
+    router.Named(engine, "admin.auth-group.index-tree").GET("/auth/group/tree", authGroupController.IndexTree)
+    router.Named(engine, "admin.auth-group.index-children").GET("/auth/group/children", authGroupController.IndexChildren)
+    router.Named(engine, "admin.auth-group.trash").GET("/auth/group/trash", authGroupController.Trash)
+    router.Named(engine, "admin.auth-group.batch-enable").PATCH("/auth/group/batch/enable", authGroupController.BatchEnable)
+    router.Named(engine, "admin.auth-group.batch-disable").PATCH("/auth/group/batch/disable", authGroupController.BatchDisable)
+    router.Named(engine, "admin.auth-group.batch-delete").DELETE("/auth/group/batch", authGroupController.BatchDelete)
+    router.Named(engine, "admin.auth-group.batch-parent").PATCH("/auth/group/batch/parent", authGroupController.BatchParent)
+    router.Named(engine, "admin.auth-group.batch-listorder").PATCH("/auth/group/batch/sort", authGroupController.BatchListorder)
+    router.Named(engine, "admin.auth-group.detail").GET("/auth/group/:id", authGroupController.Detail)
+    router.Named(engine, "admin.auth-group.create").POST("/auth/group", authGroupController.Create)
+    router.Named(engine, "admin.auth-group.update").PUT("/auth/group/:id", authGroupController.Update)
+    router.Named(engine, "admin.auth-group.delete").DELETE("/auth/group/:id", authGroupController.Delete)
+    router.Named(engine, "admin.auth-group.restore").PATCH("/auth/group/:id/restore", authGroupController.Restore)
+    router.Named(engine, "admin.auth-group.purge").DELETE("/auth/group/:id/purge", authGroupController.Purge)
+    router.Named(engine, "admin.auth-group.listorder").PATCH("/auth/group/:id/sort", authGroupController.Listorder)
+    router.Named(engine, "admin.auth-group.enable").PATCH("/auth/group/:id/enable", authGroupController.Enable)
+    router.Named(engine, "admin.auth-group.disable").PATCH("/auth/group/:id/disable", authGroupController.Disable)
+    router.Named(engine, "admin.auth-group.access").PATCH("/auth/group/:id/access", authGroupController.Access)
+
+    // 权限包
+    authBundleController := new(controller.AuthBundle)
+    router.Named(engine, "admin.auth-bundle.export").GET("/auth/bundle/export", authBundleController.Export)
+    router.Named(engine, "admin.auth-bundle.import").POST("/auth/bundle/import", authBundleController.Import)
 }
@@ -0,0 +1,471 @@
+package stubs
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/str"
+)
+
+// 自动填充的字段
+var crudAutoFields = map[string]string{
+    "add_time":    "int(datebin.NowTime())",
+    "add_ip":      "router.GetRequestIp(ctx)",
+    "update_time": "int(datebin.NowTime())",
+    "update_ip":   "router.GetRequestIp(ctx)",
+}
+
+// 字段类型长度
+var crudTypeLengthRe = regexp.MustCompile(`^\w+\((\d+)\)`)
+
+/**
+ * 数据表字段生成 CRUD 模板数据
+ *
+ * 字段格式同 SHOW FULL COLUMNS，包括 name, type, null, key, default, extra, comment
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Crud struct {
+    // 名称
+    Name string
+
+    // 表注释
+    Comment string
+
+    // 字段
+    Columns []map[string]string
+}
+
+// 构造函数
+func NewCrud(name string, comment string, columns []map[string]string) Crud {
+    return Crud{
+        Name:    name,
+        Comment: comment,
+        Columns: columns,
+    }
+}
+
+// 主键
+func (this Crud) PrimaryKey() map[string]string {
+    for _, column := range this.Columns {
+        if column["key"] == "PRI" {
+            return column
+        }
+    }
+
+    return map[string]string{
+        "name": "id",
+    }
+}
+
+// 主键是否为 uuid
+func (this Crud) IsUUIDKey() bool {
+    pk := this.PrimaryKey()
+
+    return !this.isAutoIncrement(pk) && strings.HasPrefix(pk["type"], "char(36)")
+}
+
+// 模板数据
+func (this Crud) Data(modelImport string, validateImport string) map[string]string {
+    name := str.Camel(this.Name)
+    validatePackage := strings.ReplaceAll(str.Snake(this.Name), "_", "")
+
+    comment := this.Comment
+    if comment == "" {
+        comment = name
+    }
+
+    pk := this.PrimaryKey()["name"]
+
+    defaultOrder := pk
+    if this.hasColumn("add_time") {
+        defaultOrder = "add_time"
+    }
+
+    return map[string]string{
+        "controllerName":      name,
+        "controllerLowerName": str.LowerCamel(this.Name),
+        "controllerPath":      str.Kebab(this.Name),
+        "controllerImports":   this.controllerImports(),
+        "modelName":           name,
+        "modelLowerName":      str.LowerCamel(this.Name),
+        "modelImport":         modelImport,
+        "modelImports":        this.modelImports(),
+        "modelFields":         this.modelFields(),
+        "modelHooks":          this.modelHooks(name),
+        "validatePackage":     validatePackage,
+        "validateImport":      validateImport,
+        "validateRules":       this.validateRules(),
+        "validateMessages":    this.validateMessages(),
+        "tableComment":        comment,
+        "primaryKey":          pk,
+        "defaultOrder":        defaultOrder,
+        "orderCondition":      this.orderCondition(),
+        "searchCondition":     this.searchCondition(name),
+        "createParams":        this.swaggerParams(),
+        "updateParams":        this.swaggerParams(),
+        "createFields":        this.createFields(),
+        "updateFields":        this.updateFields(),
+    }
+}
+
+// 控制器额外引入
+func (this Crud) controllerImports() string {
+    imports := make([]string, 0)
+
+    if this.hasAnyColumn("add_time", "update_time") {
+        imports = append(imports, `    "github.com/deatil/go-datebin/datebin"`)
+    }
+
+    if this.IsUUIDKey() {
+        imports = append(imports, `    "github.com/deatil/lakego-doak/lakego/uuid"`)
+    }
+
+    if len(imports) == 0 {
+        return ""
+    }
+
+    return strings.Join(imports, "\n") + "\n"
+}
+
+// 模型引入
+func (this Crud) modelImports() string {
+    imports := []string{
+        `    "gorm.io/gorm"`,
+        "",
+    }
+
+    if this.IsUUIDKey() {
+        imports = append(imports, `    "github.com/deatil/lakego-doak/lakego/uuid"`)
+    }
+
+    return strings.Join(imports, "\n")
+}
+
+// 模型字段
+func (this Crud) modelFields() string {
+    nameLen, typeLen := 0, 0
+
+    fields := make([][3]string, 0, len(this.Columns))
+    for _, column := range this.Columns {
+        field := [3]string{
+            this.fieldName(column["name"]),
+            this.goType(column),
+            this.fieldTag(column),
+        }
+
+        if len(field[0]) > nameLen {
+            nameLen = len(field[0])
+        }
+        if len(field[1]) > typeLen {
+            typeLen = len(field[1])
+        }
+
+        fields = append(fields, field)
+    }
+
+    lines := make([]string, 0, len(fields))
+    for _, field := range fields {
+        lines = append(lines, fmt.Sprintf("    %-*s %-*s %s", nameLen, field[0], typeLen, field[1], field[2]))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 模型钩子
+func (this Crud) modelHooks(name string) string {
+    if !this.IsUUIDKey() {
+        return ""
+    }
+
+    return fmt.Sprintf(`
+func (this *%s) BeforeCreate(tx *gorm.DB) error {
+    this.%s = uuid.ToUUIDString()
+
+    return nil
+}
+`, name, this.fieldName(this.PrimaryKey()["name"]))
+}
+
+// 验证规则
+func (this Crud) validateRules() string {
+    lines := make([]string, 0)
+    for _, column := range this.formColumns() {
+        rules := this.columnRules(column)
+        if len(rules) == 0 {
+            continue
+        }
+
+        lines = append(lines, fmt.Sprintf(`        "%s": "%s",`, column["name"], strings.Join(rules, ",")))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 验证提示
+func (this Crud) validateMessages() string {
+    lines := make([]string, 0)
+    for _, column := range this.formColumns() {
+        title := this.columnTitle(column)
+
+        for _, rule := range this.columnRules(column) {
+            switch {
+                case rule == "required":
+                    lines = append(lines, fmt.Sprintf(`        "%s.required": "%s不能为空",`, column["name"], title))
+                case strings.HasPrefix(rule, "max="):
+                    lines = append(lines, fmt.Sprintf(`        "%s.max": "%s最大字符需要%s个",`, column["name"], title, strings.TrimPrefix(rule, "max=")))
+            }
+        }
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 排序条件
+func (this Crud) orderCondition() string {
+    conds := make([]string, 0)
+    for _, column := range this.Columns {
+        if column["name"] == this.PrimaryKey()["name"] ||
+            column["name"] == "listorder" ||
+            column["name"] == "add_time" ||
+            column["name"] == "update_time" {
+            conds = append(conds, fmt.Sprintf(`orders[0] != "%s"`, column["name"]))
+        }
+    }
+
+    if len(conds) == 0 {
+        conds = append(conds, fmt.Sprintf(`orders[0] != "%s"`, this.PrimaryKey()["name"]))
+    }
+
+    return strings.Join(conds, " &&\n        ")
+}
+
+// 搜索条件
+func (this Crud) searchCondition(name string) string {
+    conds := make([]string, 0)
+    for _, column := range this.formColumns() {
+        if this.isStringType(column["type"]) && !strings.Contains(column["type"], "text") {
+            conds = append(conds, column["name"] + " LIKE ?")
+        }
+    }
+
+    if len(conds) == 0 {
+        return ""
+    }
+
+    args := strings.TrimSuffix(strings.Repeat("searchword, ", len(conds)), ", ")
+
+    return fmt.Sprintf(`
+        %sModel = %sModel.
+            Where("%s", %s)`, str.LowerCamel(name), str.LowerCamel(name), strings.Join(conds, " OR "), args)
+}
+
+// 接口文档参数
+func (this Crud) swaggerParams() string {
+    columns := this.formColumns()
+
+    nameLen := 0
+    for _, column := range columns {
+        if len(column["name"]) > nameLen {
+            nameLen = len(column["name"])
+        }
+    }
+
+    lines := make([]string, 0, len(columns))
+    for _, column := range columns {
+        required := "false"
+        if this.isRequired(column) {
+            required = "true"
+        }
+
+        lines = append(lines, fmt.Sprintf(
+            `// @Param %-*s formData string %s "%s"`,
+            nameLen, column["name"], required, this.columnTitle(column),
+        ))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 添加字段
+func (this Crud) createFields() string {
+    lines := make([]string, 0)
+
+    if this.IsUUIDKey() {
+        lines = append(lines, fmt.Sprintf(`        "%s": uuid.ToUUIDString(),`, this.PrimaryKey()["name"]))
+    }
+
+    for _, column := range this.formColumns() {
+        lines = append(lines, fmt.Sprintf(`        "%s": post["%s"],`, column["name"], column["name"]))
+    }
+
+    for _, name := range []string{"add_time", "add_ip"} {
+        if this.hasColumn(name) {
+            lines = append(lines, fmt.Sprintf(`        "%s": %s,`, name, crudAutoFields[name]))
+        }
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 更新字段
+func (this Crud) updateFields() string {
+    lines := make([]string, 0)
+
+    for _, column := range this.formColumns() {
+        lines = append(lines, fmt.Sprintf(`            "%s": post["%s"],`, column["name"], column["name"]))
+    }
+
+    for _, name := range []string{"update_time", "update_ip"} {
+        if this.hasColumn(name) {
+            lines = append(lines, fmt.Sprintf(`            "%s": %s,`, name, crudAutoFields[name]))
+        }
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 表单字段，不含主键及自动填充字段
+func (this Crud) formColumns() []map[string]string {
+    columns := make([]map[string]string, 0)
+
+    for _, column := range this.Columns {
+        if column["key"] == "PRI" {
+            continue
+        }
+
+        if _, ok := crudAutoFields[column["name"]]; ok {
+            continue
+        }
+
+        columns = append(columns, column)
+    }
+
+    return columns
+}
+
+// 字段验证规则
+func (this Crud) columnRules(column map[string]string) []string {
+    rules := make([]string, 0)
+
+    if this.isRequired(column) {
+        rules = append(rules, "required")
+    }
+
+    if this.isStringType(column["type"]) {
+        if match := crudTypeLengthRe.FindStringSubmatch(column["type"]); len(match) > 1 {
+            rules = append(rules, "max=" + match[1])
+        }
+    }
+
+    return rules
+}
+
+// 是否必填
+func (this Crud) isRequired(column map[string]string) bool {
+    return column["null"] == "NO" && column["default"] == "" && !this.isAutoIncrement(column)
+}
+
+// 是否自增
+func (this Crud) isAutoIncrement(column map[string]string) bool {
+    return strings.Contains(column["extra"], "auto_increment")
+}
+
+// 字段名称
+func (this Crud) columnTitle(column map[string]string) string {
+    if column["comment"] != "" {
+        return column["comment"]
+    }
+
+    return column["name"]
+}
+
+// 结构体字段名
+func (this Crud) fieldName(name string) string {
+    if name == "id" {
+        return "ID"
+    }
+
+    return str.Camel(name)
+}
+
+// 结构体标签
+func (this Crud) fieldTag(column map[string]string) string {
+    tags := []string{
+        "column:" + column["name"],
+        "type:" + column["type"],
+    }
+
+    if column["null"] == "NO" {
+        tags = append(tags, "not null")
+    }
+
+    if column["key"] == "PRI" {
+        tags = append(tags, "primaryKey")
+    }
+
+    if this.isAutoIncrement(column) {
+        tags = append(tags, "autoIncrement")
+    }
+
+    if column["comment"] != "" {
+        tags = append(tags, "comment:" + strings.ReplaceAll(column["comment"], ";", ","))
+    }
+
+    return fmt.Sprintf("`gorm:\"%s;\" json:\"%s\"`", strings.Join(tags, ";"), column["name"])
+}
+
+// 字段类型对应的 go 类型
+func (this Crud) goType(column map[string]string) string {
+    typ := strings.ToLower(column["type"])
+
+    switch {
+        case strings.HasPrefix(typ, "bigint"):
+            return "int64"
+        case strings.HasPrefix(typ, "tinyint"),
+            strings.HasPrefix(typ, "smallint"),
+            strings.HasPrefix(typ, "mediumint"),
+            strings.HasPrefix(typ, "int"),
+            strings.HasPrefix(typ, "integer"):
+            return "int"
+        case strings.HasPrefix(typ, "float"),
+            strings.HasPrefix(typ, "double"),
+            strings.HasPrefix(typ, "decimal"):
+            return "float64"
+        default:
+            return "string"
+    }
+}
+
+// 是否为字符类型
+func (this Crud) isStringType(typ string) bool {
+    typ = strings.ToLower(typ)
+
+    return strings.HasPrefix(typ, "char") ||
+        strings.HasPrefix(typ, "varchar") ||
+        strings.HasSuffix(typ, "text")
+}
+
+// 是否有字段
+func (this Crud) hasColumn(name string) bool {
+    for _, column := range this.Columns {
+        if column["name"] == name {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 是否有其中一个字段
+func (this Crud) hasAnyColumn(names ...string) bool {
+    for _, name := range names {
+        if this.hasColumn(name) {
+            return true
+        }
+    }
+
+    return false
+}
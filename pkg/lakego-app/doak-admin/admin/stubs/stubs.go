@@ -1,14 +1,17 @@
 package stubs
 
 import (
+    "os"
     "fmt"
     "embed"
     "errors"
     "strings"
+    "path/filepath"
 
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-filesystem/filesystem"
 
+    "github.com/deatil/lakego-doak/lakego/str"
     "github.com/deatil/lakego-doak/lakego/path"
 )
 
@@ -61,6 +64,135 @@ func (this Stubs) MakeModel(name string, data map[string]string, force bool) err
     return this.CopyFile(srcData, dstFile, data, force)
 }
 
+// 根据模板生成文件，dst 为相对程序根目录的路径
+func (this Stubs) Make(stub string, dst string, data map[string]string, force bool) error {
+    if _, ok := data["datetime"]; !ok {
+        data["datetime"] = datebin.Now().ToDatetimeString()
+    }
+
+    srcData, err := this.readStubFile(stub)
+    if err != nil {
+        return err
+    }
+
+    return this.CopyFile(srcData, path.RootPath(dst), data, force)
+}
+
+// 生成模块，返回生成的文件列表
+func (this Stubs) MakeModule(name string, force bool) ([]string, error) {
+    kebab := str.Kebab(name)
+
+    data := map[string]string{
+        "moduleName":      str.Camel(name),
+        "moduleLowerName": str.LowerCamel(name),
+        "moduleSnake":     str.Snake(name),
+        "moduleKebab":     kebab,
+        "moduleDir":       kebab,
+        "modulePath":      "github.com/deatil/lakego-doak-" + kebab,
+    }
+
+    root := "pkg/lakego-app/doak-" + kebab
+    dir := root + "/" + kebab
+    file := str.Snake(name) + ".go"
+
+    files := [][2]string{
+        {"module/go.mod", root + "/go.mod"},
+        {"module/lang", root + "/resources/lang/en.json"},
+        {"module/bootstrap", dir + "/bootstrap/bootstrap.go"},
+        {"module/provider", dir + "/provider/" + file},
+        {"module/route", dir + "/route/route.go"},
+        {"module/controller", dir + "/controller/" + file},
+        {"module/service", dir + "/service/" + file},
+    }
+
+    if this.Exists(path.RootPath(root)) && !force {
+        return nil, errors.New("[" + root + "] 模块已经存在 !")
+    }
+
+    made := make([]string, 0, len(files))
+    for _, f := range files {
+        if err := this.Make(f[0], f[1], data, force); err != nil {
+            return made, err
+        }
+
+        made = append(made, f[1])
+    }
+
+    return made, nil
+}
+
+// 生成中间件，dir 为相对程序根目录的模块目录
+func (this Stubs) MakeMiddleware(dir string, name string, force bool) (string, error) {
+    pkg := strings.ReplaceAll(str.Snake(name), "_", "")
+
+    data := map[string]string{
+        "middlewareName":    str.Camel(name),
+        "middlewarePackage": pkg,
+        "middlewareAlias":   str.Kebab(name),
+    }
+
+    dst := this.formatDir(dir) + "/middleware/" + pkg + "/" + pkg + ".go"
+
+    return dst, this.Make("middleware", dst, data, force)
+}
+
+// 生成脚本
+func (this Stubs) MakeCommand(dir string, name string, use string, force bool) (string, error) {
+    if use == "" {
+        use = "app:" + str.Kebab(name)
+    }
+
+    data := map[string]string{
+        "commandName":      str.Camel(name),
+        "commandLowerName": str.LowerCamel(name),
+        "commandUse":       use,
+    }
+
+    dst := this.formatDir(dir) + "/cmd/" + str.Snake(name) + ".go"
+
+    return dst, this.Make("command", dst, data, force)
+}
+
+// 根据数据表字段生成模型、验证器及控制器
+func (this Stubs) MakeCrud(dir string, crud Crud, force bool) ([]string, error) {
+    dir = this.formatDir(dir)
+
+    importPath, err := ImportPath(path.RootPath(dir))
+    if err != nil {
+        return nil, err
+    }
+
+    data := crud.Data(importPath + "/model", importPath + "/validate/" + strings.ReplaceAll(str.Snake(crud.Name), "_", ""))
+    data["slugPrefix"] = this.slugPrefix(importPath)
+
+    file := str.Snake(crud.Name) + ".go"
+
+    files := [][2]string{
+        {"crud/model", dir + "/model/" + file},
+        {"crud/validate", dir + "/validate/" + data["validatePackage"] + "/" + data["validatePackage"] + ".go"},
+        {"crud/controller", dir + "/controller/" + file},
+    }
+
+    if !force {
+        for _, f := range files {
+            if this.Exists(path.RootPath(f[1])) {
+                return nil, errors.New("[" + f[1] + "] 文件已经存在 !")
+            }
+        }
+    }
+
+    made := make([]string, 0, len(files))
+    for _, f := range files {
+        if err := this.Make(f[0], f[1], data, force); err != nil {
+            return made, err
+        }
+
+        made = append(made, f[1])
+    }
+
+    return made, nil
+}
+
 // 复制文件
 func (this Stubs) CopyFile(srcData string, dst string, data map[string]string, force bool) error {
     if this.Exists(dst) && !force {
@@ -96,6 +228,25 @@ func (this Stubs) Exists(path string) bool {
     return filesystem.Exists(path)
 }
 
+// 格式化模块目录
+func (this Stubs) formatDir(dir string) string {
+    dir = strings.Trim(filepath.ToSlash(dir), "/")
+    if dir == "" {
+        dir = "app/admin"
+    }
+
+    return dir
+}
+
+// 权限标识前缀，app 下的模块为 app-admin，其他为 lakego-admin
+func (this Stubs) slugPrefix(importPath string) string {
+    if importPath == "app" || strings.HasPrefix(importPath, "app/") {
+        return "app-admin"
+    }
+
+    return "lakego-admin"
+}
+
 // 读取模板文件
 func (this Stubs) readStubFile(name string) (string, error) {
     fileName := fmt.Sprintf("%s/%s.stub", this.stubDir, name)
@@ -107,3 +258,46 @@ func (this Stubs) readStubFile(name string) (string, error) {
 
     return string(bytes), nil
 }
+
+// 获取目录对应的 go 包路径
+func ImportPath(dir string) (string, error) {
+    dir, err := filepath.Abs(dir)
+    if err != nil {
+        return "", err
+    }
+
+    for current := dir; ; current = filepath.Dir(current) {
+        data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+        if err == nil {
+            module := ""
+            for _, line := range strings.Split(string(data), "\n") {
+                line = strings.TrimSpace(line)
+                if strings.HasPrefix(line, "module ") {
+                    module = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+                    break
+                }
+            }
+
+            if module == "" {
+                return "", errors.New("[" + current + "/go.mod] 没有模块名称 !")
+            }
+
+            rel, err := filepath.Rel(current, dir)
+            if err != nil {
+                return "", err
+            }
+
+            if rel == "." {
+                return module, nil
+            }
+
+            return module + "/" + filepath.ToSlash(rel), nil
+        }
+
+        if filepath.Dir(current) == current {
+            break
+        }
+    }
+
+    return "", errors.New("[" + dir + "] 没有找到 go.mod 文件 !")
+}
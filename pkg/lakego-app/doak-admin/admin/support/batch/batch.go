@@ -0,0 +1,209 @@
+package batch
+
+import (
+    "errors"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 单次最大数量
+const MaxItems = 500
+
+var (
+    // ID 列表为空
+    ErrEmptyIds = errors.New("batch: ids is empty")
+
+    // ID 数量超出限制
+    ErrTooManyIds = errors.New("batch: too many ids")
+
+    // 部分数据失败，事务已回滚
+    ErrRollback = errors.New("batch: rolled back")
+)
+
+// 单条结果
+type Result struct {
+    ID      string `json:"id"`
+    Success bool   `json:"success"`
+    Message string `json:"message"`
+}
+
+/**
+ * 批量结果
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Report struct {
+    // 单条结果
+    Results []Result `json:"results"`
+
+    // 总数
+    Total int `json:"total"`
+
+    // 成功数量
+    Success int `json:"success"`
+
+    // 失败数量
+    Failed int `json:"failed"`
+
+    // 是否已提交
+    Committed bool `json:"committed"`
+}
+
+// 是否有成功提交的数据
+func (this Report) Changed() bool {
+    return this.Committed && this.Success > 0
+}
+
+// 构造函数
+func New(db *gorm.DB) *Batch {
+    return &Batch{
+        db: db,
+    }
+}
+
+/**
+ * 批量操作
+ *
+ * 全部数据在同一事务中执行，默认有失败时全部回滚
+ * 允许部分成功时每条数据使用保存点，失败的数据单独回滚
+ * 数据表需要使用支持事务的引擎，比如 InnoDB
+ *
+ * report, err := batch.New(model.NewDB()).
+ *     Partial(false).
+ *     Run(ids, func(tx *gorm.DB, id string) error {
+ *         return tx.Model(&model.AuthGroup{}).
+ *             Where("id = ?", id).
+ *             Update("status", 1).
+ *             Error
+ *     })
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Batch struct {
+    // 数据库
+    db *gorm.DB
+
+    // 允许部分成功
+    partial bool
+}
+
+// 允许部分成功
+func (this *Batch) Partial(partial bool) *Batch {
+    this.partial = partial
+
+    return this
+}
+
+// 执行
+func (this *Batch) Run(ids []string, fn func(tx *gorm.DB, id string) error) (Report, error) {
+    if len(ids) == 0 {
+        return Report{}, ErrEmptyIds
+    }
+
+    if len(ids) > MaxItems {
+        return Report{}, ErrTooManyIds
+    }
+
+    report := Report{
+        Results: make([]Result, 0, len(ids)),
+        Total:   len(ids),
+    }
+
+    err := this.db.Transaction(func(tx *gorm.DB) error {
+        for i, id := range ids {
+            var err error
+            if this.partial {
+                err = this.runWithSavePoint(tx, "batch_" + goch.ToString(i), id, fn)
+            } else {
+                err = fn(tx, id)
+            }
+
+            result := Result{
+                ID:      id,
+                Success: err == nil,
+            }
+
+            if err != nil {
+                result.Message = err.Error()
+                report.Failed++
+            } else {
+                report.Success++
+            }
+
+            report.Results = append(report.Results, result)
+        }
+
+        if !this.partial && report.Failed > 0 {
+            return ErrRollback
+        }
+
+        return nil
+    })
+
+    if err != nil {
+        // 回滚后全部数据都未生效
+        report.Success = 0
+        report.Failed = report.Total
+        for i := range report.Results {
+            if report.Results[i].Success {
+                report.Results[i].Success = false
+                report.Results[i].Message = "已回滚"
+            }
+        }
+
+        return report, err
+    }
+
+    report.Committed = true
+
+    return report, nil
+}
+
+// 使用保存点执行单条数据
+func (this *Batch) runWithSavePoint(tx *gorm.DB, name string, id string, fn func(tx *gorm.DB, id string) error) error {
+    if err := tx.SavePoint(name).Error; err != nil {
+        return err
+    }
+
+    if err := fn(tx, id); err != nil {
+        tx.RollbackTo(name)
+        return err
+    }
+
+    return nil
+}
+
+// 格式化 ID 列表，支持数组及逗号分隔的字符
+func ParseIds(data any) []string {
+    items := make([]string, 0)
+
+    switch v := data.(type) {
+        case string:
+            items = strings.Split(v, ",")
+        case []string:
+            items = v
+        case []any:
+            for _, vv := range v {
+                items = append(items, goch.ToString(vv))
+            }
+    }
+
+    ids := make([]string, 0, len(items))
+    exists := make(map[string]bool, len(items))
+    for _, id := range items {
+        id = strings.TrimSpace(id)
+        if id == "" || exists[id] {
+            continue
+        }
+
+        exists[id] = true
+        ids = append(ids, id)
+    }
+
+    return ids
+}
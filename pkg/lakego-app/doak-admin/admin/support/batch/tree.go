@@ -0,0 +1,79 @@
+package batch
+
+import (
+    "sort"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 上级关系，id => parentid
+type Parents map[string]string
+
+// 获取上级关系，db 需要带有 id 及 parentid 字段
+func LoadParents(db *gorm.DB) (Parents, error) {
+    list := make([]map[string]any, 0)
+    err := db.Select("id", "parentid").
+        Find(&list).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    parents := make(Parents, len(list))
+    for _, item := range list {
+        parents[goch.ToString(item["id"])] = goch.ToString(item["parentid"])
+    }
+
+    return parents, nil
+}
+
+// id 是否存在
+func (this Parents) Has(id string) bool {
+    _, ok := this[id]
+
+    return ok
+}
+
+// id 是否为 ancestor 本身或者其子级
+func (this Parents) IsDescendant(id string, ancestor string) bool {
+    // 防止数据异常时死循环
+    for i := 0; i <= len(this); i++ {
+        if id == ancestor {
+            return true
+        }
+
+        parentid, ok := this[id]
+        if !ok {
+            return false
+        }
+
+        id = parentid
+    }
+
+    return false
+}
+
+// 层级深度
+func (this Parents) Depth(id string) int {
+    depth := 0
+    for this.Has(id) && depth <= len(this) {
+        id = this[id]
+        depth++
+    }
+
+    return depth
+}
+
+// 按层级从深到浅排序，删除时子级在前
+func (this Parents) SortByDepth(ids []string) []string {
+    sorted := make([]string, len(ids))
+    copy(sorted, ids)
+
+    sort.SliceStable(sorted, func(i, j int) bool {
+        return this.Depth(sorted[i]) > this.Depth(sorted[j])
+    })
+
+    return sorted
+}
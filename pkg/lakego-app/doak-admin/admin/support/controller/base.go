@@ -2,6 +2,8 @@ package controller
 
 import (
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/lang"
+    "github.com/deatil/lakego-doak/lakego/facade/validate"
     httpRequest "github.com/deatil/lakego-doak/lakego/http/request"
     httpResponse "github.com/deatil/lakego-doak/lakego/http/response"
 
@@ -31,3 +33,21 @@ func (this *Base) Request(ctx *router.Context) *httpRequest.Request {
 func (this *Base) response(ctx *router.Context) *httpResponse.Response {
     return httpResponse.New().WithContext(ctx)
 }
+
+/**
+ * 翻译，使用当前请求的语言
+ *
+ * this.Trans(ctx, "欢迎 :name", map[string]any{"name": "lakego"})
+ */
+func (this *Base) Trans(ctx *router.Context, key string, replace ...map[string]any) string {
+    return lang.T(ctx, key, replace...)
+}
+
+/**
+ * 验证器，使用当前请求的语言
+ *
+ * adminValidate.Create(this.Validator(ctx), post)
+ */
+func (this *Base) Validator(ctx *router.Context) *validate.Validator {
+    return validate.Locale(lang.Locale(ctx))
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+    "strings"
+
+    "gorm.io/gorm"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 筛选类型
+const (
+    // 等于，?status=1
+    FilterEq = "eq"
+
+    // 包含，?id=1,2,3
+    FilterIn = "in"
+
+    // 范围，?start_time=2022-01-01&end_time=2022-12-31
+    FilterRange = "range"
+
+    // 模糊匹配，?title=lakego
+    FilterLike = "like"
+)
+
+/**
+ * 筛选字段
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Filter struct {
+    // 数据表字段
+    Field string
+
+    // 请求参数名称，默认为 Field
+    // 范围筛选时为 start_{Param} 及 end_{Param}
+    Param string
+
+    // 筛选类型，默认为 eq
+    Type string
+
+    // 值格式化，返回 false 时跳过该条件
+    Format func(value string) (any, bool)
+}
+
+// 请求参数名称
+func (this Filter) ParamName() string {
+    if this.Param != "" {
+        return this.Param
+    }
+
+    return this.Field
+}
+
+// 添加查询条件
+func (this Filter) Apply(ctx *router.Context, db *gorm.DB) *gorm.DB {
+    param := this.ParamName()
+
+    switch this.Type {
+        case FilterIn:
+            value := ctx.Query(param)
+            if value == "" {
+                return db
+            }
+
+            values := make([]any, 0)
+            for _, v := range strings.Split(value, ",") {
+                if v = strings.TrimSpace(v); v == "" {
+                    continue
+                }
+
+                if newValue, ok := this.format(v); ok {
+                    values = append(values, newValue)
+                }
+            }
+
+            if len(values) == 0 {
+                return db
+            }
+
+            return db.Where(this.Field + " IN ?", values)
+
+        case FilterRange:
+            if start := ctx.Query("start_" + param); start != "" {
+                if value, ok := this.format(start); ok {
+                    db = db.Where(this.Field + " >= ?", value)
+                }
+            }
+
+            if end := ctx.Query("end_" + param); end != "" {
+                if value, ok := this.format(end); ok {
+                    db = db.Where(this.Field + " <= ?", value)
+                }
+            }
+
+            return db
+
+        case FilterLike:
+            value := ctx.Query(param)
+            if value == "" {
+                return db
+            }
+
+            return db.Where(this.Field + " LIKE ?", "%" + value + "%")
+
+        default:
+            value := ctx.Query(param)
+            if value == "" {
+                return db
+            }
+
+            newValue, ok := this.format(value)
+            if !ok {
+                return db
+            }
+
+            return db.Where(this.Field + " = ?", newValue)
+    }
+}
+
+// 格式化值
+func (this Filter) format(value string) (any, bool) {
+    if this.Format != nil {
+        return this.Format(value)
+    }
+
+    return value, true
+}
+
+// 时间格式化为时间戳
+func FormatDate(value string) (any, bool) {
+    return datebin.StringToTimestamp(value), true
+}
+
+// 状态格式化，open 为 1，close 为 0
+func FormatStatus(value string) (any, bool) {
+    switch value {
+        case "open":
+            return 1, true
+        case "close":
+            return 0, true
+    }
+
+    return nil, false
+}
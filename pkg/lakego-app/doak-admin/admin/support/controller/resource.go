@@ -0,0 +1,552 @@
+package controller
+
+import (
+    "strings"
+
+    "gorm.io/gorm"
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/response"
+    "github.com/deatil/lakego-doak-admin/admin/support/paginate"
+)
+
+// 分页类型
+const (
+    // start + limit 分页
+    PaginateOffset = "offset"
+
+    // 游标分页
+    PaginateCursor = "cursor"
+)
+
+// 保存场景
+const (
+    SceneCreate = "create"
+    SceneUpdate = "update"
+)
+
+/**
+ * 资源配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ResourceConfig struct {
+    // 模型
+    Model func() *gorm.DB
+
+    // 主键，默认为 id
+    PrimaryKey string
+
+    // 可排序字段
+    Sortable []string
+
+    // 默认排序，默认为 add_time__DESC
+    DefaultOrder string
+
+    // 关键字 searchword 搜索的字段
+    Searchable []string
+
+    // 筛选字段
+    Filters []Filter
+
+    // 分页类型，默认为 offset，请求带有 cursor 参数时使用游标分页
+    Paginate string
+
+    // 总数策略，exact, estimate 或者 none，默认使用 admin.paginate.total 配置
+    Total string
+
+    // 默认每页数量，默认为 10
+    Limit int
+
+    // 最大每页数量，为 0 时不限制
+    MaxLimit int
+
+    // 列表输出字段，为空时输出全部，可用 fields 参数选择其中部分字段
+    Fields []string
+
+    // 详情输出字段，为空时输出全部
+    DetailFields []string
+
+    // 添加及更新时可写入的字段
+    Fillable []string
+
+    // 验证，返回错误信息
+    Validate func(data map[string]any, scene string) string
+
+    // 保存前处理数据
+    Saving func(ctx *router.Context, data map[string]any, scene string) error
+
+    // 删除前检测
+    Deleting func(ctx *router.Context, info map[string]any) error
+
+    // 输出数据格式化
+    Format func(item map[string]any) map[string]any
+
+    // 添加时生成主键，为空时使用数据库自增
+    KeyGenerator func() any
+
+    // 自动写入 add_time, add_ip, update_time, update_ip
+    Timestamps bool
+
+    // 状态字段，默认为 status
+    StatusField string
+}
+
+// 构造函数
+func NewResource(conf ResourceConfig) Resource {
+    if conf.PrimaryKey == "" {
+        conf.PrimaryKey = "id"
+    }
+    if conf.DefaultOrder == "" {
+        conf.DefaultOrder = "add_time__DESC"
+    }
+    if conf.Paginate == "" {
+        conf.Paginate = PaginateOffset
+    }
+    if conf.Limit <= 0 {
+        conf.Limit = 10
+    }
+    if conf.StatusField == "" {
+        conf.StatusField = "status"
+    }
+
+    return Resource{
+        Config: conf,
+    }
+}
+
+/**
+ * 资源控制器
+ *
+ * 根据配置生成列表、详情、添加、更新、删除、启用及禁用接口
+ *
+ * type Book struct {
+ *     controller.Resource
+ * }
+ *
+ * bookController := &Book{
+ *     Resource: controller.NewResource(controller.ResourceConfig{
+ *         Model:      model.NewBook,
+ *         Sortable:   []string{"id", "title", "add_time"},
+ *         Searchable: []string{"title"},
+ *         Filters:    []controller.Filter{
+ *             {Field: "status", Format: controller.FormatStatus},
+ *             {Field: "add_time", Param: "time", Type: controller.FilterRange, Format: controller.FormatDate},
+ *         },
+ *         Fillable:   []string{"title", "status"},
+ *     }),
+ * }
+ * engine.GET("/book", bookController.Index)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Resource struct {
+    Base
+
+    // 配置
+    Config ResourceConfig
+}
+
+// 列表
+func (this *Resource) Index(ctx *router.Context) {
+    data, err := this.List(ctx, this.Config.Model())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", data)
+}
+
+// 详情
+func (this *Resource) Detail(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    db := this.Config.Model()
+    if len(this.Config.DetailFields) > 0 {
+        db = db.Select(this.Config.DetailFields)
+    }
+
+    info := map[string]any{}
+    err := db.
+        Where(this.Config.PrimaryKey + " = ?", id).
+        First(&info).
+        Error
+    if err != nil || len(info) < 1 {
+        this.Error(ctx, "信息不存在")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", this.format(info))
+}
+
+// 添加
+func (this *Resource) Create(ctx *router.Context) {
+    data, ok := this.bindData(ctx, SceneCreate)
+    if !ok {
+        return
+    }
+
+    if this.Config.KeyGenerator != nil {
+        data[this.Config.PrimaryKey] = this.Config.KeyGenerator()
+    }
+
+    if this.Config.Timestamps {
+        data["add_time"] = int(datebin.NowTime())
+        data["add_ip"] = router.GetRequestIp(ctx)
+    }
+
+    err := this.Config.Model().
+        Create(data).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息添加失败")
+        return
+    }
+
+    this.SuccessWithData(ctx, "信息添加成功", router.H{
+        "id": data[this.Config.PrimaryKey],
+    })
+}
+
+// 更新
+func (this *Resource) Update(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    if _, ok := this.find(ctx, id); !ok {
+        return
+    }
+
+    data, ok := this.bindData(ctx, SceneUpdate)
+    if !ok {
+        return
+    }
+
+    if this.Config.Timestamps {
+        data["update_time"] = int(datebin.NowTime())
+        data["update_ip"] = router.GetRequestIp(ctx)
+    }
+
+    err := this.Config.Model().
+        Where(this.Config.PrimaryKey + " = ?", id).
+        Updates(data).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息修改失败")
+        return
+    }
+
+    this.Success(ctx, "信息修改成功")
+}
+
+// 删除
+func (this *Resource) Delete(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    info, ok := this.find(ctx, id)
+    if !ok {
+        return
+    }
+
+    if this.Config.Deleting != nil {
+        if err := this.Config.Deleting(ctx, info); err != nil {
+            this.Error(ctx, err.Error())
+            return
+        }
+    }
+
+    err := this.Config.Model().
+        Where(this.Config.PrimaryKey + " = ?", id).
+        Delete(nil).
+        Error
+    if err != nil {
+        this.Error(ctx, "信息删除失败")
+        return
+    }
+
+    this.Success(ctx, "信息删除成功")
+}
+
+// 启用
+func (this *Resource) Enable(ctx *router.Context) {
+    this.switchStatus(ctx, 1)
+}
+
+// 禁用
+func (this *Resource) Disable(ctx *router.Context) {
+    this.switchStatus(ctx, 0)
+}
+
+// 列表数据，db 可带有额外条件
+// 带有 cursor 参数时使用游标分页
+func (this *Resource) List(ctx *router.Context, db *gorm.DB) (response.Page, error) {
+    limit := goch.ToInt(ctx.DefaultQuery("limit", goch.ToString(this.Config.Limit)))
+    if limit <= 0 {
+        limit = this.Config.Limit
+    }
+    if this.Config.MaxLimit > 0 && limit > this.Config.MaxLimit {
+        limit = this.Config.MaxLimit
+    }
+
+    field, sort := this.Order(ctx)
+
+    paginator := paginate.New(this.Query(ctx, db)).
+        Select(this.selectFields(ctx)).
+        Order(field, sort).
+        Key(this.Config.PrimaryKey).
+        Limit(limit).
+        Total(this.Config.Total)
+
+    cursor, hasCursor := ctx.GetQuery("cursor")
+    if this.Config.Paginate == PaginateCursor || hasCursor {
+        paginator.Cursor(cursor)
+    } else {
+        paginator.Offset(goch.ToInt(ctx.DefaultQuery("start", "0")))
+    }
+
+    list := make([]map[string]any, 0)
+
+    page, err := paginator.Find(&list)
+    if err != nil {
+        return page, err
+    }
+
+    page.List = this.formatList(list)
+
+    return page, nil
+}
+
+// 添加搜索及筛选条件
+func (this *Resource) Query(ctx *router.Context, db *gorm.DB) *gorm.DB {
+    // 搜索条件
+    searchword := ctx.DefaultQuery("searchword", "")
+    if searchword != "" && len(this.Config.Searchable) > 0 {
+        searchword = "%" + searchword + "%"
+
+        where := db.Session(&gorm.Session{NewDB: true})
+        for i, field := range this.Config.Searchable {
+            if i == 0 {
+                where = where.Where(field + " LIKE ?", searchword)
+            } else {
+                where = where.Or(field + " LIKE ?", searchword)
+            }
+        }
+
+        db = db.Where(where)
+    }
+
+    // 筛选条件
+    for _, filter := range this.Config.Filters {
+        db = filter.Apply(ctx, db)
+    }
+
+    return db
+}
+
+// 排序，不在可排序字段中时使用默认排序
+func (this *Resource) Order(ctx *router.Context) (string, string) {
+    orders := this.parseOrder(ctx.DefaultQuery("order", this.Config.DefaultOrder))
+    if this.isSortable(orders[0]) {
+        return orders[0], orders[1]
+    }
+
+    orders = this.parseOrder(this.Config.DefaultOrder)
+
+    return orders[0], orders[1]
+}
+
+// 查询数据，不存在时输出错误
+func (this *Resource) find(ctx *router.Context, id string) (map[string]any, bool) {
+    info := map[string]any{}
+
+    err := this.Config.Model().
+        Where(this.Config.PrimaryKey + " = ?", id).
+        First(&info).
+        Error
+    if err != nil || len(info) < 1 {
+        this.Error(ctx, "信息不存在")
+        return nil, false
+    }
+
+    return info, true
+}
+
+// 接收并验证数据，失败时输出错误
+func (this *Resource) bindData(ctx *router.Context, scene string) (map[string]any, bool) {
+    post := make(map[string]any)
+    this.Request(ctx).ShouldBindJSONWith(&post)
+
+    if this.Config.Validate != nil {
+        if validateErr := this.Config.Validate(post, scene); validateErr != "" {
+            this.Error(ctx, validateErr)
+            return nil, false
+        }
+    }
+
+    data := make(map[string]any)
+    for _, field := range this.Config.Fillable {
+        if value, ok := post[field]; ok {
+            data[field] = value
+        }
+    }
+
+    if this.Config.Saving != nil {
+        if err := this.Config.Saving(ctx, data, scene); err != nil {
+            this.Error(ctx, err.Error())
+            return nil, false
+        }
+    }
+
+    if len(data) == 0 {
+        this.Error(ctx, "数据不能为空")
+        return nil, false
+    }
+
+    return data, true
+}
+
+// 设置状态
+func (this *Resource) switchStatus(ctx *router.Context, status int) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "ID不能为空")
+        return
+    }
+
+    info, ok := this.find(ctx, id)
+    if !ok {
+        return
+    }
+
+    field := this.Config.StatusField
+
+    if goch.ToInt(info[field]) == status {
+        if status == 1 {
+            this.Error(ctx, "信息已启用")
+        } else {
+            this.Error(ctx, "信息已禁用")
+        }
+
+        return
+    }
+
+    err := this.Config.Model().
+        Where(this.Config.PrimaryKey + " = ?", id).
+        Updates(map[string]any{
+            field: status,
+        }).
+        Error
+    if err != nil {
+        if status == 1 {
+            this.Error(ctx, "启用失败")
+        } else {
+            this.Error(ctx, "禁用失败")
+        }
+
+        return
+    }
+
+    if status == 1 {
+        this.Success(ctx, "启用成功")
+    } else {
+        this.Success(ctx, "禁用成功")
+    }
+}
+
+// 输出字段，fields 参数只能选择 Fields 中的字段
+func (this *Resource) selectFields(ctx *router.Context) []string {
+    query := ctx.Query("fields")
+    if query == "" || len(this.Config.Fields) == 0 {
+        return this.Config.Fields
+    }
+
+    allowed := make(map[string]bool, len(this.Config.Fields))
+    for _, field := range this.Config.Fields {
+        allowed[field] = true
+    }
+
+    fields := make([]string, 0)
+    for _, field := range strings.Split(query, ",") {
+        field = strings.TrimSpace(field)
+        if allowed[field] {
+            fields = append(fields, field)
+        }
+    }
+
+    if len(fields) == 0 {
+        return this.Config.Fields
+    }
+
+    return fields
+}
+
+// 解析排序，格式为 field__DESC
+func (this *Resource) parseOrder(order string) []string {
+    orders := strings.SplitN(order, "__", 2)
+    if len(orders) != 2 {
+        orders = []string{orders[0], "DESC"}
+    }
+
+    orders[1] = strings.ToUpper(orders[1])
+    if orders[1] != "ASC" && orders[1] != "DESC" {
+        orders[1] = "DESC"
+    }
+
+    return orders
+}
+
+// 是否可排序
+func (this *Resource) isSortable(field string) bool {
+    return field != "" && this.inSlice(this.Config.Sortable, field)
+}
+
+// 格式化列表
+func (this *Resource) formatList(list []map[string]any) []map[string]any {
+    if this.Config.Format == nil {
+        return list
+    }
+
+    newList := make([]map[string]any, 0, len(list))
+    for _, item := range list {
+        newList = append(newList, this.Config.Format(item))
+    }
+
+    return newList
+}
+
+// 格式化数据
+func (this *Resource) format(item map[string]any) map[string]any {
+    if this.Config.Format == nil {
+        return item
+    }
+
+    return this.Config.Format(item)
+}
+
+// 是否在切片中
+func (this *Resource) inSlice(items []string, item string) bool {
+    for _, v := range items {
+        if v == item {
+            return true
+        }
+    }
+
+    return false
+}
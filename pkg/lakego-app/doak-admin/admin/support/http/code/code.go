@@ -3,11 +3,12 @@ package code
 // 常量
 const (
     // 常用业务状态码
-    StatusSuccess   int = 0
-    StatusError     int = 1
-    StatusException int = 99997
-    StatusUnknown   int = 99998
-    StatusInvalid   int = 99999
+    StatusSuccess         int = 0
+    StatusError           int = 1
+    StatusTooManyRequests int = 99996
+    StatusException       int = 99997
+    StatusUnknown         int = 99998
+    StatusInvalid         int = 99999
 
     LoginError  int = 100100
     LogoutError int = 100101
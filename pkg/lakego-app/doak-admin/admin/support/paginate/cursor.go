@@ -0,0 +1,129 @@
+package paginate
+
+import (
+    "time"
+    "bytes"
+    "errors"
+    "strings"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/json"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 游标方向
+const (
+    DirectionNext = "next"
+    DirectionPrev = "prev"
+)
+
+var (
+    // 游标格式错误或者签名不正确
+    ErrInvalidCursor = errors.New("paginate: invalid cursor")
+
+    // 游标与当前排序不一致
+    ErrCursorMismatch = errors.New("paginate: cursor does not match order")
+)
+
+/**
+ * 游标
+ *
+ * 记录翻页位置的排序字段值及唯一键值，编码后带有签名，客户端不能修改
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Cursor struct {
+    // 排序字段
+    Field string `json:"f"`
+
+    // 排序方式
+    Sort string `json:"s"`
+
+    // 排序字段值
+    Value any `json:"v"`
+
+    // 唯一键值
+    Key any `json:"k"`
+
+    // 方向
+    Direction string `json:"d"`
+}
+
+// 编码为字符
+func (this Cursor) Encode() string {
+    this.Value = normalizeValue(this.Value)
+    this.Key = normalizeValue(this.Key)
+
+    payload, _ := json.Marshal(this)
+
+    data := base64.RawURLEncoding.EncodeToString(payload)
+
+    return data + "." + sign(data)
+}
+
+// 解析游标
+func DecodeCursor(s string) (Cursor, error) {
+    data, signature, ok := strings.Cut(s, ".")
+    if !ok || !hmac.Equal([]byte(signature), []byte(sign(data))) {
+        return Cursor{}, ErrInvalidCursor
+    }
+
+    payload, err := base64.RawURLEncoding.DecodeString(data)
+    if err != nil {
+        return Cursor{}, ErrInvalidCursor
+    }
+
+    var cursor Cursor
+
+    // 数字使用 json.Number，避免大整数精度丢失
+    decoder := json.NewDecoder(bytes.NewReader(payload))
+    decoder.UseNumber()
+    if err := decoder.Decode(&cursor); err != nil {
+        return Cursor{}, ErrInvalidCursor
+    }
+
+    if cursor.Direction != DirectionNext && cursor.Direction != DirectionPrev {
+        return Cursor{}, ErrInvalidCursor
+    }
+
+    return cursor, nil
+}
+
+// 签名
+func sign(data string) string {
+    h := hmac.New(sha256.New, cursorKey())
+    h.Write([]byte(data))
+
+    return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// 签名密钥，未设置时使用签名链接的密钥
+func cursorKey() []byte {
+    key := config.New("admin").GetString("paginate.cursor-key")
+    if key == "" {
+        key = config.New("server").GetString("signed-url.key")
+    }
+
+    return []byte("lakego-paginate:" + key)
+}
+
+// 格式化字段值
+func normalizeValue(value any) any {
+    switch v := value.(type) {
+        case []byte:
+            return string(v)
+        case time.Time:
+            return v.Format("2006-01-02 15:04:05.999999")
+        case *time.Time:
+            if v == nil {
+                return nil
+            }
+
+            return v.Format("2006-01-02 15:04:05.999999")
+    }
+
+    return value
+}
@@ -0,0 +1,404 @@
+package paginate
+
+import (
+    "strings"
+
+    "gorm.io/gorm"
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/response"
+)
+
+// 总数策略
+const (
+    // count 查询
+    TotalExact = "exact"
+
+    // 使用 EXPLAIN 估算，失败时使用 count 查询
+    TotalEstimate = "estimate"
+
+    // 不查询总数
+    TotalNone = "none"
+)
+
+// 分页类型
+const (
+    ModeOffset = "offset"
+    ModeCursor = "cursor"
+)
+
+// 默认总数策略
+func DefaultTotal() string {
+    total := config.New("admin").GetString("paginate.total")
+    if total == "" {
+        return TotalExact
+    }
+
+    return total
+}
+
+// 构造函数
+func New(db *gorm.DB) *Paginator {
+    return &Paginator{
+        db:    db,
+        sort:  "DESC",
+        key:   "id",
+        limit: 10,
+        mode:  ModeOffset,
+        total: DefaultTotal(),
+    }
+}
+
+/**
+ * 分页
+ *
+ * 支持 offset 及游标分页，db 可以为 model.NewDB() 构建的任意查询
+ *
+ * list := make([]map[string]any, 0)
+ * page, err := paginate.New(model.NewActionLog().Where("status = ?", 1)).
+ *     Order("time", "DESC").
+ *     Limit(20).
+ *     Cursor(ctx.Query("cursor")).
+ *     Total(paginate.TotalNone).
+ *     Find(&list)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Paginator struct {
+    // 查询
+    db *gorm.DB
+
+    // 输出字段
+    fields []string
+
+    // 排序字段
+    field string
+
+    // 排序方式
+    sort string
+
+    // 唯一键
+    key string
+
+    // 每页数量
+    limit int
+
+    // 开始位置
+    start int
+
+    // 游标
+    cursor string
+
+    // 分页类型
+    mode string
+
+    // 总数策略
+    total string
+}
+
+// 输出字段
+func (this *Paginator) Select(fields []string) *Paginator {
+    this.fields = fields
+
+    return this
+}
+
+// 排序
+func (this *Paginator) Order(field string, sort string) *Paginator {
+    this.field = field
+
+    this.sort = strings.ToUpper(sort)
+    if this.sort != "ASC" {
+        this.sort = "DESC"
+    }
+
+    return this
+}
+
+// 唯一键，游标分页时作为第二排序字段
+func (this *Paginator) Key(key string) *Paginator {
+    this.key = key
+
+    return this
+}
+
+// 每页数量
+func (this *Paginator) Limit(limit int) *Paginator {
+    if limit > 0 {
+        this.limit = limit
+    }
+
+    return this
+}
+
+// offset 分页
+func (this *Paginator) Offset(start int) *Paginator {
+    if start < 0 {
+        start = 0
+    }
+
+    this.start = start
+    this.mode = ModeOffset
+
+    return this
+}
+
+// 游标分页，cursor 为空时为第一页
+func (this *Paginator) Cursor(cursor string) *Paginator {
+    this.cursor = cursor
+    this.mode = ModeCursor
+
+    return this
+}
+
+// 总数策略
+func (this *Paginator) Total(total string) *Paginator {
+    if total != "" {
+        this.total = total
+    }
+
+    return this
+}
+
+// 查询
+func (this *Paginator) Find(dest *[]map[string]any) (response.Page, error) {
+    if this.field == "" {
+        this.field = this.key
+    }
+
+    if this.mode == ModeCursor {
+        return this.findCursor(dest)
+    }
+
+    return this.findOffset(dest)
+}
+
+// offset 分页查询
+func (this *Paginator) findOffset(dest *[]map[string]any) (response.Page, error) {
+    err := this.query().
+        Order(this.field + " " + this.sort).
+        Offset(this.start).
+        Limit(this.limit).
+        Find(dest).
+        Error
+    if err != nil {
+        return response.Page{}, err
+    }
+
+    page := response.Page{
+        List:  *dest,
+        Start: this.start,
+        Limit: this.limit,
+    }
+
+    return page, this.countTotal(&page)
+}
+
+// 游标分页查询
+func (this *Paginator) findCursor(dest *[]map[string]any) (response.Page, error) {
+    direction := DirectionNext
+
+    query := this.query()
+
+    if this.cursor != "" {
+        cursor, err := DecodeCursor(this.cursor)
+        if err != nil {
+            return response.Page{}, err
+        }
+
+        if cursor.Field != this.field || cursor.Sort != this.sort {
+            return response.Page{}, ErrCursorMismatch
+        }
+
+        direction = cursor.Direction
+        query = this.whereCursor(query, cursor)
+    }
+
+    // 上一页时反向查询
+    sort := this.sort
+    if direction == DirectionPrev {
+        sort = reverseSort(sort)
+    }
+
+    query = query.Order(this.field + " " + sort)
+    if this.field != this.key {
+        query = query.Order(this.key + " " + sort)
+    }
+
+    // 多查询一条判断是否还有数据
+    list := make([]map[string]any, 0, this.limit + 1)
+    err := query.
+        Limit(this.limit + 1).
+        Find(&list).
+        Error
+    if err != nil {
+        return response.Page{}, err
+    }
+
+    hasMore := len(list) > this.limit
+    if hasMore {
+        list = list[:this.limit]
+    }
+
+    if direction == DirectionPrev {
+        for i, j := 0, len(list) - 1; i < j; i, j = i + 1, j - 1 {
+            list[i], list[j] = list[j], list[i]
+        }
+    }
+
+    *dest = list
+
+    page := response.Page{
+        List:  list,
+        Limit: this.limit,
+    }
+
+    if len(list) > 0 {
+        hasNext := hasMore || direction == DirectionPrev
+        hasPrev := (direction == DirectionNext && this.cursor != "") ||
+            (direction == DirectionPrev && hasMore)
+
+        if hasNext {
+            page.NextCursor = this.makeCursor(list[len(list) - 1], DirectionNext)
+        }
+        if hasPrev {
+            page.PrevCursor = this.makeCursor(list[0], DirectionPrev)
+        }
+    }
+
+    return page, this.countTotal(&page)
+}
+
+// 游标条件
+func (this *Paginator) whereCursor(query *gorm.DB, cursor Cursor) *gorm.DB {
+    op := "<"
+    if (this.sort == "ASC") == (cursor.Direction == DirectionNext) {
+        op = ">"
+    }
+
+    if this.field == this.key {
+        return query.Where(this.key + " " + op + " ?", cursor.Key)
+    }
+
+    return query.Where(
+        "(" + this.field + " " + op + " ? OR (" + this.field + " = ? AND " + this.key + " " + op + " ?))",
+        cursor.Value, cursor.Value, cursor.Key,
+    )
+}
+
+// 生成游标
+func (this *Paginator) makeCursor(item map[string]any, direction string) string {
+    return Cursor{
+        Field:     this.field,
+        Sort:      this.sort,
+        Value:     item[this.field],
+        Key:       item[this.key],
+        Direction: direction,
+    }.Encode()
+}
+
+// 总数
+func (this *Paginator) countTotal(page *response.Page) error {
+    page.TotalType = this.total
+
+    switch this.total {
+        case TotalNone:
+            return nil
+
+        case TotalEstimate:
+            if total, ok := this.estimate(); ok {
+                page.Total = &total
+                return nil
+            }
+
+            page.TotalType = TotalExact
+    }
+
+    var total int64
+    err := this.db.
+        Session(&gorm.Session{}).
+        Count(&total).
+        Error
+    if err != nil {
+        return err
+    }
+
+    page.Total = &total
+
+    return nil
+}
+
+// 使用 EXPLAIN 估算总数
+func (this *Paginator) estimate() (int64, bool) {
+    stmt := this.db.
+        Session(&gorm.Session{DryRun: true}).
+        Find(&[]map[string]any{}).
+        Statement
+
+    sql := stmt.SQL.String()
+    if sql == "" {
+        return 0, false
+    }
+
+    rows := make([]map[string]any, 0)
+    err := this.db.
+        Session(&gorm.Session{NewDB: true}).
+        Raw("EXPLAIN " + sql, stmt.Vars...).
+        Scan(&rows).
+        Error
+    if err != nil || len(rows) == 0 {
+        return 0, false
+    }
+
+    for k, v := range rows[0] {
+        if strings.ToLower(k) == "rows" {
+            return goch.ToInt64(v), true
+        }
+    }
+
+    return 0, false
+}
+
+// 列表查询
+func (this *Paginator) query() *gorm.DB {
+    query := this.db.Session(&gorm.Session{})
+    if len(this.fields) == 0 {
+        return query
+    }
+
+    fields := this.fields
+
+    // 游标需要排序字段及唯一键
+    if this.mode == ModeCursor {
+        for _, name := range []string{this.field, this.key} {
+            if !inSlice(fields, name) {
+                fields = append(fields, name)
+            }
+        }
+    }
+
+    return query.Select(fields)
+}
+
+// 反向排序
+func reverseSort(sort string) string {
+    if sort == "ASC" {
+        return "DESC"
+    }
+
+    return "ASC"
+}
+
+// 是否在切片中
+func inSlice(items []string, item string) bool {
+    for _, v := range items {
+        if v == item {
+            return true
+        }
+    }
+
+    return false
+}
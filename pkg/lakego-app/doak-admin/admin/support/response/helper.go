@@ -53,6 +53,11 @@ func SuccessWithData(ctx *router.Context, msg string, data any) {
     New().SuccessWithData(ctx, msg, data)
 }
 
+// 返回成功 json，带分页数据
+func SuccessWithPage(ctx *router.Context, msg string, page Page) {
+    New().SuccessWithPage(ctx, msg, page)
+}
+
 // 返回错误 json
 func Error(ctx *router.Context, msg string, dataCode ...int) {
     New().Error(ctx, msg, dataCode...)
@@ -2,6 +2,7 @@ package response
 
 import (
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/lang"
     "github.com/deatil/lakego-doak/lakego/http/response"
 
     "github.com/deatil/lakego-doak-admin/admin/support/http/code"
@@ -20,6 +21,24 @@ type JSONResult struct {
     Data    any    `json:"data"`
 }
 
+/**
+ * 分页数据
+ *
+ * 总数策略为 none 时 total 为 null，游标为空时表示没有上一页或下一页
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Page struct {
+    List       any    `json:"list"`
+    Start      int    `json:"start"`
+    Limit      int    `json:"limit"`
+    Total      *int64 `json:"total"`
+    TotalType  string `json:"total_type"`
+    NextCursor string `json:"next_cursor"`
+    PrevCursor string `json:"prev_cursor"`
+}
+
 /**
  * 响应
  *
@@ -81,7 +100,7 @@ func (this *Response) ReturnJson(
     resp.ReturnJson(JSONResult{
         Success: success,
         Code:    dataCode,
-        Message: msg,
+        Message: lang.T(ctx, msg),
         Data:    data,
     })
 }
@@ -106,7 +125,7 @@ func (this *Response) ReturnJsonWithAbort(
     resp.ReturnJson(JSONResult{
         Success: success,
         Code:    dataCode,
-        Message: msg,
+        Message: lang.T(ctx, msg),
         Data:    data,
     })
 
@@ -138,6 +157,13 @@ func (this *Response) SuccessWithData(ctx *router.Context, msg string, data any)
     this.ReturnJson(ctx, true, dataCode, msg, data)
 }
 
+/**
+ * 返回成功 json，带分页数据
+ */
+func (this *Response) SuccessWithPage(ctx *router.Context, msg string, page Page) {
+    this.SuccessWithData(ctx, msg, page)
+}
+
 /**
  * 返回错误 json
  */
@@ -1,6 +1,8 @@
 package route
 
 import (
+    "time"
+
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade/config"
 )
@@ -26,3 +28,19 @@ func AddRoute(f func(rg *router.RouterGroup)) {
     }
 }
 
+
+// 生成路由链接
+// route.URL("admin.attachment.detail", map[string]any{"id": id}, nil)
+func URL(name string, params map[string]any, query map[string]any) (string, error) {
+    return router.URL(name, params, query)
+}
+
+// 生成签名路由链接，expire 为空时使用默认过期时间
+func SignedURL(name string, params map[string]any, query map[string]any, expire ...time.Duration) (string, error) {
+    exp := config.New("server").GetDuration("signed-url.expire")
+    if len(expire) > 0 {
+        exp = expire[0]
+    }
+
+    return router.SignedURL(name, params, query, exp)
+}
@@ -18,6 +18,21 @@ func AdminUrl(url string) string {
     return "/" + group + "/" + url
 }
 
+// 生成路由链接，失败时返回空字符串
+func RouteUrl(name string, params map[string]any, query ...map[string]any) string {
+    var q map[string]any
+    if len(query) > 0 {
+        q = query[0]
+    }
+
+    url, err := router.URL(name, params, q)
+    if err != nil {
+        return ""
+    }
+
+    return url
+}
+
 // 附件 url
 func AttachmentUrl(path string, disk ...string) string {
     var url string
@@ -5,7 +5,7 @@ import (
 )
 
 // 创建验证
-func Create(data map[string]any) string {
+func Create(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "group_id": "required,len=36",
@@ -35,7 +35,7 @@ func Create(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapReturnOneError(data, rules, messages)
+    ok, err := v.ValidateMapReturnOneError(data, rules, messages)
     if ok {
         return ""
     }
@@ -44,7 +44,7 @@ func Create(data map[string]any) string {
 }
 
 // 编辑验证
-func Update(data map[string]any) string {
+func Update(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "name": "required,min=2,max=20",
@@ -71,7 +71,7 @@ func Update(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapReturnOneError(data, rules, messages)
+    ok, err := v.ValidateMapReturnOneError(data, rules, messages)
     if ok {
         return ""
     }
@@ -80,7 +80,7 @@ func Update(data map[string]any) string {
 }
 
 // 修改头像
-func UpdateAvatar(data map[string]any) string {
+func UpdateAvatar(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "avatar": "required,len=36",
@@ -92,7 +92,7 @@ func UpdateAvatar(data map[string]any) string {
         "avatar.len": "头像数据错误",
     }
 
-    ok, err := validate.ValidateMapReturnOneError(data, rules, messages)
+    ok, err := v.ValidateMapReturnOneError(data, rules, messages)
     if ok {
         return ""
     }
@@ -5,7 +5,7 @@ import (
 )
 
 // 创建验证
-func Create(data map[string]any) string {
+func Create(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "parentid": "required",
@@ -21,7 +21,7 @@ func Create(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapReturnOneError(data, rules, messages)
+    ok, err := v.ValidateMapReturnOneError(data, rules, messages)
     if ok {
         return ""
     }
@@ -30,7 +30,7 @@ func Create(data map[string]any) string {
 }
 
 // 编辑验证
-func Update(data map[string]any) string {
+func Update(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "parentid": "required",
@@ -46,7 +46,7 @@ func Update(data map[string]any) string {
         "status.required": "状态选项不能为空",
     }
 
-    ok, err := validate.ValidateMapReturnOneError(data, rules, messages)
+    ok, err := v.ValidateMapReturnOneError(data, rules, messages)
     if ok {
         return ""
     }
@@ -10,14 +10,13 @@ user := map[string]any{
     "password": "6b4ee75684079f24bb6331d6b4abbb57",
     "captcha": "wert",
 }
-Login(user)
+Login(validate.Locale("zh"), user)
 */
-func Login(data map[string]any) string {
+func Login(v *validate.Validator, data map[string]any, needCaptcha ...bool) string {
     // 规则
     rules := map[string]any{
         "name": "required", 
         "password": "required,len=32",
-        "captcha": "required,len=4",
     }
     
     // 错误提示
@@ -25,11 +24,17 @@ func Login(data map[string]any) string {
         "name.required": "name 字段必填", 
         "password.required": "password 字段必填",
         "password.len": "password 字段为32位长度",
-        "captcha.required": "captcha 字段必填",
-        "captcha.len": ":field 字段为4位长度",
     }
 
-    _, errs := validate.ValidateMap(data, rules, messages)
+    // 验证码
+    if len(needCaptcha) == 0 || needCaptcha[0] {
+        rules["captcha"] = "required,max=10"
+
+        messages["captcha.required"] = "captcha 字段必填"
+        messages["captcha.max"] = ":field 字段最大长度为10位"
+    }
+
+    _, errs := v.ValidateMap(data, rules, messages)
 
     if len(errs) > 0 {
         for _, err := range errs {
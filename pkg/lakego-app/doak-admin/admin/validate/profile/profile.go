@@ -5,7 +5,7 @@ import (
 )
 
 // 账号信息更新
-func Update(data map[string]any) string {
+func Update(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "nickname": "required,max=150",
@@ -24,7 +24,7 @@ func Update(data map[string]any) string {
         "introduce.max": "简介字数超过了限制",
     }
 
-    _, errs := validate.ValidateMap(data, rules, messages)
+    _, errs := v.ValidateMap(data, rules, messages)
 
     if len(errs) > 0 {
         for _, err := range errs {
@@ -36,7 +36,7 @@ func Update(data map[string]any) string {
 }
 
 // 更新头像
-func UpdateAvatar(data map[string]any) string {
+func UpdateAvatar(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "avatar": "required,len=36",
@@ -48,7 +48,7 @@ func UpdateAvatar(data map[string]any) string {
         "avatar.len": "头像数据错误",
     }
 
-    _, errs := validate.ValidateMap(data, rules, messages)
+    _, errs := v.ValidateMap(data, rules, messages)
 
     if len(errs) > 0 {
         for _, err := range errs {
@@ -60,7 +60,7 @@ func UpdateAvatar(data map[string]any) string {
 }
 
 // 修改密码
-func UpdatePasssword(data map[string]any) string {
+func UpdatePasssword(v *validate.Validator, data map[string]any) string {
     // 规则
     rules := map[string]any{
         "oldpassword": "required,len=32",
@@ -78,7 +78,7 @@ func UpdatePasssword(data map[string]any) string {
         "newpassword_confirm.len": "确认密码错误",
     }
 
-    _, errs := validate.ValidateMap(data, rules, messages)
+    _, errs := v.ValidateMap(data, rules, messages)
 
     if len(errs) > 0 {
         for _, err := range errs {
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+    "os"
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 生成证书吊销列表
+ *
+ * > ./main lakego-ca:crl [--authority=] [--out=]
+ * > main.exe lakego-ca:crl [--authority=] [--out=]
+ * > go run main.go lakego-ca:crl [--authority=] [--out=]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var CrlCmd = &command.Command{
+    Use: "lakego-ca:crl",
+    Short: "lakego-ca generate certificate revocation list.",
+    Example: "{execfile} lakego-ca:crl --authority=lakego --out=lakego.crl",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        Crl()
+    },
+}
+
+var crlAuthority string
+var crlOut string
+
+func init() {
+    pf := CrlCmd.Flags()
+    pf.StringVarP(&crlAuthority, "authority", "a", "", "签发机构ID或者名称，默认使用 ca.default 配置")
+    pf.StringVarP(&crlOut, "out", "o", "", "输出文件，为空时直接输出")
+}
+
+// 生成证书吊销列表
+func Crl() {
+    authority, err := service.LoadAuthority(crlAuthority)
+    if err != nil {
+        color.Redln("生成证书吊销列表失败！原因为：" + err.Error())
+        return
+    }
+
+    crl, err := authority.CRL(service.Operator{})
+    if err != nil {
+        color.Redln("生成证书吊销列表失败！原因为：" + err.Error())
+        return
+    }
+
+    if crlOut == "" {
+        fmt.Print(string(crl))
+        return
+    }
+
+    if err := os.WriteFile(crlOut, crl, 0644); err != nil {
+        color.Redln("写入证书吊销列表失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("证书吊销列表已写入：" + crlOut)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 创建签发机构
+ *
+ * > ./main lakego-ca:init [name] [--cn=] [--org=] [--key-type=p256] [--years=10]
+ * > main.exe lakego-ca:init [name] [--cn=] [--org=] [--key-type=p256] [--years=10]
+ * > go run main.go lakego-ca:init [name] [--cn=] [--org=] [--key-type=p256] [--years=10]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var InitCmd = &command.Command{
+    Use: "lakego-ca:init",
+    Short: "lakego-ca create certificate authority.",
+    Example: "{execfile} lakego-ca:init lakego --cn=\"Lakego Root CA\" --key-type=p256",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        Init(args[0])
+    },
+}
+
+var initCommonName string
+var initOrganization string
+var initKeyType string
+var initYears int
+
+func init() {
+    pf := InitCmd.Flags()
+    pf.StringVarP(&initCommonName, "cn", "", "", "通用名称，默认为签发机构名称")
+    pf.StringVarP(&initOrganization, "org", "", "", "组织")
+    pf.StringVarP(&initKeyType, "key-type", "k", service.KeyP256, "密钥类型，可选：rsa2048 | rsa3072 | rsa4096 | p256 | p384 | ed25519")
+    pf.IntVarP(&initYears, "years", "y", 10, "有效年数")
+}
+
+// 创建签发机构
+func Init(name string) {
+    data, err := service.CreateAuthority(service.AuthorityOptions{
+        Name:         name,
+        CommonName:   initCommonName,
+        Organization: initOrganization,
+        KeyType:      initKeyType,
+        Years:        initYears,
+    }, service.Operator{})
+    if err != nil {
+        color.Redln("创建签发机构失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("创建签发机构成功！ID：" + data.ID + "，序列号：" + data.SerialNumber)
+    fmt.Print(data.Cert)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+    "os"
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 签发证书
+ *
+ * > ./main lakego-ca:issue [--authority=] [--profile=server] [--csr=] [--cn=] [--dns=] [--ip=] [--days=] [--out=]
+ * > main.exe lakego-ca:issue [--authority=] [--profile=server] [--csr=] [--cn=] [--dns=] [--ip=] [--days=] [--out=]
+ * > go run main.go lakego-ca:issue [--authority=] [--profile=server] [--csr=] [--cn=] [--dns=] [--ip=] [--days=] [--out=]
+ *
+ * > go run main.go lakego-ca:issue --profile=server --csr=server.csr --out=server.pem
+ * > go run main.go lakego-ca:issue --profile=client --cn=worker-1 --format=p12 --password=123456 --out=worker-1.p12
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var IssueCmd = &command.Command{
+    Use: "lakego-ca:issue",
+    Short: "lakego-ca issue certificate.",
+    Example: "{execfile} lakego-ca:issue --profile=server --csr=server.csr --out=server.pem",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        Issue()
+    },
+}
+
+var issueAuthority string
+var issueProfile string
+var issueCSR string
+var issueKeyType string
+var issueCommonName string
+var issueDNSNames []string
+var issueIPAddresses []string
+var issueDays int
+var issueFormat string
+var issuePassword string
+var issueOut string
+
+func init() {
+    pf := IssueCmd.Flags()
+    pf.StringVarP(&issueAuthority, "authority", "a", "", "签发机构ID或者名称，默认使用 ca.default 配置")
+    pf.StringVarP(&issueProfile, "profile", "p", service.ProfileServer, "证书模板，可选：server | client | code-signing")
+    pf.StringVarP(&issueCSR, "csr", "", "", "PEM 格式证书请求文件，为空时生成密钥")
+    pf.StringVarP(&issueKeyType, "key-type", "k", service.KeyP256, "没有证书请求时生成的密钥类型")
+    pf.StringVarP(&issueCommonName, "cn", "", "", "通用名称")
+    pf.StringSliceVarP(&issueDNSNames, "dns", "", nil, "DNS 名称，多个用逗号分隔")
+    pf.StringSliceVarP(&issueIPAddresses, "ip", "", nil, "IP 地址，多个用逗号分隔")
+    pf.IntVarP(&issueDays, "days", "d", 0, "有效天数，默认使用模板设置")
+    pf.StringVarP(&issueFormat, "format", "f", service.BundlePEM, "输出格式，可选：pem | p12 | jks")
+    pf.StringVarP(&issuePassword, "password", "", "", "证书包密码")
+    pf.StringVarP(&issueOut, "out", "o", "", "输出文件，为空时输出证书")
+}
+
+// 签发证书
+func Issue() {
+    authority, err := service.LoadAuthority(issueAuthority)
+    if err != nil {
+        color.Redln("签发证书失败！原因为：" + err.Error())
+        return
+    }
+
+    csr := ""
+    if issueCSR != "" {
+        data, err := os.ReadFile(issueCSR)
+        if err != nil {
+            color.Redln("读取证书请求失败！原因为：" + err.Error())
+            return
+        }
+
+        csr = string(data)
+    }
+
+    cert, err := authority.Issue(service.IssueOptions{
+        Profile:     issueProfile,
+        CSR:         csr,
+        KeyType:     issueKeyType,
+        CommonName:  issueCommonName,
+        DNSNames:    issueDNSNames,
+        IPAddresses: issueIPAddresses,
+        Days:        issueDays,
+    }, service.Operator{})
+    if err != nil {
+        color.Redln("签发证书失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("签发证书成功！ID：" + cert.ID + "，序列号：" + cert.SerialNumber)
+
+    writeBundle(authority, cert, issueFormat, issuePassword, issueOut)
+}
+
+// 输出证书包
+func writeBundle(authority *service.Authority, cert *model.CaCert, format string, password string, out string) {
+    if out == "" {
+        fmt.Print(cert.Cert)
+        return
+    }
+
+    bundle, err := authority.Bundle(cert, format, password, service.Operator{})
+    if err != nil {
+        color.Redln("生成证书包失败！原因为：" + err.Error())
+        return
+    }
+
+    if err := os.WriteFile(out, bundle.Data, 0600); err != nil {
+        color.Redln("写入证书包失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("证书包已写入：" + out)
+}
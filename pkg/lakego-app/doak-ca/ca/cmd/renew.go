@@ -0,0 +1,70 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 续期证书
+ *
+ * > ./main lakego-ca:renew [id] [--days=] [--out=]
+ * > main.exe lakego-ca:renew [id] [--days=] [--out=]
+ * > go run main.go lakego-ca:renew [id] [--days=] [--out=]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var RenewCmd = &command.Command{
+    Use: "lakego-ca:renew",
+    Short: "lakego-ca renew certificate.",
+    Example: "{execfile} lakego-ca:renew [id|serial] --days=365",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        Renew(args[0])
+    },
+}
+
+var renewDays int
+var renewFormat string
+var renewPassword string
+var renewOut string
+
+func init() {
+    pf := RenewCmd.Flags()
+    pf.IntVarP(&renewDays, "days", "d", 0, "有效天数，默认使用模板设置")
+    pf.StringVarP(&renewFormat, "format", "f", service.BundlePEM, "输出格式，可选：pem | p12 | jks")
+    pf.StringVarP(&renewPassword, "password", "", "", "证书包密码")
+    pf.StringVarP(&renewOut, "out", "o", "", "输出文件，为空时输出证书")
+}
+
+// 续期证书
+func Renew(id string) {
+    old, err := service.FindCert(id)
+    if err != nil {
+        color.Redln("证书不存在！")
+        return
+    }
+
+    authority, err := service.LoadCertAuthority(old)
+    if err != nil {
+        color.Redln("续期证书失败！原因为：" + err.Error())
+        return
+    }
+
+    cert, err := authority.Renew(old, renewDays, service.Operator{})
+    if err != nil {
+        color.Redln("续期证书失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("续期证书成功！ID：" + cert.ID + "，序列号：" + cert.SerialNumber)
+
+    writeBundle(authority, cert, renewFormat, renewPassword, renewOut)
+}
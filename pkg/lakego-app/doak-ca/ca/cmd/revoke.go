@@ -0,0 +1,61 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 吊销证书
+ *
+ * > ./main lakego-ca:revoke [id] [--reason=0]
+ * > main.exe lakego-ca:revoke [id] [--reason=0]
+ * > go run main.go lakego-ca:revoke [id] [--reason=0]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var RevokeCmd = &command.Command{
+    Use: "lakego-ca:revoke",
+    Short: "lakego-ca revoke certificate.",
+    Example: "{execfile} lakego-ca:revoke [id|serial] --reason=1",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        Revoke(args[0])
+    },
+}
+
+var revokeReason int
+
+func init() {
+    pf := RevokeCmd.Flags()
+    pf.IntVarP(&revokeReason, "reason", "r", 0, "吊销原因代码，RFC 5280 5.3.1")
+}
+
+// 吊销证书
+func Revoke(id string) {
+    cert, err := service.FindCert(id)
+    if err != nil {
+        color.Redln("证书不存在！")
+        return
+    }
+
+    authority, err := service.LoadCertAuthority(cert)
+    if err != nil {
+        color.Redln("吊销证书失败！原因为：" + err.Error())
+        return
+    }
+
+    if err := authority.Revoke(cert, revokeReason, service.Operator{}); err != nil {
+        color.Redln("吊销证书失败！原因为：" + err.Error())
+        return
+    }
+
+    color.Greenln("吊销证书成功！序列号：" + cert.SerialNumber)
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+// 签发机构列表配置
+var authorityResource = supportController.NewResource(supportController.ResourceConfig{
+    Model: model.NewCaAuthority,
+    Sortable: []string{"name", "not_after", "add_time"},
+    Searchable: []string{"name", "subject"},
+    Filters: []supportController.Filter{
+        {Field: "add_time", Param: "time", Type: supportController.FilterRange, Format: supportController.FormatDate},
+        {Field: "key_type"},
+    },
+    Fields: []string{
+        "id", "name", "subject",
+        "key_type", "serial_number", "cert",
+        "crl_number", "not_before", "not_after",
+        "add_time", "add_ip",
+    },
+})
+
+/**
+ * 签发机构
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Authority struct {
+    Base
+}
+
+// 签发机构列表
+// @Summary 签发机构列表
+// @Description 签发机构列表
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param searchword query string false "搜索关键字"
+// @Param order      query string false "排序，示例：add_time__DESC"
+// @Param start_time query string false "开始时间"
+// @Param end_time   query string false "结束时间"
+// @Param key_type   query string false "密钥类型"
+// @Param start      query string false "开始数据量"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/authority [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-authority.index"}
+func (this *Authority) Index(ctx *router.Context) {
+    page, err := authorityResource.List(ctx, model.NewCaAuthority())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 创建签发机构
+// @Summary 创建签发机构
+// @Description 生成签发机构密钥及自签名证书，私钥使用 ca.key.password 配置加密保存
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param name         formData string true  "名称"
+// @Param common_name  formData string false "通用名称"
+// @Param organization formData string false "组织"
+// @Param key_type     formData string false "密钥类型，可选：rsa2048 | rsa3072 | rsa4096 | p256 | p384 | ed25519"
+// @Param years        formData string false "有效年数"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/authority [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-authority.create"}
+func (this *Authority) Create(ctx *router.Context) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    name := goch.ToString(post["name"])
+    if name == "" {
+        this.Error(ctx, "名称不能为空")
+        return
+    }
+
+    data, err := service.CreateAuthority(service.AuthorityOptions{
+        Name:         name,
+        CommonName:   goch.ToString(post["common_name"]),
+        Organization: goch.ToString(post["organization"]),
+        KeyType:      goch.ToString(post["key_type"]),
+        Years:        goch.ToInt(post["years"]),
+    }, this.Operator(ctx))
+    if err != nil {
+        this.Error(ctx, "创建签发机构失败，" + err.Error())
+        return
+    }
+
+    this.SuccessWithData(ctx, "创建签发机构成功", map[string]any{
+        "id": data.ID,
+        "serial_number": data.SerialNumber,
+        "cert": data.Cert,
+    })
+}
+
+// 下载证书吊销列表
+// @Summary 下载证书吊销列表
+// @Description 生成并下载 PEM 格式的证书吊销列表
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "签发机构ID或者名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/authority/{id}/crl [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-authority.crl"}
+func (this *Authority) Crl(ctx *router.Context) {
+    authority, err := service.LoadAuthority(ctx.Param("id"))
+    if err != nil {
+        this.Error(ctx, "签发机构不存在")
+        return
+    }
+
+    crl, err := authority.CRL(this.Operator(ctx))
+    if err != nil {
+        this.Error(ctx, "生成证书吊销列表失败")
+        return
+    }
+
+    ctx.Header("Content-Disposition", "attachment; filename=" + authority.Data.Name + ".crl")
+    ctx.Data(http.StatusOK, "application/pkix-crl", crl)
+}
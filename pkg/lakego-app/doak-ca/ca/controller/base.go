@@ -0,0 +1,33 @@
+package controller
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-admin/admin/auth/admin"
+    adminController "github.com/deatil/lakego-doak-admin/admin/controller"
+
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+/**
+ * 基类
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Base struct {
+    adminController.Base
+}
+
+// 当前操作者
+func (this *Base) Operator(ctx *router.Context) service.Operator {
+    op := service.Operator{
+        Ip: router.GetRequestIp(ctx),
+    }
+
+    if adminInfo, ok := ctx.Get("admin"); ok {
+        op.AdminId = adminInfo.(*admin.Admin).GetId()
+    }
+
+    return op
+}
@@ -0,0 +1,309 @@
+package controller
+
+import (
+    "strings"
+    "net/http"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+    "github.com/deatil/lakego-doak-ca/ca/service"
+)
+
+// 证书列表配置
+var certResource = supportController.NewResource(supportController.ResourceConfig{
+    Model: model.NewCaCert,
+    Sortable: []string{"common_name", "not_after", "add_time"},
+    Searchable: []string{"common_name", "serial_number", "dns_names"},
+    Filters: []supportController.Filter{
+        {Field: "add_time", Param: "time", Type: supportController.FilterRange, Format: supportController.FormatDate},
+        {Field: "authority_id"},
+        {Field: "profile"},
+        {Field: "status", Format: supportController.FormatStatus},
+    },
+    Fields: []string{
+        "id", "authority_id", "serial_number",
+        "common_name", "profile", "key_type",
+        "dns_names", "ip_addresses", "renew_id",
+        "status", "revoke_reason", "revoke_time",
+        "not_before", "not_after",
+        "add_time", "add_ip",
+    },
+})
+
+/**
+ * 证书
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Cert struct {
+    Base
+}
+
+// 证书列表
+// @Summary 证书列表
+// @Description 签发的证书列表
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param searchword   query string false "搜索关键字"
+// @Param order        query string false "排序，示例：add_time__DESC"
+// @Param start_time   query string false "开始时间"
+// @Param end_time     query string false "结束时间"
+// @Param authority_id query string false "签发机构ID"
+// @Param profile      query string false "证书模板"
+// @Param status       query string false "状态，可选：open | close"
+// @Param start        query string false "开始数据量"
+// @Param limit        query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.index"}
+func (this *Cert) Index(ctx *router.Context) {
+    page, err := certResource.List(ctx, model.NewCaCert())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
+
+// 证书模板
+// @Summary 证书模板
+// @Description 可用的证书模板列表
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert/profiles [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.profiles"}
+func (this *Cert) Profiles(ctx *router.Context) {
+    list := make([]map[string]any, 0)
+    for _, name := range service.ProfileNames() {
+        profile, _ := service.GetProfile(name)
+
+        list = append(list, map[string]any{
+            "name": profile.Name,
+            "days": profile.Days,
+        })
+    }
+
+    this.SuccessWithData(ctx, "获取成功", list)
+}
+
+// 证书详情
+// @Summary 证书详情
+// @Description 证书详情，可用ID或者序列号
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "证书ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert/{id} [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.detail"}
+func (this *Cert) Detail(ctx *router.Context) {
+    cert, err := service.FindCert(ctx.Param("id"))
+    if err != nil {
+        this.Error(ctx, "证书不存在")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", map[string]any{
+        "cert": cert,
+        "has_private_key": cert.HasPrivateKey(),
+    })
+}
+
+// 签发证书
+// @Summary 签发证书
+// @Description 使用证书请求签发证书，没有证书请求时生成密钥并加密保存
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param authority    formData string false "签发机构ID或者名称，默认使用 ca.default 配置"
+// @Param profile      formData string true  "证书模板，可选：server | client | code-signing"
+// @Param csr          formData string false "PEM 格式证书请求"
+// @Param key_type     formData string false "没有证书请求时生成的密钥类型"
+// @Param common_name  formData string false "通用名称"
+// @Param dns_names    formData string false "DNS 名称，多个用逗号分隔"
+// @Param ip_addresses formData string false "IP 地址，多个用逗号分隔"
+// @Param days         formData string false "有效天数"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.issue"}
+func (this *Cert) Issue(ctx *router.Context) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    profile := goch.ToString(post["profile"])
+    if profile == "" {
+        this.Error(ctx, "证书模板不能为空")
+        return
+    }
+
+    authority, err := service.LoadAuthority(goch.ToString(post["authority"]))
+    if err != nil {
+        this.Error(ctx, "签发机构不存在")
+        return
+    }
+
+    cert, err := authority.Issue(service.IssueOptions{
+        Profile:     profile,
+        CSR:         goch.ToString(post["csr"]),
+        KeyType:     goch.ToString(post["key_type"]),
+        CommonName:  goch.ToString(post["common_name"]),
+        DNSNames:    this.toList(post["dns_names"]),
+        IPAddresses: this.toList(post["ip_addresses"]),
+        Days:        goch.ToInt(post["days"]),
+    }, this.Operator(ctx))
+    if err != nil {
+        this.Error(ctx, "签发证书失败，" + err.Error())
+        return
+    }
+
+    this.SuccessWithData(ctx, "签发证书成功", map[string]any{
+        "id": cert.ID,
+        "serial_number": cert.SerialNumber,
+        "cert": cert.Cert,
+    })
+}
+
+// 续期证书
+// @Summary 续期证书
+// @Description 使用原证书公钥及名称签发新证书
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param id   path     string true  "证书ID"
+// @Param days formData string false "有效天数"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert/{id}/renew [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.renew"}
+func (this *Cert) Renew(ctx *router.Context) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    old, authority, ok := this.load(ctx)
+    if !ok {
+        return
+    }
+
+    cert, err := authority.Renew(old, goch.ToInt(post["days"]), this.Operator(ctx))
+    if err != nil {
+        this.Error(ctx, "续期证书失败，" + err.Error())
+        return
+    }
+
+    this.SuccessWithData(ctx, "续期证书成功", map[string]any{
+        "id": cert.ID,
+        "serial_number": cert.SerialNumber,
+        "cert": cert.Cert,
+    })
+}
+
+// 吊销证书
+// @Summary 吊销证书
+// @Description 吊销证书，吊销原因为 RFC 5280 吊销原因代码
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param id     path     string true  "证书ID"
+// @Param reason formData string false "吊销原因代码"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert/{id}/revoke [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.revoke"}
+func (this *Cert) Revoke(ctx *router.Context) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    cert, authority, ok := this.load(ctx)
+    if !ok {
+        return
+    }
+
+    err := authority.Revoke(cert, goch.ToInt(post["reason"]), this.Operator(ctx))
+    if err != nil {
+        this.Error(ctx, "吊销证书失败，" + err.Error())
+        return
+    }
+
+    this.Success(ctx, "吊销证书成功")
+}
+
+// 下载证书包
+// @Summary 下载证书包
+// @Description 下载证书包，p12 及 jks 格式需要签发时生成的私钥
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param id       path     string true  "证书ID"
+// @Param format   formData string false "格式，可选：pem | p12 | jks。默认：pem"
+// @Param password formData string false "证书包密码"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/cert/{id}/download [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-cert.download"}
+func (this *Cert) Download(ctx *router.Context) {
+    post := make(map[string]any)
+    this.ShouldBindJSON(ctx, &post)
+
+    cert, authority, ok := this.load(ctx)
+    if !ok {
+        return
+    }
+
+    bundle, err := authority.Bundle(
+        cert,
+        goch.ToString(post["format"]),
+        goch.ToString(post["password"]),
+        this.Operator(ctx),
+    )
+    if err != nil {
+        this.Error(ctx, "生成证书包失败，" + err.Error())
+        return
+    }
+
+    ctx.Header("Content-Disposition", "attachment; filename=" + bundle.Filename)
+    ctx.Data(http.StatusOK, bundle.ContentType, bundle.Data)
+}
+
+// 获取证书及签发机构
+func (this *Cert) load(ctx *router.Context) (*model.CaCert, *service.Authority, bool) {
+    cert, err := service.FindCert(ctx.Param("id"))
+    if err != nil {
+        this.Error(ctx, "证书不存在")
+        return nil, nil, false
+    }
+
+    authority, err := service.LoadCertAuthority(cert)
+    if err != nil {
+        this.Error(ctx, "签发机构不存在")
+        return nil, nil, false
+    }
+
+    return cert, authority, true
+}
+
+// 列表数据，可用数组或者逗号分隔的字符
+func (this *Cert) toList(data any) []string {
+    if str, ok := data.(string); ok {
+        if str == "" {
+            return nil
+        }
+
+        return strings.Split(str, ",")
+    }
+
+    return goch.ToStringSlice(data)
+}
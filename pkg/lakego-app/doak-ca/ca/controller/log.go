@@ -0,0 +1,63 @@
+package controller
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    supportController "github.com/deatil/lakego-doak-admin/admin/support/controller"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+// 审计日志列表配置
+var logResource = supportController.NewResource(supportController.ResourceConfig{
+    Model: model.NewCaLog,
+    Sortable: []string{"add_time"},
+    Searchable: []string{"serial_number", "info"},
+    Filters: []supportController.Filter{
+        {Field: "add_time", Param: "time", Type: supportController.FilterRange, Format: supportController.FormatDate},
+        {Field: "authority_id"},
+        {Field: "cert_id"},
+        {Field: "action"},
+        {Field: "admin_id"},
+    },
+})
+
+/**
+ * 签发审计日志
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Log struct {
+    Base
+}
+
+// 审计日志列表
+// @Summary 签发审计日志列表
+// @Description 签发机构创建、证书签发、续期、吊销及下载记录
+// @Tags 证书签发
+// @Accept  application/json
+// @Produce application/json
+// @Param searchword   query string false "搜索关键字"
+// @Param order        query string false "排序，示例：add_time__DESC"
+// @Param start_time   query string false "开始时间"
+// @Param end_time     query string false "结束时间"
+// @Param authority_id query string false "签发机构ID"
+// @Param cert_id      query string false "证书ID"
+// @Param action       query string false "动作，可选：init | issue | renew | revoke | download | crl"
+// @Param admin_id     query string false "管理员ID"
+// @Param start        query string false "开始数据量"
+// @Param limit        query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /ca/log [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.ca-log.index"}
+func (this *Log) Index(ctx *router.Context) {
+    page, err := logResource.List(ctx, model.NewCaLog())
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithPage(ctx, "获取成功", page)
+}
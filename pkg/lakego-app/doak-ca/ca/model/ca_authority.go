@@ -0,0 +1,34 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+)
+
+// 签发机构
+type CaAuthority struct {
+    ID           string `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`
+    Name         string `gorm:"column:name;type:varchar(100);not null;uniqueIndex;" json:"name"`
+    Subject      string `gorm:"column:subject;type:varchar(250);" json:"subject"`
+    KeyType      string `gorm:"column:key_type;type:varchar(20);" json:"key_type"`
+    SerialNumber string `gorm:"column:serial_number;type:varchar(64);" json:"serial_number"`
+    Cert         string `gorm:"column:cert;type:text;" json:"cert"`
+    PrivateKey   string `gorm:"column:private_key;type:text;" json:"-"`
+    CrlNumber    int64  `gorm:"column:crl_number;type:bigint(20);" json:"crl_number"`
+    NotBefore    int    `gorm:"column:not_before;type:int(10);" json:"not_before"`
+    NotAfter     int    `gorm:"column:not_after;type:int(10);" json:"not_after"`
+    AddTime      int    `gorm:"column:add_time;type:int(10);" json:"add_time"`
+    AddIp        string `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`
+}
+
+func (this *CaAuthority) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+func NewCaAuthority() *gorm.DB {
+    return database.New().Model(&CaAuthority{})
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+)
+
+// 签发的证书
+type CaCert struct {
+    ID           string `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`
+    AuthorityId  string `gorm:"column:authority_id;type:char(36);not null;index;" json:"authority_id"`
+    SerialNumber string `gorm:"column:serial_number;type:varchar(64);not null;uniqueIndex;" json:"serial_number"`
+    CommonName   string `gorm:"column:common_name;type:varchar(250);" json:"common_name"`
+    Profile      string `gorm:"column:profile;type:varchar(30);" json:"profile"`
+    KeyType      string `gorm:"column:key_type;type:varchar(20);" json:"key_type"`
+    DnsNames     string `gorm:"column:dns_names;type:text;" json:"dns_names"`
+    IpAddresses  string `gorm:"column:ip_addresses;type:text;" json:"ip_addresses"`
+    Csr          string `gorm:"column:csr;type:text;" json:"csr"`
+    Cert         string `gorm:"column:cert;type:text;" json:"cert"`
+    PrivateKey   string `gorm:"column:private_key;type:text;" json:"-"`
+    RenewId      string `gorm:"column:renew_id;type:char(36);" json:"renew_id"`
+    Status       int    `gorm:"column:status;type:tinyint(1);" json:"status"`
+    RevokeReason int    `gorm:"column:revoke_reason;type:tinyint(2);" json:"revoke_reason"`
+    RevokeTime   int    `gorm:"column:revoke_time;type:int(10);" json:"revoke_time"`
+    NotBefore    int    `gorm:"column:not_before;type:int(10);" json:"not_before"`
+    NotAfter     int    `gorm:"column:not_after;type:int(10);" json:"not_after"`
+    AddTime      int    `gorm:"column:add_time;type:int(10);" json:"add_time"`
+    AddIp        string `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`
+}
+
+func (this *CaCert) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+// 是否有保存的私钥
+func (this *CaCert) HasPrivateKey() bool {
+    return this.PrivateKey != ""
+}
+
+func NewCaCert() *gorm.DB {
+    return database.New().Model(&CaCert{})
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+)
+
+// 签发审计日志
+type CaLog struct {
+    ID           string `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`
+    AuthorityId  string `gorm:"column:authority_id;type:char(36);index;" json:"authority_id"`
+    CertId       string `gorm:"column:cert_id;type:char(36);index;" json:"cert_id"`
+    SerialNumber string `gorm:"column:serial_number;type:varchar(64);" json:"serial_number"`
+    Action       string `gorm:"column:action;type:varchar(20);" json:"action"`
+    Info         string `gorm:"column:info;type:text;" json:"info"`
+    AdminId      string `gorm:"column:admin_id;type:char(36);" json:"admin_id"`
+    AddTime      int    `gorm:"column:add_time;type:int(10);" json:"add_time"`
+    AddIp        string `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`
+}
+
+func (this *CaLog) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+func NewCaLog() *gorm.DB {
+    return database.New().Model(&CaLog{})
+}
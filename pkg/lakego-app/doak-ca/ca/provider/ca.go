@@ -0,0 +1,82 @@
+package provider
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/provider"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
+
+    admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
+
+    "github.com/deatil/lakego-doak-ca/ca/cmd"
+    ca_router "github.com/deatil/lakego-doak-ca/ca/route"
+)
+
+/**
+ * 服务提供者
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CA struct {
+    provider.ServiceProvider
+}
+
+// 注册
+func (this *CA) Register() {}
+
+// 引导
+func (this *CA) Boot() {
+    // 脚本
+    this.loadCommand()
+
+    // 路由
+    this.loadRoute()
+
+    // 语言包
+    this.loadTranslations()
+}
+
+/**
+ * 导入脚本
+ */
+func (this *CA) loadCommand() {
+    // 创建签发机构
+    this.AddCommand(cmd.InitCmd)
+
+    // 签发证书
+    this.AddCommand(cmd.IssueCmd)
+
+    // 续期证书
+    this.AddCommand(cmd.RenewCmd)
+
+    // 吊销证书
+    this.AddCommand(cmd.RevokeCmd)
+
+    // 生成证书吊销列表
+    this.AddCommand(cmd.CrlCmd)
+}
+
+/**
+ * 导入路由
+ */
+func (this *CA) loadRoute() {
+    // 后台路由
+    admin_route.AddRoute(func(engine *router.RouterGroup) {
+        ca_router.Route(engine)
+    })
+}
+
+/**
+ * 导入语言包
+ */
+func (this *CA) loadTranslations() {
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-ca/resources/lang")
+
+    this.LoadTranslationsFrom(path, "lakego-ca")
+
+    // 推送语言包
+    // > go run main.go lakego:publish --tag=ca-lang --force
+    this.Publishes(this, map[string]string{
+        path: pathTool.ResourcesPath("/lang/lakego-ca"),
+    }, "ca-lang")
+}
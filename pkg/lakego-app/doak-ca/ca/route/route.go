@@ -0,0 +1,32 @@
+package route
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-ca/ca/controller"
+)
+
+/**
+ * 路由
+ */
+func Route(engine router.IRouter) {
+    // 签发机构
+    authorityController := new(controller.Authority)
+    router.Named(engine, "admin.ca-authority.index").GET("/ca/authority", authorityController.Index)
+    router.Named(engine, "admin.ca-authority.create").POST("/ca/authority", authorityController.Create)
+    router.Named(engine, "admin.ca-authority.crl").GET("/ca/authority/:id/crl", authorityController.Crl)
+
+    // 证书
+    certController := new(controller.Cert)
+    router.Named(engine, "admin.ca-cert.index").GET("/ca/cert", certController.Index)
+    router.Named(engine, "admin.ca-cert.profiles").GET("/ca/cert/profiles", certController.Profiles)
+    router.Named(engine, "admin.ca-cert.detail").GET("/ca/cert/:id", certController.Detail)
+    router.Named(engine, "admin.ca-cert.issue").POST("/ca/cert", certController.Issue)
+    router.Named(engine, "admin.ca-cert.renew").PATCH("/ca/cert/:id/renew", certController.Renew)
+    router.Named(engine, "admin.ca-cert.revoke").PATCH("/ca/cert/:id/revoke", certController.Revoke)
+    router.Named(engine, "admin.ca-cert.download").POST("/ca/cert/:id/download", certController.Download)
+
+    // 审计日志
+    logController := new(controller.Log)
+    router.Named(engine, "admin.ca-log.index").GET("/ca/log", logController.Index)
+}
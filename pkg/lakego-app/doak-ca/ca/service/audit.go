@@ -0,0 +1,45 @@
+package service
+
+import (
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+// 审计动作
+const (
+    ActionInit     = "init"
+    ActionIssue    = "issue"
+    ActionRenew    = "renew"
+    ActionRevoke   = "revoke"
+    ActionDownload = "download"
+    ActionCRL      = "crl"
+)
+
+// 操作者，命令行操作时为空
+type Operator struct {
+    // 管理员 ID
+    AdminId string
+
+    // 请求 IP
+    Ip string
+}
+
+// 记录审计日志，失败时不影响操作结果
+func addLog(authorityId string, cert *model.CaCert, action string, info string, op Operator) {
+    log := &model.CaLog{
+        AuthorityId: authorityId,
+        Action:      action,
+        Info:        info,
+        AdminId:     op.AdminId,
+        AddTime:     int(datebin.NowTime()),
+        AddIp:       op.Ip,
+    }
+
+    if cert != nil {
+        log.CertId = cert.ID
+        log.SerialNumber = cert.SerialNumber
+    }
+
+    model.NewCaLog().Create(log)
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+    "time"
+    "errors"
+    "strings"
+    "math/big"
+    "crypto"
+    "crypto/rand"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/pem"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-datebin/datebin"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    cryptobin_ca "github.com/deatil/go-cryptobin/cryptobin/ca"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+var (
+    // 签发机构不存在
+    ErrAuthorityNotFound = errors.New("ca: authority not found")
+
+    // 签发机构已存在
+    ErrAuthorityExists = errors.New("ca: authority already exists")
+
+    // 签发机构名称为空
+    ErrAuthorityName = errors.New("ca: authority name is empty")
+
+    // 签发机构证书已过期
+    ErrAuthorityExpired = errors.New("ca: authority is expired")
+
+    // 证书数据错误
+    ErrCertificate = errors.New("ca: invalid certificate")
+)
+
+// 签发机构设置
+type AuthorityOptions struct {
+    // 名称，唯一
+    Name string
+
+    // 证书通用名称，为空时使用名称
+    CommonName string
+
+    // 组织
+    Organization string
+
+    // 密钥类型
+    KeyType string
+
+    // 有效年数，默认为 10
+    Years int
+}
+
+// 创建签发机构，私钥使用配置密码加密保存
+func CreateAuthority(opts AuthorityOptions, op Operator) (*model.CaAuthority, error) {
+    name := strings.TrimSpace(opts.Name)
+    if name == "" {
+        return nil, ErrAuthorityName
+    }
+
+    var count int64
+    model.NewCaAuthority().Where("name = ?", name).Count(&count)
+    if count > 0 {
+        return nil, ErrAuthorityExists
+    }
+
+    commonName := opts.CommonName
+    if commonName == "" {
+        commonName = name
+    }
+
+    years := opts.Years
+    if years <= 0 {
+        years = 10
+    }
+
+    subject := &pkix.Name{
+        CommonName: commonName,
+    }
+    if opts.Organization != "" {
+        subject.Organization = []string{opts.Organization}
+    }
+
+    newCA, err := generateKey(opts.KeyType)
+    if err != nil {
+        return nil, err
+    }
+
+    signer := newCA.GetPrivateKey().(crypto.Signer)
+
+    serial, err := newSerial()
+    if err != nil {
+        return nil, err
+    }
+
+    skid, err := subjectKeyId(signer.Public())
+    if err != nil {
+        return nil, err
+    }
+
+    newCA = newCA.
+        MakeCA(subject, years, signatureAlgorithm(signer)).
+        UpdateCert(func(cert *x509.Certificate) *x509.Certificate {
+            cert.SerialNumber = serial
+            cert.SubjectKeyId = skid
+            cert.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+            cert.ExtKeyUsage = nil
+            cert.MaxPathLenZero = true
+
+            return cert
+        }).
+        CreateCA()
+    if err := newCA.Error().First(); err != nil {
+        return nil, err
+    }
+
+    cert, err := parseCert(newCA.ToKeyString())
+    if err != nil {
+        return nil, err
+    }
+
+    privateKey, err := sealKey(signer)
+    if err != nil {
+        return nil, err
+    }
+
+    data := &model.CaAuthority{
+        Name:         name,
+        Subject:      cert.Subject.String(),
+        KeyType:      keyTypeOf(signer.Public()),
+        SerialNumber: formatSerial(cert.SerialNumber),
+        Cert:         newCA.ToKeyString(),
+        PrivateKey:   privateKey,
+        NotBefore:    int(cert.NotBefore.Unix()),
+        NotAfter:     int(cert.NotAfter.Unix()),
+        AddTime:      int(datebin.NowTime()),
+        AddIp:        op.Ip,
+    }
+
+    if err := model.NewCaAuthority().Create(data).Error; err != nil {
+        return nil, err
+    }
+
+    addLog(data.ID, nil, ActionInit, "创建签发机构 " + name, op)
+
+    return data, nil
+}
+
+// 查找签发机构，可用 ID 或者名称，为空时使用 default 配置
+func FindAuthority(key string) (*model.CaAuthority, error) {
+    if key == "" {
+        key = config.New("ca").GetString("default")
+    }
+
+    data := &model.CaAuthority{}
+    err := model.NewCaAuthority().
+        Where("id = ? OR name = ?", key, key).
+        First(data).
+        Error
+    if err != nil {
+        return nil, ErrAuthorityNotFound
+    }
+
+    return data, nil
+}
+
+// 加载签发机构
+func LoadAuthority(key string) (*Authority, error) {
+    data, err := FindAuthority(key)
+    if err != nil {
+        return nil, err
+    }
+
+    return NewAuthority(data)
+}
+
+// 加载证书所属签发机构
+func LoadCertAuthority(cert *model.CaCert) (*Authority, error) {
+    return LoadAuthority(cert.AuthorityId)
+}
+
+// 构造函数，解密签发机构私钥
+func NewAuthority(data *model.CaAuthority) (*Authority, error) {
+    cert, err := parseCert(data.Cert)
+    if err != nil {
+        return nil, err
+    }
+
+    signer, err := openKey(data.PrivateKey)
+    if err != nil {
+        return nil, err
+    }
+
+    return &Authority{
+        Data:   data,
+        Cert:   cert,
+        signer: signer,
+    }, nil
+}
+
+/**
+ * 签发机构
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Authority struct {
+    // 数据
+    Data *model.CaAuthority
+
+    // 证书
+    Cert *x509.Certificate
+
+    // 私钥
+    signer crypto.Signer
+}
+
+// 生成证书吊销列表，有效天数可以使用 crl-days 配置
+func (this *Authority) CRL(op Operator) ([]byte, error) {
+    now := time.Now()
+
+    certs := make([]model.CaCert, 0)
+    err := model.NewCaCert().
+        Where("authority_id = ?", this.Data.ID).
+        Where("status = ?", StatusRevoked).
+        Where("not_after > ?", now.Unix()).
+        Find(&certs).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    revoked := make([]pkix.RevokedCertificate, 0, len(certs))
+    for _, cert := range certs {
+        serial, ok := parseSerial(cert.SerialNumber)
+        if !ok {
+            continue
+        }
+
+        revoked = append(revoked, cryptobin_ca.NewRevokedCert(serial, time.Unix(int64(cert.RevokeTime), 0), cert.RevokeReason))
+    }
+
+    number, err := this.nextCRLNumber()
+    if err != nil {
+        return nil, err
+    }
+
+    days := config.New("ca").GetInt("crl-days")
+    if days <= 0 {
+        days = 7
+    }
+
+    newCRL := cryptobin_ca.New().
+        FromPrivateKey(this.signer).
+        CreateCRL(this.Cert, revoked, number, now, now.AddDate(0, 0, days))
+    if err := newCRL.Error().First(); err != nil {
+        return nil, err
+    }
+
+    addLog(this.Data.ID, nil, ActionCRL, "生成证书吊销列表，编号 " + number.String(), op)
+
+    return newCRL.ToKeyBytes(), nil
+}
+
+// 吊销列表编号递增
+func (this *Authority) nextCRLNumber() (*big.Int, error) {
+    err := model.NewCaAuthority().
+        Where("id = ?", this.Data.ID).
+        UpdateColumn("crl_number", gorm.Expr("crl_number + ?", 1)).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    data := &model.CaAuthority{}
+    err = model.NewCaAuthority().
+        Where("id = ?", this.Data.ID).
+        First(data).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    this.Data.CrlNumber = data.CrlNumber
+
+    return big.NewInt(data.CrlNumber), nil
+}
+
+// 生成 127 位随机序列号
+func newSerial() (*big.Int, error) {
+    serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+    if err != nil {
+        return nil, err
+    }
+
+    return serial.Add(serial, big.NewInt(1)), nil
+}
+
+// 序列号格式化为大写十六进制
+func formatSerial(serial *big.Int) string {
+    return strings.ToUpper(serial.Text(16))
+}
+
+// 解析十六进制序列号
+func parseSerial(serial string) (*big.Int, bool) {
+    return new(big.Int).SetString(serial, 16)
+}
+
+// 解析 PEM 证书
+func parseCert(data string) (*x509.Certificate, error) {
+    block, _ := pem.Decode([]byte(data))
+    if block == nil || block.Type != "CERTIFICATE" {
+        return nil, ErrCertificate
+    }
+
+    return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+    "errors"
+    "strings"
+    "crypto"
+    "crypto/x509"
+
+    "github.com/deatil/go-cryptobin/jceks"
+    cryptobin_ca "github.com/deatil/go-cryptobin/cryptobin/ca"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+// 证书包格式
+const (
+    BundlePEM    = "pem"
+    BundlePKCS12 = "p12"
+    BundleJKS    = "jks"
+)
+
+var (
+    // 不支持的证书包格式
+    ErrBundleFormat = errors.New("ca: unsupported bundle format")
+
+    // 证书包密码为空
+    ErrBundlePassword = errors.New("ca: bundle password is empty")
+
+    // 证书没有保存私钥
+    ErrNoPrivateKey = errors.New("ca: cert has no private key")
+)
+
+// 证书包
+type Bundle struct {
+    // 数据
+    Data []byte
+
+    // 文件名
+    Filename string
+
+    // 文件类型
+    ContentType string
+}
+
+// 生成证书包
+// pem 包含证书及签发机构证书，设置密码时附加使用该密码加密的私钥
+// p12 及 jks 需要密码及签发时生成的私钥
+func (this *Authority) Bundle(cert *model.CaCert, format string, password string, op Operator) (Bundle, error) {
+    if cert.AuthorityId != this.Data.ID {
+        return Bundle{}, ErrCertNotFound
+    }
+
+    if format == "" {
+        format = BundlePEM
+    }
+
+    leaf, err := parseCert(cert.Cert)
+    if err != nil {
+        return Bundle{}, err
+    }
+
+    var key crypto.Signer
+
+    switch format {
+        case BundlePEM:
+            if cert.HasPrivateKey() && password != "" {
+                key, err = openKey(cert.PrivateKey)
+                if err != nil {
+                    return Bundle{}, err
+                }
+            }
+        case BundlePKCS12, BundleJKS:
+            if password == "" {
+                return Bundle{}, ErrBundlePassword
+            }
+            if !cert.HasPrivateKey() {
+                return Bundle{}, ErrNoPrivateKey
+            }
+
+            key, err = openKey(cert.PrivateKey)
+            if err != nil {
+                return Bundle{}, err
+            }
+        default:
+            return Bundle{}, ErrBundleFormat
+    }
+
+    name := strings.ToLower(cert.SerialNumber)
+
+    var bundle Bundle
+
+    switch format {
+        case BundlePEM:
+            data := make([]byte, 0)
+            data = append(data, cert.Cert...)
+            data = append(data, this.Data.Cert...)
+
+            if key != nil {
+                keyPEM, err := encryptKey(key, []byte(password))
+                if err != nil {
+                    return Bundle{}, err
+                }
+
+                data = append(data, keyPEM...)
+            }
+
+            bundle = Bundle{
+                Data:        data,
+                Filename:    name + ".pem",
+                ContentType: "application/x-pem-file",
+            }
+        case BundlePKCS12:
+            newCA := cryptobin_ca.New().
+                FromCert(leaf).
+                FromPrivateKey(key).
+                CreatePKCS12Cert([]*x509.Certificate{this.Cert}, password)
+            if err := newCA.Error().First(); err != nil {
+                return Bundle{}, err
+            }
+
+            bundle = Bundle{
+                Data:        newCA.ToKeyBytes(),
+                Filename:    name + ".p12",
+                ContentType: "application/x-pkcs12",
+            }
+        case BundleJKS:
+            ks := jceks.NewJKS()
+
+            err := ks.AddPrivateKey(name, key, password, [][]byte{leaf.Raw, this.Cert.Raw})
+            if err != nil {
+                return Bundle{}, err
+            }
+
+            err = ks.AddTrustedCert(this.Data.Name, this.Cert.Raw)
+            if err != nil {
+                return Bundle{}, err
+            }
+
+            data, err := ks.Marshal(password)
+            if err != nil {
+                return Bundle{}, err
+            }
+
+            bundle = Bundle{
+                Data:        data,
+                Filename:    name + ".jks",
+                ContentType: "application/octet-stream",
+            }
+    }
+
+    addLog(this.Data.ID, cert, ActionDownload, "下载证书包，格式 " + format, op)
+
+    return bundle, nil
+}
@@ -0,0 +1,445 @@
+package service
+
+import (
+    "net"
+    "time"
+    "errors"
+    "strings"
+    "strconv"
+    "crypto/rsa"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/pem"
+
+    "github.com/deatil/go-datebin/datebin"
+
+    cryptobin_ca "github.com/deatil/go-cryptobin/cryptobin/ca"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+// 证书状态
+const (
+    StatusRevoked = 0
+    StatusValid   = 1
+)
+
+// 签发时间向前偏移，兼容时钟误差
+const notBeforeSkew = 5 * time.Minute
+
+var (
+    // 证书不存在
+    ErrCertNotFound = errors.New("ca: cert not found")
+
+    // 证书已吊销
+    ErrCertRevoked = errors.New("ca: cert is revoked")
+
+    // 证书请求错误
+    ErrCSR = errors.New("ca: invalid certificate request")
+
+    // 通用名称为空
+    ErrCommonName = errors.New("ca: common name is empty")
+
+    // IP 地址错误
+    ErrIPAddress = errors.New("ca: invalid ip address")
+
+    // 吊销原因错误
+    ErrRevokeReason = errors.New("ca: invalid revocation reason")
+)
+
+// 签发设置
+type IssueOptions struct {
+    // 证书模板
+    Profile string
+
+    // PEM 格式证书请求，为空时生成密钥并加密保存
+    CSR string
+
+    // 生成密钥时的密钥类型
+    KeyType string
+
+    // 通用名称，设置后覆盖证书请求中的名称
+    CommonName string
+
+    // DNS 名称，和证书请求中的合并
+    DNSNames []string
+
+    // IP 地址，和证书请求中的合并
+    IPAddresses []string
+
+    // 有效天数，为 0 时使用模板设置
+    Days int
+}
+
+// 查找证书，可用 ID 或者序列号
+func FindCert(key string) (*model.CaCert, error) {
+    data := &model.CaCert{}
+    err := model.NewCaCert().
+        Where("id = ? OR serial_number = ?", key, strings.ToUpper(key)).
+        First(data).
+        Error
+    if err != nil {
+        return nil, ErrCertNotFound
+    }
+
+    return data, nil
+}
+
+// 签发证书
+func (this *Authority) Issue(opts IssueOptions, op Operator) (*model.CaCert, error) {
+    profile, err := GetProfile(opts.Profile)
+    if err != nil {
+        return nil, err
+    }
+
+    ips, err := parseIPs(opts.IPAddresses)
+    if err != nil {
+        return nil, err
+    }
+
+    dns := opts.DNSNames
+
+    var pub any
+    var subject pkix.Name
+    var privateKey string
+
+    if opts.CSR != "" {
+        csr, err := parseCSR(opts.CSR)
+        if err != nil {
+            return nil, err
+        }
+
+        pub = csr.PublicKey
+        subject = csr.Subject
+        dns = mergeNames(csr.DNSNames, dns)
+        ips = mergeIPs(csr.IPAddresses, ips)
+    } else {
+        newKey, err := generateKey(opts.KeyType)
+        if err != nil {
+            return nil, err
+        }
+
+        pub = newKey.GetPublicKey()
+
+        privateKey, err = sealKey(newKey.GetPrivateKey())
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    if opts.CommonName != "" {
+        subject.CommonName = opts.CommonName
+    }
+
+    if subject.CommonName == "" {
+        return nil, ErrCommonName
+    }
+
+    // 服务端证书没有设置时使用通用名称
+    if profile.RequireSAN && len(dns) == 0 && len(ips) == 0 {
+        if ip := net.ParseIP(subject.CommonName); ip != nil {
+            ips = []net.IP{ip}
+        } else {
+            dns = []string{subject.CommonName}
+        }
+    }
+
+    days := opts.Days
+    if days <= 0 {
+        days = profile.Days
+    }
+
+    cert, certPEM, err := this.sign(profile, subject, pub, dns, ips, days)
+    if err != nil {
+        return nil, err
+    }
+
+    data := &model.CaCert{
+        AuthorityId:  this.Data.ID,
+        SerialNumber: formatSerial(cert.SerialNumber),
+        CommonName:   cert.Subject.CommonName,
+        Profile:      profile.Name,
+        KeyType:      keyTypeOf(pub),
+        DnsNames:     strings.Join(cert.DNSNames, ","),
+        IpAddresses:  joinIPs(cert.IPAddresses),
+        Csr:          opts.CSR,
+        Cert:         certPEM,
+        PrivateKey:   privateKey,
+        Status:       StatusValid,
+        NotBefore:    int(cert.NotBefore.Unix()),
+        NotAfter:     int(cert.NotAfter.Unix()),
+        AddTime:      int(datebin.NowTime()),
+        AddIp:        op.Ip,
+    }
+
+    if err := model.NewCaCert().Create(data).Error; err != nil {
+        return nil, err
+    }
+
+    addLog(this.Data.ID, data, ActionIssue, "签发证书 " + data.CommonName + "，模板 " + profile.Name, op)
+
+    return data, nil
+}
+
+// 续期证书，使用原证书公钥及名称签发新证书，原证书不吊销
+func (this *Authority) Renew(old *model.CaCert, days int, op Operator) (*model.CaCert, error) {
+    if old.AuthorityId != this.Data.ID {
+        return nil, ErrCertNotFound
+    }
+
+    if old.Status == StatusRevoked {
+        return nil, ErrCertRevoked
+    }
+
+    profile, err := GetProfile(old.Profile)
+    if err != nil {
+        return nil, err
+    }
+
+    oldCert, err := parseCert(old.Cert)
+    if err != nil {
+        return nil, err
+    }
+
+    if days <= 0 {
+        days = profile.Days
+    }
+
+    cert, certPEM, err := this.sign(profile, oldCert.Subject, oldCert.PublicKey, oldCert.DNSNames, oldCert.IPAddresses, days)
+    if err != nil {
+        return nil, err
+    }
+
+    data := &model.CaCert{
+        AuthorityId:  this.Data.ID,
+        SerialNumber: formatSerial(cert.SerialNumber),
+        CommonName:   cert.Subject.CommonName,
+        Profile:      profile.Name,
+        KeyType:      old.KeyType,
+        DnsNames:     old.DnsNames,
+        IpAddresses:  old.IpAddresses,
+        Csr:          old.Csr,
+        Cert:         certPEM,
+        PrivateKey:   old.PrivateKey,
+        RenewId:      old.ID,
+        Status:       StatusValid,
+        NotBefore:    int(cert.NotBefore.Unix()),
+        NotAfter:     int(cert.NotAfter.Unix()),
+        AddTime:      int(datebin.NowTime()),
+        AddIp:        op.Ip,
+    }
+
+    if err := model.NewCaCert().Create(data).Error; err != nil {
+        return nil, err
+    }
+
+    addLog(this.Data.ID, data, ActionRenew, "续期证书，原序列号 " + old.SerialNumber, op)
+
+    return data, nil
+}
+
+// 吊销证书，原因为 RFC 5280 吊销原因代码
+func (this *Authority) Revoke(cert *model.CaCert, reason int, op Operator) error {
+    if cert.AuthorityId != this.Data.ID {
+        return ErrCertNotFound
+    }
+
+    if reason < cryptobin_ca.CRLReasonUnspecified ||
+        reason > cryptobin_ca.CRLReasonAACompromise ||
+        reason == 7 {
+        return ErrRevokeReason
+    }
+
+    if cert.Status == StatusRevoked {
+        return ErrCertRevoked
+    }
+
+    revokeTime := int(datebin.NowTime())
+
+    result := model.NewCaCert().
+        Where("id = ?", cert.ID).
+        Where("status = ?", StatusValid).
+        Updates(map[string]any{
+            "status":        StatusRevoked,
+            "revoke_reason": reason,
+            "revoke_time":   revokeTime,
+        })
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return ErrCertRevoked
+    }
+
+    cert.Status = StatusRevoked
+    cert.RevokeReason = reason
+    cert.RevokeTime = revokeTime
+
+    addLog(this.Data.ID, cert, ActionRevoke, "吊销证书，原因 " + strconv.Itoa(reason), op)
+
+    return nil
+}
+
+// 签发
+func (this *Authority) sign(
+    profile Profile,
+    subject pkix.Name,
+    pub any,
+    dns []string,
+    ips []net.IP,
+    days int,
+) (*x509.Certificate, string, error) {
+    now := time.Now()
+    if now.After(this.Cert.NotAfter) {
+        return nil, "", ErrAuthorityExpired
+    }
+
+    // 有效期不超过签发机构证书
+    notAfter := now.AddDate(0, 0, days)
+    if notAfter.After(this.Cert.NotAfter) {
+        notAfter = this.Cert.NotAfter
+    }
+
+    serial, err := newSerial()
+    if err != nil {
+        return nil, "", err
+    }
+
+    skid, err := subjectKeyId(pub)
+    if err != nil {
+        return nil, "", err
+    }
+
+    // 只有 RSA 密钥可以用于密钥加密
+    keyUsage := profile.KeyUsage
+    if _, ok := pub.(*rsa.PublicKey); !ok {
+        keyUsage &^= x509.KeyUsageKeyEncipherment
+    }
+
+    newCert := cryptobin_ca.New().
+        FromPublicKey(pub).
+        FromPrivateKey(this.signer).
+        MakeCert(&subject, 0, dns, ips, signatureAlgorithm(this.signer)).
+        UpdateCert(func(cert *x509.Certificate) *x509.Certificate {
+            cert.SerialNumber = serial
+            cert.SubjectKeyId = skid
+            cert.NotBefore = now.Add(-notBeforeSkew)
+            cert.NotAfter = notAfter
+            cert.KeyUsage = keyUsage
+            cert.ExtKeyUsage = profile.ExtKeyUsage
+            cert.BasicConstraintsValid = true
+
+            return cert
+        }).
+        CreateCert(this.Cert)
+    if err := newCert.Error().First(); err != nil {
+        return nil, "", err
+    }
+
+    certPEM := newCert.ToKeyString()
+
+    cert, err := parseCert(certPEM)
+    if err != nil {
+        return nil, "", err
+    }
+
+    return cert, certPEM, nil
+}
+
+// 解析并验证证书请求
+func parseCSR(data string) (*x509.CertificateRequest, error) {
+    der := []byte(data)
+
+    block, _ := pem.Decode(der)
+    if block != nil {
+        if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+            return nil, ErrCSR
+        }
+
+        der = block.Bytes
+    }
+
+    csr, err := x509.ParseCertificateRequest(der)
+    if err != nil {
+        return nil, ErrCSR
+    }
+
+    if err := csr.CheckSignature(); err != nil {
+        return nil, ErrCSR
+    }
+
+    return csr, nil
+}
+
+// 解析 IP 地址
+func parseIPs(data []string) ([]net.IP, error) {
+    ips := make([]net.IP, 0, len(data))
+    for _, v := range data {
+        if v = strings.TrimSpace(v); v == "" {
+            continue
+        }
+
+        ip := net.ParseIP(v)
+        if ip == nil {
+            return nil, ErrIPAddress
+        }
+
+        ips = append(ips, ip)
+    }
+
+    return ips, nil
+}
+
+// 合并名称并去重
+func mergeNames(a, b []string) []string {
+    names := make([]string, 0, len(a) + len(b))
+    for _, name := range append(append([]string{}, a...), b...) {
+        if name = strings.TrimSpace(name); name == "" {
+            continue
+        }
+
+        exists := false
+        for _, v := range names {
+            if strings.EqualFold(v, name) {
+                exists = true
+                break
+            }
+        }
+
+        if !exists {
+            names = append(names, name)
+        }
+    }
+
+    return names
+}
+
+// 合并 IP 并去重
+func mergeIPs(a, b []net.IP) []net.IP {
+    ips := make([]net.IP, 0, len(a) + len(b))
+    for _, ip := range append(append([]net.IP{}, a...), b...) {
+        exists := false
+        for _, v := range ips {
+            if v.Equal(ip) {
+                exists = true
+                break
+            }
+        }
+
+        if !exists {
+            ips = append(ips, ip)
+        }
+    }
+
+    return ips
+}
+
+// IP 列表转为字符
+func joinIPs(ips []net.IP) string {
+    data := make([]string, 0, len(ips))
+    for _, ip := range ips {
+        data = append(data, ip.String())
+    }
+
+    return strings.Join(data, ",")
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+    "net"
+    "time"
+    "testing"
+    "math/big"
+    "crypto"
+    "crypto/rand"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/pem"
+
+    "github.com/deatil/lakego-doak-ca/ca/model"
+)
+
+// 生成测试签发机构
+func newTestAuthority(t *testing.T, keyType string, notAfter time.Time) *Authority {
+    newKey, err := generateKey(keyType)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    signer := newKey.GetPrivateKey().(crypto.Signer)
+
+    tmpl := &x509.Certificate{
+        SerialNumber:          big.NewInt(1),
+        Subject:               pkix.Name{CommonName: "test root"},
+        NotBefore:             time.Now().Add(-time.Hour),
+        NotAfter:              notAfter,
+        KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+        BasicConstraintsValid: true,
+        IsCA:                  true,
+    }
+
+    der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, signer.Public(), signer)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    cert, err := x509.ParseCertificate(der)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return &Authority{
+        Data:   &model.CaAuthority{ID: "test", Name: "test root"},
+        Cert:   cert,
+        signer: signer,
+    }
+}
+
+// 生成证书请求
+func newTestCSR(t *testing.T, cn string, dns []string, ips []net.IP) string {
+    newKey, err := generateKey(KeyP256)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+        Subject:     pkix.Name{CommonName: cn},
+        DNSNames:    dns,
+        IPAddresses: ips,
+    }, newKey.GetPrivateKey())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestAuthoritySign(t *testing.T) {
+    for _, keyType := range []string{KeyRSA2048, KeyP256, KeyP384, KeyEd25519} {
+        authority := newTestAuthority(t, keyType, time.Now().AddDate(1, 0, 0))
+
+        csr, err := parseCSR(newTestCSR(t, "example.com", []string{"example.com"}, nil))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        profile, err := GetProfile(ProfileServer)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        dns := mergeNames(csr.DNSNames, []string{"www.example.com", "EXAMPLE.com"})
+        ips := []net.IP{net.ParseIP("127.0.0.1")}
+
+        cert, certPEM, err := authority.sign(profile, csr.Subject, csr.PublicKey, dns, ips, 30)
+        if err != nil {
+            t.Fatalf("%s: %v", keyType, err)
+        }
+
+        parsed, err := parseCert(certPEM)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !parsed.Equal(cert) {
+            t.Errorf("%s: returned PEM is not the certificate", keyType)
+        }
+
+        roots := x509.NewCertPool()
+        roots.AddCert(authority.Cert)
+
+        _, err = cert.Verify(x509.VerifyOptions{
+            Roots:     roots,
+            DNSName:   "www.example.com",
+            KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+        })
+        if err != nil {
+            t.Fatalf("%s: %v", keyType, err)
+        }
+
+        if cert.Subject.CommonName != "example.com" || len(cert.DNSNames) != 2 || len(cert.IPAddresses) != 1 {
+            t.Errorf("%s: subject %s, dns %v, ips %v", keyType, cert.Subject.CommonName, cert.DNSNames, cert.IPAddresses)
+        }
+
+        // 非 RSA 密钥不能用于密钥加密
+        if cert.KeyUsage & x509.KeyUsageKeyEncipherment != 0 {
+            t.Errorf("%s: ecdsa certificate has KeyEncipherment usage", keyType)
+        }
+
+        // 客户端证书不能用于服务端
+        _, err = cert.Verify(x509.VerifyOptions{
+            Roots:     roots,
+            KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+        })
+        if err == nil {
+            t.Errorf("%s: server certificate should not verify for client auth", keyType)
+        }
+
+        // 其他签发机构
+        other := newTestAuthority(t, keyType, time.Now().AddDate(1, 0, 0))
+        otherRoots := x509.NewCertPool()
+        otherRoots.AddCert(other.Cert)
+
+        if _, err := cert.Verify(x509.VerifyOptions{Roots: otherRoots}); err == nil {
+            t.Errorf("%s: certificate should not verify with other authority", keyType)
+        }
+
+        if cert.SerialNumber.Sign() <= 0 || formatSerial(cert.SerialNumber) == "" {
+            t.Errorf("%s: invalid serial %s", keyType, cert.SerialNumber)
+        }
+
+        if serial, ok := parseSerial(formatSerial(cert.SerialNumber)); !ok || serial.Cmp(cert.SerialNumber) != 0 {
+            t.Errorf("%s: parseSerial does not match formatSerial", keyType)
+        }
+    }
+}
+
+func TestAuthoritySignValidity(t *testing.T) {
+    notAfter := time.Now().AddDate(0, 0, 10).Truncate(time.Second)
+    authority := newTestAuthority(t, KeyP256, notAfter)
+
+    csr, err := parseCSR(newTestCSR(t, "client", nil, nil))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    profile, err := GetProfile(ProfileClient)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // 有效期不超过签发机构证书
+    cert, _, err := authority.sign(profile, csr.Subject, csr.PublicKey, nil, nil, 365)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if !cert.NotAfter.Equal(authority.Cert.NotAfter) {
+        t.Errorf("NotAfter got %s, want %s", cert.NotAfter, authority.Cert.NotAfter)
+    }
+
+    if !cert.NotBefore.Before(time.Now().Add(-notBeforeSkew + time.Minute)) {
+        t.Errorf("NotBefore got %s, want before now", cert.NotBefore)
+    }
+
+    // 过期的签发机构
+    expired := newTestAuthority(t, KeyP256, time.Now().Add(-time.Minute))
+    if _, _, err := expired.sign(profile, csr.Subject, csr.PublicKey, nil, nil, 30); err != ErrAuthorityExpired {
+        t.Errorf("sign got %v, want ErrAuthorityExpired", err)
+    }
+
+    if _, err := GetProfile("ca"); err != ErrProfile {
+        t.Errorf("GetProfile got %v, want ErrProfile", err)
+    }
+}
+
+func TestParseCSR(t *testing.T) {
+    data := newTestCSR(t, "example.com", []string{"example.com"}, []net.IP{net.ParseIP("::1")})
+
+    csr, err := parseCSR(data)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if csr.Subject.CommonName != "example.com" {
+        t.Errorf("CommonName got %s", csr.Subject.CommonName)
+    }
+
+    // DER 格式
+    block, _ := pem.Decode([]byte(data))
+    if _, err := parseCSR(string(block.Bytes)); err != nil {
+        t.Fatal(err)
+    }
+
+    // 修改请求内容，签名验证失败
+    tampered := append([]byte{}, block.Bytes...)
+    idx := len(tampered) - len(csr.Signature) - 20
+    tampered[idx] ^= 0x01
+
+    if _, err := parseCSR(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: tampered}))); err != ErrCSR {
+        t.Errorf("parseCSR got %v, want ErrCSR", err)
+    }
+
+    // 其他 PEM 类型
+    if _, err := parseCSR(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: block.Bytes}))); err != ErrCSR {
+        t.Errorf("parseCSR got %v, want ErrCSR", err)
+    }
+
+    if _, err := parseCSR("csr"); err != ErrCSR {
+        t.Errorf("parseCSR got %v, want ErrCSR", err)
+    }
+}
+
+func TestMergeNames(t *testing.T) {
+    names := mergeNames([]string{"a.com", " b.com "}, []string{"A.com", "", "c.com"})
+    if len(names) != 3 || names[0] != "a.com" || names[1] != "b.com" || names[2] != "c.com" {
+        t.Errorf("mergeNames got %v", names)
+    }
+
+    ips, err := parseIPs([]string{"127.0.0.1", " ", "::1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ips = mergeIPs(ips, []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("10.0.0.1")})
+    if joinIPs(ips) != "127.0.0.1,::1,10.0.0.1" {
+        t.Errorf("mergeIPs got %s", joinIPs(ips))
+    }
+
+    if _, err := parseIPs([]string{"example.com"}); err != ErrIPAddress {
+        t.Errorf("parseIPs got %v, want ErrIPAddress", err)
+    }
+}
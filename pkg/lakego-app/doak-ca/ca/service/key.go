@@ -0,0 +1,221 @@
+package service
+
+import (
+    "errors"
+    "crypto"
+    "crypto/rsa"
+    "crypto/sha1"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/x509"
+    "crypto/ed25519"
+    "crypto/elliptic"
+    "encoding/pem"
+    "encoding/asn1"
+    "crypto/x509/pkix"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/go-cryptobin/pkcs8"
+    cryptobin_ca "github.com/deatil/go-cryptobin/cryptobin/ca"
+)
+
+// 密钥类型
+const (
+    KeyRSA2048 = "rsa2048"
+    KeyRSA3072 = "rsa3072"
+    KeyRSA4096 = "rsa4096"
+    KeyP256    = "p256"
+    KeyP384    = "p384"
+    KeyEd25519 = "ed25519"
+)
+
+// 加密私钥 PEM 类型
+const encryptedKeyType = "ENCRYPTED PRIVATE KEY"
+
+var (
+    // 不支持的密钥类型
+    ErrKeyType = errors.New("ca: unsupported key type")
+
+    // 没有设置私钥加密密码
+    ErrKeyPassword = errors.New("ca: key password is empty")
+
+    // 私钥数据错误
+    ErrPrivateKey = errors.New("ca: invalid private key")
+)
+
+// 生成密钥，默认为 p256
+func generateKey(keyType string) (cryptobin_ca.CA, error) {
+    newCA := cryptobin_ca.New()
+
+    switch keyType {
+        case KeyRSA2048:
+            newCA = newCA.GenerateRsaKey(2048)
+        case KeyRSA3072:
+            newCA = newCA.GenerateRsaKey(3072)
+        case KeyRSA4096:
+            newCA = newCA.GenerateRsaKey(4096)
+        case KeyP256, "":
+            newCA = newCA.GenerateEcdsaKey("P256")
+        case KeyP384:
+            newCA = newCA.GenerateEcdsaKey("P384")
+        case KeyEd25519:
+            newCA = newCA.GenerateEdDSAKey()
+        default:
+            return newCA, ErrKeyType
+    }
+
+    if err := newCA.Error().First(); err != nil {
+        return newCA, err
+    }
+
+    return newCA, nil
+}
+
+// 公钥对应的密钥类型
+func keyTypeOf(pub any) string {
+    switch key := pub.(type) {
+        case *rsa.PublicKey:
+            switch key.N.BitLen() {
+                case 3072:
+                    return KeyRSA3072
+                case 4096:
+                    return KeyRSA4096
+            }
+
+            return KeyRSA2048
+        case *ecdsa.PublicKey:
+            if key.Curve == elliptic.P384() {
+                return KeyP384
+            }
+
+            return KeyP256
+        case ed25519.PublicKey:
+            return KeyEd25519
+    }
+
+    return ""
+}
+
+// 签发者私钥对应的签名方式
+func signatureAlgorithm(signer crypto.Signer) string {
+    switch key := signer.Public().(type) {
+        case *ecdsa.PublicKey:
+            if key.Curve == elliptic.P384() {
+                return "ECDSAWithSHA384"
+            }
+
+            return "ECDSAWithSHA256"
+        case ed25519.PublicKey:
+            return "PureEd25519"
+    }
+
+    return "SHA256WithRSA"
+}
+
+// 公钥标识，RFC 5280 4.2.1.2 方式一
+func subjectKeyId(pub any) ([]byte, error) {
+    der, err := x509.MarshalPKIXPublicKey(pub)
+    if err != nil {
+        return nil, err
+    }
+
+    var publicKeyInfo struct {
+        Algorithm pkix.AlgorithmIdentifier
+        PublicKey asn1.BitString
+    }
+    if _, err := asn1.Unmarshal(der, &publicKeyInfo); err != nil {
+        return nil, err
+    }
+
+    id := sha1.Sum(publicKeyInfo.PublicKey.Bytes)
+
+    return id[:], nil
+}
+
+// 私钥加密密码
+func keyPassword() ([]byte, error) {
+    password := config.New("ca").GetString("key.password")
+    if password == "" {
+        return nil, ErrKeyPassword
+    }
+
+    return []byte(password), nil
+}
+
+// PBES2 加密设置，迭代次数可以使用 key.iter 配置
+func keyOpts() pkcs8.Opts {
+    iter := config.New("ca").GetInt("key.iter")
+    if iter <= 0 {
+        iter = 100000
+    }
+
+    return pkcs8.Opts{
+        Cipher:  pkcs8.AES256CBC,
+        KDFOpts: pkcs8.PBKDF2Opts{
+            SaltSize:       16,
+            IterationCount: iter,
+            HMACHash:       pkcs8.SHA256,
+        },
+    }
+}
+
+// 使用 PKCS#8 PBES2 加密私钥
+func encryptKey(key any, password []byte) (string, error) {
+    der, err := x509.MarshalPKCS8PrivateKey(key)
+    if err != nil {
+        return "", err
+    }
+
+    block, err := pkcs8.EncryptPKCS8PrivateKey(rand.Reader, encryptedKeyType, der, password, keyOpts())
+    if err != nil {
+        return "", err
+    }
+
+    return string(pem.EncodeToMemory(block)), nil
+}
+
+// 解密私钥
+func decryptKey(data string, password []byte) (crypto.Signer, error) {
+    block, _ := pem.Decode([]byte(data))
+    if block == nil || block.Type != encryptedKeyType {
+        return nil, ErrPrivateKey
+    }
+
+    der, err := pkcs8.DecryptPEMBlock(block, password)
+    if err != nil {
+        return nil, err
+    }
+
+    key, err := x509.ParsePKCS8PrivateKey(der)
+    if err != nil {
+        return nil, err
+    }
+
+    signer, ok := key.(crypto.Signer)
+    if !ok {
+        return nil, ErrPrivateKey
+    }
+
+    return signer, nil
+}
+
+// 使用配置密码加密私钥
+func sealKey(key any) (string, error) {
+    password, err := keyPassword()
+    if err != nil {
+        return "", err
+    }
+
+    return encryptKey(key, password)
+}
+
+// 使用配置密码解密私钥
+func openKey(data string) (crypto.Signer, error) {
+    password, err := keyPassword()
+    if err != nil {
+        return nil, err
+    }
+
+    return decryptKey(data, password)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+    "strings"
+    "testing"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/pem"
+    "encoding/asn1"
+)
+
+var testKeyPassword = []byte("test-key-password")
+
+func TestEncryptKey(t *testing.T) {
+    keyTypes := []string{KeyRSA2048, KeyP256, KeyP384, KeyEd25519, ""}
+
+    for _, keyType := range keyTypes {
+        newKey, err := generateKey(keyType)
+        if err != nil {
+            t.Fatalf("%s: %v", keyType, err)
+        }
+
+        want := keyType
+        if want == "" {
+            want = KeyP256
+        }
+
+        if got := keyTypeOf(newKey.GetPublicKey()); got != want {
+            t.Errorf("keyTypeOf got %s, want %s", got, want)
+        }
+
+        data, err := encryptKey(newKey.GetPrivateKey(), testKeyPassword)
+        if err != nil {
+            t.Fatalf("%s: %v", keyType, err)
+        }
+
+        if !strings.Contains(data, encryptedKeyType) {
+            t.Errorf("%s: encryptKey should return encrypted PEM", keyType)
+        }
+
+        signer, err := decryptKey(data, testKeyPassword)
+        if err != nil {
+            t.Fatalf("%s: %v", keyType, err)
+        }
+
+        if keyTypeOf(signer.Public()) != want {
+            t.Errorf("%s: decrypted key type is %s", keyType, keyTypeOf(signer.Public()))
+        }
+
+        // 错误密码
+        if _, err := decryptKey(data, []byte("wrong-password")); err == nil {
+            t.Errorf("%s: decryptKey should fail with wrong password", keyType)
+        }
+    }
+
+    if _, err := generateKey("dsa"); err != ErrKeyType {
+        t.Errorf("generateKey got %v, want ErrKeyType", err)
+    }
+}
+
+func TestDecryptKeyError(t *testing.T) {
+    newKey, err := generateKey(KeyP256)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    data, err := encryptKey(newKey.GetPrivateKey(), testKeyPassword)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // 修改加密数据第一块，CBC 模式没有认证，修改后面的块不一定能检测到
+    block, _ := pem.Decode([]byte(data))
+
+    var info struct {
+        Algo          pkix.AlgorithmIdentifier
+        EncryptedData []byte
+    }
+    if _, err := asn1.Unmarshal(block.Bytes, &info); err != nil {
+        t.Fatal(err)
+    }
+
+    block.Bytes[len(block.Bytes) - len(info.EncryptedData)] ^= 0x01
+
+    if _, err := decryptKey(string(pem.EncodeToMemory(block)), testKeyPassword); err == nil {
+        t.Error("decryptKey should fail with tampered key")
+    }
+
+    // 截断数据
+    block, _ = pem.Decode([]byte(data))
+    block.Bytes = block.Bytes[:len(block.Bytes) - 16]
+
+    if _, err := decryptKey(string(pem.EncodeToMemory(block)), testKeyPassword); err == nil {
+        t.Error("decryptKey should fail with truncated key")
+    }
+
+    // 未加密的私钥
+    der, err := x509.MarshalPKCS8PrivateKey(newKey.GetPrivateKey())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    plain := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+    if _, err := decryptKey(string(plain), testKeyPassword); err != ErrPrivateKey {
+        t.Errorf("decryptKey got %v, want ErrPrivateKey", err)
+    }
+
+    if _, err := decryptKey("key", testKeyPassword); err != ErrPrivateKey {
+        t.Errorf("decryptKey got %v, want ErrPrivateKey", err)
+    }
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+    "errors"
+    "crypto/x509"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 证书模板名称
+const (
+    ProfileServer      = "server"
+    ProfileClient      = "client"
+    ProfileCodeSigning = "code-signing"
+)
+
+// 不支持的证书模板
+var ErrProfile = errors.New("ca: unsupported profile")
+
+/**
+ * 证书模板
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Profile struct {
+    // 名称
+    Name string
+
+    // 密钥用途
+    KeyUsage x509.KeyUsage
+
+    // 扩展密钥用途
+    ExtKeyUsage []x509.ExtKeyUsage
+
+    // 默认有效天数
+    Days int
+
+    // 是否需要 DNS 或者 IP
+    RequireSAN bool
+}
+
+// 默认模板
+var profiles = map[string]Profile{
+    ProfileServer: {
+        Name:        ProfileServer,
+        KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+        ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+        Days:        397,
+        RequireSAN:  true,
+    },
+    ProfileClient: {
+        Name:        ProfileClient,
+        KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+        ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+        Days:        365,
+    },
+    ProfileCodeSigning: {
+        Name:        ProfileCodeSigning,
+        KeyUsage:    x509.KeyUsageDigitalSignature,
+        ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+        Days:        365,
+    },
+}
+
+// 获取模板，有效天数可以使用 profiles.{name}.days 配置
+func GetProfile(name string) (Profile, error) {
+    profile, ok := profiles[name]
+    if !ok {
+        return Profile{}, ErrProfile
+    }
+
+    days := config.New("ca").GetInt("profiles." + name + ".days")
+    if days > 0 {
+        profile.Days = days
+    }
+
+    return profile, nil
+}
+
+// 模板名称列表
+func ProfileNames() []string {
+    return []string{
+        ProfileServer,
+        ProfileClient,
+        ProfileCodeSigning,
+    }
+}
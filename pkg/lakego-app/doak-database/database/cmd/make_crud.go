@@ -0,0 +1,90 @@
+package cmd
+
+import (
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+
+    "github.com/deatil/lakego-doak-admin/admin/stubs"
+
+    "github.com/deatil/lakego-doak-database/database/service"
+)
+
+/**
+ * 根据数据表生成 CRUD
+ *
+ * > ./main lakego-admin:make-crud [table] [--dir=app/admin] [--force]
+ * > main.exe lakego-admin:make-crud [table] [--dir=app/admin] [--force]
+ * > go run main.go lakego-admin:make-crud [table] [--dir=app/admin] [--force]
+ *
+ * > go run main.go make:crud hot_book
+ * > go run main.go make:crud lakego_hot_book --dir=pkg/lakego-app/doak-blog/blog
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var MakeCrudCmd = &command.Command{
+    Use: "lakego-admin:make-crud",
+    Aliases: []string{"make:crud"},
+    Short: "lakego-admin make crud from table.",
+    Example: "{execfile} lakego-admin:make-crud [table] --dir=[dir]",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeCrud(args[0])
+    },
+}
+
+var dir string
+var force bool
+
+func init() {
+    pf := MakeCrudCmd.Flags()
+    pf.StringVarP(&dir, "dir", "d", "app/admin", "生成目录，相对程序根目录")
+    pf.BoolVarP(&force, "force", "f", false, "是否覆盖")
+}
+
+// 生成 CRUD
+func MakeCrud(table string) {
+    prefix, _ := database.GetConfig("prefix")
+    tablePrefix := goch.ToString(prefix)
+
+    // 模型名称不含表前缀
+    name := strings.TrimPrefix(table, tablePrefix)
+    tableName := tablePrefix + name
+
+    db := service.NewDatabase()
+
+    columns := db.GetFullColumnsFromTable(tableName)
+    if len(columns) == 0 {
+        color.Redln("数据表[" + tableName + "]不存在或者没有字段！")
+        return
+    }
+
+    comment := ""
+    for _, status := range db.GetTableStatus() {
+        if status["name"] == tableName {
+            comment = status["comment"]
+            break
+        }
+    }
+
+    files, err := stubs.New().MakeCrud(dir, stubs.NewCrud(name, comment, columns), force)
+    if err != nil {
+        color.Redln("生成 CRUD 失败！原因为：" + err.Error())
+        return
+    }
+
+    for _, file := range files {
+        color.Greenln("生成文件：" + file)
+    }
+
+    color.Greenln("生成 CRUD 成功！")
+    color.Yellowln("路由及权限请参考生成的控制器注释，添加后运行 lakego-admin:import-route 导入权限")
+}
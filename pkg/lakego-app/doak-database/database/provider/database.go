@@ -3,9 +3,11 @@ package provider
 import (
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
 
     admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
 
+    "github.com/deatil/lakego-doak-database/database/cmd"
     database_router "github.com/deatil/lakego-doak-database/database/route"
 )
 
@@ -24,10 +26,23 @@ func (this *Database) Register() {}
 
 // 引导
 func (this *Database) Boot() {
+    // 脚本
+    this.loadCommand()
+
     // 路由
     this.loadRoute()
+
+    // 语言包
+    this.loadTranslations()
 }
 
+/**
+ * 导入脚本
+ */
+func (this *Database) loadCommand() {
+    // 根据数据表生成 CRUD
+    this.AddCommand(cmd.MakeCrudCmd)
+}
 
 /**
  * 导入路由
@@ -39,3 +54,17 @@ func (this *Database) loadRoute() {
     })
 }
 
+/**
+ * 导入语言包
+ */
+func (this *Database) loadTranslations() {
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-database/resources/lang")
+
+    this.LoadTranslationsFrom(path, "lakego-database")
+
+    // 推送语言包
+    // > go run main.go lakego:publish --tag=database-lang --force
+    this.Publishes(this, map[string]string{
+        path: pathTool.ResourcesPath("/lang/lakego-database"),
+    }, "database-lang")
+}
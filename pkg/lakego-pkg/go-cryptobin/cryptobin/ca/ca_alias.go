@@ -4,6 +4,7 @@ import (
     "crypto/x509"
     "crypto/x509/pkix"
 
+    "golang.org/x/crypto/ocsp"
     sm2X509 "github.com/tjfoc/gmsm/x509"
 )
 
@@ -84,3 +85,15 @@ type (
     // PublicKeyAlgorithm
     CASM2PublicKeyAlgorithm = sm2X509.PublicKeyAlgorithm
 )
+
+// ocsp
+type (
+    // OCSP 请求
+    CAOCSPRequest = ocsp.Request
+
+    // OCSP 响应
+    CAOCSPResponse = ocsp.Response
+
+    // OCSP 请求配置
+    CAOCSPRequestOptions = ocsp.RequestOptions
+)
@@ -0,0 +1,176 @@
+package ca
+
+import (
+    "time"
+    "errors"
+    "math/big"
+    "crypto"
+    "crypto/rand"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/pem"
+
+    "github.com/tjfoc/gmsm/sm2"
+    sm2_x509 "github.com/tjfoc/gmsm/x509"
+)
+
+// 吊销原因
+// RFC 5280, 5.3.1
+const (
+    CRLReasonUnspecified          = 0
+    CRLReasonKeyCompromise        = 1
+    CRLReasonCACompromise         = 2
+    CRLReasonAffiliationChanged   = 3
+    CRLReasonSuperseded           = 4
+    CRLReasonCessationOfOperation = 5
+    CRLReasonCertificateHold      = 6
+    CRLReasonRemoveFromCRL        = 8
+    CRLReasonPrivilegeWithdrawn   = 9
+    CRLReasonAACompromise         = 10
+)
+
+// 生成吊销证书数据
+func NewRevokedCert(serial *big.Int, revokedAt time.Time, reason int) pkix.RevokedCertificate {
+    revoked := pkix.RevokedCertificate{
+        SerialNumber:   serial,
+        RevocationTime: revokedAt.UTC(),
+    }
+
+    if reason > 0 {
+        // 吊销原因为 ENUMERATED 类型
+        revoked.Extensions = []pkix.Extension{
+            {
+                Id:    oidExtensionReasonCode,
+                Value: []byte{0x0a, 0x01, byte(reason)},
+            },
+        }
+    }
+
+    return revoked
+}
+
+// 生成证书吊销列表，需要 CA 私钥
+// ca 可用 [*x509.Certificate | *sm2_x509.Certificate]
+// SM2 签名的吊销列表不包括 number 扩展
+func (this CA) CreateCRL(
+    ca any,
+    revoked []pkix.RevokedCertificate,
+    number *big.Int,
+    thisUpdate time.Time,
+    nextUpdate time.Time,
+) CA {
+    if this.privateKey == nil {
+        err := errors.New("CA: [CreateCRL()] privateKey error.")
+        return this.AppendError(err)
+    }
+
+    var crlBytes []byte
+    var err error
+
+    switch privateKey := this.privateKey.(type) {
+        case *sm2.PrivateKey:
+            newCa, ok := ca.(*sm2_x509.Certificate)
+            if !ok {
+                err := errors.New("CA: [CreateCRL()] sm2 ca error.")
+                return this.AppendError(err)
+            }
+
+            crlBytes, err = newCa.CreateCRL(rand.Reader, privateKey, revoked, thisUpdate, nextUpdate)
+
+        default:
+            newCa, ok := ca.(*x509.Certificate)
+            if !ok {
+                err := errors.New("CA: [CreateCRL()] ca error.")
+                return this.AppendError(err)
+            }
+
+            signer, ok := this.privateKey.(crypto.Signer)
+            if !ok {
+                err := errors.New("CA: [CreateCRL()] privateKey is not a signer.")
+                return this.AppendError(err)
+            }
+
+            if number == nil {
+                number = big.NewInt(thisUpdate.Unix())
+            }
+
+            crlBytes, err = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+                RevokedCertificates: revoked,
+                Number:              number,
+                ThisUpdate:          thisUpdate,
+                NextUpdate:          nextUpdate,
+            }, newCa, signer)
+    }
+
+    if err != nil {
+        return this.AppendError(err)
+    }
+
+    crlBlock := &pem.Block{
+        Type: "X509 CRL",
+        Bytes: crlBytes,
+    }
+
+    this.keyData = pem.EncodeToMemory(crlBlock)
+
+    return this
+}
+
+// 解析证书吊销列表，支持 PEM 及 DER 格式
+func (this CA) ParseCRL(crlBytes []byte) (*pkix.CertificateList, error) {
+    crl, err := sm2_x509.ParseCRL(crlBytes)
+    if err != nil {
+        return nil, errors.New("CA: [ParseCRL()] failed to parse crl: " + err.Error())
+    }
+
+    return crl, nil
+}
+
+// 验证证书吊销列表签名
+// issuer 可用 [*x509.Certificate | *sm2_x509.Certificate]
+func (this CA) VerifyCRL(crl *pkix.CertificateList, issuer any) error {
+    var err error
+
+    switch cert := issuer.(type) {
+        case *sm2_x509.Certificate:
+            err = cert.CheckCRLSignature(crl)
+        case *x509.Certificate:
+            // 使用 sm2 证书解析，同时支持 SM2 签名
+            sm2Cert, perr := sm2_x509.ParseCertificate(cert.Raw)
+            if perr != nil {
+                return errors.New("CA: [VerifyCRL()] failed to parse issuer: " + perr.Error())
+            }
+
+            err = sm2Cert.CheckCRLSignature(crl)
+        default:
+            return errors.New("CA: [VerifyCRL()] issuer error.")
+    }
+
+    if err != nil {
+        return errors.New("CA: [VerifyCRL()] failed to verify crl: " + err.Error())
+    }
+
+    return nil
+}
+
+// 查找吊销的证书
+func (this CA) FindRevokedCert(crl *pkix.CertificateList, serial *big.Int) (pkix.RevokedCertificate, bool) {
+    for _, revoked := range crl.TBSCertList.RevokedCertificates {
+        if revoked.SerialNumber != nil && revoked.SerialNumber.Cmp(serial) == 0 {
+            return revoked, true
+        }
+    }
+
+    return pkix.RevokedCertificate{}, false
+}
+
+// 吊销原因，没有设置时返回 CRLReasonUnspecified
+func (this CA) GetRevokedReason(revoked pkix.RevokedCertificate) int {
+    for _, ext := range revoked.Extensions {
+        if ext.Id.Equal(oidExtensionReasonCode) && len(ext.Value) == 3 {
+            return int(ext.Value[2])
+        }
+    }
+
+    return CRLReasonUnspecified
+}
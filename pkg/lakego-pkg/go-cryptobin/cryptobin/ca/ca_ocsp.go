@@ -0,0 +1,278 @@
+package ca
+
+import (
+    "io"
+    "time"
+    "bytes"
+    "errors"
+    "strings"
+    "net/url"
+    "net/http"
+    "math/big"
+    "crypto"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+    "encoding/base64"
+
+    "golang.org/x/crypto/ocsp"
+)
+
+// OCSP 证书状态
+const (
+    OCSPGood    = ocsp.Good
+    OCSPRevoked = ocsp.Revoked
+    OCSPUnknown = ocsp.Unknown
+)
+
+// OCSP 请求最大长度
+const maxOCSPRequestSize = 10240
+
+// 生成 OCSP 请求
+// OCSP 只支持 [RSA | ECDSA | EdDSA] 证书
+func (this CA) CreateOCSPRequest(cert *x509.Certificate, issuer *x509.Certificate, opts *ocsp.RequestOptions) ([]byte, error) {
+    return ocsp.CreateRequest(cert, issuer, opts)
+}
+
+// 解析 OCSP 请求
+func (this CA) ParseOCSPRequest(data []byte) (*ocsp.Request, error) {
+    return ocsp.ParseRequest(data)
+}
+
+// 生成 OCSP 响应，使用 CA 私钥或者 OCSP 签名证书私钥签名
+// responder 为空时使用 issuer 签名
+func (this CA) CreateOCSPResponse(issuer *x509.Certificate, responder *x509.Certificate, template ocsp.Response) ([]byte, error) {
+    signer, ok := this.privateKey.(crypto.Signer)
+    if !ok {
+        return nil, errors.New("CA: [CreateOCSPResponse()] privateKey error.")
+    }
+
+    if responder == nil {
+        responder = issuer
+    }
+
+    return ocsp.CreateResponse(issuer, responder, template, signer)
+}
+
+// 解析并验证 OCSP 响应
+// 验证签名、序列号、委托签名证书用途及有效期
+func (this CA) VerifyOCSPResponse(data []byte, cert *x509.Certificate, issuer *x509.Certificate) (*ocsp.Response, error) {
+    resp, err := ocsp.ParseResponseForCert(data, cert, issuer)
+    if err != nil {
+        return nil, errors.New("CA: [VerifyOCSPResponse()] failed to parse response: " + err.Error())
+    }
+
+    if cert != nil && resp.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+        return nil, errors.New("CA: [VerifyOCSPResponse()] serial number mismatch")
+    }
+
+    // 委托签名证书需要 OCSPSigning 用途
+    if resp.Certificate != nil && !bytes.Equal(resp.Certificate.Raw, issuer.Raw) {
+        allowed := false
+        for _, usage := range resp.Certificate.ExtKeyUsage {
+            if usage == x509.ExtKeyUsageOCSPSigning {
+                allowed = true
+                break
+            }
+        }
+
+        if !allowed {
+            return nil, errors.New("CA: [VerifyOCSPResponse()] responder certificate is not authorized")
+        }
+    }
+
+    if !resp.NextUpdate.IsZero() && time.Now().After(resp.NextUpdate) {
+        return nil, errors.New("CA: [VerifyOCSPResponse()] response is expired")
+    }
+
+    return resp, nil
+}
+
+// ==========
+
+// OCSP 证书状态
+type OCSPStatus struct {
+    // 状态 [OCSPGood | OCSPRevoked | OCSPUnknown]
+    Status int
+
+    // 吊销时间
+    RevokedAt time.Time
+
+    // 吊销原因
+    RevocationReason int
+}
+
+// 根据序列号获取证书状态
+type OCSPStatusFunc = func(serial *big.Int) (OCSPStatus, error)
+
+/**
+ * OCSP 响应服务
+ *
+ * 实现 http.Handler，支持 GET 及 POST 请求
+ * lakego 路由中可以使用 router.WrapH(responder) 挂载
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type OCSPResponder struct {
+    // 签发者证书
+    Issuer *x509.Certificate
+
+    // 签名证书，为空时使用签发者证书
+    Responder *x509.Certificate
+
+    // 签名私钥
+    Signer crypto.Signer
+
+    // 证书状态
+    Status OCSPStatusFunc
+
+    // 响应有效时长，为 0 时不设置下次更新时间
+    NextUpdate time.Duration
+}
+
+// 构造函数
+func NewOCSPResponder(issuer *x509.Certificate, signer crypto.Signer, status OCSPStatusFunc) *OCSPResponder {
+    return &OCSPResponder{
+        Issuer:     issuer,
+        Signer:     signer,
+        Status:     status,
+        NextUpdate: time.Hour,
+    }
+}
+
+// 使用证书吊销列表作为证书状态
+// 签发者签发的证书都可以查询，不在列表中的为正常状态
+func NewCRLStatus(crl *pkix.CertificateList) OCSPStatusFunc {
+    return func(serial *big.Int) (OCSPStatus, error) {
+        revoked, ok := New().FindRevokedCert(crl, serial)
+        if !ok {
+            return OCSPStatus{
+                Status: OCSPGood,
+            }, nil
+        }
+
+        return OCSPStatus{
+            Status:           OCSPRevoked,
+            RevokedAt:        revoked.RevocationTime,
+            RevocationReason: New().GetRevokedReason(revoked),
+        }, nil
+    }
+}
+
+// 处理请求
+func (this *OCSPResponder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    var data []byte
+    var err error
+
+    switch r.Method {
+        case http.MethodGet:
+            // GET 请求使用路径最后一段 url 编码后的 base64 数据
+            path := r.URL.EscapedPath()
+            if idx := strings.LastIndex(path, "/"); idx >= 0 {
+                path = path[idx+1:]
+            }
+
+            path, err = url.PathUnescape(path)
+            if err == nil {
+                data, err = base64.StdEncoding.DecodeString(path)
+            }
+        case http.MethodPost:
+            data, err = io.ReadAll(io.LimitReader(r.Body, maxOCSPRequestSize))
+        default:
+            w.WriteHeader(http.StatusMethodNotAllowed)
+            return
+    }
+
+    if err != nil {
+        this.write(w, ocsp.MalformedRequestErrorResponse)
+        return
+    }
+
+    // 出错时返回对应的 OCSP 错误响应
+    resp, _ := this.Respond(data)
+
+    this.write(w, resp)
+}
+
+// 生成响应数据，出错时返回对应的错误响应
+func (this *OCSPResponder) Respond(data []byte) ([]byte, error) {
+    req, err := ocsp.ParseRequest(data)
+    if err != nil {
+        return ocsp.MalformedRequestErrorResponse, err
+    }
+
+    // 只响应当前签发者的证书
+    if !this.matchIssuer(req) {
+        return ocsp.UnauthorizedErrorResponse, errors.New("CA: [OCSPResponder] issuer mismatch")
+    }
+
+    status, err := this.Status(req.SerialNumber)
+    if err != nil {
+        return ocsp.InternalErrorErrorResponse, err
+    }
+
+    now := time.Now().UTC().Truncate(time.Minute)
+
+    template := ocsp.Response{
+        Status:           status.Status,
+        SerialNumber:     req.SerialNumber,
+        ThisUpdate:       now,
+        RevokedAt:        status.RevokedAt,
+        RevocationReason: status.RevocationReason,
+        IssuerHash:       req.HashAlgorithm,
+    }
+
+    if this.NextUpdate > 0 {
+        template.NextUpdate = now.Add(this.NextUpdate)
+    }
+
+    responder := this.Responder
+    if responder == nil {
+        responder = this.Issuer
+    }
+
+    // 委托签名证书需要放入响应中
+    if !bytes.Equal(responder.Raw, this.Issuer.Raw) {
+        template.Certificate = responder
+    }
+
+    resp, err := ocsp.CreateResponse(this.Issuer, responder, template, this.Signer)
+    if err != nil {
+        return ocsp.InternalErrorErrorResponse, err
+    }
+
+    return resp, nil
+}
+
+// 检测请求中的签发者
+func (this *OCSPResponder) matchIssuer(req *ocsp.Request) bool {
+    if !req.HashAlgorithm.Available() {
+        return false
+    }
+
+    var publicKeyInfo struct {
+        Algorithm pkix.AlgorithmIdentifier
+        PublicKey asn1.BitString
+    }
+    if _, err := asn1.Unmarshal(this.Issuer.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+        return false
+    }
+
+    h := req.HashAlgorithm.New()
+    h.Write(publicKeyInfo.PublicKey.RightAlign())
+    keyHash := h.Sum(nil)
+
+    h.Reset()
+    h.Write(this.Issuer.RawSubject)
+    nameHash := h.Sum(nil)
+
+    return bytes.Equal(keyHash, req.IssuerKeyHash) &&
+        bytes.Equal(nameHash, req.IssuerNameHash)
+}
+
+func (this *OCSPResponder) write(w http.ResponseWriter, data []byte) {
+    w.Header().Set("Content-Type", "application/ocsp-response")
+    w.WriteHeader(http.StatusOK)
+    w.Write(data)
+}
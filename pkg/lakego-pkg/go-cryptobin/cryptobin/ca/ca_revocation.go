@@ -0,0 +1,226 @@
+package ca
+
+import (
+    "io"
+    "fmt"
+    "time"
+    "bytes"
+    "errors"
+    "net/http"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+
+    sm2_x509 "github.com/tjfoc/gmsm/x509"
+)
+
+// 吊销原因扩展
+var oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// OCSP 响应最大长度
+const maxOCSPResponseSize = 1 << 20
+
+var (
+    // 证书已吊销
+    ErrCertRevoked = errors.New("CA: certificate is revoked")
+
+    // 证书状态未知
+    ErrCertStatusUnknown = errors.New("CA: certificate status is unknown")
+)
+
+// 吊销检测，证书及签发者证书都为 DER 数据
+type RevocationChecker interface {
+    CheckRevocation(cert []byte, issuer []byte) error
+}
+
+// 检测证书链，根证书不检测
+func checkChainRevocation(chain [][]byte, checkers []RevocationChecker) error {
+    for i := 0; i < len(chain) - 1; i++ {
+        for _, checker := range checkers {
+            if err := checker.CheckRevocation(chain[i], chain[i+1]); err != nil {
+                return err
+            }
+        }
+    }
+
+    return nil
+}
+
+// ==========
+
+/**
+ * 证书吊销列表检测
+ *
+ * 支持 SM2 签名的吊销列表，检测时验证吊销列表签名
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CRLChecker struct {
+    // 吊销列表
+    CRLs []*pkix.CertificateList
+
+    // 没有签发者的吊销列表时报错
+    Strict bool
+
+    // 允许过期的吊销列表
+    AllowExpired bool
+}
+
+// 构造函数
+func NewCRLChecker(crls ...*pkix.CertificateList) *CRLChecker {
+    return &CRLChecker{
+        CRLs: crls,
+    }
+}
+
+// 检测
+func (this *CRLChecker) CheckRevocation(certDER []byte, issuerDER []byte) error {
+    cert, err := sm2_x509.ParseCertificate(certDER)
+    if err != nil {
+        return err
+    }
+
+    issuer, err := sm2_x509.ParseCertificate(issuerDER)
+    if err != nil {
+        return err
+    }
+
+    var issuerName pkix.RDNSequence
+    if _, err := asn1.Unmarshal(cert.RawIssuer, &issuerName); err != nil {
+        return err
+    }
+
+    found := false
+    for _, crl := range this.CRLs {
+        if crl.TBSCertList.Issuer.String() != issuerName.String() {
+            continue
+        }
+
+        if err := issuer.CheckCRLSignature(crl); err != nil {
+            return errors.New("CA: failed to verify crl: " + err.Error())
+        }
+
+        if !this.AllowExpired && crl.HasExpired(time.Now()) {
+            return errors.New("CA: crl is expired")
+        }
+
+        found = true
+
+        revoked, ok := New().FindRevokedCert(crl, cert.SerialNumber)
+        if ok {
+            return fmt.Errorf("%w: serial %s revoked at %s", ErrCertRevoked, cert.SerialNumber, revoked.RevocationTime.Format(time.RFC3339))
+        }
+    }
+
+    if !found && this.Strict {
+        return errors.New("CA: no crl for issuer " + issuerName.String())
+    }
+
+    return nil
+}
+
+// ==========
+
+/**
+ * OCSP 检测
+ *
+ * 只支持 [RSA | ECDSA | EdDSA] 证书
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type OCSPChecker struct {
+    // OCSP 服务地址，为空时使用证书中的地址
+    Server string
+
+    // 请求客户端
+    Client *http.Client
+
+    // 证书状态未知时报错
+    Strict bool
+}
+
+// 构造函数
+func NewOCSPChecker(server ...string) *OCSPChecker {
+    checker := &OCSPChecker{
+        Client: &http.Client{
+            Timeout: 10 * time.Second,
+        },
+    }
+
+    if len(server) > 0 {
+        checker.Server = server[0]
+    }
+
+    return checker
+}
+
+// 检测
+func (this *OCSPChecker) CheckRevocation(certDER []byte, issuerDER []byte) error {
+    cert, err := x509.ParseCertificate(certDER)
+    if err != nil {
+        return err
+    }
+
+    issuer, err := x509.ParseCertificate(issuerDER)
+    if err != nil {
+        return err
+    }
+
+    server := this.Server
+    if server == "" {
+        if len(cert.OCSPServer) == 0 {
+            if this.Strict {
+                return errors.New("CA: certificate has no ocsp server")
+            }
+
+            return nil
+        }
+
+        server = cert.OCSPServer[0]
+    }
+
+    ca := New()
+
+    req, err := ca.CreateOCSPRequest(cert, issuer, nil)
+    if err != nil {
+        return err
+    }
+
+    client := this.Client
+    if client == nil {
+        client = http.DefaultClient
+    }
+
+    httpResp, err := client.Post(server, "application/ocsp-request", bytes.NewReader(req))
+    if err != nil {
+        return err
+    }
+    defer httpResp.Body.Close()
+
+    if httpResp.StatusCode != http.StatusOK {
+        return fmt.Errorf("CA: ocsp server return status %d", httpResp.StatusCode)
+    }
+
+    data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxOCSPResponseSize))
+    if err != nil {
+        return err
+    }
+
+    resp, err := ca.VerifyOCSPResponse(data, cert, issuer)
+    if err != nil {
+        return err
+    }
+
+    switch resp.Status {
+        case OCSPRevoked:
+            return fmt.Errorf("%w: serial %s revoked at %s", ErrCertRevoked, cert.SerialNumber, resp.RevokedAt.Format(time.RFC3339))
+        case OCSPUnknown:
+            if this.Strict {
+                return ErrCertStatusUnknown
+            }
+    }
+
+    return nil
+}
@@ -0,0 +1,501 @@
+package ca
+
+import (
+    "time"
+    "bytes"
+    "errors"
+    "testing"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/x509"
+    "crypto/elliptic"
+    "crypto/x509/pkix"
+    "encoding/pem"
+    "encoding/base64"
+
+    "golang.org/x/crypto/ocsp"
+    "github.com/tjfoc/gmsm/sm2"
+    sm2_x509 "github.com/tjfoc/gmsm/x509"
+)
+
+// 测试证书
+type testCert struct {
+    cert *x509.Certificate
+    key  *ecdsa.PrivateKey
+}
+
+// 生成证书，parent 为空时生成根证书
+func newTestCert(t *testing.T, name string, serial int64, parent *testCert, usage ...x509.ExtKeyUsage) *testCert {
+    key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tmpl := &x509.Certificate{
+        SerialNumber:          big.NewInt(serial),
+        Subject:               pkix.Name{CommonName: name},
+        NotBefore:             time.Now().Add(-time.Hour),
+        NotAfter:              time.Now().Add(time.Hour),
+        KeyUsage:              x509.KeyUsageDigitalSignature,
+        ExtKeyUsage:           usage,
+        BasicConstraintsValid: true,
+    }
+
+    parentCert, parentKey := tmpl, key
+    if parent == nil {
+        tmpl.IsCA = true
+        tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+    } else {
+        parentCert, parentKey = parent.cert, parent.key
+    }
+
+    der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    cert, err := x509.ParseCertificate(der)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return &testCert{cert, key}
+}
+
+// 生成吊销列表
+func newTestCRL(t *testing.T, issuer *testCert, revoked []pkix.RevokedCertificate, nextUpdate time.Time) *pkix.CertificateList {
+    ca := New().
+        WithPrivateKey(issuer.key).
+        CreateCRL(issuer.cert, revoked, nil, time.Now().Add(-2 * time.Hour), nextUpdate)
+    if ca.Error().Count() > 0 {
+        t.Fatal(ca.Error().First())
+    }
+
+    crl, err := New().ParseCRL(ca.ToKeyBytes())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return crl
+}
+
+func TestCRL(t *testing.T) {
+    root := newTestCert(t, "root", 1, nil)
+    other := newTestCert(t, "other", 1, nil)
+
+    revokedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+    ca := New().
+        WithPrivateKey(root.key).
+        CreateCRL(root.cert, []pkix.RevokedCertificate{
+            NewRevokedCert(big.NewInt(10), revokedAt, CRLReasonKeyCompromise),
+            NewRevokedCert(big.NewInt(11), revokedAt, CRLReasonUnspecified),
+        }, big.NewInt(2), time.Now(), time.Now().Add(time.Hour))
+    if ca.Error().Count() > 0 {
+        t.Fatal(ca.Error().First())
+    }
+
+    crlPEM := ca.ToKeyBytes()
+
+    block, _ := pem.Decode(crlPEM)
+    if block == nil || block.Type != "X509 CRL" {
+        t.Fatal("CreateCRL should return X509 CRL PEM")
+    }
+
+    // PEM 及 DER 都可以解析
+    for _, data := range [][]byte{crlPEM, block.Bytes} {
+        crl, err := New().ParseCRL(data)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if err := New().VerifyCRL(crl, root.cert); err != nil {
+            t.Fatal(err)
+        }
+
+        if err := New().VerifyCRL(crl, other.cert); err == nil {
+            t.Error("VerifyCRL should fail with other issuer")
+        }
+
+        revoked, ok := New().FindRevokedCert(crl, big.NewInt(10))
+        if !ok {
+            t.Fatal("FindRevokedCert should find serial 10")
+        }
+
+        if !revoked.RevocationTime.Equal(revokedAt) {
+            t.Errorf("RevocationTime got %s, want %s", revoked.RevocationTime, revokedAt)
+        }
+
+        if reason := New().GetRevokedReason(revoked); reason != CRLReasonKeyCompromise {
+            t.Errorf("GetRevokedReason got %d, want %d", reason, CRLReasonKeyCompromise)
+        }
+
+        revoked, ok = New().FindRevokedCert(crl, big.NewInt(11))
+        if !ok {
+            t.Fatal("FindRevokedCert should find serial 11")
+        }
+
+        if reason := New().GetRevokedReason(revoked); reason != CRLReasonUnspecified {
+            t.Errorf("GetRevokedReason got %d, want %d", reason, CRLReasonUnspecified)
+        }
+
+        if _, ok := New().FindRevokedCert(crl, big.NewInt(12)); ok {
+            t.Error("FindRevokedCert should not find serial 12")
+        }
+    }
+
+    // 修改吊销列表内容
+    tampered := append([]byte{}, block.Bytes...)
+    idx := bytes.Index(tampered, []byte{0x02, 0x01, 0x0a})
+    if idx < 0 {
+        t.Fatal("serial 10 not found in crl")
+    }
+    tampered[idx + 2] = 0x0c
+
+    crl, err := New().ParseCRL(tampered)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := New().VerifyCRL(crl, root.cert); err == nil {
+        t.Error("VerifyCRL should fail with tampered crl")
+    }
+
+    // 没有私钥
+    if ca := New().CreateCRL(root.cert, nil, nil, time.Now(), time.Now().Add(time.Hour)); ca.Error().Count() == 0 {
+        t.Error("CreateCRL should fail without private key")
+    }
+
+    if _, err := New().ParseCRL([]byte("crl")); err == nil {
+        t.Error("ParseCRL should fail with invalid data")
+    }
+}
+
+func TestCRLSM2(t *testing.T) {
+    key, err := sm2.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tmpl := &sm2_x509.Certificate{
+        SerialNumber:          big.NewInt(1),
+        Subject:               pkix.Name{CommonName: "sm2 root"},
+        NotBefore:             time.Now().Add(-time.Hour),
+        NotAfter:              time.Now().Add(time.Hour),
+        KeyUsage:              sm2_x509.KeyUsageCertSign | sm2_x509.KeyUsageCRLSign,
+        BasicConstraintsValid: true,
+        IsCA:                  true,
+    }
+
+    der, err := sm2_x509.CreateCertificate(tmpl, tmpl, &key.PublicKey, key)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    root, err := sm2_x509.ParseCertificate(der)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ca := New().
+        WithPrivateKey(key).
+        CreateCRL(root, []pkix.RevokedCertificate{
+            NewRevokedCert(big.NewInt(10), time.Now(), CRLReasonSuperseded),
+        }, nil, time.Now(), time.Now().Add(time.Hour))
+    if ca.Error().Count() > 0 {
+        t.Fatal(ca.Error().First())
+    }
+
+    crl, err := New().ParseCRL(ca.ToKeyBytes())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := New().VerifyCRL(crl, root); err != nil {
+        t.Fatal(err)
+    }
+
+    revoked, ok := New().FindRevokedCert(crl, big.NewInt(10))
+    if !ok {
+        t.Fatal("FindRevokedCert should find serial 10")
+    }
+
+    if reason := New().GetRevokedReason(revoked); reason != CRLReasonSuperseded {
+        t.Errorf("GetRevokedReason got %d, want %d", reason, CRLReasonSuperseded)
+    }
+
+    // SM2 私钥需要 SM2 证书
+    if ca := New().WithPrivateKey(key).CreateCRL(&x509.Certificate{}, nil, nil, time.Now(), time.Now()); ca.Error().Count() == 0 {
+        t.Error("CreateCRL should fail with x509 ca and sm2 key")
+    }
+}
+
+func TestCRLChecker(t *testing.T) {
+    root := newTestCert(t, "root", 1, nil)
+    good := newTestCert(t, "good", 10, root)
+    revoked := newTestCert(t, "revoked", 11, root)
+
+    crl := newTestCRL(t, root, []pkix.RevokedCertificate{
+        NewRevokedCert(revoked.cert.SerialNumber, time.Now(), CRLReasonKeyCompromise),
+    }, time.Now().Add(time.Hour))
+
+    checker := NewCRLChecker(crl)
+
+    if err := checker.CheckRevocation(good.cert.Raw, root.cert.Raw); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := checker.CheckRevocation(revoked.cert.Raw, root.cert.Raw); !errors.Is(err, ErrCertRevoked) {
+        t.Errorf("CheckRevocation got %v, want ErrCertRevoked", err)
+    }
+
+    // 同名签发者伪造的吊销列表
+    fake := newTestCert(t, "root", 1, nil)
+    fakeCRL := newTestCRL(t, fake, nil, time.Now().Add(time.Hour))
+
+    if err := NewCRLChecker(fakeCRL).CheckRevocation(good.cert.Raw, root.cert.Raw); err == nil {
+        t.Error("CheckRevocation should fail with crl of other key")
+    }
+
+    // 过期的吊销列表
+    expired := newTestCRL(t, root, nil, time.Now().Add(-time.Hour))
+
+    if err := NewCRLChecker(expired).CheckRevocation(good.cert.Raw, root.cert.Raw); err == nil {
+        t.Error("CheckRevocation should fail with expired crl")
+    }
+
+    expiredChecker := NewCRLChecker(expired)
+    expiredChecker.AllowExpired = true
+    if err := expiredChecker.CheckRevocation(good.cert.Raw, root.cert.Raw); err != nil {
+        t.Fatal(err)
+    }
+
+    // 没有对应的吊销列表
+    other := newTestCert(t, "other", 1, nil)
+    otherCert := newTestCert(t, "other good", 10, other)
+
+    if err := checker.CheckRevocation(otherCert.cert.Raw, other.cert.Raw); err != nil {
+        t.Fatal(err)
+    }
+
+    checker.Strict = true
+    if err := checker.CheckRevocation(otherCert.cert.Raw, other.cert.Raw); err == nil {
+        t.Error("CheckRevocation should fail without crl in strict mode")
+    }
+}
+
+func TestVerifyWithRevocation(t *testing.T) {
+    root := newTestCert(t, "root", 1, nil)
+    good := newTestCert(t, "good", 10, root)
+    revoked := newTestCert(t, "revoked", 11, root)
+
+    crl := newTestCRL(t, root, []pkix.RevokedCertificate{
+        NewRevokedCert(revoked.cert.SerialNumber, time.Now(), CRLReasonKeyCompromise),
+    }, time.Now().Add(time.Hour))
+
+    rootPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.cert.Raw}))
+    goodPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: good.cert.Raw}))
+    revokedPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: revoked.cert.Raw}))
+
+    ok, err := New().Verify(rootPEM, goodPEM, x509.VerifyOptions{}, NewCRLChecker(crl))
+    if !ok || err != nil {
+        t.Fatalf("Verify got %v, %v", ok, err)
+    }
+
+    ok, err = New().Verify(rootPEM, revokedPEM, x509.VerifyOptions{}, NewCRLChecker(crl))
+    if ok || !errors.Is(err, ErrCertRevoked) {
+        t.Errorf("Verify got %v, %v, want ErrCertRevoked", ok, err)
+    }
+
+    // 不检测吊销
+    ok, err = New().Verify(rootPEM, revokedPEM, x509.VerifyOptions{})
+    if !ok || err != nil {
+        t.Fatalf("Verify got %v, %v", ok, err)
+    }
+}
+
+func TestOCSPResponder(t *testing.T) {
+    root := newTestCert(t, "root", 1, nil)
+    good := newTestCert(t, "good", 10, root)
+    revoked := newTestCert(t, "revoked", 11, root)
+
+    revokedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+    crl := newTestCRL(t, root, []pkix.RevokedCertificate{
+        NewRevokedCert(revoked.cert.SerialNumber, revokedAt, CRLReasonKeyCompromise),
+    }, time.Now().Add(time.Hour))
+
+    responder := NewOCSPResponder(root.cert, root.key, NewCRLStatus(crl))
+
+    server := httptest.NewServer(responder)
+    defer server.Close()
+
+    // 请求及验证
+    req, err := New().CreateOCSPRequest(revoked.cert, root.cert, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    data, err := responder.Respond(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err := New().VerifyOCSPResponse(data, revoked.cert, root.cert)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if resp.Status != OCSPRevoked {
+        t.Errorf("Status got %d, want %d", resp.Status, OCSPRevoked)
+    }
+
+    if !resp.RevokedAt.Equal(revokedAt) || resp.RevocationReason != CRLReasonKeyCompromise {
+        t.Errorf("revoked info got %s %d", resp.RevokedAt, resp.RevocationReason)
+    }
+
+    // 响应不能用于其他证书
+    if _, err := New().VerifyOCSPResponse(data, good.cert, root.cert); err == nil {
+        t.Error("VerifyOCSPResponse should fail with other certificate")
+    }
+
+    // 修改响应
+    tampered := append([]byte{}, data...)
+    tampered[len(tampered) - 5] ^= 0x01
+    if _, err := New().VerifyOCSPResponse(tampered, revoked.cert, root.cert); err == nil {
+        t.Error("VerifyOCSPResponse should fail with tampered response")
+    }
+
+    // 通过 http 检测
+    checker := NewOCSPChecker(server.URL)
+
+    if err := checker.CheckRevocation(good.cert.Raw, root.cert.Raw); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := checker.CheckRevocation(revoked.cert.Raw, root.cert.Raw); !errors.Is(err, ErrCertRevoked) {
+        t.Errorf("CheckRevocation got %v, want ErrCertRevoked", err)
+    }
+
+    // GET 请求
+    httpResp, err := http.Get(server.URL + "/" + base64.StdEncoding.EncodeToString(req))
+    if err != nil {
+        t.Fatal(err)
+    }
+    httpResp.Body.Close()
+
+    if httpResp.StatusCode != http.StatusOK || httpResp.Header.Get("Content-Type") != "application/ocsp-response" {
+        t.Errorf("GET got %d %s", httpResp.StatusCode, httpResp.Header.Get("Content-Type"))
+    }
+
+    // 其他签发者的请求
+    other := newTestCert(t, "other", 1, nil)
+    otherCert := newTestCert(t, "other good", 10, other)
+
+    otherReq, err := New().CreateOCSPRequest(otherCert.cert, other.cert, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    data, err = responder.Respond(otherReq)
+    if err == nil || !bytes.Equal(data, ocsp.UnauthorizedErrorResponse) {
+        t.Errorf("Respond got %v, want unauthorized response", err)
+    }
+
+    if err := checker.CheckRevocation(otherCert.cert.Raw, other.cert.Raw); err == nil {
+        t.Error("CheckRevocation should fail with other issuer")
+    }
+
+    // 错误请求
+    data, err = responder.Respond([]byte("request"))
+    if err == nil || !bytes.Equal(data, ocsp.MalformedRequestErrorResponse) {
+        t.Errorf("Respond got %v, want malformed response", err)
+    }
+}
+
+func TestOCSPDelegatedResponder(t *testing.T) {
+    root := newTestCert(t, "root", 1, nil)
+    cert := newTestCert(t, "cert", 10, root)
+    signer := newTestCert(t, "ocsp", 20, root, x509.ExtKeyUsageOCSPSigning)
+    notSigner := newTestCert(t, "not ocsp", 21, root)
+
+    req, err := New().CreateOCSPRequest(cert.cert, root.cert, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    status := func(serial *big.Int) (OCSPStatus, error) {
+        return OCSPStatus{Status: OCSPGood}, nil
+    }
+
+    responder := NewOCSPResponder(root.cert, signer.key, status)
+    responder.Responder = signer.cert
+
+    data, err := responder.Respond(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err := New().VerifyOCSPResponse(data, cert.cert, root.cert)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if resp.Status != OCSPGood {
+        t.Errorf("Status got %d, want %d", resp.Status, OCSPGood)
+    }
+
+    // 没有 OCSPSigning 用途的委托证书
+    responder = NewOCSPResponder(root.cert, notSigner.key, status)
+    responder.Responder = notSigner.cert
+
+    data, err = responder.Respond(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New().VerifyOCSPResponse(data, cert.cert, root.cert); err == nil {
+        t.Error("VerifyOCSPResponse should fail with unauthorized responder")
+    }
+
+    // 使用 CA 私钥生成响应
+    data, err = New().
+        WithPrivateKey(root.key).
+        CreateOCSPResponse(root.cert, nil, ocsp.Response{
+            Status:       OCSPUnknown,
+            SerialNumber: cert.cert.SerialNumber,
+            ThisUpdate:   time.Now(),
+        })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err = New().VerifyOCSPResponse(data, cert.cert, root.cert)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if resp.Status != OCSPUnknown {
+        t.Errorf("Status got %d, want %d", resp.Status, OCSPUnknown)
+    }
+
+    // 过期的响应
+    data, err = New().
+        WithPrivateKey(root.key).
+        CreateOCSPResponse(root.cert, nil, ocsp.Response{
+            Status:       OCSPGood,
+            SerialNumber: cert.cert.SerialNumber,
+            ThisUpdate:   time.Now().Add(-2 * time.Hour),
+            NextUpdate:   time.Now().Add(-time.Hour),
+        })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New().VerifyOCSPResponse(data, cert.cert, root.cert); err == nil {
+        t.Error("VerifyOCSPResponse should fail with expired response")
+    }
+}
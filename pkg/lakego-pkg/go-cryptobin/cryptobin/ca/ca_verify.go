@@ -1,6 +1,7 @@
 package ca
 
 import (
+    "fmt"
     "errors"
     "crypto/x509"
     "encoding/pem"
@@ -8,8 +9,8 @@ import (
     sm2X509 "github.com/tjfoc/gmsm/x509"
 )
 
-// 验证
-func (this CA) Verify(rootPEM string, certPEM string, opts x509.VerifyOptions) (bool, error) {
+// 验证，可以设置吊销检测
+func (this CA) Verify(rootPEM string, certPEM string, opts x509.VerifyOptions, checkers ...RevocationChecker) (bool, error) {
     roots := x509.NewCertPool()
     ok := roots.AppendCertsFromPEM([]byte(rootPEM))
     if !ok {
@@ -29,15 +30,27 @@ func (this CA) Verify(rootPEM string, certPEM string, opts x509.VerifyOptions) (
     // 重设
     opts.Roots = roots
 
-    if _, err := cert.Verify(opts); err != nil {
+    chains, err := cert.Verify(opts)
+    if err != nil {
         return false, errors.New("CA: [Verify()] failed to verify certificate: " + err.Error())
     }
 
+    if len(checkers) > 0 {
+        chain := make([][]byte, 0, len(chains[0]))
+        for _, c := range chains[0] {
+            chain = append(chain, c.Raw)
+        }
+
+        if err := checkChainRevocation(chain, checkers); err != nil {
+            return false, fmt.Errorf("CA: [Verify()] failed to check revocation: %w", err)
+        }
+    }
+
     return true, nil
 }
 
-// SM2 验证
-func (this CA) SM2Verify(rootPEM string, certPEM string, opts sm2X509.VerifyOptions) (bool, error) {
+// SM2 验证，可以设置吊销检测
+func (this CA) SM2Verify(rootPEM string, certPEM string, opts sm2X509.VerifyOptions, checkers ...RevocationChecker) (bool, error) {
     roots := sm2X509.NewCertPool()
     ok := roots.AppendCertsFromPEM([]byte(rootPEM))
     if !ok {
@@ -57,9 +70,21 @@ func (this CA) SM2Verify(rootPEM string, certPEM string, opts sm2X509.VerifyOpti
     // 重设
     opts.Roots = roots
 
-    if _, err := cert.Verify(opts); err != nil {
+    chains, err := cert.Verify(opts)
+    if err != nil {
         return false, errors.New("failed to verify certificate: " + err.Error())
     }
 
+    if len(checkers) > 0 {
+        chain := make([][]byte, 0, len(chains[0]))
+        for _, c := range chains[0] {
+            chain = append(chain, c.Raw)
+        }
+
+        if err := checkChainRevocation(chain, checkers); err != nil {
+            return false, fmt.Errorf("failed to check revocation: %w", err)
+        }
+    }
+
     return true, nil
 }
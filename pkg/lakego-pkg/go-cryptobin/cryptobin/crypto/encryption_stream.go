@@ -0,0 +1,402 @@
+package crypto
+
+import (
+    "io"
+    "fmt"
+    "errors"
+    "crypto/rc4"
+    "crypto/cipher"
+
+    "golang.org/x/crypto/chacha20"
+    "golang.org/x/crypto/chacha20poly1305"
+
+    cryptobin_cipher "github.com/deatil/go-cryptobin/cipher"
+)
+
+// 流式分块默认大小
+const DefaultStreamChunkSize = 64 * 1024
+
+// 流式分块最大大小
+const MaxStreamChunkSize = 16 * 1024 * 1024
+
+var (
+    // 分块数据被截断
+    ErrStreamTruncated = errors.New("Cryptobin: stream is truncated")
+
+    // 分块数据头部错误
+    ErrStreamHeader = errors.New("Cryptobin: stream header is invalid")
+
+    // 写入已关闭
+    ErrStreamClosed = errors.New("Cryptobin: stream is closed")
+)
+
+// 流式加密，返回的写入器需要 Close 才会写入最后的数据
+// Close 不会关闭 w
+//
+// CFB, CFB8, OFB, OFB8, CTR 模式及 Chacha20, RC4 直接流式处理
+// ECB, CBC 模式按块处理，最后一块补码
+// GCM, CCM 模式及 Chacha20poly1305, Chacha20poly1305X 使用分块认证加密
+func (this Cryptobin) EncryptWriter(w io.Writer) (io.WriteCloser, error) {
+    kind, err := this.streamKind("EncryptWriter")
+    if err != nil {
+        return nil, err
+    }
+
+    switch kind {
+        case streamXOR:
+            stream, err := this.xorStream(false)
+            if err != nil {
+                return nil, err
+            }
+
+            return &streamWriter{
+                w:      w,
+                stream: stream,
+            }, nil
+        case streamBlock:
+            mode, err := this.blockMode(false)
+            if err != nil {
+                return nil, err
+            }
+
+            return &blockWriter{
+                c:    this,
+                w:    w,
+                mode: mode,
+            }, nil
+        default:
+            aead, err := this.streamAEAD()
+            if err != nil {
+                return nil, err
+            }
+
+            return newAEADWriter(w, aead)
+    }
+}
+
+// 流式解密
+func (this Cryptobin) DecryptReader(r io.Reader) (io.Reader, error) {
+    kind, err := this.streamKind("DecryptReader")
+    if err != nil {
+        return nil, err
+    }
+
+    switch kind {
+        case streamXOR:
+            stream, err := this.xorStream(true)
+            if err != nil {
+                return nil, err
+            }
+
+            return &cipher.StreamReader{
+                S: stream,
+                R: r,
+            }, nil
+        case streamBlock:
+            mode, err := this.blockMode(true)
+            if err != nil {
+                return nil, err
+            }
+
+            return &blockReader{
+                c:    this,
+                r:    r,
+                mode: mode,
+            }, nil
+        default:
+            aead, err := this.streamAEAD()
+            if err != nil {
+                return nil, err
+            }
+
+            return newAEADReader(r, aead)
+    }
+}
+
+// 从 src 读取数据加密后写入 dst，返回读取的数据长度
+func (this Cryptobin) EncryptStream(dst io.Writer, src io.Reader) (int64, error) {
+    w, err := this.EncryptWriter(dst)
+    if err != nil {
+        return 0, err
+    }
+
+    n, err := io.Copy(w, src)
+    if err != nil {
+        return n, err
+    }
+
+    return n, w.Close()
+}
+
+// 从 src 读取数据解密后写入 dst，返回写入的数据长度
+func (this Cryptobin) DecryptStream(dst io.Writer, src io.Reader) (int64, error) {
+    r, err := this.DecryptReader(src)
+    if err != nil {
+        return 0, err
+    }
+
+    return io.Copy(dst, r)
+}
+
+// ====================
+
+type streamType uint
+
+const (
+    streamXOR streamType = 1 + iota
+    streamBlock
+    streamAEAD
+)
+
+// 流式处理方式
+func (this Cryptobin) streamKind(fn string) (streamType, error) {
+    switch this.multiple {
+        case Chacha20, RC4:
+            return streamXOR, nil
+        case Chacha20poly1305, Chacha20poly1305X:
+            return streamAEAD, nil
+        case Xts:
+            return 0, fmt.Errorf("Cryptobin: [%s()] Multiple [%s] is not support stream.", fn, this.multiple)
+    }
+
+    switch this.mode {
+        case CFB, CFB8, OFB, OFB8, CTR:
+            return streamXOR, nil
+        case ECB, CBC:
+            if this.padding == PKCS1Padding {
+                return 0, fmt.Errorf("Cryptobin: [%s()] Padding [%s] is not support stream.", fn, this.padding)
+            }
+
+            return streamBlock, nil
+        case GCM, CCM:
+            return streamAEAD, nil
+    }
+
+    return 0, fmt.Errorf("Cryptobin: [%s()] Mode [%s] is error.", fn, this.mode)
+}
+
+// 分组加密
+func (this Cryptobin) streamBlock() (cipher.Block, error) {
+    block, err := this.CipherBlock(this.key)
+    if err != nil {
+        return nil, err
+    }
+
+    if block == nil {
+        return nil, fmt.Errorf("Cryptobin: Multiple [%s] is error.", this.multiple)
+    }
+
+    return block, nil
+}
+
+// 流加密
+func (this Cryptobin) xorStream(decrypt bool) (cipher.Stream, error) {
+    switch this.multiple {
+        case Chacha20:
+            nonce, ok := this.config["nonce"]
+            if !ok {
+                return nil, fmt.Errorf("Cryptobin: chacha20 error: nonce is empty.")
+            }
+
+            chacha, err := chacha20.NewUnauthenticatedCipher(this.key, nonce.([]byte))
+            if err != nil {
+                return nil, fmt.Errorf("Cryptobin: chacha20.New(),error:%w", err)
+            }
+
+            counter, ok := this.config["counter"]
+            if ok {
+                chacha.SetCounter(counter.(uint32))
+            }
+
+            return chacha, nil
+        case RC4:
+            rc, err := rc4.NewCipher(this.key)
+            if err != nil {
+                return nil, fmt.Errorf("Cryptobin: rc4.NewCipher(),error:%w", err)
+            }
+
+            return rc, nil
+    }
+
+    block, err := this.streamBlock()
+    if err != nil {
+        return nil, err
+    }
+
+    iv := this.iv
+    if len(iv) != block.BlockSize() {
+        return nil, fmt.Errorf("Cryptobin: iv length must equal block size %d", block.BlockSize())
+    }
+
+    switch this.mode {
+        case CFB:
+            if decrypt {
+                return cipher.NewCFBDecrypter(block, iv), nil
+            }
+
+            return cipher.NewCFBEncrypter(block, iv), nil
+        case CFB8:
+            return cryptobin_cipher.NewCFB8(block, iv, decrypt), nil
+        case OFB:
+            return cipher.NewOFB(block, iv), nil
+        case OFB8:
+            return cryptobin_cipher.NewOFB8(block, iv), nil
+        default:
+            return cipher.NewCTR(block, iv), nil
+    }
+}
+
+// 块加密模式
+func (this Cryptobin) blockMode(decrypt bool) (cipher.BlockMode, error) {
+    block, err := this.streamBlock()
+    if err != nil {
+        return nil, err
+    }
+
+    if this.mode == ECB {
+        return &ecbMode{
+            block:   block,
+            decrypt: decrypt,
+        }, nil
+    }
+
+    iv := this.iv
+    if len(iv) != block.BlockSize() {
+        return nil, fmt.Errorf("Cryptobin: iv length must equal block size %d", block.BlockSize())
+    }
+
+    if decrypt {
+        return cipher.NewCBCDecrypter(block, iv), nil
+    }
+
+    return cipher.NewCBCEncrypter(block, iv), nil
+}
+
+// 认证加密，返回的 aead 带有基础 nonce 及附加数据
+func (this Cryptobin) streamAEAD() (*streamAEADCipher, error) {
+    nonce, ok := this.config["nonce"]
+    if !ok {
+        return nil, fmt.Errorf("Cryptobin: nonce is empty.")
+    }
+
+    nonceBytes := nonce.([]byte)
+
+    var additionalBytes []byte
+    additional, _ := this.config["additional"]
+    if additional != nil {
+        additionalBytes = additional.([]byte)
+    }
+
+    var aead cipher.AEAD
+    var err error
+
+    switch this.multiple {
+        case Chacha20poly1305:
+            aead, err = chacha20poly1305.New(this.key)
+        case Chacha20poly1305X:
+            aead, err = chacha20poly1305.NewX(this.key)
+        default:
+            block, berr := this.streamBlock()
+            if berr != nil {
+                return nil, berr
+            }
+
+            if this.mode == GCM {
+                aead, err = cipher.NewGCMWithNonceSize(block, len(nonceBytes))
+            } else {
+                aead, err = cryptobin_cipher.NewCCMWithNonceSize(block, len(nonceBytes))
+            }
+    }
+
+    if err != nil {
+        return nil, err
+    }
+
+    if len(nonceBytes) != aead.NonceSize() {
+        return nil, fmt.Errorf("Cryptobin: nonce length must be %d", aead.NonceSize())
+    }
+
+    // 计数器写入 nonce 后 8 位
+    if len(nonceBytes) < 8 {
+        return nil, fmt.Errorf("Cryptobin: nonce length must be at least 8 for stream")
+    }
+
+    chunkSize := DefaultStreamChunkSize
+    if size, ok := this.config["chunk_size"]; ok {
+        chunkSize = size.(int)
+    }
+
+    if chunkSize <= 0 || chunkSize > MaxStreamChunkSize {
+        return nil, fmt.Errorf("Cryptobin: chunk size must be in (0, %d]", MaxStreamChunkSize)
+    }
+
+    return &streamAEADCipher{
+        aead:       aead,
+        nonce:      nonceBytes,
+        additional: additionalBytes,
+        chunkSize:  chunkSize,
+    }, nil
+}
+
+// ====================
+
+// ECB 模式
+type ecbMode struct {
+    block   cipher.Block
+    decrypt bool
+}
+
+func (this *ecbMode) BlockSize() int {
+    return this.block.BlockSize()
+}
+
+func (this *ecbMode) CryptBlocks(dst, src []byte) {
+    bs := this.block.BlockSize()
+
+    for len(src) > 0 {
+        if this.decrypt {
+            this.block.Decrypt(dst, src[:bs])
+        } else {
+            this.block.Encrypt(dst, src[:bs])
+        }
+
+        src = src[bs:]
+        dst = dst[bs:]
+    }
+}
+
+// ====================
+
+// 流加密写入
+type streamWriter struct {
+    w      io.Writer
+    stream cipher.Stream
+    buf    []byte
+    closed bool
+}
+
+func (this *streamWriter) Write(p []byte) (int, error) {
+    if this.closed {
+        return 0, ErrStreamClosed
+    }
+
+    if cap(this.buf) < len(p) {
+        this.buf = make([]byte, len(p))
+    }
+
+    buf := this.buf[:len(p)]
+    this.stream.XORKeyStream(buf, p)
+
+    n, err := this.w.Write(buf)
+    if n != len(p) && err == nil {
+        err = io.ErrShortWrite
+    }
+
+    return n, err
+}
+
+func (this *streamWriter) Close() error {
+    this.closed = true
+
+    return nil
+}
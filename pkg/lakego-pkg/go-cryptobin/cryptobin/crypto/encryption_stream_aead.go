@@ -0,0 +1,276 @@
+package crypto
+
+import (
+    "io"
+    "fmt"
+    "bytes"
+    "crypto/cipher"
+    "encoding/binary"
+)
+
+// 分块数据头部标识及版本
+var streamMagic = []byte{'C', 'B', 'S', 1}
+
+// 头部长度，标识及分块大小
+const streamHeaderSize = 8
+
+// 认证加密及分块配置
+type streamAEADCipher struct {
+    aead       cipher.AEAD
+    nonce      []byte
+    additional []byte
+    chunkSize  int
+}
+
+// 每块的 nonce，基础 nonce 后 8 位与块序号异或
+func (this *streamAEADCipher) chunkNonce(dst []byte, counter uint64) []byte {
+    dst = append(dst[:0], this.nonce...)
+
+    var ctr [8]byte
+    binary.BigEndian.PutUint64(ctr[:], counter)
+
+    offset := len(dst) - 8
+    for i := 0; i < 8; i++ {
+        dst[offset+i] ^= ctr[i]
+    }
+
+    return dst
+}
+
+// 每块的附加数据，包括头部及是否为最后一块
+func (this *streamAEADCipher) chunkAdditional(dst []byte, header []byte, final bool) []byte {
+    dst = append(dst[:0], this.additional...)
+    dst = append(dst, header...)
+
+    if final {
+        return append(dst, 1)
+    }
+
+    return append(dst, 0)
+}
+
+/**
+ * 分块认证加密
+ *
+ * 数据格式: 头部 [标识 4 字节][分块大小 4 字节] + 多个加密块
+ * 每块使用独立 nonce，最后一块在附加数据中标记，用于检测数据截断
+ * 最后一块可能为满块，数据为空时只有一个空数据块
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type aeadWriter struct {
+    w       io.Writer
+    c       *streamAEADCipher
+    header  []byte
+    buf     []byte
+    out     []byte
+    nonce   []byte
+    ad      []byte
+    counter uint64
+    closed  bool
+}
+
+func newAEADWriter(w io.Writer, c *streamAEADCipher) (*aeadWriter, error) {
+    header := make([]byte, streamHeaderSize)
+    copy(header, streamMagic)
+    binary.BigEndian.PutUint32(header[4:], uint32(c.chunkSize))
+
+    if _, err := w.Write(header); err != nil {
+        return nil, err
+    }
+
+    return &aeadWriter{
+        w:      w,
+        c:      c,
+        header: header,
+        buf:    make([]byte, 0, c.chunkSize),
+    }, nil
+}
+
+func (this *aeadWriter) Write(p []byte) (int, error) {
+    if this.closed {
+        return 0, ErrStreamClosed
+    }
+
+    total := len(p)
+    size := this.c.chunkSize
+
+    for len(p) > 0 {
+        // 缓存满一块且还有数据时才写入，最后一块在 Close 时写入
+        if len(this.buf) == size {
+            if err := this.seal(false); err != nil {
+                return total - len(p), err
+            }
+        }
+
+        n := size - len(this.buf)
+        if n > len(p) {
+            n = len(p)
+        }
+
+        this.buf = append(this.buf, p[:n]...)
+        p = p[n:]
+    }
+
+    return total, nil
+}
+
+func (this *aeadWriter) Close() error {
+    if this.closed {
+        return nil
+    }
+
+    this.closed = true
+
+    return this.seal(true)
+}
+
+func (this *aeadWriter) seal(final bool) error {
+    this.nonce = this.c.chunkNonce(this.nonce, this.counter)
+    this.ad = this.c.chunkAdditional(this.ad, this.header, final)
+
+    this.out = this.c.aead.Seal(this.out[:0], this.nonce, this.buf, this.ad)
+
+    this.counter++
+    this.buf = this.buf[:0]
+
+    n, err := this.w.Write(this.out)
+    if err == nil && n != len(this.out) {
+        err = io.ErrShortWrite
+    }
+
+    return err
+}
+
+/**
+ * 分块认证解密
+ *
+ * 每块认证通过后才输出数据，没有读取到最后一块时返回 ErrStreamTruncated
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type aeadReader struct {
+    r       io.Reader
+    c       *streamAEADCipher
+    header  []byte
+    in      []byte
+    buf     []byte
+    out     []byte
+    nonce   []byte
+    ad      []byte
+    counter uint64
+    peek    []byte
+    err     error
+}
+
+func newAEADReader(r io.Reader, c *streamAEADCipher) (*aeadReader, error) {
+    return &aeadReader{
+        r: r,
+        c: c,
+    }, nil
+}
+
+func (this *aeadReader) Read(p []byte) (int, error) {
+    for len(this.out) == 0 {
+        if this.err != nil {
+            return 0, this.err
+        }
+
+        if this.header == nil {
+            this.err = this.readHeader()
+            continue
+        }
+
+        this.err = this.open()
+    }
+
+    n := copy(p, this.out)
+    this.out = this.out[n:]
+
+    return n, nil
+}
+
+func (this *aeadReader) readHeader() error {
+    header := make([]byte, streamHeaderSize)
+    if _, err := io.ReadFull(this.r, header); err != nil {
+        if err == io.EOF || err == io.ErrUnexpectedEOF {
+            return ErrStreamHeader
+        }
+
+        return err
+    }
+
+    if !bytes.Equal(header[:4], streamMagic) {
+        return ErrStreamHeader
+    }
+
+    chunkSize := int(binary.BigEndian.Uint32(header[4:]))
+    if chunkSize <= 0 || chunkSize > MaxStreamChunkSize {
+        return ErrStreamHeader
+    }
+
+    this.c.chunkSize = chunkSize
+    this.header = header
+    this.in = make([]byte, chunkSize + this.c.aead.Overhead())
+
+    return nil
+}
+
+func (this *aeadReader) open() error {
+    // 上次多读取的 1 字节
+    n := copy(this.in, this.peek)
+    this.peek = this.peek[:0]
+
+    m, err := io.ReadFull(this.r, this.in[n:])
+    n += m
+
+    final := false
+    switch err {
+        case nil:
+            // 再读取 1 字节判断是否为最后一块
+            var b [1]byte
+            _, err = io.ReadFull(this.r, b[:])
+            if err == io.EOF {
+                final = true
+            } else if err != nil {
+                return err
+            } else {
+                this.peek = append(this.peek, b[0])
+            }
+        case io.EOF, io.ErrUnexpectedEOF:
+            final = true
+        default:
+            return err
+    }
+
+    if n < this.c.aead.Overhead() {
+        return ErrStreamTruncated
+    }
+
+    this.nonce = this.c.chunkNonce(this.nonce, this.counter)
+    this.ad = this.c.chunkAdditional(this.ad, this.header, final)
+
+    this.buf, err = this.c.aead.Open(this.buf[:0], this.nonce, this.in[:n], this.ad)
+    if err != nil {
+        // 非最后一块认证通过，说明后面的数据被截断
+        if final {
+            this.ad = this.c.chunkAdditional(this.ad, this.header, false)
+            if _, nerr := this.c.aead.Open(nil, this.nonce, this.in[:n], this.ad); nerr == nil {
+                return ErrStreamTruncated
+            }
+        }
+
+        return fmt.Errorf("Cryptobin: [DecryptReader()] chunk %d error:%w", this.counter, err)
+    }
+
+    this.counter++
+    this.out = this.buf
+
+    if final {
+        return io.EOF
+    }
+
+    return nil
+}
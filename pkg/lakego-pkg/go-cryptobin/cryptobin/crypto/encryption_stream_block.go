@@ -0,0 +1,178 @@
+package crypto
+
+import (
+    "io"
+    "fmt"
+    "crypto/cipher"
+)
+
+// 块模式每次读取的块数
+const blockReadBlocks = 256
+
+/**
+ * 块模式流式加密
+ *
+ * 保留最后不超过一块的数据，Close 时补码后写入
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type blockWriter struct {
+    c      Cryptobin
+    w      io.Writer
+    mode   cipher.BlockMode
+    buf    []byte
+    out    []byte
+    closed bool
+}
+
+func (this *blockWriter) Write(p []byte) (int, error) {
+    if this.closed {
+        return 0, ErrStreamClosed
+    }
+
+    bs := this.mode.BlockSize()
+
+    this.buf = append(this.buf, p...)
+
+    // 保留最后一块用于补码
+    keep := len(this.buf) % bs
+    if keep == 0 {
+        keep = bs
+    }
+
+    full := len(this.buf) - keep
+    if full <= 0 {
+        return len(p), nil
+    }
+
+    if err := this.crypt(this.buf[:full]); err != nil {
+        return 0, err
+    }
+
+    this.buf = append(this.buf[:0], this.buf[full:]...)
+
+    return len(p), nil
+}
+
+func (this *blockWriter) Close() error {
+    if this.closed {
+        return nil
+    }
+
+    this.closed = true
+
+    bs := this.mode.BlockSize()
+
+    last := this.c.Padding(this.buf, bs)
+    if len(last)%bs != 0 {
+        return fmt.Errorf("Cryptobin: [EncryptWriter()] the length of the completed data must be an integer multiple of the block, the completed data size is %d, block size is %d", len(last), bs)
+    }
+
+    this.buf = nil
+
+    return this.crypt(last)
+}
+
+func (this *blockWriter) crypt(src []byte) error {
+    if len(src) == 0 {
+        return nil
+    }
+
+    if cap(this.out) < len(src) {
+        this.out = make([]byte, len(src))
+    }
+
+    out := this.out[:len(src)]
+    this.mode.CryptBlocks(out, src)
+
+    n, err := this.w.Write(out)
+    if err == nil && n != len(out) {
+        err = io.ErrShortWrite
+    }
+
+    return err
+}
+
+/**
+ * 块模式流式解密
+ *
+ * 解密后保留最后一块，读取结束时去除补码
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type blockReader struct {
+    c    Cryptobin
+    r    io.Reader
+    mode cipher.BlockMode
+    in   []byte
+    buf  []byte
+    out  []byte
+    last []byte
+    err  error
+}
+
+func (this *blockReader) Read(p []byte) (int, error) {
+    for len(this.out) == 0 {
+        if this.err != nil {
+            return 0, this.err
+        }
+
+        this.err = this.fill()
+    }
+
+    n := copy(p, this.out)
+    this.out = this.out[n:]
+
+    return n, nil
+}
+
+func (this *blockReader) fill() error {
+    bs := this.mode.BlockSize()
+
+    if this.in == nil {
+        this.in = make([]byte, bs*blockReadBlocks)
+    }
+
+    n, err := io.ReadFull(this.r, this.in)
+    eof := false
+
+    switch err {
+        case nil:
+        case io.EOF, io.ErrUnexpectedEOF:
+            eof = true
+        default:
+            return err
+    }
+
+    if n%bs != 0 {
+        return fmt.Errorf("Cryptobin: [DecryptReader()] improper decrypt type, block size is %d", bs)
+    }
+
+    data := this.in[:n]
+    this.mode.CryptBlocks(data, data)
+
+    // 上次保留的块可以输出
+    this.buf = append(this.buf[:0], this.last...)
+
+    if eof {
+        this.buf = append(this.buf, data...)
+
+        if len(this.buf) > 0 {
+            tail := len(this.buf) - bs
+            this.buf = append(this.buf[:tail], this.c.UnPadding(this.buf[tail:])...)
+        }
+
+        this.out = this.buf
+
+        return io.EOF
+    }
+
+    this.buf = append(this.buf, data[:n-bs]...)
+    this.last = append(this.last[:0], data[n-bs:]...)
+
+    this.out = this.buf
+
+    return nil
+}
@@ -0,0 +1,277 @@
+package crypto
+
+import (
+    "bytes"
+    "errors"
+    "testing"
+)
+
+var testStreamKey = "dfertf12dfertf12"
+var testStreamIv  = "dfertf12dfertf12"
+
+// 流式测试数据，包括空数据、不满一块及多个分块
+var testStreamData = [][]byte{
+    []byte(""),
+    []byte("t"),
+    []byte("test-pass-16byte"),
+    bytes.Repeat([]byte("stream-data-"), 100),
+}
+
+func testStreamCiphers() map[string]Cryptobin {
+    return map[string]Cryptobin{
+        "aes-ecb":      New().SetKey(testStreamKey).Aes().ECB().PKCS7Padding(),
+        "aes-cbc":      New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CBC().PKCS7Padding(),
+        "sm4-cbc-zero": New().SetKey(testStreamKey).SetIv(testStreamIv).SM4().CBC().ZeroPadding(),
+        "aes-cfb":      New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CFB(),
+        "aes-cfb8":     New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CFB8(),
+        "aes-ofb":      New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().OFB(),
+        "aes-ctr":      New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CTR(),
+        "rc4":          New().SetKey(testStreamKey).RC4(),
+        "chacha20":     New().SetKey(testStreamKey + testStreamKey).Chacha20("werfrewerfre"),
+        "aes-gcm":      New().SetKey(testStreamKey).Aes().GCM("werfrewerfre", "additional").SetChunkSize(64),
+        "aes-ccm":      New().SetKey(testStreamKey).Aes().CCM("werfrewerfre").SetChunkSize(64),
+        "chacha20poly1305":  New().SetKey(testStreamKey + testStreamKey).Chacha20poly1305("werfrewerfre", "additional").SetChunkSize(64),
+        "chacha20poly1305x": New().SetKey(testStreamKey + testStreamKey).Chacha20poly1305X("werfrewerfrewerfrewerfre", "").SetChunkSize(64),
+    }
+}
+
+// 流式加密
+func testEncryptStream(t *testing.T, c Cryptobin, data []byte) []byte {
+    var enc bytes.Buffer
+    n, err := c.EncryptStream(&enc, bytes.NewReader(data))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if n != int64(len(data)) {
+        t.Fatalf("EncryptStream read %d, want %d", n, len(data))
+    }
+
+    return enc.Bytes()
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+    for name, c := range testStreamCiphers() {
+        for _, data := range testStreamData {
+            enc := testEncryptStream(t, c, data)
+
+            var dec bytes.Buffer
+            if _, err := c.DecryptStream(&dec, bytes.NewReader(enc)); err != nil {
+                t.Fatalf("%s: %v", name, err)
+            }
+
+            if !bytes.Equal(dec.Bytes(), data) {
+                t.Errorf("%s: DecryptStream got %q, want %q", name, dec.Bytes(), data)
+            }
+        }
+    }
+}
+
+// 非认证加密的结果和 Encrypt 一致
+func TestStreamSameAsEncrypt(t *testing.T) {
+    ciphers := testStreamCiphers()
+
+    for _, name := range []string{"aes-ecb", "aes-cbc", "aes-cfb", "aes-ofb", "aes-ctr", "rc4", "chacha20"} {
+        c := ciphers[name]
+
+        for _, data := range testStreamData[1:] {
+            enc := testEncryptStream(t, c, data)
+
+            want := c.FromBytes(data).Encrypt()
+            if want.Error().Count() > 0 {
+                t.Fatalf("%s: %v", name, want.Error().First())
+            }
+
+            if !bytes.Equal(enc, want.ToBytes()) {
+                t.Errorf("%s: stream output is not same as Encrypt()", name)
+            }
+        }
+    }
+}
+
+// 多次写入
+func TestStreamWriter(t *testing.T) {
+    for name, c := range testStreamCiphers() {
+        data := testStreamData[3]
+
+        var enc bytes.Buffer
+        w, err := c.EncryptWriter(&enc)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        for i := 0; i < len(data); i += 7 {
+            end := i + 7
+            if end > len(data) {
+                end = len(data)
+            }
+
+            if _, err := w.Write(data[i:end]); err != nil {
+                t.Fatalf("%s: %v", name, err)
+            }
+        }
+
+        if err := w.Close(); err != nil {
+            t.Fatalf("%s: %v", name, err)
+        }
+
+        if _, err := w.Write(data); err != ErrStreamClosed {
+            t.Errorf("%s: Write after Close got %v, want ErrStreamClosed", name, err)
+        }
+
+        if !bytes.Equal(enc.Bytes(), testEncryptStream(t, c, data)) {
+            t.Errorf("%s: writer output is not same as EncryptStream()", name)
+        }
+    }
+}
+
+func TestStreamAEADTruncated(t *testing.T) {
+    c := New().SetKey(testStreamKey).Aes().GCM("werfrewerfre").SetChunkSize(64)
+
+    // 4 块，最后一块 8 字节
+    data := bytes.Repeat([]byte("a"), 200)
+    enc := testEncryptStream(t, c, data)
+
+    // 去掉最后一块
+    var dec bytes.Buffer
+    _, err := c.DecryptStream(&dec, bytes.NewReader(enc[:streamHeaderSize + 3 * (64 + 16)]))
+    if !errors.Is(err, ErrStreamTruncated) {
+        t.Errorf("DecryptStream got %v, want ErrStreamTruncated", err)
+    }
+
+    // 最后一块不完整
+    dec.Reset()
+    _, err = c.DecryptStream(&dec, bytes.NewReader(enc[:len(enc) - 5]))
+    if err == nil {
+        t.Error("DecryptStream should fail with truncated chunk")
+    }
+
+    // 只有头部
+    dec.Reset()
+    _, err = c.DecryptStream(&dec, bytes.NewReader(enc[:streamHeaderSize]))
+    if !errors.Is(err, ErrStreamTruncated) {
+        t.Errorf("DecryptStream got %v, want ErrStreamTruncated", err)
+    }
+
+    // 头部不完整
+    dec.Reset()
+    _, err = c.DecryptStream(&dec, bytes.NewReader(enc[:streamHeaderSize - 1]))
+    if !errors.Is(err, ErrStreamHeader) {
+        t.Errorf("DecryptStream got %v, want ErrStreamHeader", err)
+    }
+}
+
+func TestStreamAEADTampered(t *testing.T) {
+    c := New().SetKey(testStreamKey).Aes().GCM("werfrewerfre").SetChunkSize(64)
+
+    data := bytes.Repeat([]byte("a"), 200)
+    enc := testEncryptStream(t, c, data)
+
+    // 修改数据块
+    tampered := append([]byte{}, enc...)
+    tampered[streamHeaderSize + 70] ^= 0x01
+
+    var dec bytes.Buffer
+    if _, err := c.DecryptStream(&dec, bytes.NewReader(tampered)); err == nil {
+        t.Error("DecryptStream should fail with tampered chunk")
+    }
+
+    // 认证失败的块不能输出
+    if dec.Len() > 64 {
+        t.Errorf("DecryptStream output %d bytes, want at most 64", dec.Len())
+    }
+
+    // 修改头部标识
+    tampered = append([]byte{}, enc...)
+    tampered[0] ^= 0x01
+
+    dec.Reset()
+    if _, err := c.DecryptStream(&dec, bytes.NewReader(tampered)); !errors.Is(err, ErrStreamHeader) {
+        t.Errorf("DecryptStream got %v, want ErrStreamHeader", err)
+    }
+
+    // 修改分块大小
+    tampered = append([]byte{}, enc...)
+    tampered[7] = 32
+
+    dec.Reset()
+    if _, err := c.DecryptStream(&dec, bytes.NewReader(tampered)); err == nil {
+        t.Error("DecryptStream should fail with tampered chunk size")
+    }
+
+    // 调换数据块
+    swapped := append([]byte{}, enc[:streamHeaderSize]...)
+    swapped = append(swapped, enc[streamHeaderSize + 80:streamHeaderSize + 160]...)
+    swapped = append(swapped, enc[streamHeaderSize:streamHeaderSize + 80]...)
+    swapped = append(swapped, enc[streamHeaderSize + 160:]...)
+
+    dec.Reset()
+    if _, err := c.DecryptStream(&dec, bytes.NewReader(swapped)); err == nil {
+        t.Error("DecryptStream should fail with reordered chunks")
+    }
+}
+
+func TestStreamWrongKey(t *testing.T) {
+    data := bytes.Repeat([]byte("a"), 200)
+
+    enc := testEncryptStream(t, New().SetKey(testStreamKey).Aes().GCM("werfrewerfre", "additional"), data)
+
+    tests := map[string]Cryptobin{
+        "key":        New().SetKey("dfertf12dfertf13").Aes().GCM("werfrewerfre", "additional"),
+        "nonce":      New().SetKey(testStreamKey).Aes().GCM("werfrewerfrf", "additional"),
+        "additional": New().SetKey(testStreamKey).Aes().GCM("werfrewerfre", "other"),
+    }
+
+    for name, c := range tests {
+        var dec bytes.Buffer
+        if _, err := c.DecryptStream(&dec, bytes.NewReader(enc)); err == nil {
+            t.Errorf("%s: DecryptStream should fail", name)
+        }
+
+        if dec.Len() > 0 {
+            t.Errorf("%s: DecryptStream should not output data", name)
+        }
+    }
+
+    // 非认证加密不能检测错误密钥
+    cbc := testEncryptStream(t, New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CBC().PKCS7Padding(), data)
+
+    var dec bytes.Buffer
+    New().SetKey("dfertf12dfertf13").SetIv(testStreamIv).Aes().CBC().PKCS7Padding().DecryptStream(&dec, bytes.NewReader(cbc))
+    if bytes.Equal(dec.Bytes(), data) {
+        t.Error("DecryptStream with wrong key should not get the data")
+    }
+}
+
+func TestStreamBlockTruncated(t *testing.T) {
+    c := New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CBC().PKCS7Padding()
+
+    enc := testEncryptStream(t, c, testStreamData[3])
+
+    var dec bytes.Buffer
+    if _, err := c.DecryptStream(&dec, bytes.NewReader(enc[:len(enc) - 3])); err == nil {
+        t.Error("DecryptStream should fail with data not multiple of block size")
+    }
+}
+
+func TestStreamConfigError(t *testing.T) {
+    tests := map[string]Cryptobin{
+        "xts":           New().SetKey(testStreamKey + testStreamKey).Xts("Aes", 0x3333333333),
+        "pkcs1":         New().SetKey(testStreamKey).SetIv(testStreamIv).Aes().CBC().PKCS1Padding(),
+        "iv":            New().SetKey(testStreamKey).SetIv("short").Aes().CTR(),
+        "nonce":         New().SetKey(testStreamKey).Aes().GCM("short"),
+        "chunk-size":    New().SetKey(testStreamKey).Aes().GCM("werfrewerfre").SetChunkSize(0),
+        "chunk-size-max": New().SetKey(testStreamKey).Aes().GCM("werfrewerfre").SetChunkSize(MaxStreamChunkSize + 1),
+    }
+
+    for name, c := range tests {
+        var enc bytes.Buffer
+        if _, err := c.EncryptWriter(&enc); err == nil {
+            t.Errorf("%s: EncryptWriter should fail", name)
+        }
+
+        if _, err := c.DecryptReader(&enc); err == nil {
+            t.Errorf("%s: DecryptReader should fail", name)
+        }
+    }
+}
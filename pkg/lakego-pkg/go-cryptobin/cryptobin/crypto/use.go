@@ -311,6 +311,13 @@ func (this Cryptobin) SetKey(data string) Cryptobin {
     return this
 }
 
+// 流式认证加密分块大小
+func (this Cryptobin) SetChunkSize(size int) Cryptobin {
+    this.config["chunk_size"] = size
+
+    return this
+}
+
 // ==========
 
 // 不做处理
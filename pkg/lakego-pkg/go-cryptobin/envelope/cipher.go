@@ -0,0 +1,100 @@
+package envelope
+
+import (
+    "errors"
+    "crypto/aes"
+    "crypto/cipher"
+
+    "github.com/tjfoc/gmsm/sm4"
+    "golang.org/x/crypto/chacha20poly1305"
+)
+
+// 内容加密算法
+const (
+    AES128GCM        = "AES-128-GCM"
+    AES256GCM        = "AES-256-GCM"
+    SM4GCM           = "SM4-GCM"
+    ChaCha20Poly1305 = "CHACHA20-POLY1305"
+)
+
+// 默认内容加密算法
+var DefaultCipher = AES256GCM
+
+// 不支持的加密算法
+var ErrCipher = errors.New("envelope: unsupported cipher")
+
+// 密钥长度
+func cipherKeySize(name string) (int, error) {
+    switch name {
+        case AES128GCM, SM4GCM:
+            return 16, nil
+        case AES256GCM:
+            return 32, nil
+        case ChaCha20Poly1305:
+            return chacha20poly1305.KeySize, nil
+    }
+
+    return 0, ErrCipher
+}
+
+// 认证加密
+func newAEAD(name string, key []byte) (cipher.AEAD, error) {
+    size, err := cipherKeySize(name)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(key) != size {
+        return nil, errors.New("envelope: invalid key size")
+    }
+
+    switch name {
+        case ChaCha20Poly1305:
+            return chacha20poly1305.New(key)
+        case SM4GCM:
+            block, err := sm4.NewCipher(key)
+            if err != nil {
+                return nil, err
+            }
+
+            return cipher.NewGCM(block)
+        default:
+            block, err := aes.NewCipher(key)
+            if err != nil {
+                return nil, err
+            }
+
+            return cipher.NewGCM(block)
+    }
+}
+
+// 使用密钥加密密钥包装内容密钥，类型作为附加数据
+func wrapWithKEK(stanza *Stanza, kek []byte, cek []byte) error {
+    aead, err := newAEAD(AES256GCM, kek)
+    if err != nil {
+        return err
+    }
+
+    stanza.Nonce, err = randBytes(aead.NonceSize())
+    if err != nil {
+        return err
+    }
+
+    stanza.Key = aead.Seal(nil, stanza.Nonce, cek, []byte(stanza.Type))
+
+    return nil
+}
+
+// 使用密钥加密密钥解包内容密钥
+func unwrapWithKEK(stanza Stanza, kek []byte) ([]byte, error) {
+    aead, err := newAEAD(AES256GCM, kek)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(stanza.Nonce) != aead.NonceSize() {
+        return nil, ErrFormat
+    }
+
+    return aead.Open(nil, stanza.Nonce, stanza.Key, []byte(stanza.Type))
+}
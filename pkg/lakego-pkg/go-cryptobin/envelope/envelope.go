@@ -0,0 +1,279 @@
+package envelope
+
+import (
+    "bytes"
+    "errors"
+    "crypto/rand"
+    "encoding/json"
+    "encoding/binary"
+)
+
+// 当前版本
+const Version = 1
+
+// 数据标识
+var magic = []byte("CBEV")
+
+// 头部最大长度
+const maxHeaderSize = 1 << 20
+
+var (
+    // 数据格式错误
+    ErrFormat = errors.New("envelope: invalid format")
+
+    // 不支持的版本
+    ErrVersion = errors.New("envelope: unsupported version")
+
+    // 没有接收者
+    ErrNoRecipients = errors.New("envelope: no recipients")
+
+    // 没有可以解密的接收者
+    ErrNoIdentity = errors.New("envelope: no identity matched")
+
+    // 接收者类型不匹配，尝试下一个
+    ErrIncorrectIdentity = errors.New("envelope: incorrect identity")
+
+    // 只能有一个密码接收者
+    ErrPasswordRecipients = errors.New("envelope: only one password recipient is allowed")
+)
+
+/**
+ * 数据信封
+ *
+ * 数据格式: [标识 4 字节][头部长度 4 字节][头部 json][认证加密数据]
+ * 头部记录加密算法、nonce 及每个接收者包装后的内容密钥
+ * 头部作为附加数据参与认证，修改头部会导致解密失败
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Header struct {
+    // 版本
+    Version int `json:"version"`
+
+    // 内容加密算法
+    Cipher string `json:"cipher"`
+
+    // 内容加密 nonce
+    Nonce []byte `json:"nonce"`
+
+    // 接收者
+    Recipients []Stanza `json:"recipients"`
+}
+
+// 接收者包装后的内容密钥
+type Stanza struct {
+    // 接收者类型
+    Type string `json:"type"`
+
+    // 密钥 ID，可选
+    Kid string `json:"kid,omitempty"`
+
+    // 密码派生参数
+    KDF *KDFParams `json:"kdf,omitempty"`
+
+    // 临时公钥
+    Epk []byte `json:"epk,omitempty"`
+
+    // 包装 nonce
+    Nonce []byte `json:"nonce,omitempty"`
+
+    // 包装后的内容密钥
+    Key []byte `json:"key"`
+}
+
+// 接收者，包装内容密钥
+type Recipient interface {
+    Wrap(cek []byte) (Stanza, error)
+}
+
+// 解密身份，解包内容密钥
+// 类型不匹配时返回 ErrIncorrectIdentity
+type Identity interface {
+    Unwrap(stanza Stanza) ([]byte, error)
+}
+
+// 加密设置
+type Options struct {
+    // 内容加密算法，默认 AES-256-GCM
+    Cipher string
+}
+
+// 加密，每个接收者都可以单独解密
+func Seal(data []byte, opts Options, recipients ...Recipient) ([]byte, error) {
+    if len(recipients) == 0 {
+        return nil, ErrNoRecipients
+    }
+
+    cipherName := opts.Cipher
+    if cipherName == "" {
+        cipherName = DefaultCipher
+    }
+
+    keySize, err := cipherKeySize(cipherName)
+    if err != nil {
+        return nil, err
+    }
+
+    // 内容密钥
+    cek, err := randBytes(keySize)
+    if err != nil {
+        return nil, err
+    }
+
+    header := Header{
+        Version:    Version,
+        Cipher:     cipherName,
+        Recipients: make([]Stanza, 0, len(recipients)),
+    }
+
+    for _, recipient := range recipients {
+        stanza, err := recipient.Wrap(cek)
+        if err != nil {
+            return nil, err
+        }
+
+        header.Recipients = append(header.Recipients, stanza)
+    }
+
+    if err := checkRecipients(header.Recipients); err != nil {
+        return nil, err
+    }
+
+    aead, err := newAEAD(cipherName, cek)
+    if err != nil {
+        return nil, err
+    }
+
+    header.Nonce, err = randBytes(aead.NonceSize())
+    if err != nil {
+        return nil, err
+    }
+
+    prefix, err := encodeHeader(header)
+    if err != nil {
+        return nil, err
+    }
+
+    return aead.Seal(prefix, header.Nonce, data, prefix), nil
+}
+
+// 解密，依次使用身份尝试解包内容密钥
+func Open(data []byte, identities ...Identity) ([]byte, error) {
+    header, body, err := ParseHeader(data)
+    if err != nil {
+        return nil, err
+    }
+
+    prefix := data[:len(data)-len(body)]
+
+    cek, err := unwrapKey(header, identities)
+    if err != nil {
+        return nil, err
+    }
+
+    aead, err := newAEAD(header.Cipher, cek)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(header.Nonce) != aead.NonceSize() {
+        return nil, ErrFormat
+    }
+
+    return aead.Open(nil, header.Nonce, body, prefix)
+}
+
+// 解析头部，返回头部及加密数据
+func ParseHeader(data []byte) (Header, []byte, error) {
+    if len(data) < len(magic) + 4 || !bytes.Equal(data[:len(magic)], magic) {
+        return Header{}, nil, ErrFormat
+    }
+
+    size := binary.BigEndian.Uint32(data[len(magic):])
+    start := len(magic) + 4
+
+    if size > maxHeaderSize || uint64(len(data) - start) < uint64(size) {
+        return Header{}, nil, ErrFormat
+    }
+
+    var header Header
+    if err := json.Unmarshal(data[start:start+int(size)], &header); err != nil {
+        return Header{}, nil, ErrFormat
+    }
+
+    if header.Version != Version {
+        return Header{}, nil, ErrVersion
+    }
+
+    if err := checkRecipients(header.Recipients); err != nil {
+        return Header{}, nil, err
+    }
+
+    return header, data[start+int(size):], nil
+}
+
+// 编码头部
+func encodeHeader(header Header) ([]byte, error) {
+    headerBytes, err := json.Marshal(header)
+    if err != nil {
+        return nil, err
+    }
+
+    buf := make([]byte, len(magic) + 4, len(magic) + 4 + len(headerBytes))
+    copy(buf, magic)
+    binary.BigEndian.PutUint32(buf[len(magic):], uint32(len(headerBytes)))
+    buf = append(buf, headerBytes...)
+
+    return buf, nil
+}
+
+// 检测接收者，密码派生消耗较大，只能有一个密码接收者
+func checkRecipients(stanzas []Stanza) error {
+    count := 0
+    for _, stanza := range stanzas {
+        if stanza.Type == TypePassword {
+            count++
+        }
+    }
+
+    if count > 1 {
+        return ErrPasswordRecipients
+    }
+
+    return nil
+}
+
+// 解包内容密钥
+func unwrapKey(header Header, identities []Identity) ([]byte, error) {
+    var lastErr error
+
+    for _, identity := range identities {
+        for _, stanza := range header.Recipients {
+            cek, err := identity.Unwrap(stanza)
+            if err == nil {
+                return cek, nil
+            }
+
+            if err != ErrIncorrectIdentity {
+                lastErr = err
+            }
+        }
+    }
+
+    if lastErr != nil {
+        return nil, lastErr
+    }
+
+    return nil, ErrNoIdentity
+}
+
+// 随机数据
+func randBytes(size int) ([]byte, error) {
+    buf := make([]byte, size)
+    if _, err := rand.Read(buf); err != nil {
+        return nil, err
+    }
+
+    return buf, nil
+}
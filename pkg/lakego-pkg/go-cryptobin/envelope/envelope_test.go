@@ -0,0 +1,329 @@
+package envelope
+
+import (
+    "bytes"
+    "testing"
+    "crypto/rsa"
+    "crypto/rand"
+
+    "github.com/tjfoc/gmsm/sm2"
+
+    "github.com/deatil/go-cryptobin/dh/curve25519"
+)
+
+var testData = []byte("envelope test data")
+
+var testPassword = []byte("test-pass")
+
+// 测试使用较小的迭代次数
+var testPBKDF2Opts = KDFOpts{
+    Name:     KDFPBKDF2,
+    Hash:     "SHA256",
+    SaltSize: 16,
+    Iter:     MinIter,
+}
+
+type testKeys struct {
+    rsa       *rsa.PrivateKey
+    sm2       *sm2.PrivateKey
+    x25519    *curve25519.PrivateKey
+    x25519Pub *curve25519.PublicKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+    rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    sm2Key, err := sm2.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    x25519Key, x25519Pub, err := curve25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return testKeys{
+        rsa:       rsaKey,
+        sm2:       sm2Key,
+        x25519:    x25519Key,
+        x25519Pub: x25519Pub,
+    }
+}
+
+// 加密，使用全部接收者
+func testSeal(t *testing.T, keys testKeys, cipher string) []byte {
+    data, err := Seal(testData, Options{Cipher: cipher},
+        NewPasswordRecipient(testPassword, testPBKDF2Opts),
+        NewRSARecipient(&keys.rsa.PublicKey, "rsa-2026"),
+        NewSM2Recipient(&keys.sm2.PublicKey),
+        NewX25519Recipient(keys.x25519Pub),
+    )
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return data
+}
+
+func TestSealOpen(t *testing.T) {
+    keys := newTestKeys(t)
+
+    identities := map[string]Identity{
+        "password": NewPasswordIdentity(testPassword),
+        "rsa":      NewRSAIdentity(keys.rsa, "rsa-2026"),
+        "sm2":      NewSM2Identity(keys.sm2),
+        "x25519":   NewX25519Identity(keys.x25519),
+    }
+
+    for _, cipher := range []string{"", AES128GCM, AES256GCM, SM4GCM, ChaCha20Poly1305} {
+        data := testSeal(t, keys, cipher)
+
+        header, _, err := ParseHeader(data)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        want := cipher
+        if want == "" {
+            want = DefaultCipher
+        }
+
+        if header.Cipher != want {
+            t.Errorf("header cipher got %s, want %s", header.Cipher, want)
+        }
+
+        if len(header.Recipients) != 4 {
+            t.Errorf("header recipients got %d, want 4", len(header.Recipients))
+        }
+
+        for name, identity := range identities {
+            plain, err := Open(data, identity)
+            if err != nil {
+                t.Fatalf("%s %s: %v", want, name, err)
+            }
+
+            if !bytes.Equal(plain, testData) {
+                t.Errorf("%s %s: Open got %q, want %q", want, name, plain, testData)
+            }
+        }
+    }
+}
+
+func TestSealKDF(t *testing.T) {
+    opts := []KDFOpts{
+        testPBKDF2Opts,
+        {Name: KDFPKCS12, Hash: "SM3", SaltSize: 16, Iter: MinIter},
+        {Name: KDFScrypt, SaltSize: 32, N: 1024, R: 8, P: 1},
+    }
+
+    for _, opt := range opts {
+        data, err := Seal(testData, Options{}, NewPasswordRecipient(testPassword, opt))
+        if err != nil {
+            t.Fatalf("%s: %v", opt.Name, err)
+        }
+
+        plain, err := Open(data, NewPasswordIdentity(testPassword))
+        if err != nil {
+            t.Fatalf("%s: %v", opt.Name, err)
+        }
+
+        if !bytes.Equal(plain, testData) {
+            t.Errorf("%s: Open got %q, want %q", opt.Name, plain, testData)
+        }
+    }
+}
+
+func TestOpenTampered(t *testing.T) {
+    keys := newTestKeys(t)
+    identity := NewX25519Identity(keys.x25519)
+
+    data := testSeal(t, keys, AES256GCM)
+
+    _, body, err := ParseHeader(data)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    headerSize := len(data) - len(body)
+
+    // 修改加密数据
+    tampered := append([]byte{}, data...)
+    tampered[len(tampered) - 1] ^= 0x01
+
+    if _, err := Open(tampered, identity); err == nil {
+        t.Error("Open should fail with tampered body")
+    }
+
+    // 修改头部，头部参与认证
+    header, _, _ := ParseHeader(data)
+    header.Recipients = header.Recipients[:len(header.Recipients) - 1]
+    header.Recipients = append(header.Recipients, mustStanza(t, NewX25519Recipient(keys.x25519Pub), header))
+
+    prefix, err := encodeHeader(header)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := Open(append(prefix, body...), identity); err == nil {
+        t.Error("Open should fail with tampered header")
+    }
+
+    // 截断数据
+    truncated := [][]byte{
+        data[:len(data) - 1],
+        data[:headerSize],
+        data[:headerSize - 1],
+        data[:len(magic) + 2],
+        nil,
+    }
+
+    for i, d := range truncated {
+        if _, err := Open(d, identity); err == nil {
+            t.Errorf("%d: Open should fail with truncated data", i)
+        }
+    }
+
+    // 修改标识
+    tampered = append([]byte{}, data...)
+    tampered[0] ^= 0x01
+
+    if _, err := Open(tampered, identity); err != ErrFormat {
+        t.Errorf("Open got %v, want ErrFormat", err)
+    }
+}
+
+// 使用原内容密钥无法获取，这里使用随机密钥包装
+func mustStanza(t *testing.T, recipient Recipient, header Header) Stanza {
+    size, err := cipherKeySize(header.Cipher)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    cek, err := randBytes(size)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    stanza, err := recipient.Wrap(cek)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return stanza
+}
+
+func TestOpenWrongIdentity(t *testing.T) {
+    keys := newTestKeys(t)
+    other := newTestKeys(t)
+
+    data := testSeal(t, keys, AES256GCM)
+
+    wrong := map[string]Identity{
+        "password": NewPasswordIdentity([]byte("wrong-pass")),
+        "rsa":      NewRSAIdentity(other.rsa),
+        "sm2":      NewSM2Identity(other.sm2),
+        "x25519":   NewX25519Identity(other.x25519),
+    }
+
+    for name, identity := range wrong {
+        if _, err := Open(data, identity); err == nil {
+            t.Errorf("%s: Open should fail with wrong key", name)
+        }
+    }
+
+    // 密钥 ID 不匹配
+    if _, err := Open(data, NewRSAIdentity(keys.rsa, "rsa-2025")); err != ErrNoIdentity {
+        t.Errorf("Open got %v, want ErrNoIdentity", err)
+    }
+
+    // 错误身份之后的正确身份可以解密
+    plain, err := Open(data, wrong["x25519"], NewSM2Identity(keys.sm2))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if !bytes.Equal(plain, testData) {
+        t.Errorf("Open got %q, want %q", plain, testData)
+    }
+}
+
+func TestSealError(t *testing.T) {
+    if _, err := Seal(testData, Options{}); err != ErrNoRecipients {
+        t.Errorf("Seal got %v, want ErrNoRecipients", err)
+    }
+
+    if _, err := Seal(testData, Options{Cipher: "AES-256-CBC"}, NewPasswordRecipient(testPassword, testPBKDF2Opts)); err != ErrCipher {
+        t.Errorf("Seal got %v, want ErrCipher", err)
+    }
+
+    _, err := Seal(testData, Options{},
+        NewPasswordRecipient(testPassword, testPBKDF2Opts),
+        NewPasswordRecipient([]byte("other-pass"), testPBKDF2Opts),
+    )
+    if err != ErrPasswordRecipients {
+        t.Errorf("Seal got %v, want ErrPasswordRecipients", err)
+    }
+}
+
+func TestKDFParamsCheck(t *testing.T) {
+    salt := bytes.Repeat([]byte{1}, MinSaltSize)
+
+    tests := map[string]KDFParams{
+        "short salt":    {Name: KDFPBKDF2, Salt: salt[:MinSaltSize - 1], Iter: MinIter},
+        "empty salt":    {Name: KDFScrypt, N: 1024, R: 8, P: 1},
+        "low iter":      {Name: KDFPBKDF2, Salt: salt, Iter: MinIter - 1},
+        "high iter":     {Name: KDFPKCS12, Salt: salt, Iter: MaxIter + 1},
+        "scrypt n":      {Name: KDFScrypt, Salt: salt, N: MaxScryptN * 2, R: 8, P: 1},
+        "scrypt memory": {Name: KDFScrypt, Salt: salt, N: MaxScryptN, R: 8, P: 2},
+        "scrypt r":      {Name: KDFScrypt, Salt: salt, N: 1024, R: 0, P: 1},
+    }
+
+    for name, params := range tests {
+        if _, err := params.DeriveKey(testPassword, kekSize); err != ErrKDFParams {
+            t.Errorf("%s: DeriveKey got %v, want ErrKDFParams", name, err)
+        }
+    }
+
+    params := KDFParams{Name: "argon2", Salt: salt}
+    if _, err := params.DeriveKey(testPassword, kekSize); err != ErrKDF {
+        t.Errorf("DeriveKey got %v, want ErrKDF", err)
+    }
+
+    params = KDFParams{Name: KDFPBKDF2, Hash: "MD5", Salt: salt, Iter: MinIter}
+    if _, err := params.DeriveKey(testPassword, kekSize); err != ErrHash {
+        t.Errorf("DeriveKey got %v, want ErrHash", err)
+    }
+
+    // 盐过短时不能加密
+    opts := testPBKDF2Opts
+    opts.SaltSize = 8
+    if _, err := Seal(testData, Options{}, NewPasswordRecipient(testPassword, opts)); err != ErrKDFParams {
+        t.Errorf("Seal got %v, want ErrKDFParams", err)
+    }
+
+    // 头部中的盐被改短时不能解密
+    data, err := Seal(testData, Options{}, NewPasswordRecipient(testPassword, testPBKDF2Opts))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    header, body, err := ParseHeader(data)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    header.Recipients[0].KDF.Salt = header.Recipients[0].KDF.Salt[:8]
+
+    prefix, err := encodeHeader(header)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := Open(append(prefix, body...), NewPasswordIdentity(testPassword)); err != ErrKDFParams {
+        t.Errorf("Open got %v, want ErrKDFParams", err)
+    }
+}
@@ -0,0 +1,260 @@
+package envelope
+
+import (
+    "io"
+    "errors"
+    "crypto/rsa"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+
+    "golang.org/x/crypto/hkdf"
+    "github.com/tjfoc/gmsm/sm2"
+
+    "github.com/deatil/go-cryptobin/dh/curve25519"
+)
+
+// 公钥接收者类型
+const (
+    TypeRSAOAEP = "RSA-OAEP-SHA256"
+    TypeSM2     = "SM2"
+    TypeX25519  = "X25519"
+)
+
+// x25519 派生密钥信息
+var x25519Info = []byte("go-cryptobin/envelope/x25519")
+
+// 公钥错误
+var ErrPublicKey = errors.New("envelope: invalid public key")
+
+// 检测密钥 ID，都设置时需要相同
+func matchKid(stanza Stanza, typ string, kid string) bool {
+    if stanza.Type != typ {
+        return false
+    }
+
+    if stanza.Kid != "" && kid != "" && stanza.Kid != kid {
+        return false
+    }
+
+    return true
+}
+
+// 获取密钥 ID
+func firstKid(kid []string) string {
+    if len(kid) > 0 {
+        return kid[0]
+    }
+
+    return ""
+}
+
+// ==========
+
+// RSA 接收者，使用 RSA-OAEP 及 SHA256
+type RSARecipient struct {
+    pub *rsa.PublicKey
+    kid string
+}
+
+// RSA 接收者
+func NewRSARecipient(pub *rsa.PublicKey, kid ...string) *RSARecipient {
+    return &RSARecipient{
+        pub: pub,
+        kid: firstKid(kid),
+    }
+}
+
+// 包装
+func (this *RSARecipient) Wrap(cek []byte) (Stanza, error) {
+    key, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, this.pub, cek, []byte(TypeRSAOAEP))
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    return Stanza{
+        Type: TypeRSAOAEP,
+        Kid:  this.kid,
+        Key:  key,
+    }, nil
+}
+
+// RSA 身份
+type RSAIdentity struct {
+    pri *rsa.PrivateKey
+    kid string
+}
+
+// RSA 身份
+func NewRSAIdentity(pri *rsa.PrivateKey, kid ...string) *RSAIdentity {
+    return &RSAIdentity{
+        pri: pri,
+        kid: firstKid(kid),
+    }
+}
+
+// 解包
+func (this *RSAIdentity) Unwrap(stanza Stanza) ([]byte, error) {
+    if !matchKid(stanza, TypeRSAOAEP, this.kid) {
+        return nil, ErrIncorrectIdentity
+    }
+
+    return rsa.DecryptOAEP(sha256.New(), nil, this.pri, stanza.Key, []byte(TypeRSAOAEP))
+}
+
+// ==========
+
+// SM2 接收者，使用 C1C3C2 模式
+type SM2Recipient struct {
+    pub *sm2.PublicKey
+    kid string
+}
+
+// SM2 接收者
+func NewSM2Recipient(pub *sm2.PublicKey, kid ...string) *SM2Recipient {
+    return &SM2Recipient{
+        pub: pub,
+        kid: firstKid(kid),
+    }
+}
+
+// 包装
+func (this *SM2Recipient) Wrap(cek []byte) (Stanza, error) {
+    key, err := sm2.Encrypt(this.pub, cek, rand.Reader, sm2.C1C3C2)
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    return Stanza{
+        Type: TypeSM2,
+        Kid:  this.kid,
+        Key:  key,
+    }, nil
+}
+
+// SM2 身份
+type SM2Identity struct {
+    pri *sm2.PrivateKey
+    kid string
+}
+
+// SM2 身份
+func NewSM2Identity(pri *sm2.PrivateKey, kid ...string) *SM2Identity {
+    return &SM2Identity{
+        pri: pri,
+        kid: firstKid(kid),
+    }
+}
+
+// 解包
+func (this *SM2Identity) Unwrap(stanza Stanza) ([]byte, error) {
+    if !matchKid(stanza, TypeSM2, this.kid) {
+        return nil, ErrIncorrectIdentity
+    }
+
+    return sm2.Decrypt(this.pri, stanza.Key, sm2.C1C3C2)
+}
+
+// ==========
+
+// X25519 接收者，使用临时密钥协商后 HKDF-SHA256 派生密钥加密密钥
+type X25519Recipient struct {
+    pub *curve25519.PublicKey
+    kid string
+}
+
+// X25519 接收者
+func NewX25519Recipient(pub *curve25519.PublicKey, kid ...string) *X25519Recipient {
+    return &X25519Recipient{
+        pub: pub,
+        kid: firstKid(kid),
+    }
+}
+
+// 包装
+func (this *X25519Recipient) Wrap(cek []byte) (Stanza, error) {
+    if err := this.pub.Check(); err != nil {
+        return Stanza{}, ErrPublicKey
+    }
+
+    ephemeral, epk, err := curve25519.GenerateKey(rand.Reader)
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    kek, err := x25519KEK(ephemeral.ComputeSecret(this.pub), epk.Y, this.pub.Y)
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    stanza := Stanza{
+        Type: TypeX25519,
+        Kid:  this.kid,
+        Epk:  epk.Y,
+    }
+
+    if err := wrapWithKEK(&stanza, kek, cek); err != nil {
+        return Stanza{}, err
+    }
+
+    return stanza, nil
+}
+
+// X25519 身份
+type X25519Identity struct {
+    pri *curve25519.PrivateKey
+    kid string
+}
+
+// X25519 身份
+func NewX25519Identity(pri *curve25519.PrivateKey, kid ...string) *X25519Identity {
+    return &X25519Identity{
+        pri: pri,
+        kid: firstKid(kid),
+    }
+}
+
+// 解包
+func (this *X25519Identity) Unwrap(stanza Stanza) ([]byte, error) {
+    if !matchKid(stanza, TypeX25519, this.kid) {
+        return nil, ErrIncorrectIdentity
+    }
+
+    epk := &curve25519.PublicKey{
+        Y: stanza.Epk,
+    }
+    if err := epk.Check(); err != nil {
+        return nil, ErrFormat
+    }
+
+    pub, err := curve25519.GeneratePublicKey(this.pri)
+    if err != nil {
+        return nil, err
+    }
+
+    kek, err := x25519KEK(this.pri.ComputeSecret(epk), epk.Y, pub.Y)
+    if err != nil {
+        return nil, err
+    }
+
+    return unwrapWithKEK(stanza, kek)
+}
+
+// 派生密钥加密密钥，盐为临时公钥及接收者公钥
+func x25519KEK(secret, epk, pub []byte) ([]byte, error) {
+    // 低阶点协商结果全为 0
+    if subtle.ConstantTimeCompare(secret, make([]byte, len(secret))) == 1 {
+        return nil, ErrPublicKey
+    }
+
+    salt := make([]byte, 0, len(epk) + len(pub))
+    salt = append(salt, epk...)
+    salt = append(salt, pub...)
+
+    kek := make([]byte, kekSize)
+    if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, x25519Info), kek); err != nil {
+        return nil, err
+    }
+
+    return kek, nil
+}
@@ -0,0 +1,279 @@
+package envelope
+
+import (
+    "hash"
+    "errors"
+    "crypto/sha1"
+    "crypto/sha256"
+    "crypto/sha512"
+
+    "github.com/tjfoc/gmsm/sm3"
+    "golang.org/x/crypto/scrypt"
+    "golang.org/x/crypto/pbkdf2"
+
+    "github.com/deatil/go-cryptobin/kdf/pbkdf"
+)
+
+// 密码类型
+const TypePassword = "password"
+
+// 密码派生方式
+const (
+    KDFPBKDF2 = "pbkdf2"
+    KDFPKCS12 = "pkcs12"
+    KDFScrypt = "scrypt"
+)
+
+// 密钥加密密钥长度
+const kekSize = 32
+
+// 派生参数限制，防止头部参数过大消耗资源或者过小不安全
+var (
+    MinSaltSize = 16
+    MinIter     = 1000
+    MaxIter     = 10000000
+    MaxScryptN  = 1 << 20
+
+    // scrypt 最大内存 128 * N * R * P
+    MaxScryptMemory = 256 << 20
+)
+
+var (
+    // 不支持的派生方式
+    ErrKDF = errors.New("envelope: unsupported kdf")
+
+    // 派生参数错误
+    ErrKDFParams = errors.New("envelope: invalid kdf params")
+
+    // 不支持的 hash
+    ErrHash = errors.New("envelope: unsupported hash")
+)
+
+// 密码派生参数
+type KDFParams struct {
+    // 派生方式
+    Name string `json:"name"`
+
+    // hash 方式，pbkdf2 及 pkcs12 使用
+    Hash string `json:"hash,omitempty"`
+
+    // 盐
+    Salt []byte `json:"salt"`
+
+    // 迭代次数，pbkdf2 及 pkcs12 使用
+    Iter int `json:"iter,omitempty"`
+
+    // scrypt 参数
+    N int `json:"n,omitempty"`
+    R int `json:"r,omitempty"`
+    P int `json:"p,omitempty"`
+}
+
+// 生成密钥
+func (this KDFParams) DeriveKey(password []byte, size int) ([]byte, error) {
+    if err := this.check(); err != nil {
+        return nil, err
+    }
+
+    switch this.Name {
+        case KDFPBKDF2:
+            h, err := hashByName(this.Hash)
+            if err != nil {
+                return nil, err
+            }
+
+            return pbkdf2.Key(password, this.Salt, this.Iter, size, h), nil
+        case KDFPKCS12:
+            h, err := hashByName(this.Hash)
+            if err != nil {
+                return nil, err
+            }
+
+            // 密码直接使用，不转换为 BMPString
+            return pbkdf.Key(h, h().Size(), h().BlockSize(), this.Salt, password, this.Iter, 1, size), nil
+        case KDFScrypt:
+            return scrypt.Key(password, this.Salt, this.N, this.R, this.P, size)
+    }
+
+    return nil, ErrKDF
+}
+
+// 检测参数
+func (this KDFParams) check() error {
+    if len(this.Salt) < MinSaltSize {
+        return ErrKDFParams
+    }
+
+    switch this.Name {
+        case KDFPBKDF2, KDFPKCS12:
+            if this.Iter < MinIter || this.Iter > MaxIter {
+                return ErrKDFParams
+            }
+        case KDFScrypt:
+            if this.N <= 1 || this.N > MaxScryptN || this.R < 1 || this.P < 1 {
+                return ErrKDFParams
+            }
+
+            // 分开比较防止溢出
+            if this.R > MaxScryptMemory / 128 / this.N ||
+                this.P > MaxScryptMemory / 128 / this.N / this.R {
+                return ErrKDFParams
+            }
+    }
+
+    return nil
+}
+
+// 密码派生设置
+type KDFOpts struct {
+    // 派生方式
+    Name string
+
+    // hash 方式，可选：SHA1 | SHA256 | SHA384 | SHA512 | SM3
+    Hash string
+
+    // 盐长度
+    SaltSize int
+
+    // 迭代次数
+    Iter int
+
+    // scrypt 参数
+    N int
+    R int
+    P int
+}
+
+// 生成派生参数
+func (this KDFOpts) Params() (*KDFParams, error) {
+    salt, err := randBytes(this.SaltSize)
+    if err != nil {
+        return nil, err
+    }
+
+    return &KDFParams{
+        Name: this.Name,
+        Hash: this.Hash,
+        Salt: salt,
+        Iter: this.Iter,
+        N:    this.N,
+        R:    this.R,
+        P:    this.P,
+    }, nil
+}
+
+// 默认设置
+var (
+    DefaultPBKDF2Opts = KDFOpts{
+        Name:     KDFPBKDF2,
+        Hash:     "SHA256",
+        SaltSize: 16,
+        Iter:     100000,
+    }
+
+    DefaultPKCS12Opts = KDFOpts{
+        Name:     KDFPKCS12,
+        Hash:     "SHA256",
+        SaltSize: 16,
+        Iter:     10000,
+    }
+
+    DefaultScryptOpts = KDFOpts{
+        Name:     KDFScrypt,
+        SaltSize: 16,
+        N:        32768,
+        R:        8,
+        P:        1,
+    }
+)
+
+// 密码接收者
+type PasswordRecipient struct {
+    password []byte
+    opts     KDFOpts
+}
+
+// 密码接收者，opts 为空时使用 DefaultScryptOpts
+func NewPasswordRecipient(password []byte, opts ...KDFOpts) *PasswordRecipient {
+    opt := DefaultScryptOpts
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    return &PasswordRecipient{
+        password: password,
+        opts:     opt,
+    }
+}
+
+// 包装
+func (this *PasswordRecipient) Wrap(cek []byte) (Stanza, error) {
+    params, err := this.opts.Params()
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    kek, err := params.DeriveKey(this.password, kekSize)
+    if err != nil {
+        return Stanza{}, err
+    }
+
+    stanza := Stanza{
+        Type: TypePassword,
+        KDF:  params,
+    }
+
+    if err := wrapWithKEK(&stanza, kek, cek); err != nil {
+        return Stanza{}, err
+    }
+
+    return stanza, nil
+}
+
+// 密码身份
+type PasswordIdentity struct {
+    password []byte
+}
+
+// 密码身份
+func NewPasswordIdentity(password []byte) *PasswordIdentity {
+    return &PasswordIdentity{
+        password: password,
+    }
+}
+
+// 解包
+func (this *PasswordIdentity) Unwrap(stanza Stanza) ([]byte, error) {
+    if stanza.Type != TypePassword {
+        return nil, ErrIncorrectIdentity
+    }
+
+    if stanza.KDF == nil {
+        return nil, ErrFormat
+    }
+
+    kek, err := stanza.KDF.DeriveKey(this.password, kekSize)
+    if err != nil {
+        return nil, err
+    }
+
+    return unwrapWithKEK(stanza, kek)
+}
+
+// hash 方式
+func hashByName(name string) (func() hash.Hash, error) {
+    switch name {
+        case "SHA1":
+            return sha1.New, nil
+        case "SHA256", "":
+            return sha256.New, nil
+        case "SHA384":
+            return sha512.New384, nil
+        case "SHA512":
+            return sha512.New, nil
+        case "SM3":
+            return sm3.New, nil
+    }
+
+    return nil, ErrHash
+}
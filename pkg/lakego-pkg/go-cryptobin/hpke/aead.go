@@ -0,0 +1,59 @@
+package hpke
+
+import (
+    "crypto/aes"
+    "crypto/cipher"
+
+    "golang.org/x/crypto/chacha20poly1305"
+)
+
+// 认证加密算法
+type AEAD uint16
+
+const (
+    AES128GCM        AEAD = 0x0001
+    AES256GCM        AEAD = 0x0002
+    ChaCha20Poly1305 AEAD = 0x0003
+
+    // 只用于导出密钥，不能加密
+    ExportOnly AEAD = 0xFFFF
+)
+
+// 密钥及 nonce 长度
+func (this AEAD) sizes() (keySize int, nonceSize int, err error) {
+    switch this {
+        case AES128GCM:
+            return 16, 12, nil
+        case AES256GCM:
+            return 32, 12, nil
+        case ChaCha20Poly1305:
+            return chacha20poly1305.KeySize, chacha20poly1305.NonceSize, nil
+        case ExportOnly:
+            return 0, 0, nil
+    }
+
+    return 0, 0, ErrUnsupportedAEAD
+}
+
+// 有效性
+func (this AEAD) IsValid() bool {
+    _, _, err := this.sizes()
+    return err == nil
+}
+
+// 创建加密
+func (this AEAD) new(key []byte) (cipher.AEAD, error) {
+    switch this {
+        case AES128GCM, AES256GCM:
+            block, err := aes.NewCipher(key)
+            if err != nil {
+                return nil, err
+            }
+
+            return cipher.NewGCM(block)
+        case ChaCha20Poly1305:
+            return chacha20poly1305.New(key)
+    }
+
+    return nil, ErrUnsupportedAEAD
+}
@@ -0,0 +1,381 @@
+package hpke
+
+import (
+    "io"
+    "math"
+    "errors"
+    "crypto/cipher"
+    "encoding/binary"
+)
+
+// 模式
+type Mode uint8
+
+const (
+    ModeBase    Mode = 0x00
+    ModePSK     Mode = 0x01
+    ModeAuth    Mode = 0x02
+    ModeAuthPSK Mode = 0x03
+)
+
+var (
+    ErrUnsupportedKEM  = errors.New("hpke: unsupported kem")
+    ErrUnsupportedKDF  = errors.New("hpke: unsupported kdf")
+    ErrUnsupportedAEAD = errors.New("hpke: unsupported aead")
+
+    ErrPublicKey     = errors.New("hpke: invalid public key")
+    ErrPrivateKey    = errors.New("hpke: invalid private key")
+    ErrDeriveKeyPair = errors.New("hpke: derive key pair failed")
+
+    ErrPSKInputs    = errors.New("hpke: psk and psk id must be both set or both empty")
+    ErrPSKLength    = errors.New("hpke: psk must be at least 32 bytes")
+    ErrExportOnly   = errors.New("hpke: aead is export only")
+    ErrExportLength = errors.New("hpke: export length too large")
+    ErrMessageLimit = errors.New("hpke: message limit reached")
+    ErrOpen         = errors.New("hpke: message authentication failed")
+)
+
+// PSK 最小长度
+const MinPSKLength = 32
+
+// 配置，按设置的字段选择模式
+type Options struct {
+    // PSK 模式，PSK 及 PSKID 需要同时设置，PSK 最少 32 字节
+    PSK   []byte
+    PSKID []byte
+
+    // Auth 模式，发送方私钥，加密时使用
+    SenderPrivateKey []byte
+
+    // Auth 模式，发送方公钥，解密时使用
+    SenderPublicKey []byte
+
+    // 随机数，为空时使用 crypto/rand
+    Rand io.Reader
+}
+
+// 模式
+func (this Options) mode(auth bool) (Mode, error) {
+    hasPSK := len(this.PSK) > 0
+    if hasPSK != (len(this.PSKID) > 0) {
+        return 0, ErrPSKInputs
+    }
+
+    // RFC 9180 要求 PSK 至少有 32 字节熵
+    if hasPSK && len(this.PSK) < MinPSKLength {
+        return 0, ErrPSKLength
+    }
+
+    switch {
+        case hasPSK && auth:
+            return ModeAuthPSK, nil
+        case hasPSK:
+            return ModePSK, nil
+        case auth:
+            return ModeAuth, nil
+    }
+
+    return ModeBase, nil
+}
+
+/**
+ * HPKE 算法组合
+ * https://www.rfc-editor.org/rfc/rfc9180
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Suite struct {
+    KEM  KEM
+    KDF  KDF
+    AEAD AEAD
+}
+
+// NewSuite returns the cipher suite
+func NewSuite(kem KEM, kdf KDF, aead AEAD) Suite {
+    return Suite{
+        KEM:  kem,
+        KDF:  kdf,
+        AEAD: aead,
+    }
+}
+
+// 检测算法
+func (this Suite) check() (*dhKEM, error) {
+    kem, err := this.KEM.dhKEM()
+    if err != nil {
+        return nil, err
+    }
+
+    if !this.KDF.IsValid() {
+        return nil, ErrUnsupportedKDF
+    }
+
+    if !this.AEAD.IsValid() {
+        return nil, ErrUnsupportedAEAD
+    }
+
+    return kem, nil
+}
+
+// suite_id
+func (this Suite) suiteID() []byte {
+    id := make([]byte, 10)
+    copy(id, "HPKE")
+    binary.BigEndian.PutUint16(id[4:], uint16(this.KEM))
+    binary.BigEndian.PutUint16(id[6:], uint16(this.KDF))
+    binary.BigEndian.PutUint16(id[8:], uint16(this.AEAD))
+
+    return id
+}
+
+// NewSender encapsulates a shared secret to pkR and returns the
+// encapsulated key with the sender context
+func (this Suite) NewSender(pkR, info []byte, opts ...Options) ([]byte, *Sender, error) {
+    var opt Options
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    kem, err := this.check()
+    if err != nil {
+        return nil, nil, err
+    }
+
+    mode, err := opt.mode(len(opt.SenderPrivateKey) > 0)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    sharedSecret, enc, err := kem.encap(opt.Rand, pkR, opt.SenderPrivateKey)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    ctx, err := this.keySchedule(mode, sharedSecret, info, opt.PSK, opt.PSKID)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return enc, &Sender{ctx}, nil
+}
+
+// NewReceiver decapsulates enc with skR and returns the receiver context
+func (this Suite) NewReceiver(skR, enc, info []byte, opts ...Options) (*Receiver, error) {
+    var opt Options
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    kem, err := this.check()
+    if err != nil {
+        return nil, err
+    }
+
+    mode, err := opt.mode(len(opt.SenderPublicKey) > 0)
+    if err != nil {
+        return nil, err
+    }
+
+    sharedSecret, err := kem.decap(enc, skR, opt.SenderPublicKey)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx, err := this.keySchedule(mode, sharedSecret, info, opt.PSK, opt.PSKID)
+    if err != nil {
+        return nil, err
+    }
+
+    return &Receiver{ctx}, nil
+}
+
+// Seal encrypts a single message to pkR
+func (this Suite) Seal(pkR, info, aad, plaintext []byte, opts ...Options) (enc []byte, ciphertext []byte, err error) {
+    enc, sender, err := this.NewSender(pkR, info, opts...)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    ciphertext, err = sender.Seal(aad, plaintext)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return enc, ciphertext, nil
+}
+
+// Open decrypts a single message with skR
+func (this Suite) Open(skR, enc, info, aad, ciphertext []byte, opts ...Options) ([]byte, error) {
+    receiver, err := this.NewReceiver(skR, enc, info, opts...)
+    if err != nil {
+        return nil, err
+    }
+
+    return receiver.Open(aad, ciphertext)
+}
+
+// SendExport derives a secret of length bytes shared with pkR
+func (this Suite) SendExport(pkR, info, exporterContext []byte, length int, opts ...Options) (enc []byte, secret []byte, err error) {
+    enc, sender, err := this.NewSender(pkR, info, opts...)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    secret, err = sender.Export(exporterContext, length)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return enc, secret, nil
+}
+
+// ReceiveExport derives the secret shared by SendExport
+func (this Suite) ReceiveExport(skR, enc, info, exporterContext []byte, length int, opts ...Options) ([]byte, error) {
+    receiver, err := this.NewReceiver(skR, enc, info, opts...)
+    if err != nil {
+        return nil, err
+    }
+
+    return receiver.Export(exporterContext, length)
+}
+
+// 密钥计划
+func (this Suite) keySchedule(mode Mode, sharedSecret, info, psk, pskID []byte) (*context, error) {
+    hash, _ := this.KDF.hash()
+
+    kdf := labeledKDF{
+        hash:    hash,
+        suiteID: this.suiteID(),
+    }
+
+    pskIDHash := kdf.extract(nil, "psk_id_hash", pskID)
+    infoHash := kdf.extract(nil, "info_hash", info)
+
+    keyScheduleContext := append([]byte{byte(mode)}, pskIDHash...)
+    keyScheduleContext = append(keyScheduleContext, infoHash...)
+
+    secret := kdf.extract(sharedSecret, "secret", psk)
+
+    exporterSecret, err := kdf.expand(secret, "exp", keyScheduleContext, hash().Size())
+    if err != nil {
+        return nil, err
+    }
+
+    ctx := &context{
+        suite:          this,
+        mode:           mode,
+        kdf:            kdf,
+        exporterSecret: exporterSecret,
+    }
+
+    if this.AEAD == ExportOnly {
+        return ctx, nil
+    }
+
+    keySize, nonceSize, _ := this.AEAD.sizes()
+
+    key, err := kdf.expand(secret, "key", keyScheduleContext, keySize)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx.baseNonce, err = kdf.expand(secret, "base_nonce", keyScheduleContext, nonceSize)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx.aead, err = this.AEAD.new(key)
+    if err != nil {
+        return nil, err
+    }
+
+    return ctx, nil
+}
+
+// 加密上下文，不能并发使用
+type context struct {
+    suite          Suite
+    mode           Mode
+    kdf            labeledKDF
+    aead           cipher.AEAD
+    baseNonce      []byte
+    seq            uint64
+    exporterSecret []byte
+}
+
+// 模式
+func (this *context) Mode() Mode {
+    return this.mode
+}
+
+// 算法组合
+func (this *context) Suite() Suite {
+    return this.suite
+}
+
+// Export derives a secret of length bytes from the context
+func (this *context) Export(exporterContext []byte, length int) ([]byte, error) {
+    return this.kdf.expand(this.exporterSecret, "sec", exporterContext, length)
+}
+
+// 当前 nonce
+func (this *context) nextNonce() ([]byte, error) {
+    if this.aead == nil {
+        return nil, ErrExportOnly
+    }
+
+    if this.seq == math.MaxUint64 {
+        return nil, ErrMessageLimit
+    }
+
+    nonce := append([]byte{}, this.baseNonce...)
+
+    var seq [8]byte
+    binary.BigEndian.PutUint64(seq[:], this.seq)
+    for i := range seq {
+        nonce[len(nonce)-8+i] ^= seq[i]
+    }
+
+    return nonce, nil
+}
+
+// 发送方上下文
+type Sender struct {
+    *context
+}
+
+// Seal encrypts the next message
+func (this *Sender) Seal(aad, plaintext []byte) ([]byte, error) {
+    nonce, err := this.nextNonce()
+    if err != nil {
+        return nil, err
+    }
+
+    ciphertext := this.aead.Seal(nil, nonce, plaintext, aad)
+    this.seq++
+
+    return ciphertext, nil
+}
+
+// 接收方上下文
+type Receiver struct {
+    *context
+}
+
+// Open decrypts the next message
+func (this *Receiver) Open(aad, ciphertext []byte) ([]byte, error) {
+    nonce, err := this.nextNonce()
+    if err != nil {
+        return nil, err
+    }
+
+    plaintext, err := this.aead.Open(nil, nonce, ciphertext, aad)
+    if err != nil {
+        return nil, ErrOpen
+    }
+
+    this.seq++
+
+    return plaintext, nil
+}
@@ -0,0 +1,674 @@
+package hpke
+
+import (
+    "io"
+    "bytes"
+    "strconv"
+    "testing"
+    "encoding/hex"
+
+    "golang.org/x/crypto/sha3"
+)
+
+// RFC 9180 测试向量，base 模式
+// 加密及导出结果使用 SHAKE128 累积，与 CFRG 测试向量格式相同
+// info 为 "Ode on a Grecian Urn"
+var testInfo = []byte("Ode on a Grecian Urn")
+
+type testVector struct {
+    suite       Suite
+    ikmE        string
+    ikmR        string
+    skR         string
+    pkR         string
+    enc         string
+    encryptions string
+    exports     string
+}
+
+var testVectors = []testVector{
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA256, AES128GCM),
+        ikmE:        "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+        ikmR:        "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+        skR:         "4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8",
+        pkR:         "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d",
+        enc:         "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+        encryptions: "dcabb32ad8e8acea785275323395abd0",
+        exports:     "45db490fc51c86ba46cca1217f66a75e",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA256, AES256GCM),
+        ikmE:        "2cd7c601cefb3d42a62b04b7a9041494c06c7843818e0ce28a8f704ae7ab20f9",
+        ikmR:        "dac33b0e9db1b59dbbea58d59a14e7b5896e9bdf98fad6891e99d1686492b9ee",
+        skR:         "497b4502664cfea5d5af0b39934dac72242a74f8480451e1aee7d6a53320333d",
+        pkR:         "430f4b9859665145a6b1ba274024487bd66f03a2dd577d7753c68d7d7d00c00c",
+        enc:         "6c93e09869df3402d7bf231bf540fadd35cd56be14f97178f0954db94b7fc256",
+        encryptions: "1702e73e1e71705faa8241022af1deea",
+        exports:     "5cb678bf1c52afbd9afb58b8f7c1ced3",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA256, ChaCha20Poly1305),
+        ikmE:        "909a9b35d3dc4713a5e72a4da274b55d3d3821a37e5d099e74a647db583a904b",
+        ikmR:        "1ac01f181fdf9f352797655161c58b75c656a6cc2716dcb66372da835542e1df",
+        skR:         "8057991eef8f1f1af18f4a9491d16a1ce333f695d4db8e38da75975c4478e0fb",
+        pkR:         "4310ee97d88cc1f088a5576c77ab0cf5c3ac797f3d95139c6c84b5429c59662a",
+        enc:         "1afa08d3dec047a643885163f1180476fa7ddb54c6a8029ea33f95796bf2ac4a",
+        encryptions: "225fb3d35da3bb25e4371bcee4273502",
+        exports:     "54e2189c04100b583c84452f94eb9a4a",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA256, ExportOnly),
+        ikmE:        "55bc245ee4efda25d38f2d54d5bb6665291b99f8108a8c4b686c2b14893ea5d9",
+        ikmR:        "683ae0da1d22181e74ed2e503ebf82840deb1d5e872cade20f4b458d99783e31",
+        skR:         "33d196c830a12f9ac65d6e565a590d80f04ee9b19c83c87f2c170d972a812848",
+        pkR:         "194141ca6c3c3beb4792cd97ba0ea1faff09d98435012345766ee33aae2d7664",
+        enc:         "e5e8f9bfff6c2f29791fc351d2c25ce1299aa5eaca78a757c0b4fb4bcd830918",
+        exports:     "3fe376e3f9c349bc5eae67bbce867a16",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA512, AES128GCM),
+        ikmE:        "895221ae20f39cbf46871d6ea162d44b84dd7ba9cc7a3c80f16d6ea4242cd6d4",
+        ikmR:        "59a9b44375a297d452fc18e5bba1a64dec709f23109486fce2d3a5428ed2000a",
+        skR:         "ddfbb71d7ea8ebd98fa9cc211aa7b535d258fe9ab4a08bc9896af270e35aad35",
+        pkR:         "adf16c696b87995879b27d470d37212f38a58bfe7f84e6d50db638b8f2c22340",
+        enc:         "8998da4c3d6ade83c53e861a022c046db909f1c31107196ab4c2f4dd37e1a949",
+        encryptions: "19a0d0fb001f83e7606948507842f913",
+        exports:     "e5d853af841b92602804e7a40c1f2487",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA512, AES256GCM),
+        ikmE:        "e72b39232ee9ef9f6537a72afe28f551dbe632006aa1b300a00518883a3f2dc1",
+        ikmR:        "a0484936abc95d587acf7034156229f9970e9dfa76773754e40fb30e53c9de16",
+        skR:         "bdd8943c1e60191f3ea4e69fc4f322aa1086db9650f1f952fdce88395a4bd1af",
+        pkR:         "aa7bddcf5ca0b2c0cf760b5dffc62740a8e761ec572032a809bebc87aaf7575e",
+        enc:         "c12ba9fb91d7ebb03057d8bea4398688dcc1d1d1ff3b97f09b96b9bf89bd1e4a",
+        encryptions: "20402e520fdbfee76b2b0af73d810deb",
+        exports:     "80b7f603f0966ca059dd5e8a7cede735",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA512, ChaCha20Poly1305),
+        ikmE:        "636d1237a5ae674c24caa0c32a980d3218d84f916ba31e16699892d27103a2a9",
+        ikmR:        "969bb169aa9c24a501ee9d962e96c310226d427fb6eb3fc579d9882dbc708315",
+        skR:         "fad15f488c09c167bd18d8f48f282e30d944d624c5676742ad820119de44ea91",
+        pkR:         "06aa193a5612d89a1935c33f1fda3109fcdf4b867da4c4507879f184340b0e0e",
+        enc:         "1d38fc578d4209ea0ef3ee5f1128ac4876a9549d74dc2d2f46e75942a6188244",
+        encryptions: "c03e64ef58b22065f04be776d77e160c",
+        exports:     "fa84b4458d580b5069a1be60b4785eac",
+    },
+    {
+        suite:       NewSuite(DHKEMX25519, HKDFSHA512, ExportOnly),
+        ikmE:        "3cfbc97dece2c497126df8909efbdd3d56b3bbe97ddf6555c99a04ff4402474c",
+        ikmR:        "dff9a966e02b161472f167c0d4252d400069449e62384beb78111cb596220921",
+        skR:         "7596739457c72bbd6758c7021cfcb4d2fcd677d1232896b8f00da223c5519c36",
+        pkR:         "9a83674c1bc12909fd59635ba1445592b82a7c01d4dad3ffc8f3975e76c43732",
+        enc:         "444fbbf83d64fef654dfb2a17997d82ca37cd8aeb8094371da33afb95e0c5b0e",
+        exports:     "7557bdf93eadf06e3682fce3d765277f",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA256, AES128GCM),
+        ikmE:        "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e",
+        ikmR:        "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550",
+        skR:         "f3ce7fdae57e1a310d87f1ebbde6f328be0a99cdbcadf4d6589cf29de4b8ffd2",
+        pkR:         "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f62fb3d30ad6aa8f80e30f1d128aafd68a2ce72ea0",
+        enc:         "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900be863c403ce65c9bfcb9382657222d18c4",
+        encryptions: "fcb852ae6a1e19e874fbd18a199df3e4",
+        exports:     "655be1f8b189a6b103528ac6d28d3109",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA256, AES256GCM),
+        ikmE:        "a90d3417c3da9cb6c6ae19b4b5dd6cc9529a4cc24efb7ae0ace1f31887a8cd6c",
+        ikmR:        "a0ce15d49e28bd47a18a97e147582d814b08cbe00109fed5ec27d1b4e9f6f5e3",
+        skR:         "317f915db7bc629c48fe765587897e01e282d3e8445f79f27f65d031a88082b2",
+        pkR:         "04abc7e49a4c6b3566d77d0304addc6ed0e98512ffccf505e6a8e3eb25c685136f853148544876de76c0f2ef99cdc3a05ccf5ded7860c7c021238f9e2073d2356c",
+        enc:         "04c06b4f6bebc7bb495cb797ab753f911aff80aefb86fd8b6fcc35525f3ab5f03e0b21bd31a86c6048af3cb2d98e0d3bf01da5cc4c39ff5370d331a4f1f7d5a4e0",
+        encryptions: "8d3263541fc1695b6e88ff3a1208577c",
+        exports:     "038af0baa5ce3c4c5f371c3823b15217",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA256, ChaCha20Poly1305),
+        ikmE:        "f1f1a3bc95416871539ecb51c3a8f0cf608afb40fbbe305c0a72819d35c33f1f",
+        ikmR:        "61092f3f56994dd424405899154a9918353e3e008171517ad576b900ddb275e7",
+        skR:         "a4d1c55836aa30f9b3fbb6ac98d338c877c2867dd3a77396d13f68d3ab150d3b",
+        pkR:         "04a697bffde9405c992883c5c439d6cc358170b51af72812333b015621dc0f40bad9bb726f68a5c013806a790ec716ab8669f84f6b694596c2987cf35baba2a006",
+        enc:         "04c07836a0206e04e31d8ae99bfd549380b072a1b1b82e563c935c095827824fc1559eac6fb9e3c70cd3193968994e7fe9781aa103f5b50e934b5b2f387e381291",
+        encryptions: "702cdecae9ba5c571c8b00ad1f313dbf",
+        exports:     "2e0951156f1e7718a81be3004d606800",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA256, ExportOnly),
+        ikmE:        "3800bb050bb4882791fc6b2361d7adc2543e4e0abbac367cf00a0c4251844350",
+        ikmR:        "c6638d8079a235ea4054885355a7caefee67151c6ff2a04f4ba26d099c3a8b02",
+        skR:         "62c3868357a464f8461d03aa0182c7cebcde841036aea7230ddc7339f1088346",
+        pkR:         "046c6bb9e1976402c692fef72552f4aaeedd83a5e5079de3d7ae732da0f397b15921fb9c52c9866affc8e29c0271a35937023a9245982ec18bab1eb157cf16fc33",
+        enc:         "04d804370b7e24b94749eb1dc8df6d4d4a5d75f9effad01739ebcad5c54a40d57aaa8b4190fc124dbde2e4f1e1d1b012a3bc4038157dc29b55533a932306d8d38d",
+        exports:     "a6d39296bc2704db6194b7d6180ede8a",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA512, AES128GCM),
+        ikmE:        "4ab11a9dd78c39668f7038f921ffc0993b368171d3ddde8031501ee1e08c4c9a",
+        ikmR:        "ea9ff7cc5b2705b188841c7ace169290ff312a9cb31467784ca92d7a2e6e1be8",
+        skR:         "3ac8530ad1b01885960fab38cf3cdc4f7aef121eaa239f222623614b4079fb38",
+        pkR:         "04085aa5b665dc3826f9650ccbcc471be268c8ada866422f739e2d531d4a8818a9466bc6b449357096232919ec4fe9070ccbac4aac30f4a1a53efcf7af90610edd",
+        enc:         "0493ed86735bdfb978cc055c98b45695ad7ce61ce748f4dd63c525a3b8d53a15565c6897888070070c1579db1f86aaa56deb8297e64db7e8924e72866f9a472580",
+        encryptions: "3d670fc7760ce5b208454bb678fbc1dd",
+        exports:     "0a3e30b572dafc58b998cd51959924be",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA512, AES256GCM),
+        ikmE:        "0c4b7c8090d9995e298d6fd61c7a0a66bb765a12219af1aacfaac99b4deaf8ad",
+        ikmR:        "a2f6e7c4d9e108e03be268a64fe73e11a320963c85375a30bfc9ec4a214c6a55",
+        skR:         "9648e8711e9b6cb12dc19abf9da350cf61c3669c017b1db17bb36913b54a051d",
+        pkR:         "0400f209b1bf3b35b405d750ef577d0b2dc81784005d1c67ff4f6d2860d7640ca379e22ac7fa105d94bc195758f4dfc0b82252098a8350c1bfeda8275ce4dd4262",
+        enc:         "0404dc39344526dbfa728afba96986d575811b5af199c11f821a0e603a4d191b25544a402f25364964b2c129cb417b3c1dab4dfc0854f3084e843f731654392726",
+        encryptions: "9da1683aade69d882aa094aa57201481",
+        exports:     "80ab8f941a71d59f566e5032c6e2c675",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA512, ChaCha20Poly1305),
+        ikmE:        "02bd2bdbb430c0300cea89b37ada706206a9a74e488162671d1ff68b24deeb5f",
+        ikmR:        "8d283ea65b27585a331687855ab0836a01191d92ab689374f3f8d655e702d82f",
+        skR:         "ebedc3ca088ad03dfbbfcd43f438c4bb5486376b8ccaea0dc25fc64b2f7fc0da",
+        pkR:         "048fed808e948d46d95f778bd45236ce0c464567a1dc6f148ba71dc5aeff2ad52a43c71851b99a2cdbf1dad68d00baad45007e0af443ff80ad1b55322c658b7372",
+        enc:         "044415d6537c2e9dd4c8b73f2868b5b9e7e8e3d836990dc2fd5b466d1324c88f2df8436bac7aa2e6ebbfd13bd09eaaa7c57c7495643bacba2121dca2f2040e1c5f",
+        encryptions: "f025dca38d668cee68e7c434e1b98f9f",
+        exports:     "2efbb7ade3f87133810f507fdd73f874",
+    },
+    {
+        suite:       NewSuite(DHKEMP256, HKDFSHA512, ExportOnly),
+        ikmE:        "497efeca99592461588394f7e9496129ed89e62b58204e076d1b7141e999abda",
+        ikmR:        "49b7cbfc1756e8ae010dc80330108f5be91268b3636f3e547dbc714d6bcd3d16",
+        skR:         "9d34abe85f6da91b286fbbcfbd12c64402de3d7f63819e6c613037746b4eae6b",
+        pkR:         "0453a4d1a4333b291e32d50a77ac9157bbc946059941cf9ed5784c15adbc7ad8fe6bf34a504ed81fd9bc1b6bb066a037da30fccd6c0b42d72bf37b9fef43c8e498",
+        enc:         "04f910248e120076be2a4c93428ac0c8a6b89621cfef19f0f9e113d835cf39d5feabbf6d26444ebbb49c991ec22338ade3a5edff35a929be67c4e5f33dcff96706",
+        exports:     "6df17307eeb20a9180cff75ea183dd60",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA256, AES128GCM),
+        ikmE:        "5040af7a10269b11f78bb884812ad20041866db8bbd749a6a69e3f33e54da7164598f005bce09a9fe190e29c2f42df9e9e3aad040fccc625ddbd7aa99063fc594f40",
+        ikmR:        "39a28dc317c3e48b908948f99d608059f882d3d09c0541824bc25f94e6dee7aa0df1c644296b06fbb76e84aef5008f8a908e08fbabadf70658538d74753a85f8856a",
+        skR:         "009227b4b91cf1eb6eecb6c0c0bae93a272d24e11c63bd4c34a581c49f9c3ca01c16bbd32a0a1fac22784f2ae985c85f183baad103b2d02aee787179dfc1a94fea11",
+        pkR:         "0400b81073b1612cf7fdb6db07b35cf4bc17bda5854f3d270ecd9ea99f6c07b46795b8014b66c523ceed6f4829c18bc3886c891b63fa902500ce3ddeb1fbec7e608ac70050b76a0a7fc081dbf1cb30b005981113e635eb501a973aba662d7f16fcc12897dd752d657d37774bb16197c0d9724eecc1ed65349fb6ac1f280749e7669766f8cd",
+        enc:         "0400bec215e31718cd2eff5ba61d55d062d723527ec2029d7679a9c867d5c68219c9b217a9d7f78562dc0af3242fef35d1d6f4a28ee75f0d4b31bc918937b559b70762004c4fd6ad7373db7e31da8735fbd6171bbdcfa770211420682c760a40a482cc24f4125edbea9cb31fe71d5d796cfe788dc408857697a52fef711fb921fa7c385218",
+        encryptions: "94209973d36203eef2e56d155ef241d5",
+        exports:     "31f25ea5e192561bce5f2c2822a9432c",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA256, AES256GCM),
+        ikmE:        "9953fbd633be69d984fc4fffc4d7749f007dbf97102d36a647a8108b0bb7c609e826b026aec1cd47b93fc5acb7518fa455ed38d0c29e900c56990635612fd3d220d2",
+        ikmR:        "17320bc93d9bc1d422ba0c705bf693e9a51a855d6e09c11bddea5687adc1a1122ec81384dc7e47959cae01c420a69e8e39337d9ebf9a9b2f3905cb76a35b0693ac34",
+        skR:         "01a27e65890d64a121cfe59b41484b63fd1213c989c00e05a049ac4ede1f5caeec52bf43a59bdc36731cb6f8a0b7d7724b047ff52803c421ee99d61d4ea2e569c825",
+        pkR:         "0400eb4010ca82412c044b52bdc218625c4ea797e061236206843e318882b3c1642e7e14e7cc1b4b171a433075ac0c8563043829eee51059a8b68197c8a7f6922465650075f40b6f440fdf525e2512b0c2023709294d912d8c68f94140390bff228097ce2d5f89b2b21f50d4c0892cfb955c380293962d5fe72060913870b61adc8b111953",
+        enc:         "0401c1cf49cafa9e26e24a9e20d7fa44a50a4e88d27236ef17358e79f3615a97f825899a985b3edb5195cad24a4fb64828701e81fbfd9a7ef673efde508e789509bd7c00fd5bfe053377bbee22e40ae5d64aa6fb47b314b5ab7d71b652db9259962dce742317d54084f0cf62a4b7e3f3caa9e6afb8efd6bf1eb8a2e13a7e73ec9213070d68",
+        encryptions: "69d16fa7c814cd8be9aa2122fda8768f",
+        exports:     "d295fad3aef8be1f89d785800f83a30b",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA256, ChaCha20Poly1305),
+        ikmE:        "566568b6cbfd1c6c06d1b0a2dc22d4e4965858bf3d54bf6cba5c018be0fad7a5cd9237937800f3cb57f10fa5691faeecab1685aa6da9b667469224a0989ff82b822b",
+        ikmR:        "f9f594556282cfe3eb30958ca2ef90ecd2a6ffd2661d41eb39ba184f3dae9f914aad297dd80cc763cb6525437a61ceae448aeeb304de137dc0f28dd007f0d592e137",
+        skR:         "0168c8bf969b30bd949e154bf2db1964535e3f230f6604545bc9a33e9cd80fb17f4002170a9c91d55d7dd21db48e687cea83083498768cc008c6adf1e0ca08a309bd",
+        pkR:         "040086b1a785a52af34a9a830332999896e99c5df0007a2ec3243ee3676ba040e60fde21bacf8e5f8db26b5acd42a2c81160286d54a2f124ca8816ac697993727431e50002aa5f5ebe70d88ff56445ade400fb979b466c9046123bbf5be72db9d90d1cde0bb7c217cff8ea0484445150eaf60170b039f54a5f6baeb7288bc62b1dedb59a1b",
+        enc:         "0401f828650ec526a647386324a31dadf75b54550b06707ae3e1fb83874b2633c935bb862bc4f07791ccfafbb08a1f00e18c531a34fec76f2cf3d581e7915fa40bbc3b010ab7c3d9162ea69928e71640ecff08b97f4fa9e8c66dfe563a13bf561cee7635563f91d387e2a38ee674ea28b24c633a988d1a08968b455e96307c64bda3f094b7",
+        encryptions: "586d5a92612828afbd7fdcea96006892",
+        exports:     "a70389af65de4452a3f3147b66bd5c73",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA256, ExportOnly),
+        ikmE:        "5dfb76f8b4708970acb4a6efa35ec4f2cebd61a3276a711c2fa42ef0bc9c191ea9dac7c0ac907336d830cea4a8394ab69e9171f344c4817309f93170cb34914987a5",
+        ikmR:        "9fd2aad24a653787f53df4a0d514c6d19610ca803298d7812bc0460b76c21da99315ebfec2343b4848d34ce526f0d39ce5a8dfddd9544e1c4d4b9a62f4191d096b42",
+        skR:         "01ca47cf2f6f36fef46a01a46b393c30672224dd566aa3dd07a229519c49632c83d800e66149c3a7a07b840060549accd0d480ec5c71d2a975f88f6aa2fc0810b393",
+        pkR:         "040143b7db23907d3ae1c43ef4882a6cdb142ca05a21c2475985c199807dd143e898136c65faf1ca1b6c6c2e8a92d67a0ab9c24f8c5cff7610cb942a73eb2ec4217c26018d67621cc78a60ec4bd1e23f90eb772adba2cf5a566020ee651f017b280a155c016679bd7e7ebad49e28e7ab679f66765f4ef34eae6b38a99f31bc73ea0f0d694d",
+        enc:         "040073dda7343ce32926c028c3be28508cccb751e2d4c6187bcc4e9b1de82d3d70c5702c6c866a920d9d9a574f5a4d4a0102db76207d5b3b77da16bb57486c5cc2a95f006b5d2e15efb24e297bdf8f2b6d7b25bf226d1b6efca47627b484d2942c14df6fe018d82ab9fb7306370c248864ea48fe5ca94934993517aacaa3b6bca8f92efc84",
+        exports:     "d8fa94ac5e6829caf5ab4cdd1e05f5e1",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA512, AES128GCM),
+        ikmE:        "018b6bb1b8bbcefbd91e66db4e1300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+        ikmR:        "7bf9fd92611f2ff4e6c2ab4dd636a320e0397d6a93d014277b025a7533684c3255a02aa1f2a142be5391eebfc60a6a9c729b79c2428b8d78fa36497b1e89e446d402",
+        skR:         "019db24a3e8b1f383436cd06997dd864eb091418ff561e3876cee2e4762a0cc0b69688af9a7a4963c90d394b2be579144af97d4933c0e6c2c2d13e7505ea51a06b0d",
+        pkR:         "0401e06b350786c48a60dfc50eed324b58ecafc4efba26242c46c14274bd97f0989487a6fae0626188fea971ae1cb53f5d0e87188c1c62af92254f17138bbcebf5acd0018e574ee1d695813ce9dc45b404d2cf9c04f27627c4c55da1f936d813fd39435d0713d4a3cdc5409954a1180eb2672bdfc4e0e79c04eda89f857f625e058742a1c8",
+        enc:         "0400ac8d1611948105f23cf5e6842b07bd39b352d9d1e7bff2c93ac063731d6372e2661eff2afce604d4a679b49195f15e4fa228432aed971f2d46c1beb51fb3e5812501fe199c3d94c1b199393642500443dd82ce1c01701a1279cc3d74e29773030e26a70d3512f761e1eb0d7882209599eb9acd295f5939311c55e737f11c19988878d6",
+        encryptions: "207972885962115e69daaa3bc5015151",
+        exports:     "8e9c577501320d86ee84407840188f5f",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA512, AES256GCM),
+        ikmE:        "7f06ab8215105fc46aceeb2e3dc5028b44364f960426eb0d8e4026c2f8b5d7e7a986688f1591abf5ab753c357a5d6f0440414b4ed4ede71317772ac98d9239f70904",
+        ikmR:        "2ad954bbe39b7122529f7dde780bff626cd97f850d0784a432784e69d86eccaade43b6c10a8ffdb94bf943c6da479db137914ec835a7e715e36e45e29b587bab3bf1",
+        skR:         "01462680369ae375e4b3791070a7458ed527842f6a98a79ff5e0d4cbde83c27196a3916956655523a6a2556a7af62c5cadabe2ef9da3760bb21e005202f7b2462847",
+        pkR:         "0401b45498c1714e2dce167d3caf162e45e0642afc7ed435df7902ccae0e84ba0f7d373f646b7738bbbdca11ed91bdeae3cdcba3301f2457be452f271fa6837580e661012af49583a62e48d44bed350c7118c0d8dc861c238c72a2bda17f64704f464b57338e7f40b60959480c0e58e6559b190d81663ed816e523b6b6a418f66d2451ec64",
+        enc:         "040138b385ca16bb0d5fa0c0665fbbd7e69e3ee29f63991d3e9b5fa740aab8900aaeed46ed73a49055758425a0ce36507c54b29cc5b85a5cee6bae0cf1c21f2731ece2013dc3fb7c8d21654bb161b463962ca19e8c654ff24c94dd2898de12051f1ed0692237fb02b2f8d1dc1c73e9b366b529eb436e98a996ee522aef863dd5739d2f29b0",
+        encryptions: "31769e36bcca13288177eb1c92f616ae",
+        exports:     "fbffd93db9f000f51cf8ab4c1127fbda",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA512, ChaCha20Poly1305),
+        ikmE:        "f9d540fde009bb1e5e71617c122a079862306b97144c8c4dca45ef6605c2ec9c43527c150800f5608a7e4cff771226579e7c776fb3def4e22e68e9fdc92340e94b6e",
+        ikmR:        "5273f7762dea7a2408333dbf8db9f6ef2ac4c475ad9e81a3b0b8c8805304adf5c876105d8703b42117ad8ee350df881e3d52926aafcb5c90f649faf94be81952c78a",
+        skR:         "015b59f17366a1d4442e5b92d883a8f35fe8d88fea0e5bac6dfac7153c78fd0c6248c618b083899a7d62ba6e00e8a22cdde628dd5399b9a3377bb898792ff6f54ab9",
+        pkR:         "040084698a47358f06a92926ee826a6784341285ee45f4b8269de271a8c6f03d5e8e24f628de13f5c37377b7cabfbd67bc98f9e8e758dfbee128b2fe752cd32f0f3ccd0061baec1ed7c6b52b7558bc120f783e5999c8952242d9a20baf421ccfc2a2b87c42d7b5b806fea6d518d5e9cd7bfd6c85beb5adeb72da41ac3d4f27bba83cff24d7",
+        enc:         "0400edc201c9b32988897a7f7b19104ebb54fc749faa41a67e9931e87ec30677194898074afb9a5f40a97df2972368a0c594e5b60e90d1ff83e9e35f8ff3ad200fd6d70028b5645debe9f1f335dbc1225c066218e85cf82a05fbe361fa477740b906cb3083076e4d17232513d102627597d38e354762cf05b3bd0f33dc4d0fb78531afd3fd",
+        encryptions: "aa69356025f552372770ef126fa2e59a",
+        exports:     "1fcffb5d8bc1d825daf904a0c6f4a4d3",
+    },
+    {
+        suite:       NewSuite(DHKEMP521, HKDFSHA512, ExportOnly),
+        ikmE:        "3018d74c67d0c61b5e4075190621fc192996e928b8859f45b3ad2399af8599df69c34b7a3eefeda7ee49ae73d4579300b85dde1654c0dfc3a3f78143d239a628cf72",
+        ikmR:        "a243eff510b99140034c72587e9f131809b9bce03a9da3da458771297f535cede0f48167200bf49ac123b52adfd789cf0adfd5cded6be2f146aeb00c34d4e6d234fc",
+        skR:         "0045fe00b1d55eb64182d334e301e9ac553d6dbafbf69935e65f5bf89c761b9188c0e4d50a0167de6b98af7bebd05b2627f45f5fca84690cd86a61ba5a612870cf53",
+        pkR:         "0401635b3074ad37b752696d5ca311da9cc790a899116030e4c71b83edd06ced92fdd238f6c921132852f20e6a2cbcf2659739232f4a69390f2b14d80667bcf9b71983000a919d29366554f53107a6c4cc7f8b24fa2de97b42433610cbd236d5a2c668e991ff4c4383e9fe0a9e7858fc39064e31fca1964e809a2f898c32fba46ce33575b8",
+        enc:         "0400932d9ff83ca4b799968bda0dd9dac4d02c9232cdcf133db7c53cfbf3d80a299fd99bc42da38bb78f57976bdb69988819b6e2924fadacdad8c05052997cf50b29110139f000af5b2c599b05fc63537d60a8384ca984821f8cd12621577a974ebadaf98bfdad6d1643dd4316062d7c0bda5ba0f0a2719992e993af615568abf19a256993",
+        exports:     "29c0f6150908f6e0d979172f23f1d57b",
+    },
+}
+
+// RFC 9180 A.1.2 及 A.1.3 测试向量，psk 及 auth 模式
+// 明文为 "Beauty is truth, truth beauty"，第 n 条消息的 aad 为 "Count-n"
+type testModeVector struct {
+    mode        Mode
+    ikmE        string
+    ikmR        string
+    skR         string
+    pkR         string
+    ikmS        string
+    skS         string
+    pkS         string
+    psk         string
+    pskID       string
+    enc         string
+    ciphertexts []string
+}
+
+var testModeVectors = []testModeVector{
+    {
+        mode:  ModePSK,
+        ikmE:  "78628c354e46f3e169bd231be7b2ff1c77aa302460a26dbfa15515684c00130b",
+        ikmR:  "d4a09d09f575fef425905d2ab396c1449141463f698f8efdb7accfaff8995098",
+        skR:   "c5eb01eb457fe6c6f57577c5413b931550a162c71a03ac8d196babbd4e5ce0fd",
+        pkR:   "9fed7e8c17387560e92cc6462a68049657246a09bfa8ade7aefe589672016366",
+        psk:   "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82",
+        pskID: "456e6e796e20447572696e206172616e204d6f726961",
+        enc:   "0ad0950d9fb9588e59690b74f1237ecdf1d775cd60be2eca57af5a4b0471c91b",
+        ciphertexts: []string{
+            "e52c6fed7f758d0cf7145689f21bc1be6ec9ea097fef4e959440012f4feb73fb611b946199e681f4cfc34db8ea",
+            "49f3b19b28a9ea9f43e8c71204c00d4a490ee7f61387b6719db765e948123b45b61633ef059ba22cd62437c8ba",
+        },
+    },
+    {
+        mode: ModeAuth,
+        ikmE: "6e6d8f200ea2fb20c30b003a8b4f433d2f4ed4c2658d5bc8ce2fef718059c9f7",
+        ikmR: "f1d4a30a4cef8d6d4e3b016e6fd3799ea057db4f345472ed302a67ce1c20cdec",
+        skR:  "fdea67cf831f1ca98d8e27b1f6abeb5b7745e9d35348b80fa407ff6958f9137e",
+        pkR:  "1632d5c2f71c2b38d0a8fcc359355200caa8b1ffdf28618080466c909cb69b2e",
+        ikmS: "94b020ce91d73fca4649006c7e7329a67b40c55e9e93cc907d282bbbff386f58",
+        skS:  "dc4a146313cce60a278a5323d321f051c5707e9c45ba21a3479fecdf76fc69dd",
+        pkS:  "8b0c70873dc5aecb7f9ee4e62406a397b350e57012be45cf53b7105ae731790b",
+        enc:  "23fb952571a14a25e3d678140cd0e5eb47a0961bb18afcf85896e5453c312e76",
+        ciphertexts: []string{
+            "5fd92cc9d46dbf8943e72a07e42f363ed5f721212cd90bcfd072bfd9f44e06b80fd17824947496e21b680c141b",
+        },
+    },
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+    t.Helper()
+
+    b, err := hex.DecodeString(s)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return b
+}
+
+// 从 r 读取长度及数据
+func drawInput(t *testing.T, r io.Reader) []byte {
+    t.Helper()
+
+    l := make([]byte, 1)
+    if _, err := r.Read(l); err != nil {
+        t.Fatal(err)
+    }
+
+    b := make([]byte, int(l[0]))
+    if _, err := r.Read(b); err != nil {
+        t.Fatal(err)
+    }
+
+    return b
+}
+
+func TestVectors(t *testing.T) {
+    for i, v := range testVectors {
+        ikmE := mustDecodeHex(t, v.ikmE)
+        ikmR := mustDecodeHex(t, v.ikmR)
+        skR := mustDecodeHex(t, v.skR)
+        pkR := mustDecodeHex(t, v.pkR)
+
+        sk, pk, err := v.suite.KEM.DeriveKeyPair(ikmR)
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+        if !bytes.Equal(sk, skR) || !bytes.Equal(pk, pkR) {
+            t.Fatalf("%d: DeriveKeyPair got %x %x", i, sk, pk)
+        }
+
+        enc, sender, err := v.suite.NewSender(pkR, testInfo, Options{
+            Rand: bytes.NewReader(ikmE),
+        })
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+        if !bytes.Equal(enc, mustDecodeHex(t, v.enc)) {
+            t.Fatalf("%d: enc got %x", i, enc)
+        }
+
+        receiver, err := v.suite.NewReceiver(skR, enc, testInfo)
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+
+        if v.suite.AEAD == ExportOnly {
+            if _, err := sender.Seal(nil, nil); err != ErrExportOnly {
+                t.Errorf("%d: Seal with export only aead got %v", i, err)
+            }
+            if _, err := receiver.Open(nil, nil); err != ErrExportOnly {
+                t.Errorf("%d: Open with export only aead got %v", i, err)
+            }
+        } else {
+            source, sink := sha3.NewShake128(), sha3.NewShake128()
+            for j := 0; j < 1000; j++ {
+                aad, plaintext := drawInput(t, source), drawInput(t, source)
+
+                ciphertext, err := sender.Seal(aad, plaintext)
+                if err != nil {
+                    t.Fatalf("%d: %v", i, err)
+                }
+                sink.Write(ciphertext)
+
+                got, err := receiver.Open(aad, ciphertext)
+                if err != nil {
+                    t.Fatalf("%d: %v", i, err)
+                }
+                if !bytes.Equal(got, plaintext) {
+                    t.Fatalf("%d: Open got %x, want %x", i, got, plaintext)
+                }
+            }
+
+            encryptions := make([]byte, 16)
+            sink.Read(encryptions)
+            if !bytes.Equal(encryptions, mustDecodeHex(t, v.encryptions)) {
+                t.Errorf("%d: encryptions got %x, want %s", i, encryptions, v.encryptions)
+            }
+        }
+
+        source, sink := sha3.NewShake128(), sha3.NewShake128()
+        for l := 0; l < 1000; l++ {
+            exporterContext := drawInput(t, source)
+
+            value, err := sender.Export(exporterContext, l)
+            if err != nil {
+                t.Fatalf("%d: %v", i, err)
+            }
+            sink.Write(value)
+
+            got, err := receiver.Export(exporterContext, l)
+            if err != nil {
+                t.Fatalf("%d: %v", i, err)
+            }
+            if !bytes.Equal(got, value) {
+                t.Fatalf("%d: receiver Export got %x, want %x", i, got, value)
+            }
+        }
+
+        exports := make([]byte, 16)
+        sink.Read(exports)
+        if !bytes.Equal(exports, mustDecodeHex(t, v.exports)) {
+            t.Errorf("%d: exports got %x, want %s", i, exports, v.exports)
+        }
+    }
+}
+
+func TestModeVectors(t *testing.T) {
+    suite := NewSuite(DHKEMX25519, HKDFSHA256, AES128GCM)
+    plaintext := []byte("Beauty is truth, truth beauty")
+
+    for i, v := range testModeVectors {
+        skR := mustDecodeHex(t, v.skR)
+        pkR := mustDecodeHex(t, v.pkR)
+
+        sk, pk, err := suite.KEM.DeriveKeyPair(mustDecodeHex(t, v.ikmR))
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+        if !bytes.Equal(sk, skR) || !bytes.Equal(pk, pkR) {
+            t.Fatalf("%d: DeriveKeyPair got %x %x", i, sk, pk)
+        }
+
+        sender := Options{
+            Rand: bytes.NewReader(mustDecodeHex(t, v.ikmE)),
+        }
+        receiver := Options{}
+
+        if v.psk != "" {
+            sender.PSK, sender.PSKID = mustDecodeHex(t, v.psk), mustDecodeHex(t, v.pskID)
+            receiver.PSK, receiver.PSKID = sender.PSK, sender.PSKID
+        }
+
+        if v.ikmS != "" {
+            skS, pkS, err := suite.KEM.DeriveKeyPair(mustDecodeHex(t, v.ikmS))
+            if err != nil {
+                t.Fatalf("%d: %v", i, err)
+            }
+            if !bytes.Equal(skS, mustDecodeHex(t, v.skS)) || !bytes.Equal(pkS, mustDecodeHex(t, v.pkS)) {
+                t.Fatalf("%d: sender DeriveKeyPair got %x %x", i, skS, pkS)
+            }
+
+            sender.SenderPrivateKey = skS
+            receiver.SenderPublicKey = pkS
+        }
+
+        enc, s, err := suite.NewSender(pkR, testInfo, sender)
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+        if s.Mode() != v.mode {
+            t.Fatalf("%d: mode got %d, want %d", i, s.Mode(), v.mode)
+        }
+        if !bytes.Equal(enc, mustDecodeHex(t, v.enc)) {
+            t.Fatalf("%d: enc got %x", i, enc)
+        }
+
+        r, err := suite.NewReceiver(skR, enc, testInfo, receiver)
+        if err != nil {
+            t.Fatalf("%d: %v", i, err)
+        }
+
+        for j, want := range v.ciphertexts {
+            aad := []byte("Count-" + strconv.Itoa(j))
+
+            ciphertext, err := s.Seal(aad, plaintext)
+            if err != nil {
+                t.Fatalf("%d: %v", i, err)
+            }
+            if !bytes.Equal(ciphertext, mustDecodeHex(t, want)) {
+                t.Fatalf("%d: ciphertext %d got %x", i, j, ciphertext)
+            }
+
+            got, err := r.Open(aad, ciphertext)
+            if err != nil {
+                t.Fatalf("%d: %v", i, err)
+            }
+            if !bytes.Equal(got, plaintext) {
+                t.Fatalf("%d: Open got %q", i, got)
+            }
+        }
+    }
+}
+
+func TestModes(t *testing.T) {
+    suites := []Suite{
+        NewSuite(DHKEMX25519, HKDFSHA256, ChaCha20Poly1305),
+        NewSuite(DHKEMP256, HKDFSHA256, AES128GCM),
+        NewSuite(DHKEMP384, HKDFSHA384, AES256GCM),
+        NewSuite(DHKEMP521, HKDFSHA512, AES256GCM),
+    }
+
+    psk := []byte("0123456789abcdef0123456789abcdef")
+    pskID := []byte("psk-id")
+
+    for _, suite := range suites {
+        skR, pkR, err := suite.KEM.GenerateKeyPair(nil)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        skS, pkS, err := suite.KEM.GenerateKeyPair(nil)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        tests := []struct {
+            mode     Mode
+            sender   Options
+            receiver Options
+        }{
+            {ModeBase, Options{}, Options{}},
+            {ModePSK, Options{PSK: psk, PSKID: pskID}, Options{PSK: psk, PSKID: pskID}},
+            {ModeAuth, Options{SenderPrivateKey: skS}, Options{SenderPublicKey: pkS}},
+            {
+                ModeAuthPSK,
+                Options{PSK: psk, PSKID: pskID, SenderPrivateKey: skS},
+                Options{PSK: psk, PSKID: pskID, SenderPublicKey: pkS},
+            },
+        }
+
+        for _, test := range tests {
+            aad := []byte("aad")
+            msg := []byte("hpke message")
+
+            enc, sender, err := suite.NewSender(pkR, testInfo, test.sender)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if sender.Mode() != test.mode {
+                t.Fatalf("%v: mode got %d, want %d", suite, sender.Mode(), test.mode)
+            }
+
+            ciphertext, err := sender.Seal(aad, msg)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            plaintext, err := suite.Open(skR, enc, testInfo, aad, ciphertext, test.receiver)
+            if err != nil {
+                t.Fatalf("%v mode %d: %v", suite, test.mode, err)
+            }
+            if !bytes.Equal(plaintext, msg) {
+                t.Fatalf("%v mode %d: Open got %q", suite, test.mode, plaintext)
+            }
+
+            // 其他模式不能解密
+            if _, err := suite.Open(skR, enc, testInfo, aad, ciphertext); test.mode != ModeBase && err == nil {
+                t.Fatalf("%v mode %d: Open in base mode should fail", suite, test.mode)
+            }
+        }
+    }
+}
+
+func TestSealOpen(t *testing.T) {
+    suite := NewSuite(DHKEMX25519, HKDFSHA256, AES128GCM)
+
+    skR, pkR, err := suite.KEM.GenerateKeyPair(nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    enc, ciphertext, err := suite.Seal(pkR, testInfo, []byte("aad"), []byte("message"))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    plaintext, err := suite.Open(skR, enc, testInfo, []byte("aad"), ciphertext)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if string(plaintext) != "message" {
+        t.Fatalf("Open got %q", plaintext)
+    }
+
+    if _, err := suite.Open(skR, enc, testInfo, []byte("other"), ciphertext); err != ErrOpen {
+        t.Fatalf("Open with wrong aad got %v", err)
+    }
+
+    if _, err := suite.Open(skR, enc, []byte("other"), []byte("aad"), ciphertext); err != ErrOpen {
+        t.Fatalf("Open with wrong info got %v", err)
+    }
+
+    enc, secret, err := suite.SendExport(pkR, testInfo, []byte("context"), 32)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    got, err := suite.ReceiveExport(skR, enc, testInfo, []byte("context"), 32)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !bytes.Equal(got, secret) {
+        t.Fatalf("ReceiveExport got %x, want %x", got, secret)
+    }
+}
+
+func TestErrors(t *testing.T) {
+    suite := NewSuite(DHKEMP256, HKDFSHA256, AES128GCM)
+
+    _, pkR, err := suite.KEM.GenerateKeyPair(nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, _, err := suite.NewSender(pkR, nil, Options{PSK: []byte("psk")}); err != ErrPSKInputs {
+        t.Errorf("psk without psk id got %v", err)
+    }
+
+    if _, _, err := suite.NewSender(pkR, nil, Options{PSK: []byte("short psk"), PSKID: []byte("psk-id")}); err != ErrPSKLength {
+        t.Errorf("short psk got %v", err)
+    }
+
+    if _, _, err := suite.NewSender(pkR[:10], nil); err != ErrPublicKey {
+        t.Errorf("short public key got %v", err)
+    }
+
+    badPoint := append([]byte{}, pkR...)
+    badPoint[len(badPoint)-1] ^= 1
+    if _, _, err := suite.NewSender(badPoint, nil); err != ErrPublicKey {
+        t.Errorf("point not on curve got %v", err)
+    }
+
+    x25519 := NewSuite(DHKEMX25519, HKDFSHA256, AES128GCM)
+    if _, _, err := x25519.NewSender(make([]byte, 32), nil); err != ErrPublicKey {
+        t.Errorf("low order x25519 point got %v", err)
+    }
+
+    if _, _, err := NewSuite(KEM(0x0021), HKDFSHA256, AES128GCM).NewSender(pkR, nil); err != ErrUnsupportedKEM {
+        t.Errorf("unsupported kem got %v", err)
+    }
+
+    if _, _, err := NewSuite(DHKEMP256, KDF(9), AES128GCM).NewSender(pkR, nil); err != ErrUnsupportedKDF {
+        t.Errorf("unsupported kdf got %v", err)
+    }
+
+    if _, _, err := NewSuite(DHKEMP256, HKDFSHA256, AEAD(9)).NewSender(pkR, nil); err != ErrUnsupportedAEAD {
+        t.Errorf("unsupported aead got %v", err)
+    }
+
+    _, sender, err := suite.NewSender(pkR, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := sender.Export(nil, 255 * 32 + 1); err != ErrExportLength {
+        t.Errorf("export length got %v", err)
+    }
+}
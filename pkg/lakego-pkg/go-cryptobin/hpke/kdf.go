@@ -0,0 +1,80 @@
+package hpke
+
+import (
+    "hash"
+    "crypto/sha256"
+    "crypto/sha512"
+    "encoding/binary"
+
+    "golang.org/x/crypto/hkdf"
+)
+
+// HPKE 版本标签
+const versionLabel = "HPKE-v1"
+
+// 密钥派生算法
+type KDF uint16
+
+const (
+    HKDFSHA256 KDF = 0x0001
+    HKDFSHA384 KDF = 0x0002
+    HKDFSHA512 KDF = 0x0003
+)
+
+// 摘要算法
+func (this KDF) hash() (func() hash.Hash, error) {
+    switch this {
+        case HKDFSHA256:
+            return sha256.New, nil
+        case HKDFSHA384:
+            return sha512.New384, nil
+        case HKDFSHA512:
+            return sha512.New, nil
+    }
+
+    return nil, ErrUnsupportedKDF
+}
+
+// 有效性
+func (this KDF) IsValid() bool {
+    _, err := this.hash()
+    return err == nil
+}
+
+// 带标签的 HKDF
+type labeledKDF struct {
+    hash    func() hash.Hash
+    suiteID []byte
+}
+
+// LabeledExtract
+func (this labeledKDF) extract(salt []byte, label string, ikm []byte) []byte {
+    labeledIKM := make([]byte, 0, len(versionLabel) + len(this.suiteID) + len(label) + len(ikm))
+    labeledIKM = append(labeledIKM, versionLabel...)
+    labeledIKM = append(labeledIKM, this.suiteID...)
+    labeledIKM = append(labeledIKM, label...)
+    labeledIKM = append(labeledIKM, ikm...)
+
+    return hkdf.Extract(this.hash, labeledIKM, salt)
+}
+
+// LabeledExpand
+func (this labeledKDF) expand(prk []byte, label string, info []byte, length int) ([]byte, error) {
+    if length > 255 * this.hash().Size() {
+        return nil, ErrExportLength
+    }
+
+    labeledInfo := make([]byte, 2, 2 + len(versionLabel) + len(this.suiteID) + len(label) + len(info))
+    binary.BigEndian.PutUint16(labeledInfo, uint16(length))
+    labeledInfo = append(labeledInfo, versionLabel...)
+    labeledInfo = append(labeledInfo, this.suiteID...)
+    labeledInfo = append(labeledInfo, label...)
+    labeledInfo = append(labeledInfo, info...)
+
+    out := make([]byte, length)
+    if _, err := hkdf.Expand(this.hash, prk, labeledInfo).Read(out); err != nil {
+        return nil, err
+    }
+
+    return out, nil
+}
@@ -0,0 +1,335 @@
+package hpke
+
+import (
+    "io"
+    "math/big"
+    "crypto/rand"
+    "crypto/subtle"
+    "crypto/elliptic"
+    "encoding/binary"
+
+    "github.com/deatil/go-cryptobin/dh/ecdh"
+    "github.com/deatil/go-cryptobin/dh/curve25519"
+)
+
+// 密钥封装算法，公钥及私钥使用 RFC 9180 7.1.1 序列化格式，
+// EC 公钥为未压缩点，与 dh/ecdh 及 dh/curve25519 的 Y 及 X 相同
+type KEM uint16
+
+const (
+    DHKEMP256   KEM = 0x0010
+    DHKEMP384   KEM = 0x0011
+    DHKEMP521   KEM = 0x0012
+    DHKEMX25519 KEM = 0x0020
+)
+
+// DHKEM 参数
+type dhKEM struct {
+    id      KEM
+    curve   elliptic.Curve
+    kdf     KDF
+    nSecret int
+    nPk     int
+    nSk     int
+    bitmask byte
+}
+
+// 获取 DHKEM 参数
+func (this KEM) dhKEM() (*dhKEM, error) {
+    switch this {
+        case DHKEMP256:
+            return &dhKEM{this, ecdh.P256(), HKDFSHA256, 32, 65, 32, 0xff}, nil
+        case DHKEMP384:
+            return &dhKEM{this, ecdh.P384(), HKDFSHA384, 48, 97, 48, 0xff}, nil
+        case DHKEMP521:
+            return &dhKEM{this, ecdh.P521(), HKDFSHA512, 64, 133, 66, 0x01}, nil
+        case DHKEMX25519:
+            return &dhKEM{this, nil, HKDFSHA256, 32, 32, 32, 0}, nil
+    }
+
+    return nil, ErrUnsupportedKEM
+}
+
+// 有效性
+func (this KEM) IsValid() bool {
+    _, err := this.dhKEM()
+    return err == nil
+}
+
+// GenerateKeyPair generates a key pair, rand defaults to crypto/rand
+func (this KEM) GenerateKeyPair(random io.Reader) (priv []byte, pub []byte, err error) {
+    kem, err := this.dhKEM()
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return kem.generateKeyPair(random)
+}
+
+// DeriveKeyPair derives a key pair from ikm
+func (this KEM) DeriveKeyPair(ikm []byte) (priv []byte, pub []byte, err error) {
+    kem, err := this.dhKEM()
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return kem.deriveKeyPair(ikm)
+}
+
+// PublicKey returns the public key of priv
+func (this KEM) PublicKey(priv []byte) ([]byte, error) {
+    kem, err := this.dhKEM()
+    if err != nil {
+        return nil, err
+    }
+
+    return kem.publicKey(priv)
+}
+
+// KEM 内部的 HKDF
+func (this *dhKEM) labeledKDF() labeledKDF {
+    suiteID := make([]byte, 5)
+    copy(suiteID, "KEM")
+    binary.BigEndian.PutUint16(suiteID[3:], uint16(this.id))
+
+    hash, _ := this.kdf.hash()
+
+    return labeledKDF{
+        hash:    hash,
+        suiteID: suiteID,
+    }
+}
+
+// 生成密钥对，使用随机 ikm 派生
+func (this *dhKEM) generateKeyPair(random io.Reader) ([]byte, []byte, error) {
+    if random == nil {
+        random = rand.Reader
+    }
+
+    ikm := make([]byte, this.nSk)
+    if _, err := io.ReadFull(random, ikm); err != nil {
+        return nil, nil, err
+    }
+
+    return this.deriveKeyPair(ikm)
+}
+
+// 派生密钥对
+func (this *dhKEM) deriveKeyPair(ikm []byte) ([]byte, []byte, error) {
+    kdf := this.labeledKDF()
+
+    dkpPrk := kdf.extract(nil, "dkp_prk", ikm)
+
+    if this.curve == nil {
+        sk, err := kdf.expand(dkpPrk, "sk", nil, this.nSk)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        pk, err := this.publicKey(sk)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        return sk, pk, nil
+    }
+
+    order := this.curve.Params().N
+    for counter := 0; counter < 256; counter++ {
+        sk, err := kdf.expand(dkpPrk, "candidate", []byte{byte(counter)}, this.nSk)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        sk[0] &= this.bitmask
+
+        d := new(big.Int).SetBytes(sk)
+        if d.Sign() == 0 || d.Cmp(order) >= 0 {
+            continue
+        }
+
+        pk, err := this.publicKey(sk)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        return sk, pk, nil
+    }
+
+    return nil, nil, ErrDeriveKeyPair
+}
+
+// 私钥对应的公钥
+func (this *dhKEM) publicKey(sk []byte) ([]byte, error) {
+    if len(sk) != this.nSk {
+        return nil, ErrPrivateKey
+    }
+
+    if this.curve == nil {
+        pub, err := curve25519.GeneratePublicKey(&curve25519.PrivateKey{
+            X: sk,
+        })
+        if err != nil {
+            return nil, err
+        }
+
+        return pub.Y, nil
+    }
+
+    if new(big.Int).SetBytes(sk).Sign() == 0 {
+        return nil, ErrPrivateKey
+    }
+
+    priv := &ecdh.PrivateKey{
+        X: sk,
+    }
+    priv.Curve = this.curve
+
+    pub, err := ecdh.GeneratePublicKey(priv)
+    if err != nil {
+        return nil, ErrPrivateKey
+    }
+
+    return pub.Y, nil
+}
+
+// 检测公钥
+func (this *dhKEM) checkPublicKey(pk []byte) error {
+    if len(pk) != this.nPk {
+        return ErrPublicKey
+    }
+
+    if this.curve == nil {
+        return nil
+    }
+
+    if pk[0] != 4 {
+        return ErrPublicKey
+    }
+
+    pub := &ecdh.PublicKey{
+        Curve: this.curve,
+        Y:     pk,
+    }
+    if pub.Check() != nil {
+        return ErrPublicKey
+    }
+
+    return nil
+}
+
+// DH 计算，共享密钥为固定长度
+func (this *dhKEM) dh(sk, pk []byte) ([]byte, error) {
+    if len(sk) != this.nSk {
+        return nil, ErrPrivateKey
+    }
+
+    if err := this.checkPublicKey(pk); err != nil {
+        return nil, err
+    }
+
+    var secret []byte
+    if this.curve == nil {
+        priv := &curve25519.PrivateKey{
+            X: sk,
+        }
+
+        secret = curve25519.ComputeSecret(priv, &curve25519.PublicKey{
+            Y: pk,
+        })
+    } else {
+        priv := &ecdh.PrivateKey{
+            X: sk,
+        }
+        priv.Curve = this.curve
+
+        // ecdh 返回的 x 坐标会去掉前导 0
+        x := ecdh.ComputeSecret(priv, &ecdh.PublicKey{
+            Curve: this.curve,
+            Y:     pk,
+        })
+
+        secret = make([]byte, this.nSk)
+        copy(secret[len(secret)-len(x):], x)
+    }
+
+    zero := make([]byte, len(secret))
+    if subtle.ConstantTimeCompare(secret, zero) == 1 {
+        return nil, ErrPublicKey
+    }
+
+    return secret, nil
+}
+
+// ExtractAndExpand
+func (this *dhKEM) extractAndExpand(dh, kemContext []byte) ([]byte, error) {
+    kdf := this.labeledKDF()
+
+    eaePrk := kdf.extract(nil, "eae_prk", dh)
+
+    return kdf.expand(eaePrk, "shared_secret", kemContext, this.nSecret)
+}
+
+// 封装共享密钥，skS 不为空时使用 AuthEncap
+func (this *dhKEM) encap(random io.Reader, pkR, skS []byte) (sharedSecret []byte, enc []byte, err error) {
+    skE, pkE, err := this.generateKeyPair(random)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    dh, err := this.dh(skE, pkR)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    kemContext := append(append([]byte{}, pkE...), pkR...)
+
+    if skS != nil {
+        dhS, err := this.dh(skS, pkR)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        pkS, err := this.publicKey(skS)
+        if err != nil {
+            return nil, nil, err
+        }
+
+        dh = append(dh, dhS...)
+        kemContext = append(kemContext, pkS...)
+    }
+
+    sharedSecret, err = this.extractAndExpand(dh, kemContext)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    return sharedSecret, pkE, nil
+}
+
+// 解封装共享密钥，pkS 不为空时使用 AuthDecap
+func (this *dhKEM) decap(enc, skR, pkS []byte) ([]byte, error) {
+    dh, err := this.dh(skR, enc)
+    if err != nil {
+        return nil, err
+    }
+
+    pkR, err := this.publicKey(skR)
+    if err != nil {
+        return nil, err
+    }
+
+    kemContext := append(append([]byte{}, enc...), pkR...)
+
+    if pkS != nil {
+        dhS, err := this.dh(skR, pkS)
+        if err != nil {
+            return nil, err
+        }
+
+        dh = append(dh, dhS...)
+        kemContext = append(kemContext, pkS...)
+    }
+
+    return this.extractAndExpand(dh, kemContext)
+}
@@ -0,0 +1,178 @@
+package keyring
+
+import (
+    "crypto"
+    "crypto/dsa"
+    "crypto/rsa"
+    "crypto/ecdsa"
+    "crypto/ed25519"
+
+    "github.com/tjfoc/gmsm/sm2"
+
+    cryptobin_rsa "github.com/deatil/go-cryptobin/cryptobin/rsa"
+    cryptobin_dsa "github.com/deatil/go-cryptobin/cryptobin/dsa"
+    cryptobin_sm2 "github.com/deatil/go-cryptobin/cryptobin/sm2"
+    cryptobin_ecdsa "github.com/deatil/go-cryptobin/cryptobin/ecdsa"
+    cryptobin_eddsa "github.com/deatil/go-cryptobin/cryptobin/eddsa"
+)
+
+// ECDSA 签名摘要
+func ecdsaSignHash(alg string) string {
+    switch alg {
+        case AlgES384:
+            return "SHA384"
+        case AlgES512:
+            return "SHA512"
+    }
+
+    return "SHA256"
+}
+
+// 签名，RSA 使用 PKCS#1 v1.5，ECDSA, SM2 及 DSA 为 ASN.1 格式
+func sign(priv crypto.PrivateKey, data []byte) ([]byte, error) {
+    switch k := priv.(type) {
+        case *rsa.PrivateKey:
+            res := cryptobin_rsa.New().
+                WithPrivateKey(k).
+                WithData(data).
+                WithSignHash("SHA256").
+                Sign()
+
+            return res.ToBytes(), res.Error().First()
+        case *ecdsa.PrivateKey:
+            res := cryptobin_ecdsa.New().
+                WithPrivateKey(k).
+                WithData(data).
+                WithSignHash(ecdsaSignHash(keyAlgorithm(k))).
+                SignAsn1()
+
+            return res.ToBytes(), res.Error().First()
+        case ed25519.PrivateKey:
+            res := cryptobin_eddsa.New().
+                WithPrivateKey(k).
+                WithData(data).
+                Sign()
+
+            return res.ToBytes(), res.Error().First()
+        case *sm2.PrivateKey:
+            res := cryptobin_sm2.New().
+                WithPrivateKey(k).
+                WithData(data).
+                Sign()
+
+            return res.ToBytes(), res.Error().First()
+        case *dsa.PrivateKey:
+            res := cryptobin_dsa.New().
+                WithPrivateKey(k).
+                WithData(data).
+                WithSignHash("SHA256").
+                SignAsn1()
+
+            return res.ToBytes(), res.Error().First()
+    }
+
+    return nil, ErrKeyType
+}
+
+// 验证签名
+func verify(pub crypto.PublicKey, data, sig []byte) error {
+    var verified bool
+    var err error
+
+    switch k := pub.(type) {
+        case *rsa.PublicKey:
+            res := cryptobin_rsa.New().
+                WithPublicKey(k).
+                WithData(sig).
+                WithSignHash("SHA256").
+                Verify(data)
+
+            verified, err = res.GetVerify(), res.Error().First()
+        case *ecdsa.PublicKey:
+            res := cryptobin_ecdsa.New().
+                WithPublicKey(k).
+                WithData(sig).
+                WithSignHash(ecdsaSignHash(keyAlgorithm(&ecdsa.PrivateKey{PublicKey: *k}))).
+                VerifyAsn1(data)
+
+            verified, err = res.GetVerify(), res.Error().First()
+        case ed25519.PublicKey:
+            res := cryptobin_eddsa.New().
+                WithPublicKey(k).
+                WithData(sig).
+                Verify(data)
+
+            verified, err = res.GetVerify(), res.Error().First()
+        case *sm2.PublicKey:
+            res := cryptobin_sm2.New().
+                WithPublicKey(k).
+                WithData(sig).
+                Verify(data)
+
+            verified, err = res.GetVerify(), res.Error().First()
+        case *dsa.PublicKey:
+            res := cryptobin_dsa.New().
+                WithPublicKey(k).
+                WithData(sig).
+                WithSignHash("SHA256").
+                VerifyAsn1(data)
+
+            verified, err = res.GetVerify(), res.Error().First()
+        default:
+            return ErrKeyType
+    }
+
+    if err != nil {
+        return err
+    }
+
+    if !verified {
+        return ErrVerify
+    }
+
+    return nil
+}
+
+// 公钥加密，RSA 使用 OAEP SHA256
+func encrypt(pub crypto.PublicKey, plaintext []byte) ([]byte, error) {
+    switch k := pub.(type) {
+        case *rsa.PublicKey:
+            res := cryptobin_rsa.New().
+                WithPublicKey(k).
+                WithData(plaintext).
+                EncryptOAEP("SHA256")
+
+            return res.ToBytes(), res.Error().First()
+        case *sm2.PublicKey:
+            res := cryptobin_sm2.New().
+                WithPublicKey(k).
+                WithData(plaintext).
+                Encrypt()
+
+            return res.ToBytes(), res.Error().First()
+    }
+
+    return nil, ErrKeyType
+}
+
+// 私钥解密
+func decrypt(priv crypto.PrivateKey, ciphertext []byte) ([]byte, error) {
+    switch k := priv.(type) {
+        case *rsa.PrivateKey:
+            res := cryptobin_rsa.New().
+                WithPrivateKey(k).
+                WithData(ciphertext).
+                DecryptOAEP("SHA256")
+
+            return res.ToBytes(), res.Error().First()
+        case *sm2.PrivateKey:
+            res := cryptobin_sm2.New().
+                WithPrivateKey(k).
+                WithData(ciphertext).
+                Decrypt()
+
+            return res.ToBytes(), res.Error().First()
+    }
+
+    return nil, ErrKeyType
+}
@@ -0,0 +1,102 @@
+package keyring
+
+import (
+    "math/big"
+    "crypto/rsa"
+    "crypto/ecdsa"
+    "crypto/ed25519"
+    "encoding/json"
+    "encoding/base64"
+
+    "github.com/tjfoc/gmsm/sm2"
+)
+
+// JWK 公钥
+type JWK struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Use string `json:"use"`
+    Alg string `json:"alg"`
+
+    // RSA
+    N string `json:"n,omitempty"`
+    E string `json:"e,omitempty"`
+
+    // EC 及 OKP
+    Crv string `json:"crv,omitempty"`
+    X   string `json:"x,omitempty"`
+    Y   string `json:"y,omitempty"`
+}
+
+// JWK 集合
+type JWKSet struct {
+    Keys []JWK `json:"keys"`
+}
+
+// 转换为 JWK，DSA 没有 JWK 格式，返回 false
+func publicJWK(key *Key) (JWK, bool) {
+    jwk := JWK{
+        Kid: key.Kid,
+        Use: "sig",
+        Alg: key.Algorithm(),
+    }
+
+    switch k := key.Public().(type) {
+        case *rsa.PublicKey:
+            jwk.Kty = "RSA"
+            jwk.N = encodeSegment(k.N.Bytes())
+            jwk.E = encodeSegment(big.NewInt(int64(k.E)).Bytes())
+        case *ecdsa.PublicKey:
+            params := k.Curve.Params()
+
+            jwk.Kty = "EC"
+            jwk.Crv = params.Name
+            jwk.X, jwk.Y = encodePoint(k.X, k.Y, params.BitSize)
+        case ed25519.PublicKey:
+            jwk.Kty = "OKP"
+            jwk.Crv = "Ed25519"
+            jwk.X = encodeSegment(k)
+        case *sm2.PublicKey:
+            // 非标准曲线名称
+            jwk.Kty = "EC"
+            jwk.Crv = "SM2"
+            jwk.X, jwk.Y = encodePoint(k.X, k.Y, k.Curve.Params().BitSize)
+        default:
+            return jwk, false
+    }
+
+    return jwk, true
+}
+
+// MarshalJWKS encodes the public keys as a JSON Web Key Set,
+// DSA keys are skipped
+func MarshalJWKS(keys []*Key) ([]byte, error) {
+    set := JWKSet{
+        Keys: make([]JWK, 0, len(keys)),
+    }
+
+    for _, key := range keys {
+        if jwk, ok := publicJWK(key); ok {
+            set.Keys = append(set.Keys, jwk)
+        }
+    }
+
+    return json.Marshal(set)
+}
+
+// 坐标补齐到曲线长度
+func encodePoint(x, y *big.Int, bitSize int) (string, string) {
+    size := (bitSize + 7) / 8
+
+    xBytes := make([]byte, size)
+    yBytes := make([]byte, size)
+
+    x.FillBytes(xBytes)
+    y.FillBytes(yBytes)
+
+    return encodeSegment(xBytes), encodeSegment(yBytes)
+}
+
+func encodeSegment(data []byte) string {
+    return base64.RawURLEncoding.EncodeToString(data)
+}
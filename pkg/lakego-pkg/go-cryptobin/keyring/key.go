@@ -0,0 +1,172 @@
+package keyring
+
+import (
+    "io"
+    "time"
+    "crypto"
+    "crypto/dsa"
+    "crypto/rsa"
+    "crypto/ecdsa"
+    "crypto/sha256"
+    "crypto/ed25519"
+    "crypto/elliptic"
+    "encoding/hex"
+
+    "github.com/tjfoc/gmsm/sm2"
+
+    "github.com/deatil/go-cryptobin/jceks"
+)
+
+// 密钥状态
+type State string
+
+const (
+    // 当前使用的密钥，用于签名及加密
+    StateActive State = "active"
+
+    // 已停用的密钥，只用于验证及解密
+    StateRetired State = "retired"
+)
+
+// 签名算法名称，和 JWS 名称相同
+const (
+    AlgRS256 = "RS256"
+    AlgES256 = "ES256"
+    AlgES384 = "ES384"
+    AlgES512 = "ES512"
+    AlgEdDSA = "EdDSA"
+    AlgSM2   = "SM2"
+    AlgDSA   = "DSA"
+)
+
+/**
+ * 密钥环中的密钥
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Key struct {
+    // 密钥 ID
+    Kid string
+
+    // 版本，从 1 开始递增
+    Version int
+
+    // 状态
+    State State
+
+    // 创建时间
+    CreatedAt time.Time
+
+    // 停用时间，激活状态时为空
+    RetiredAt time.Time
+
+    // 私钥，支持 RSA, ECDSA, EdDSA, SM2 及 DSA
+    PrivateKey crypto.PrivateKey
+}
+
+// 公钥
+func (this *Key) Public() crypto.PublicKey {
+    switch k := this.PrivateKey.(type) {
+        case *rsa.PrivateKey:
+            return &k.PublicKey
+        case *ecdsa.PrivateKey:
+            return &k.PublicKey
+        case ed25519.PrivateKey:
+            return k.Public()
+        case *sm2.PrivateKey:
+            return &k.PublicKey
+        case *dsa.PrivateKey:
+            return &k.PublicKey
+    }
+
+    return nil
+}
+
+// 签名算法
+func (this *Key) Algorithm() string {
+    return keyAlgorithm(this.PrivateKey)
+}
+
+// 是否为激活状态
+func (this *Key) IsActive() bool {
+    return this.State == StateActive
+}
+
+// 签名算法
+func keyAlgorithm(priv crypto.PrivateKey) string {
+    switch k := priv.(type) {
+        case *rsa.PrivateKey:
+            return AlgRS256
+        case *ecdsa.PrivateKey:
+            switch k.Curve {
+                case elliptic.P384():
+                    return AlgES384
+                case elliptic.P521():
+                    return AlgES512
+            }
+
+            return AlgES256
+        case ed25519.PrivateKey:
+            return AlgEdDSA
+        case *sm2.PrivateKey:
+            return AlgSM2
+        case *dsa.PrivateKey:
+            return AlgDSA
+    }
+
+    return ""
+}
+
+// 密钥 ID，使用 PKCS#8 公钥的 SHA256 前 8 字节
+func keyID(priv crypto.PrivateKey) (string, error) {
+    key := &Key{
+        PrivateKey: priv,
+    }
+
+    pub := key.Public()
+    if pub == nil {
+        return "", ErrKeyType
+    }
+
+    der, err := jceks.MarshalPKCS8PublicKey(pub)
+    if err != nil {
+        return "", err
+    }
+
+    sum := sha256.Sum256(der)
+
+    return hex.EncodeToString(sum[:8]), nil
+}
+
+// 生成和 priv 相同类型及参数的密钥
+func generateLike(random io.Reader, priv crypto.PrivateKey) (crypto.PrivateKey, error) {
+    switch k := priv.(type) {
+        case *rsa.PrivateKey:
+            return rsa.GenerateKey(random, k.N.BitLen())
+        case *ecdsa.PrivateKey:
+            return ecdsa.GenerateKey(k.Curve, random)
+        case ed25519.PrivateKey:
+            _, newKey, err := ed25519.GenerateKey(random)
+            return newKey, err
+        case *sm2.PrivateKey:
+            return sm2.GenerateKey(random)
+        case *dsa.PrivateKey:
+            newKey := &dsa.PrivateKey{}
+            newKey.Parameters = k.Parameters
+
+            if err := dsa.GenerateKey(newKey, random); err != nil {
+                return nil, err
+            }
+
+            return newKey, nil
+    }
+
+    return nil, ErrKeyType
+}
+
+// 复制
+func (this *Key) clone() *Key {
+    key := *this
+    return &key
+}
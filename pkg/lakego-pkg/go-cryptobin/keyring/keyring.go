@@ -0,0 +1,410 @@
+package keyring
+
+import (
+    "io"
+    "sort"
+    "sync"
+    "time"
+    "errors"
+    "crypto"
+    "crypto/rand"
+)
+
+var (
+    ErrKeyType     = errors.New("keyring: unsupported key type")
+    ErrKeyNotFound = errors.New("keyring: key not found")
+    ErrKeyExists   = errors.New("keyring: key already exists")
+    ErrNoActiveKey = errors.New("keyring: no active key")
+    ErrKeyActive   = errors.New("keyring: active key can not be removed")
+    ErrVerify      = errors.New("keyring: signature verification failed")
+    ErrDecrypt     = errors.New("keyring: decryption failed")
+)
+
+// Keyring 接口
+type Keyring interface {
+    // 当前激活的密钥
+    Active() (*Key, error)
+
+    // 根据密钥 ID 获取密钥
+    Get(kid string) (*Key, error)
+
+    // 全部密钥，按版本从新到旧排序
+    List() []*Key
+
+    // 添加密钥作为新版本并激活，原激活密钥变为停用状态
+    Add(priv crypto.PrivateKey) (*Key, error)
+
+    // 生成和当前激活密钥相同类型的新密钥并激活
+    Rotate() (*Key, error)
+
+    // 停用密钥，不会激活其他密钥
+    Retire(kid string) error
+
+    // 删除停用状态的密钥
+    Remove(kid string) error
+
+    // 使用激活密钥签名
+    Sign(data []byte) (kid string, sig []byte, err error)
+
+    // 验证签名，kid 为空时依次尝试全部密钥
+    Verify(kid string, data, sig []byte) error
+
+    // 使用激活密钥加密，只支持 RSA 及 SM2
+    Encrypt(plaintext []byte) (kid string, ciphertext []byte, err error)
+
+    // 解密，kid 为空时依次尝试全部密钥
+    Decrypt(kid string, ciphertext []byte) ([]byte, error)
+
+    // 导出 JWKS 格式公钥
+    JWKS() ([]byte, error)
+}
+
+// 配置
+type Options struct {
+    // 存储，为空时只保存在内存中
+    Store Store
+
+    // 生成新密钥，为空时生成和激活密钥相同类型的密钥
+    Generate func(random io.Reader) (crypto.PrivateKey, error)
+
+    // 随机数，为空时使用 crypto/rand
+    Rand io.Reader
+}
+
+/**
+ * 密钥环
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type keyring struct {
+    mu   sync.RWMutex
+    keys []*Key
+    opts Options
+}
+
+// New returns a Keyring, keys are loaded from the store when set
+func New(opts ...Options) (Keyring, error) {
+    var opt Options
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    if opt.Rand == nil {
+        opt.Rand = rand.Reader
+    }
+
+    ring := &keyring{
+        opts: opt,
+    }
+
+    if opt.Store != nil {
+        keys, err := opt.Store.Load()
+        if err != nil {
+            return nil, err
+        }
+
+        ring.keys = keys
+        ring.sortKeys()
+    }
+
+    return ring, nil
+}
+
+// 当前激活的密钥
+func (this *keyring) Active() (*Key, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    key, err := this.active()
+    if err != nil {
+        return nil, err
+    }
+
+    return key.clone(), nil
+}
+
+// 根据密钥 ID 获取密钥
+func (this *keyring) Get(kid string) (*Key, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    key, err := this.get(kid)
+    if err != nil {
+        return nil, err
+    }
+
+    return key.clone(), nil
+}
+
+// 全部密钥
+func (this *keyring) List() []*Key {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    keys := make([]*Key, 0, len(this.keys))
+    for _, key := range this.keys {
+        keys = append(keys, key.clone())
+    }
+
+    return keys
+}
+
+// 添加密钥并激活
+func (this *keyring) Add(priv crypto.PrivateKey) (*Key, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.add(priv)
+}
+
+// 生成新密钥并激活
+func (this *keyring) Rotate() (*Key, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    var priv crypto.PrivateKey
+    var err error
+
+    if this.opts.Generate != nil {
+        priv, err = this.opts.Generate(this.opts.Rand)
+    } else {
+        active, activeErr := this.active()
+        if activeErr != nil {
+            return nil, activeErr
+        }
+
+        priv, err = generateLike(this.opts.Rand, active.PrivateKey)
+    }
+
+    if err != nil {
+        return nil, err
+    }
+
+    return this.add(priv)
+}
+
+// 停用密钥
+func (this *keyring) Retire(kid string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    key, err := this.get(kid)
+    if err != nil {
+        return err
+    }
+
+    if key.State == StateRetired {
+        return nil
+    }
+
+    key.State = StateRetired
+    key.RetiredAt = time.Now()
+
+    if err := this.save(); err != nil {
+        key.State = StateActive
+        key.RetiredAt = time.Time{}
+
+        return err
+    }
+
+    return nil
+}
+
+// 删除密钥
+func (this *keyring) Remove(kid string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    for i, key := range this.keys {
+        if key.Kid != kid {
+            continue
+        }
+
+        if key.IsActive() {
+            return ErrKeyActive
+        }
+
+        keys := this.keys
+        this.keys = append(append([]*Key{}, keys[:i]...), keys[i+1:]...)
+
+        if err := this.save(); err != nil {
+            this.keys = keys
+            return err
+        }
+
+        return nil
+    }
+
+    return ErrKeyNotFound
+}
+
+// 使用激活密钥签名
+func (this *keyring) Sign(data []byte) (string, []byte, error) {
+    key, err := this.Active()
+    if err != nil {
+        return "", nil, err
+    }
+
+    sig, err := sign(key.PrivateKey, data)
+    if err != nil {
+        return "", nil, err
+    }
+
+    return key.Kid, sig, nil
+}
+
+// 验证签名
+func (this *keyring) Verify(kid string, data, sig []byte) error {
+    keys, err := this.candidates(kid)
+    if err != nil {
+        return err
+    }
+
+    for _, key := range keys {
+        if verify(key.Public(), data, sig) == nil {
+            return nil
+        }
+    }
+
+    return ErrVerify
+}
+
+// 使用激活密钥加密
+func (this *keyring) Encrypt(plaintext []byte) (string, []byte, error) {
+    key, err := this.Active()
+    if err != nil {
+        return "", nil, err
+    }
+
+    ciphertext, err := encrypt(key.Public(), plaintext)
+    if err != nil {
+        return "", nil, err
+    }
+
+    return key.Kid, ciphertext, nil
+}
+
+// 解密
+func (this *keyring) Decrypt(kid string, ciphertext []byte) ([]byte, error) {
+    keys, err := this.candidates(kid)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, key := range keys {
+        if plaintext, err := decrypt(key.PrivateKey, ciphertext); err == nil {
+            return plaintext, nil
+        }
+    }
+
+    return nil, ErrDecrypt
+}
+
+// 导出 JWKS
+func (this *keyring) JWKS() ([]byte, error) {
+    return MarshalJWKS(this.List())
+}
+
+// 需要尝试的密钥，kid 为空时为全部密钥
+func (this *keyring) candidates(kid string) ([]*Key, error) {
+    if kid == "" {
+        keys := this.List()
+        if len(keys) == 0 {
+            return nil, ErrKeyNotFound
+        }
+
+        return keys, nil
+    }
+
+    key, err := this.Get(kid)
+    if err != nil {
+        return nil, err
+    }
+
+    return []*Key{key}, nil
+}
+
+func (this *keyring) active() (*Key, error) {
+    for _, key := range this.keys {
+        if key.IsActive() {
+            return key, nil
+        }
+    }
+
+    return nil, ErrNoActiveKey
+}
+
+func (this *keyring) get(kid string) (*Key, error) {
+    for _, key := range this.keys {
+        if key.Kid == kid {
+            return key, nil
+        }
+    }
+
+    return nil, ErrKeyNotFound
+}
+
+func (this *keyring) add(priv crypto.PrivateKey) (*Key, error) {
+    kid, err := keyID(priv)
+    if err != nil {
+        return nil, err
+    }
+
+    if _, err := this.get(kid); err == nil {
+        return nil, ErrKeyExists
+    }
+
+    version := 1
+    if len(this.keys) > 0 {
+        version = this.keys[0].Version + 1
+    }
+
+    now := time.Now()
+
+    // 原激活密钥变为停用状态
+    prev, _ := this.active()
+    if prev != nil {
+        prev.State = StateRetired
+        prev.RetiredAt = now
+    }
+
+    key := &Key{
+        Kid:        kid,
+        Version:    version,
+        State:      StateActive,
+        CreatedAt:  now,
+        PrivateKey: priv,
+    }
+
+    this.keys = append([]*Key{key}, this.keys...)
+
+    if err := this.save(); err != nil {
+        this.keys = this.keys[1:]
+
+        if prev != nil {
+            prev.State = StateActive
+            prev.RetiredAt = time.Time{}
+        }
+
+        return nil, err
+    }
+
+    return key.clone(), nil
+}
+
+// 保存到存储
+func (this *keyring) save() error {
+    if this.opts.Store == nil {
+        return nil
+    }
+
+    return this.opts.Store.Save(this.keys)
+}
+
+// 按版本从新到旧排序
+func (this *keyring) sortKeys() {
+    sort.SliceStable(this.keys, func(i, j int) bool {
+        return this.keys[i].Version > this.keys[j].Version
+    })
+}
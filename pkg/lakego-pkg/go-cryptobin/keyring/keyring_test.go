@@ -0,0 +1,279 @@
+package keyring
+
+import (
+    "testing"
+    "crypto/rsa"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/ed25519"
+    "crypto/elliptic"
+    "encoding/json"
+
+    "github.com/tjfoc/gmsm/sm2"
+)
+
+var testData = []byte("keyring test data")
+
+func TestKeyringRotate(t *testing.T) {
+    ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ring, err := New()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, _, err := ring.Sign(testData); err != ErrNoActiveKey {
+        t.Errorf("Sign got %v, want ErrNoActiveKey", err)
+    }
+
+    first, err := ring.Add(ecKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if first.Version != 1 || !first.IsActive() || first.Algorithm() != AlgES256 {
+        t.Errorf("first key got version %d, state %s, alg %s", first.Version, first.State, first.Algorithm())
+    }
+
+    if _, err := ring.Add(ecKey); err != ErrKeyExists {
+        t.Errorf("Add got %v, want ErrKeyExists", err)
+    }
+
+    kid, sig, err := ring.Sign(testData)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if kid != first.Kid {
+        t.Errorf("Sign kid got %s, want %s", kid, first.Kid)
+    }
+
+    second, err := ring.Rotate()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if second.Version != 2 || second.Algorithm() != AlgES256 {
+        t.Errorf("second key got version %d, alg %s", second.Version, second.Algorithm())
+    }
+
+    // 原激活密钥变为停用状态，仍可验证
+    old, err := ring.Get(first.Kid)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if old.IsActive() || old.RetiredAt.IsZero() {
+        t.Errorf("old key got state %s", old.State)
+    }
+
+    if err := ring.Verify(first.Kid, testData, sig); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ring.Verify("", testData, sig); err != nil {
+        t.Fatal(err)
+    }
+
+    // 错误的数据或者签名
+    if err := ring.Verify(first.Kid, []byte("other data"), sig); err != ErrVerify {
+        t.Errorf("Verify got %v, want ErrVerify", err)
+    }
+
+    if err := ring.Verify(second.Kid, testData, sig); err != ErrVerify {
+        t.Errorf("Verify got %v, want ErrVerify", err)
+    }
+
+    if err := ring.Verify("unknown", testData, sig); err != ErrKeyNotFound {
+        t.Errorf("Verify got %v, want ErrKeyNotFound", err)
+    }
+
+    keys := ring.List()
+    if len(keys) != 2 || keys[0].Kid != second.Kid || keys[1].Kid != first.Kid {
+        t.Errorf("List should sort keys from new to old")
+    }
+
+    // 激活密钥不能删除
+    if err := ring.Remove(second.Kid); err != ErrKeyActive {
+        t.Errorf("Remove got %v, want ErrKeyActive", err)
+    }
+
+    if err := ring.Remove(first.Kid); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ring.Verify("", testData, sig); err != ErrVerify {
+        t.Errorf("Verify got %v, want ErrVerify", err)
+    }
+
+    // 停用后没有激活密钥
+    if err := ring.Retire(second.Kid); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := ring.Active(); err != ErrNoActiveKey {
+        t.Errorf("Active got %v, want ErrNoActiveKey", err)
+    }
+}
+
+func TestKeyringSign(t *testing.T) {
+    rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    _, edKey, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    sm2Key, err := sm2.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ring, err := New()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    for _, priv := range []any{rsaKey, edKey, sm2Key, ecKey} {
+        key, err := ring.Add(priv)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        kid, sig, err := ring.Sign(testData)
+        if err != nil {
+            t.Fatalf("%s: %v", key.Algorithm(), err)
+        }
+
+        if err := ring.Verify(kid, testData, sig); err != nil {
+            t.Fatalf("%s: %v", key.Algorithm(), err)
+        }
+
+        tampered := append([]byte{}, sig...)
+        tampered[len(tampered) / 2] ^= 0x01
+
+        if err := ring.Verify(kid, testData, tampered); err != ErrVerify {
+            t.Errorf("%s: Verify got %v, want ErrVerify", key.Algorithm(), err)
+        }
+    }
+
+    // JWKS 包含全部密钥
+    data, err := ring.JWKS()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var set JWKSet
+    if err := json.Unmarshal(data, &set); err != nil {
+        t.Fatal(err)
+    }
+
+    if len(set.Keys) != 4 {
+        t.Fatalf("JWKS got %d keys, want 4", len(set.Keys))
+    }
+
+    kids := make(map[string]bool)
+    for _, key := range ring.List() {
+        kids[key.Kid] = true
+    }
+
+    for _, jwk := range set.Keys {
+        if !kids[jwk.Kid] {
+            t.Errorf("JWKS has unknown kid %s", jwk.Kid)
+        }
+    }
+}
+
+func TestKeyringEncrypt(t *testing.T) {
+    rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    sm2Key, err := sm2.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ring, err := New()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var ciphertexts [][]byte
+
+    for _, priv := range []any{rsaKey, sm2Key} {
+        if _, err := ring.Add(priv); err != nil {
+            t.Fatal(err)
+        }
+
+        kid, ciphertext, err := ring.Encrypt(testData)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        plaintext, err := ring.Decrypt(kid, ciphertext)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if string(plaintext) != string(testData) {
+            t.Errorf("Decrypt got %q, want %q", plaintext, testData)
+        }
+
+        ciphertexts = append(ciphertexts, ciphertext)
+    }
+
+    // 停用的密钥可以解密
+    for _, ciphertext := range ciphertexts {
+        if _, err := ring.Decrypt("", ciphertext); err != nil {
+            t.Fatal(err)
+        }
+
+        tampered := append([]byte{}, ciphertext...)
+        tampered[len(tampered) - 1] ^= 0x01
+
+        if _, err := ring.Decrypt("", tampered); err != ErrDecrypt {
+            t.Errorf("Decrypt got %v, want ErrDecrypt", err)
+        }
+    }
+
+    // 其他密钥
+    other, err := New()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := other.Add(rsaKey); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := other.Decrypt("", ciphertexts[1]); err != ErrDecrypt {
+        t.Errorf("Decrypt got %v, want ErrDecrypt", err)
+    }
+
+    // ed25519 不支持加密
+    _, edKey, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := ring.Add(edKey); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, _, err := ring.Encrypt(testData); err == nil {
+        t.Error("Encrypt should fail with ed25519 key")
+    }
+}
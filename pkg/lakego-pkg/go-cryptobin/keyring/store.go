@@ -0,0 +1,183 @@
+package keyring
+
+import (
+    "io"
+    "os"
+    "time"
+    "errors"
+    "crypto"
+    "path/filepath"
+    "encoding/pem"
+    "encoding/json"
+
+    "github.com/deatil/go-cryptobin/jceks"
+    "github.com/deatil/go-cryptobin/pkcs8"
+)
+
+var (
+    ErrStoreKid = errors.New("keyring: stored key does not match its kid")
+    ErrStoreAlg = errors.New("keyring: unsupported stored key algorithm")
+)
+
+// 存储接口
+type Store interface {
+    // 读取全部密钥
+    Load() ([]*Key, error)
+
+    // 保存全部密钥
+    Save(keys []*Key) error
+}
+
+// 私钥加密默认配置
+var DefaultStoreOpts = pkcs8.Opts{
+    Cipher:  pkcs8.AES256CBC,
+    KDFOpts: pkcs8.PBKDF2Opts{
+        SaltSize:       16,
+        IterationCount: 100000,
+        HMACHash:       pkcs8.SHA256,
+    },
+}
+
+// 密钥信息
+type keyMeta struct {
+    Kid       string    `json:"kid"`
+    Version   int       `json:"version"`
+    State     State     `json:"state"`
+    Alg       string    `json:"alg"`
+    CreatedAt time.Time `json:"created_at"`
+    RetiredAt time.Time `json:"retired_at"`
+}
+
+func newKeyMeta(key *Key) keyMeta {
+    return keyMeta{
+        Kid:       key.Kid,
+        Version:   key.Version,
+        State:     key.State,
+        Alg:       key.Algorithm(),
+        CreatedAt: key.CreatedAt,
+        RetiredAt: key.RetiredAt,
+    }
+}
+
+// 生成密钥
+func (this keyMeta) key(priv crypto.PrivateKey) *Key {
+    return &Key{
+        Kid:        this.Kid,
+        Version:    this.Version,
+        State:      this.State,
+        CreatedAt:  this.CreatedAt,
+        RetiredAt:  this.RetiredAt,
+        PrivateKey: priv,
+    }
+}
+
+// 按算法解析，P-256 和 SM2 的 PKCS#8 编码不能只靠 OID 区分
+func storeKeyType(alg string) (jceks.Key, error) {
+    switch alg {
+        case AlgRS256:
+            return jceks.KeyRsa{}, nil
+        case AlgES256, AlgES384, AlgES512:
+            return jceks.KeyEcdsa{}, nil
+        case AlgEdDSA:
+            return jceks.KeyEdDSA{}, nil
+        case AlgSM2:
+            return jceks.KeySM2{}, nil
+        case AlgDSA:
+            return jceks.KeyDSA{}, nil
+    }
+
+    return nil, ErrStoreAlg
+}
+
+// 加密私钥，返回 ENCRYPTED PRIVATE KEY 数据
+func encryptStoreKey(random io.Reader, key *Key, password []byte, opts pkcs8.Opts) (*pem.Block, error) {
+    keyType, err := storeKeyType(key.Algorithm())
+    if err != nil {
+        return nil, err
+    }
+
+    der, err := keyType.MarshalPKCS8PrivateKey(key.PrivateKey)
+    if err != nil {
+        return nil, err
+    }
+
+    return pkcs8.EncryptPKCS8PrivateKey(random, "ENCRYPTED PRIVATE KEY", der, password, opts)
+}
+
+// 解密私钥并检测密钥 ID
+func decryptStoreKey(meta keyMeta, block *pem.Block, password []byte) (*Key, error) {
+    keyType, err := storeKeyType(meta.Alg)
+    if err != nil {
+        return nil, err
+    }
+
+    der, err := pkcs8.DecryptPEMBlock(block, password)
+    if err != nil {
+        return nil, err
+    }
+
+    priv, err := keyType.ParsePKCS8PrivateKey(der)
+    if err != nil {
+        return nil, err
+    }
+
+    kid, err := keyID(priv)
+    if err != nil {
+        return nil, err
+    }
+
+    if kid != meta.Kid {
+        return nil, ErrStoreKid
+    }
+
+    return meta.key(priv), nil
+}
+
+// 写入文件，先写临时文件再重命名
+func writeFileAtomic(name string, data []byte) error {
+    tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name) + ".tmp*")
+    if err != nil {
+        return err
+    }
+
+    tmpName := tmp.Name()
+
+    if err := tmp.Chmod(0600); err != nil {
+        tmp.Close()
+        os.Remove(tmpName)
+        return err
+    }
+
+    if _, err := tmp.Write(data); err != nil {
+        tmp.Close()
+        os.Remove(tmpName)
+        return err
+    }
+
+    if err := tmp.Sync(); err != nil {
+        tmp.Close()
+        os.Remove(tmpName)
+        return err
+    }
+
+    if err := tmp.Close(); err != nil {
+        os.Remove(tmpName)
+        return err
+    }
+
+    if err := os.Rename(tmpName, name); err != nil {
+        os.Remove(tmpName)
+        return err
+    }
+
+    return nil
+}
+
+func marshalMetas(keys []*Key) ([]byte, error) {
+    metas := make([]keyMeta, 0, len(keys))
+    for _, key := range keys {
+        metas = append(metas, newKeyMeta(key))
+    }
+
+    return json.MarshalIndent(metas, "", "    ")
+}
@@ -0,0 +1,152 @@
+package keyring
+
+import (
+    "os"
+    "errors"
+    "crypto/rand"
+    "path/filepath"
+    "encoding/pem"
+    "encoding/json"
+
+    "github.com/deatil/go-cryptobin/pkcs8"
+)
+
+// 密钥信息文件名
+const fileStoreMeta = "keyring.json"
+
+/**
+ * 文件存储，每个私钥保存为加密的 PKCS#8 PEM 文件，
+ * 密钥信息保存在 keyring.json 中
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FileStore struct {
+    dir      string
+    password []byte
+    opts     pkcs8.Opts
+}
+
+// NewFileStore returns a Store that keeps keys in dir, private keys
+// are encrypted with password using DefaultStoreOpts unless opts is set
+func NewFileStore(dir string, password []byte, opts ...pkcs8.Opts) *FileStore {
+    opt := DefaultStoreOpts
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    return &FileStore{
+        dir:      dir,
+        password: password,
+        opts:     opt,
+    }
+}
+
+// 读取密钥，目录不存在时为空
+func (this *FileStore) Load() ([]*Key, error) {
+    metas, err := this.loadMetas()
+    if err != nil {
+        return nil, err
+    }
+
+    keys := make([]*Key, 0, len(metas))
+    for _, meta := range metas {
+        pemData, err := os.ReadFile(this.keyFile(meta.Kid))
+        if err != nil {
+            return nil, err
+        }
+
+        block, _ := pem.Decode(pemData)
+        if block == nil {
+            return nil, errors.New("keyring: invalid pem file for key " + meta.Kid)
+        }
+
+        key, err := decryptStoreKey(meta, block, this.password)
+        if err != nil {
+            return nil, err
+        }
+
+        keys = append(keys, key)
+    }
+
+    return keys, nil
+}
+
+// 保存密钥，已存在的私钥文件不会重写
+// 只删除原 keyring.json 中有而新密钥中没有的私钥文件
+func (this *FileStore) Save(keys []*Key) error {
+    if err := os.MkdirAll(this.dir, 0700); err != nil {
+        return err
+    }
+
+    prevs, err := this.loadMetas()
+    if err != nil {
+        return err
+    }
+
+    kids := make(map[string]bool, len(keys))
+
+    for _, key := range keys {
+        kids[key.Kid] = true
+
+        name := this.keyFile(key.Kid)
+        if _, err := os.Stat(name); err == nil {
+            continue
+        }
+
+        block, err := encryptStoreKey(rand.Reader, key, this.password, this.opts)
+        if err != nil {
+            return err
+        }
+
+        if err := writeFileAtomic(name, pem.EncodeToMemory(block)); err != nil {
+            return err
+        }
+    }
+
+    data, err := marshalMetas(keys)
+    if err != nil {
+        return err
+    }
+
+    if err := writeFileAtomic(filepath.Join(this.dir, fileStoreMeta), data); err != nil {
+        return err
+    }
+
+    // 删除已移除密钥的私钥文件，目录中的其他文件不处理
+    for _, prev := range prevs {
+        if kids[prev.Kid] {
+            continue
+        }
+
+        err := os.Remove(this.keyFile(prev.Kid))
+        if err != nil && !errors.Is(err, os.ErrNotExist) {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 读取密钥信息，文件不存在时为空
+func (this *FileStore) loadMetas() ([]keyMeta, error) {
+    data, err := os.ReadFile(filepath.Join(this.dir, fileStoreMeta))
+    if err != nil {
+        if errors.Is(err, os.ErrNotExist) {
+            return nil, nil
+        }
+
+        return nil, err
+    }
+
+    var metas []keyMeta
+    if err := json.Unmarshal(data, &metas); err != nil {
+        return nil, err
+    }
+
+    return metas, nil
+}
+
+func (this *FileStore) keyFile(kid string) string {
+    return filepath.Join(this.dir, kid + ".pem")
+}
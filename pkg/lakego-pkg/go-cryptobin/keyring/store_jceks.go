@@ -0,0 +1,123 @@
+package keyring
+
+import (
+    "os"
+    "errors"
+    "crypto/rand"
+    "encoding/pem"
+    "encoding/json"
+
+    "github.com/deatil/go-cryptobin/jceks"
+    "github.com/deatil/go-cryptobin/pkcs8"
+)
+
+// 密钥信息别名
+const jceksStoreMeta = "keyring.meta"
+
+/**
+ * JCEKS 存储，每个私钥以密钥 ID 为别名保存为 SecretKey 条目，
+ * 条目内容为加密的 PKCS#8 私钥
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type JCEKSStore struct {
+    path     string
+    password []byte
+    opts     pkcs8.Opts
+}
+
+// NewJCEKSStore returns a Store that keeps keys in the JCEKS file at path
+func NewJCEKSStore(path string, password []byte, opts ...pkcs8.Opts) *JCEKSStore {
+    opt := DefaultStoreOpts
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    return &JCEKSStore{
+        path:     path,
+        password: password,
+        opts:     opt,
+    }
+}
+
+// 读取密钥，文件不存在时为空
+func (this *JCEKSStore) Load() ([]*Key, error) {
+    data, err := os.ReadFile(this.path)
+    if err != nil {
+        if errors.Is(err, os.ErrNotExist) {
+            return nil, nil
+        }
+
+        return nil, err
+    }
+
+    ks, err := jceks.LoadJceksFromBytes(data, string(this.password))
+    if err != nil {
+        return nil, err
+    }
+
+    metaData, err := ks.GetSecretKey(jceksStoreMeta, string(this.password))
+    if err != nil {
+        return nil, err
+    }
+
+    var metas []keyMeta
+    if err := json.Unmarshal(metaData, &metas); err != nil {
+        return nil, err
+    }
+
+    keys := make([]*Key, 0, len(metas))
+    for _, meta := range metas {
+        der, err := ks.GetSecretKey(meta.Kid, string(this.password))
+        if err != nil {
+            return nil, err
+        }
+
+        block := &pem.Block{
+            Type:  "ENCRYPTED PRIVATE KEY",
+            Bytes: der,
+        }
+
+        key, err := decryptStoreKey(meta, block, this.password)
+        if err != nil {
+            return nil, err
+        }
+
+        keys = append(keys, key)
+    }
+
+    return keys, nil
+}
+
+// 保存密钥
+func (this *JCEKSStore) Save(keys []*Key) error {
+    ks := jceks.NewJCEKS()
+
+    for _, key := range keys {
+        block, err := encryptStoreKey(rand.Reader, key, this.password, this.opts)
+        if err != nil {
+            return err
+        }
+
+        if err := ks.AddSecretKey(key.Kid, block.Bytes, string(this.password)); err != nil {
+            return err
+        }
+    }
+
+    metaData, err := marshalMetas(keys)
+    if err != nil {
+        return err
+    }
+
+    if err := ks.AddSecretKey(jceksStoreMeta, metaData, string(this.password)); err != nil {
+        return err
+    }
+
+    data, err := ks.Marshal(string(this.password))
+    if err != nil {
+        return err
+    }
+
+    return writeFileAtomic(this.path, data)
+}
@@ -0,0 +1,268 @@
+package keyring
+
+import (
+    "os"
+    "testing"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "path/filepath"
+    "encoding/pem"
+
+    "github.com/tjfoc/gmsm/sm2"
+
+    "github.com/deatil/go-cryptobin/pkcs8"
+)
+
+var testStorePassword = []byte("store-password")
+
+// 测试使用较小的迭代次数
+var testStoreOpts = pkcs8.Opts{
+    Cipher:  pkcs8.AES256CBC,
+    KDFOpts: pkcs8.PBKDF2Opts{
+        SaltSize:       16,
+        IterationCount: 1000,
+        HMACHash:       pkcs8.SHA256,
+    },
+}
+
+// 生成包含 P256 及 SM2 密钥的密钥环
+func newTestStoreKeyring(t *testing.T, store Store) Keyring {
+    ring, err := New(Options{Store: store})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    sm2Key, err := sm2.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := ring.Add(ecKey); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := ring.Add(sm2Key); err != nil {
+        t.Fatal(err)
+    }
+
+    return ring
+}
+
+// 检测重新加载的密钥
+func testStoreReload(t *testing.T, ring Keyring, store Store) Keyring {
+    kid, sig, err := ring.Sign(testData)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    loaded, err := New(Options{Store: store})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    want := ring.List()
+    got := loaded.List()
+
+    if len(got) != len(want) {
+        t.Fatalf("Load got %d keys, want %d", len(got), len(want))
+    }
+
+    for i := range want {
+        if got[i].Kid != want[i].Kid || got[i].Version != want[i].Version || got[i].State != want[i].State {
+            t.Errorf("key %d got %s v%d %s, want %s v%d %s", i, got[i].Kid, got[i].Version, got[i].State, want[i].Kid, want[i].Version, want[i].State)
+        }
+
+        if got[i].Algorithm() != want[i].Algorithm() {
+            t.Errorf("key %d got alg %s, want %s", i, got[i].Algorithm(), want[i].Algorithm())
+        }
+    }
+
+    if err := loaded.Verify(kid, testData, sig); err != nil {
+        t.Fatal(err)
+    }
+
+    return loaded
+}
+
+func TestFileStore(t *testing.T) {
+    dir := t.TempDir()
+    store := NewFileStore(dir, testStorePassword, testStoreOpts)
+
+    ring := newTestStoreKeyring(t, store)
+    testStoreReload(t, ring, store)
+
+    // 私钥文件加密保存
+    for _, key := range ring.List() {
+        data, err := os.ReadFile(filepath.Join(dir, key.Kid + ".pem"))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        block, _ := pem.Decode(data)
+        if block == nil || block.Type != "ENCRYPTED PRIVATE KEY" {
+            t.Errorf("key file of %s is not encrypted", key.Kid)
+        }
+    }
+
+    // 错误密码
+    if _, err := New(Options{Store: NewFileStore(dir, []byte("wrong-password"), testStoreOpts)}); err == nil {
+        t.Error("Load should fail with wrong password")
+    }
+
+    // 删除的密钥文件
+    retired := ring.List()[1]
+    if err := ring.Remove(retired.Kid); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := os.Stat(filepath.Join(dir, retired.Kid + ".pem")); !os.IsNotExist(err) {
+        t.Errorf("key file of removed key should be deleted, got %v", err)
+    }
+
+    testStoreReload(t, ring, store)
+}
+
+func TestFileStoreKeepsOtherFiles(t *testing.T) {
+    dir := t.TempDir()
+    store := NewFileStore(dir, testStorePassword, testStoreOpts)
+
+    // 目录中原有的其他文件
+    others := map[string][]byte{
+        "cert.pem":             []byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"),
+        "0123456789abcdef.pem": []byte("other key"),
+        "README":               []byte("readme"),
+    }
+
+    for name, data := range others {
+        if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    ring := newTestStoreKeyring(t, store)
+
+    if _, err := ring.Rotate(); err != nil {
+        t.Fatal(err)
+    }
+
+    keys := ring.List()
+    if err := ring.Remove(keys[len(keys) - 1].Kid); err != nil {
+        t.Fatal(err)
+    }
+
+    for name, want := range others {
+        data, err := os.ReadFile(filepath.Join(dir, name))
+        if err != nil {
+            t.Fatalf("%s: %v", name, err)
+        }
+
+        if string(data) != string(want) {
+            t.Errorf("%s was changed", name)
+        }
+    }
+
+    testStoreReload(t, ring, store)
+}
+
+func TestFileStoreTampered(t *testing.T) {
+    dir := t.TempDir()
+    store := NewFileStore(dir, testStorePassword, testStoreOpts)
+
+    ring := newTestStoreKeyring(t, store)
+    keys := ring.List()
+
+    // 私钥文件换成其他密钥
+    name0 := filepath.Join(dir, keys[0].Kid + ".pem")
+    name1 := filepath.Join(dir, keys[1].Kid + ".pem")
+
+    data0, err := os.ReadFile(name0)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    data1, err := os.ReadFile(name1)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := os.WriteFile(name0, data1, 0600); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New(Options{Store: store}); err == nil {
+        t.Error("Load should fail with swapped key file")
+    }
+
+    // 截断的私钥文件
+    if err := os.WriteFile(name0, data0[:len(data0) / 2], 0600); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New(Options{Store: store}); err == nil {
+        t.Error("Load should fail with truncated key file")
+    }
+
+    // 私钥文件不存在
+    if err := os.Remove(name0); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New(Options{Store: store}); err == nil {
+        t.Error("Load should fail with missing key file")
+    }
+
+    // 恢复后可以读取
+    if err := os.WriteFile(name0, data0, 0600); err != nil {
+        t.Fatal(err)
+    }
+
+    testStoreReload(t, ring, store)
+
+    // 密钥信息损坏
+    if err := os.WriteFile(filepath.Join(dir, fileStoreMeta), []byte("[{"), 0600); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New(Options{Store: store}); err == nil {
+        t.Error("Load should fail with invalid keyring.json")
+    }
+}
+
+func TestJCEKSStore(t *testing.T) {
+    path := filepath.Join(t.TempDir(), "keyring.jceks")
+    store := NewJCEKSStore(path, testStorePassword, testStoreOpts)
+
+    ring := newTestStoreKeyring(t, store)
+    loaded := testStoreReload(t, ring, store)
+
+    if _, err := loaded.Rotate(); err != nil {
+        t.Fatal(err)
+    }
+
+    testStoreReload(t, loaded, store)
+
+    // 错误密码
+    if _, err := New(Options{Store: NewJCEKSStore(path, []byte("wrong-password"), testStoreOpts)}); err == nil {
+        t.Error("Load should fail with wrong password")
+    }
+
+    // 截断文件
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := os.WriteFile(path, data[:len(data) - 10], 0600); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := New(Options{Store: store}); err == nil {
+        t.Error("Load should fail with truncated file")
+    }
+}
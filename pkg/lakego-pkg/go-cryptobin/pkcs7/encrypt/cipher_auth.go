@@ -0,0 +1,158 @@
+package encrypt
+
+import (
+    "errors"
+    "crypto/cipher"
+    "encoding/asn1"
+
+    cryptobin_cipher "github.com/deatil/go-cryptobin/cipher"
+)
+
+// 认证加密参数
+// RFC 5084 GCMParameters 及 CCMParameters
+type authParams struct {
+    Nonce  []byte
+    ICVLen int `asn1:"optional,default:12"`
+}
+
+// gcm 认证加密
+type AuthCipherGCM struct {
+    cipherFunc func(key []byte) (cipher.Block, error)
+    keySize    int
+    nonceSize  int
+    tagSize    int
+    identifier asn1.ObjectIdentifier
+}
+
+// 值大小
+func (this AuthCipherGCM) KeySize() int {
+    return this.keySize
+}
+
+// oid
+func (this AuthCipherGCM) OID() asn1.ObjectIdentifier {
+    return this.identifier
+}
+
+// 加密
+func (this AuthCipherGCM) Encrypt(key, plaintext, additional []byte) ([]byte, []byte, []byte, error) {
+    return authSeal(this.newAEAD, key, this.nonceSize, this.tagSize, plaintext, additional)
+}
+
+// 解密
+func (this AuthCipherGCM) Decrypt(key, params, ciphertext, mac, additional []byte) ([]byte, error) {
+    return authOpen(this.newAEAD, key, params, ciphertext, mac, additional)
+}
+
+func (this AuthCipherGCM) newAEAD(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+    block, err := this.cipherFunc(key)
+    if err != nil {
+        return nil, err
+    }
+
+    if nonceSize == 12 {
+        return cipher.NewGCMWithTagSize(block, tagSize)
+    }
+
+    if tagSize != 16 {
+        return nil, errors.New("pkcs7: unsupported gcm nonce and tag size")
+    }
+
+    return cipher.NewGCMWithNonceSize(block, nonceSize)
+}
+
+// ccm 认证加密
+type AuthCipherCCM struct {
+    cipherFunc func(key []byte) (cipher.Block, error)
+    keySize    int
+    nonceSize  int
+    tagSize    int
+    identifier asn1.ObjectIdentifier
+}
+
+// 值大小
+func (this AuthCipherCCM) KeySize() int {
+    return this.keySize
+}
+
+// oid
+func (this AuthCipherCCM) OID() asn1.ObjectIdentifier {
+    return this.identifier
+}
+
+// 加密
+func (this AuthCipherCCM) Encrypt(key, plaintext, additional []byte) ([]byte, []byte, []byte, error) {
+    return authSeal(this.newAEAD, key, this.nonceSize, this.tagSize, plaintext, additional)
+}
+
+// 解密
+func (this AuthCipherCCM) Decrypt(key, params, ciphertext, mac, additional []byte) ([]byte, error) {
+    return authOpen(this.newAEAD, key, params, ciphertext, mac, additional)
+}
+
+func (this AuthCipherCCM) newAEAD(key []byte, nonceSize, tagSize int) (cipher.AEAD, error) {
+    block, err := this.cipherFunc(key)
+    if err != nil {
+        return nil, err
+    }
+
+    return cryptobin_cipher.NewCCMWithNonceAndTagSize(block, nonceSize, tagSize)
+}
+
+// 加密，密文和 mac 分开返回
+func authSeal(
+    newAEAD func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error),
+    key []byte,
+    nonceSize, tagSize int,
+    plaintext, additional []byte,
+) ([]byte, []byte, []byte, error) {
+    aead, err := newAEAD(key, nonceSize, tagSize)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+
+    nonce, err := genRandom(nonceSize)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+
+    sealed := aead.Seal(nil, nonce, plaintext, additional)
+
+    paramBytes, err := asn1.Marshal(authParams{
+        Nonce:  nonce,
+        ICVLen: tagSize,
+    })
+    if err != nil {
+        return nil, nil, nil, err
+    }
+
+    n := len(sealed) - tagSize
+
+    return sealed[:n], sealed[n:], paramBytes, nil
+}
+
+// 解密
+func authOpen(
+    newAEAD func(key []byte, nonceSize, tagSize int) (cipher.AEAD, error),
+    key, params, ciphertext, mac, additional []byte,
+) ([]byte, error) {
+    var param authParams
+    if _, err := asn1.Unmarshal(params, &param); err != nil {
+        return nil, errors.New("pkcs7: invalid auth cipher params")
+    }
+
+    if param.ICVLen != len(mac) {
+        return nil, errors.New("pkcs7: invalid mac size")
+    }
+
+    aead, err := newAEAD(key, len(param.Nonce), param.ICVLen)
+    if err != nil {
+        return nil, err
+    }
+
+    sealed := make([]byte, 0, len(ciphertext) + len(mac))
+    sealed = append(sealed, ciphertext...)
+    sealed = append(sealed, mac...)
+
+    return aead.Open(nil, param.Nonce, sealed, additional)
+}
@@ -0,0 +1,106 @@
+package encrypt
+
+import (
+    "crypto/aes"
+
+    "github.com/tjfoc/gmsm/sm4"
+)
+
+// 认证加密使用 RFC 5084 的参数格式
+// AuthAES128GCM is the 128-bit key AES cipher in GCM mode.
+var AuthAES128GCM = AuthCipherGCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    16,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES128GCM,
+}
+// AuthAES192GCM is the 192-bit key AES cipher in GCM mode.
+var AuthAES192GCM = AuthCipherGCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    24,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES192GCM,
+}
+// AuthAES256GCM is the 256-bit key AES cipher in GCM mode.
+var AuthAES256GCM = AuthCipherGCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    32,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES256GCM,
+}
+// AuthSM4GCM is the 128-bit key SM4 cipher in GCM mode.
+var AuthSM4GCM = AuthCipherGCM{
+    cipherFunc: sm4.NewCipher,
+    keySize:    16,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidSM4GCM,
+}
+
+// ==========
+
+// AuthAES128CCM is the 128-bit key AES cipher in CCM mode.
+var AuthAES128CCM = AuthCipherCCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    16,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES128CCM,
+}
+// AuthAES192CCM is the 192-bit key AES cipher in CCM mode.
+var AuthAES192CCM = AuthCipherCCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    24,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES192CCM,
+}
+// AuthAES256CCM is the 256-bit key AES cipher in CCM mode.
+var AuthAES256CCM = AuthCipherCCM{
+    cipherFunc: aes.NewCipher,
+    keySize:    32,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidAES256CCM,
+}
+// AuthSM4CCM is the 128-bit key SM4 cipher in CCM mode.
+var AuthSM4CCM = AuthCipherCCM{
+    cipherFunc: sm4.NewCipher,
+    keySize:    16,
+    nonceSize:  12,
+    tagSize:    16,
+    identifier: oidSM4CCM,
+}
+
+func init() {
+    // gcm
+    AddAuthCipher(oidAES128GCM, func() AuthCipher {
+        return AuthAES128GCM
+    })
+    AddAuthCipher(oidAES192GCM, func() AuthCipher {
+        return AuthAES192GCM
+    })
+    AddAuthCipher(oidAES256GCM, func() AuthCipher {
+        return AuthAES256GCM
+    })
+    AddAuthCipher(oidSM4GCM, func() AuthCipher {
+        return AuthSM4GCM
+    })
+
+    // ccm
+    AddAuthCipher(oidAES128CCM, func() AuthCipher {
+        return AuthAES128CCM
+    })
+    AddAuthCipher(oidAES192CCM, func() AuthCipher {
+        return AuthAES192CCM
+    })
+    AddAuthCipher(oidAES256CCM, func() AuthCipher {
+        return AuthAES256CCM
+    })
+    AddAuthCipher(oidSM4CCM, func() AuthCipher {
+        return AuthSM4CCM
+    })
+}
@@ -12,38 +12,115 @@ import (
     pkcs7_ber "github.com/deatil/go-cryptobin/pkcs7/ber"
 )
 
-// 解析
+// 解析，支持 EnvelopedData 及 AuthEnvelopedData
 func Decrypt(data []byte, cert *x509.Certificate, pkey crypto.PrivateKey) ([]byte, error) {
-    info, contentType, err := parseData(data)
+    env, err := parseEnveloped(data)
     if err != nil {
         return nil, err
     }
 
-    if !contentType.Equal(oidEnvelopedData) {
-        return nil, errors.New("pkcs7: contentType error")
-    }
-
-    var endata envelopedData
-    if _, err := asn1.Unmarshal(info, &endata); err != nil {
+    contentKey, err := decryptRecipientKey(env.recipients, cert, pkey)
+    if err != nil {
         return nil, err
     }
 
-    recipient := selectRecipientForCertificate(endata.RecipientInfos, cert)
-    if recipient.EncryptedKey == nil {
-        return nil, errors.New("pkcs7: no enveloped recipient for provided certificate")
+    return env.decrypt(contentKey)
+}
+
+// DecryptUsingPassword decrypts enveloped data for a password recipient
+func DecryptUsingPassword(data []byte, password []byte) ([]byte, error) {
+    env, err := parseEnveloped(data)
+    if err != nil {
+        return nil, err
     }
 
-    keyEncrypt, err := parseKeyEncrypt(recipient.KeyEncryptionAlgorithm)
+    contentKeys, err := decryptPasswordKey(env.recipients, password)
     if err != nil {
         return nil, err
     }
 
-    contentKey, err := keyEncrypt.Decrypt(recipient.EncryptedKey, pkey)
+    var content []byte
+    for _, contentKey := range contentKeys {
+        content, err = env.decrypt(contentKey)
+        if err == nil {
+            return content, nil
+        }
+    }
+
+    return nil, err
+}
+
+// 解析后的加密数据
+type parsedEnveloped struct {
+    recipients []asn1.RawValue
+    eci        encryptedContentInfo
+
+    // AuthEnvelopedData
+    auth      bool
+    authAttrs []byte
+    mac       []byte
+}
+
+// 解析 EnvelopedData 及 AuthEnvelopedData
+func parseEnveloped(data []byte) (*parsedEnveloped, error) {
+    info, contentType, err := parseData(data)
     if err != nil {
         return nil, err
     }
 
-    return encryptedContentInfoDecrypt(endata.EncryptedContentInfo, contentKey)
+    switch {
+        case contentType.Equal(oidEnvelopedData):
+            var endata envelopedDataWithRecipients
+            if _, err := asn1.Unmarshal(info, &endata); err != nil {
+                return nil, err
+            }
+
+            return &parsedEnveloped{
+                recipients: endata.RecipientInfos,
+                eci:        endata.EncryptedContentInfo,
+            }, nil
+
+        case contentType.Equal(oidAuthEnvelopedData):
+            var endata authEnvelopedData
+            if _, err := asn1.Unmarshal(info, &endata); err != nil {
+                return nil, err
+            }
+
+            return &parsedEnveloped{
+                recipients: endata.RecipientInfos,
+                eci:        endata.AuthEncryptedContentInfo,
+                auth:       true,
+                authAttrs:  endata.AuthAttrs.FullBytes,
+                mac:        endata.MAC,
+            }, nil
+    }
+
+    return nil, errors.New("pkcs7: contentType error")
+}
+
+// 解密内容
+func (this *parsedEnveloped) decrypt(key []byte) ([]byte, error) {
+    if !this.auth {
+        return encryptedContentInfoDecrypt(this.eci, key)
+    }
+
+    oid := this.eci.ContentEncryptionAlgorithm.Algorithm.String()
+    newCipher, ok := authCiphers[oid]
+    if !ok {
+        return nil, fmt.Errorf("pkcs7: unsupported auth cipher (OID: %s)", oid)
+    }
+
+    // RFC 5083, 认证属性使用 SET OF 编码参与认证
+    var additional []byte
+    if len(this.authAttrs) > 0 {
+        additional = make([]byte, len(this.authAttrs))
+        copy(additional, this.authAttrs)
+        additional[0] = 0x31
+    }
+
+    params := this.eci.ContentEncryptionAlgorithm.Parameters.FullBytes
+
+    return newCipher().Decrypt(key, params, encryptedContentBytes(this.eci), this.mac, additional)
 }
 
 // DecryptUsingPSK decrypts encrypted data using caller provided
@@ -67,6 +144,22 @@ func DecryptUsingPSK(data []byte, key []byte) ([]byte, error) {
 }
 
 func encryptedContentInfoDecrypt(eci encryptedContentInfo, key []byte) ([]byte, error) {
+    cyphertext := encryptedContentBytes(eci)
+
+    cipher, cipherParams, err := parseEncryptionScheme(eci.ContentEncryptionAlgorithm)
+    if err != nil {
+        return nil, err
+    }
+
+    decryptedKey, err := cipher.Decrypt(key, cipherParams, cyphertext)
+    if err != nil {
+        return nil, err
+    }
+
+    return decryptedKey, nil
+}
+
+func encryptedContentBytes(eci encryptedContentInfo) []byte {
     // EncryptedContent can either be constructed of multple OCTET STRINGs
     // or _be_ a tagged OCTET STRING
     var cyphertext []byte
@@ -88,17 +181,7 @@ func encryptedContentInfoDecrypt(eci encryptedContentInfo, key []byte) ([]byte,
         cyphertext = eci.EncryptedContent.Bytes
     }
 
-    cipher, cipherParams, err := parseEncryptionScheme(eci.ContentEncryptionAlgorithm)
-    if err != nil {
-        return nil, err
-    }
-
-    decryptedKey, err := cipher.Decrypt(key, cipherParams, cyphertext)
-    if err != nil {
-        return nil, err
-    }
-
-    return decryptedKey, nil
+    return cyphertext
 }
 
 func parseKeyEncrypt(keyEncrypt pkix.AlgorithmIdentifier) (KeyEncrypt, error) {
@@ -151,16 +234,6 @@ func parseData(data []byte) ([]byte, asn1.ObjectIdentifier, error) {
     return content, contentType, nil
 }
 
-func selectRecipientForCertificate(recipients []recipientInfo, cert *x509.Certificate) recipientInfo {
-    for _, recp := range recipients {
-        if isCertMatchForIssuerAndSerial(cert, recp.IssuerAndSerialNumber) {
-            return recp
-        }
-    }
-
-    return recipientInfo{}
-}
-
 func isCertMatchForIssuerAndSerial(cert *x509.Certificate, ias issuerAndSerial) bool {
     return cert.SerialNumber.Cmp(ias.SerialNumber) == 0 && bytes.Equal(cert.RawIssuer, ias.IssuerName.FullBytes)
 }
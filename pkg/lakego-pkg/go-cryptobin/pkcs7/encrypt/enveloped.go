@@ -0,0 +1,368 @@
+package encrypt
+
+import (
+    "errors"
+    "crypto"
+    "crypto/rsa"
+    "crypto/ecdh"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+
+    "github.com/tjfoc/gmsm/sm2"
+
+    "github.com/deatil/go-cryptobin/pkcs8"
+)
+
+var (
+    // RFC 5083 AuthEnvelopedData
+    oidAuthEnvelopedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 23}
+)
+
+// EnvelopedData，接收者使用 RawValue 保存
+type envelopedDataWithRecipients struct {
+    Version              int
+    OriginatorInfo       asn1.RawValue   `asn1:"optional,tag:0"`
+    RecipientInfos       []asn1.RawValue `asn1:"set"`
+    EncryptedContentInfo encryptedContentInfo
+    UnprotectedAttrs     asn1.RawValue   `asn1:"optional,tag:1"`
+}
+
+// AuthEnvelopedData
+type authEnvelopedData struct {
+    Version                  int
+    OriginatorInfo           asn1.RawValue   `asn1:"optional,tag:0"`
+    RecipientInfos           []asn1.RawValue `asn1:"set"`
+    AuthEncryptedContentInfo encryptedContentInfo
+    AuthAttrs                asn1.RawValue   `asn1:"optional,tag:1"`
+    MAC                      []byte
+    UnauthAttrs              asn1.RawValue   `asn1:"optional,tag:2"`
+}
+
+// 接收者配置
+type RecipientOpts struct {
+    // 公钥加密方式，RSA 及 SM2 证书使用，为空时按公钥选择
+    KeyEncrypt KeyEncrypt
+
+    // 密钥协商方式，EC 及 X25519 证书使用
+    // 为空时 EC 使用 KeyAgreeSHA256，X25519 使用 KeyAgreeHKDFSHA256
+    KeyAgree KeyAgree
+
+    // 密钥包装方式，为空时按内容密钥长度选择
+    KeyWrap KeyWrap
+
+    // 密钥协商使用的用户密钥材料
+    UKM []byte
+}
+
+// 密码接收者配置
+type PasswordOpts struct {
+    // 密钥加密，需要为 CBC 模式
+    Cipher Cipher
+
+    // 密钥派生
+    KDFOpts pkcs8.KDFOpts
+}
+
+// 默认密码接收者配置
+var DefaultPasswordOpts = PasswordOpts{
+    Cipher:  AES256CBC,
+    KDFOpts: pkcs8.PBKDF2Opts{
+        SaltSize:       16,
+        IterationCount: 10000,
+        HMACHash:       pkcs8.SHA256,
+    },
+}
+
+/**
+ * 多接收者加密数据，支持 EnvelopedData 及 AuthEnvelopedData
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type EnvelopedData struct {
+    content    []byte
+    cipher     Cipher
+    authCipher AuthCipher
+    key        []byte
+    recipients []asn1.RawValue
+    hasKARI    bool
+    hasPWRI    bool
+}
+
+// NewEnvelopedData creates an EnvelopedData for content encrypted with cipher
+func NewEnvelopedData(content []byte, cipher Cipher) (*EnvelopedData, error) {
+    if cipher == nil {
+        return nil, errors.New("pkcs7: cipher is empty")
+    }
+
+    key, err := genRandom(cipher.KeySize())
+    if err != nil {
+        return nil, err
+    }
+
+    return &EnvelopedData{
+        content: content,
+        cipher:  cipher,
+        key:     key,
+    }, nil
+}
+
+// NewAuthEnvelopedData creates an AuthEnvelopedData for content encrypted
+// with the authenticated cipher
+func NewAuthEnvelopedData(content []byte, cipher AuthCipher) (*EnvelopedData, error) {
+    if cipher == nil {
+        return nil, errors.New("pkcs7: cipher is empty")
+    }
+
+    key, err := genRandom(cipher.KeySize())
+    if err != nil {
+        return nil, err
+    }
+
+    return &EnvelopedData{
+        content:    content,
+        authCipher: cipher,
+        key:        key,
+    }, nil
+}
+
+// AddRecipient adds a recipient certificate. RSA and SM2 keys use key
+// transport, EC and X25519 keys use ephemeral-static key agreement.
+func (this *EnvelopedData) AddRecipient(cert *x509.Certificate, opts ...RecipientOpts) error {
+    return this.AddRecipients([]*x509.Certificate{cert}, opts...)
+}
+
+// AddRecipients adds recipient certificates. Key agreement recipients on
+// the same curve share one KeyAgreeRecipientInfo and ephemeral key.
+func (this *EnvelopedData) AddRecipients(certs []*x509.Certificate, opts ...RecipientOpts) error {
+    var opt RecipientOpts
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    type kariGroup struct {
+        certs []*x509.Certificate
+        pubs  []*ecdh.PublicKey
+    }
+
+    curves := make([]ecdh.Curve, 0)
+    groups := make(map[ecdh.Curve]*kariGroup)
+
+    for _, cert := range certs {
+        switch certPub := certPublicKey(cert).(type) {
+            case *rsa.PublicKey, *sm2.PublicKey:
+                keyEncrypt := opt.KeyEncrypt
+                if keyEncrypt == nil {
+                    keyEncrypt = KeyEncryptRSA
+                    if _, ok := certPub.(*sm2.PublicKey); ok {
+                        keyEncrypt = KeyEncryptSM2
+                    }
+                }
+
+                recipient, err := marshalKeyTransRecipient(cert, keyEncrypt, this.key)
+                if err != nil {
+                    return err
+                }
+
+                this.recipients = append(this.recipients, recipient)
+
+            default:
+                pub, err := ecdhPublicKey(certPub)
+                if err != nil {
+                    return err
+                }
+
+                group, ok := groups[pub.Curve()]
+                if !ok {
+                    group = &kariGroup{}
+                    groups[pub.Curve()] = group
+                    curves = append(curves, pub.Curve())
+                }
+
+                group.certs = append(group.certs, cert)
+                group.pubs = append(group.pubs, pub)
+        }
+    }
+
+    for _, curve := range curves {
+        keyAgree := opt.KeyAgree
+        if keyAgree == nil {
+            keyAgree = KeyAgreeSHA256
+            if curve == ecdh.X25519() {
+                keyAgree = KeyAgreeHKDFSHA256
+            }
+        }
+
+        keyWrap := opt.KeyWrap
+        if keyWrap == nil {
+            keyWrap = defaultKeyWrap(len(this.key))
+        }
+
+        group := groups[curve]
+
+        recipient, err := marshalKeyAgreeRecipient(group.certs, group.pubs, keyAgree, keyWrap, opt.UKM, this.key)
+        if err != nil {
+            return err
+        }
+
+        this.recipients = append(this.recipients, recipient)
+        this.hasKARI = true
+    }
+
+    return nil
+}
+
+// AddPasswordRecipient adds a recipient that derives the key encryption
+// key from a password, the default settings use PBKDF2 and AES-256-CBC.
+func (this *EnvelopedData) AddPasswordRecipient(password []byte, opts ...PasswordOpts) error {
+    opt := DefaultPasswordOpts
+    if len(opts) > 0 {
+        opt = opts[0]
+    }
+
+    keyCipher, ok := opt.Cipher.(CipherCBC)
+    if !ok {
+        return errors.New("pkcs7: password key encryption cipher must be in CBC mode")
+    }
+
+    if opt.KDFOpts == nil {
+        return errors.New("pkcs7: password kdfOpts is empty")
+    }
+
+    recipient, err := marshalPasswordRecipient(password, opt.KDFOpts, keyCipher, this.key)
+    if err != nil {
+        return err
+    }
+
+    this.recipients = append(this.recipients, recipient)
+    this.hasPWRI = true
+
+    return nil
+}
+
+// Finish encrypts the content and marshals the final structure
+func (this *EnvelopedData) Finish() ([]byte, error) {
+    if len(this.recipients) == 0 {
+        return nil, errors.New("pkcs7: no recipients")
+    }
+
+    var contentType asn1.ObjectIdentifier
+    var innerContent []byte
+    var err error
+
+    if this.authCipher != nil {
+        contentType = oidAuthEnvelopedData
+        innerContent, err = this.marshalAuthEnveloped()
+    } else {
+        contentType = oidEnvelopedData
+        innerContent, err = this.marshalEnveloped()
+    }
+
+    if err != nil {
+        return nil, err
+    }
+
+    wrapper := contentInfo{
+        ContentType: contentType,
+        Content:     asn1.RawValue{
+            Class: 2,
+            Tag: 0,
+            IsCompound: true,
+            Bytes: innerContent,
+        },
+    }
+
+    return asn1.Marshal(wrapper)
+}
+
+func (this *EnvelopedData) marshalEnveloped() ([]byte, error) {
+    encrypted, paramBytes, err := this.cipher.Encrypt(this.key, this.content)
+    if err != nil {
+        return nil, err
+    }
+
+    // RFC 5652 版本规则
+    version := 0
+    if this.hasPWRI {
+        version = 3
+    } else if this.hasKARI {
+        version = 2
+    }
+
+    envelope := envelopedDataWithRecipients{
+        Version:              version,
+        RecipientInfos:       this.recipients,
+        EncryptedContentInfo: encryptedContentInfo{
+            ContentType: oidData,
+            ContentEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+                Algorithm: this.cipher.OID(),
+                Parameters: asn1.RawValue{
+                    FullBytes: paramBytes,
+                },
+            },
+            EncryptedContent: asn1.RawValue{
+                Class: 2,
+                Tag: 0,
+                Bytes: encrypted,
+            },
+        },
+    }
+
+    return asn1.Marshal(envelope)
+}
+
+func (this *EnvelopedData) marshalAuthEnveloped() ([]byte, error) {
+    encrypted, mac, paramBytes, err := this.authCipher.Encrypt(this.key, this.content, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    envelope := authEnvelopedData{
+        Version:                  0,
+        RecipientInfos:           this.recipients,
+        AuthEncryptedContentInfo: encryptedContentInfo{
+            ContentType: oidData,
+            ContentEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+                Algorithm: this.authCipher.OID(),
+                Parameters: asn1.RawValue{
+                    FullBytes: paramBytes,
+                },
+            },
+            EncryptedContent: asn1.RawValue{
+                Class: 2,
+                Tag: 0,
+                Bytes: encrypted,
+            },
+        },
+        MAC: mac,
+    }
+
+    return asn1.Marshal(envelope)
+}
+
+// 证书公钥，x509 不解析证书中的 X25519 公钥
+func certPublicKey(cert *x509.Certificate) crypto.PublicKey {
+    if cert.PublicKey != nil {
+        return cert.PublicKey
+    }
+
+    pub, err := x509.ParsePKIXPublicKey(cert.RawSubjectPublicKeyInfo)
+    if err != nil {
+        return nil
+    }
+
+    return pub
+}
+
+// 按内容密钥长度选择密钥包装
+func defaultKeyWrap(keySize int) KeyWrap {
+    switch {
+        case keySize <= 16:
+            return KeyWrapAES128
+        case keySize <= 24:
+            return KeyWrapAES192
+    }
+
+    return KeyWrapAES256
+}
@@ -45,3 +45,63 @@ var keyens = make(map[string]func() KeyEncrypt)
 func AddkeyEncrypt(oid asn1.ObjectIdentifier, fn func() KeyEncrypt) {
     keyens[oid.String()] = fn
 }
+
+// 认证加密接口
+type AuthCipher interface {
+    // oid
+    OID() asn1.ObjectIdentifier
+
+    // 值大小
+    KeySize() int
+
+    // 加密, 返回: [加密后数据, mac, 参数, error]
+    Encrypt(key, plaintext, additional []byte) ([]byte, []byte, []byte, error)
+
+    // 解密
+    Decrypt(key, params, ciphertext, mac, additional []byte) ([]byte, error)
+}
+
+// 密钥协商 KDF 接口
+type KeyAgree interface {
+    // oid
+    OID() asn1.ObjectIdentifier
+
+    // 生成密钥加密密钥
+    DeriveKey(secret, ukm, sharedInfo []byte, size int) ([]byte, error)
+}
+
+// 密钥包装接口
+type KeyWrap interface {
+    // oid
+    OID() asn1.ObjectIdentifier
+
+    // 值大小
+    KeySize() int
+
+    // 包装
+    Wrap(kek, key []byte) ([]byte, error)
+
+    // 解包
+    Unwrap(kek, wrapped []byte) ([]byte, error)
+}
+
+var authCiphers = make(map[string]func() AuthCipher)
+
+// 添加认证加密
+func AddAuthCipher(oid asn1.ObjectIdentifier, cipher func() AuthCipher) {
+    authCiphers[oid.String()] = cipher
+}
+
+var keyAgrees = make(map[string]func() KeyAgree)
+
+// 添加密钥协商 KDF
+func AddKeyAgree(oid asn1.ObjectIdentifier, fn func() KeyAgree) {
+    keyAgrees[oid.String()] = fn
+}
+
+var keyWraps = make(map[string]func() KeyWrap)
+
+// 添加密钥包装
+func AddKeyWrap(oid asn1.ObjectIdentifier, fn func() KeyWrap) {
+    keyWraps[oid.String()] = fn
+}
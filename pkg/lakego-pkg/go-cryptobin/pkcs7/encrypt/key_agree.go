@@ -0,0 +1,87 @@
+package encrypt
+
+import (
+    "io"
+    "hash"
+    "errors"
+    "encoding/asn1"
+    "encoding/binary"
+    "crypto/x509/pkix"
+
+    "golang.org/x/crypto/hkdf"
+)
+
+// RFC 5753 ECC-CMS-SharedInfo
+type eccCMSSharedInfo struct {
+    KeyInfo     pkix.AlgorithmIdentifier
+    EntityUInfo []byte `asn1:"optional,explicit,tag:0"`
+    SuppPubInfo []byte `asn1:"explicit,tag:2"`
+}
+
+// 生成 SharedInfo
+func marshalSharedInfo(wrapOid asn1.ObjectIdentifier, ukm []byte, size int) ([]byte, error) {
+    suppPubInfo := make([]byte, 4)
+    binary.BigEndian.PutUint32(suppPubInfo, uint32(size * 8))
+
+    return asn1.Marshal(eccCMSSharedInfo{
+        KeyInfo:     pkix.AlgorithmIdentifier{
+            Algorithm: wrapOid,
+        },
+        EntityUInfo: ukm,
+        SuppPubInfo: suppPubInfo,
+    })
+}
+
+// X9.63 KDF 密钥协商
+type KeyAgreeWithX963 struct {
+    hashFunc   func() hash.Hash
+    identifier asn1.ObjectIdentifier
+}
+
+// oid
+func (this KeyAgreeWithX963) OID() asn1.ObjectIdentifier {
+    return this.identifier
+}
+
+// 生成密钥加密密钥
+// ukm 已经包含在 sharedInfo 中
+func (this KeyAgreeWithX963) DeriveKey(secret, ukm, sharedInfo []byte, size int) ([]byte, error) {
+    h := this.hashFunc()
+
+    key := make([]byte, 0, size + h.Size())
+
+    counter := make([]byte, 4)
+    for i := uint32(1); len(key) < size; i++ {
+        binary.BigEndian.PutUint32(counter, i)
+
+        h.Reset()
+        h.Write(secret)
+        h.Write(counter)
+        h.Write(sharedInfo)
+
+        key = h.Sum(key)
+    }
+
+    return key[:size], nil
+}
+
+// RFC 8418 HKDF 密钥协商
+type KeyAgreeWithHKDF struct {
+    hashFunc   func() hash.Hash
+    identifier asn1.ObjectIdentifier
+}
+
+// oid
+func (this KeyAgreeWithHKDF) OID() asn1.ObjectIdentifier {
+    return this.identifier
+}
+
+// 生成密钥加密密钥，ukm 作为盐
+func (this KeyAgreeWithHKDF) DeriveKey(secret, ukm, sharedInfo []byte, size int) ([]byte, error) {
+    key := make([]byte, size)
+    if _, err := io.ReadFull(hkdf.New(this.hashFunc, secret, ukm, sharedInfo), key); err != nil {
+        return nil, errors.New("pkcs7: failed to derive key")
+    }
+
+    return key, nil
+}
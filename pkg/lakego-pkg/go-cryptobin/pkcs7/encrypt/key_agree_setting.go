@@ -0,0 +1,136 @@
+package encrypt
+
+import (
+    "crypto/sha1"
+    "crypto/sha256"
+    "crypto/sha512"
+    "encoding/asn1"
+)
+
+var (
+    // RFC 5753 密钥协商
+    oidDhSinglePassStdDHSHA1KDF   = asn1.ObjectIdentifier{1, 3, 133, 16, 840, 63, 0, 2}
+    oidDhSinglePassStdDHSHA224KDF = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 0}
+    oidDhSinglePassStdDHSHA256KDF = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 1}
+    oidDhSinglePassStdDHSHA384KDF = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 2}
+    oidDhSinglePassStdDHSHA512KDF = asn1.ObjectIdentifier{1, 3, 132, 1, 11, 3}
+
+    // RFC 8418 密钥协商
+    oidDhSinglePassStdDHHKDFSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 3, 19}
+    oidDhSinglePassStdDHHKDFSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 3, 20}
+    oidDhSinglePassStdDHHKDFSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 3, 21}
+
+    // RFC 3394 密钥包装
+    oidAES128Wrap = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 5}
+    oidAES192Wrap = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 25}
+    oidAES256Wrap = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 45}
+)
+
+// KeyAgreeSHA1 dhSinglePass-stdDH-sha1kdf-scheme
+var KeyAgreeSHA1 = KeyAgreeWithX963{
+    hashFunc:   sha1.New,
+    identifier: oidDhSinglePassStdDHSHA1KDF,
+}
+
+// KeyAgreeSHA224 dhSinglePass-stdDH-sha224kdf-scheme
+var KeyAgreeSHA224 = KeyAgreeWithX963{
+    hashFunc:   sha256.New224,
+    identifier: oidDhSinglePassStdDHSHA224KDF,
+}
+
+// KeyAgreeSHA256 dhSinglePass-stdDH-sha256kdf-scheme
+var KeyAgreeSHA256 = KeyAgreeWithX963{
+    hashFunc:   sha256.New,
+    identifier: oidDhSinglePassStdDHSHA256KDF,
+}
+
+// KeyAgreeSHA384 dhSinglePass-stdDH-sha384kdf-scheme
+var KeyAgreeSHA384 = KeyAgreeWithX963{
+    hashFunc:   sha512.New384,
+    identifier: oidDhSinglePassStdDHSHA384KDF,
+}
+
+// KeyAgreeSHA512 dhSinglePass-stdDH-sha512kdf-scheme
+var KeyAgreeSHA512 = KeyAgreeWithX963{
+    hashFunc:   sha512.New,
+    identifier: oidDhSinglePassStdDHSHA512KDF,
+}
+
+// KeyAgreeHKDFSHA256 dhSinglePass-stdDH-hkdf-sha256-scheme
+var KeyAgreeHKDFSHA256 = KeyAgreeWithHKDF{
+    hashFunc:   sha256.New,
+    identifier: oidDhSinglePassStdDHHKDFSHA256,
+}
+
+// KeyAgreeHKDFSHA384 dhSinglePass-stdDH-hkdf-sha384-scheme
+var KeyAgreeHKDFSHA384 = KeyAgreeWithHKDF{
+    hashFunc:   sha512.New384,
+    identifier: oidDhSinglePassStdDHHKDFSHA384,
+}
+
+// KeyAgreeHKDFSHA512 dhSinglePass-stdDH-hkdf-sha512-scheme
+var KeyAgreeHKDFSHA512 = KeyAgreeWithHKDF{
+    hashFunc:   sha512.New,
+    identifier: oidDhSinglePassStdDHHKDFSHA512,
+}
+
+// ==========
+
+// KeyWrapAES128 id-aes128-wrap
+var KeyWrapAES128 = KeyWrapAES{
+    keySize:    16,
+    identifier: oidAES128Wrap,
+}
+
+// KeyWrapAES192 id-aes192-wrap
+var KeyWrapAES192 = KeyWrapAES{
+    keySize:    24,
+    identifier: oidAES192Wrap,
+}
+
+// KeyWrapAES256 id-aes256-wrap
+var KeyWrapAES256 = KeyWrapAES{
+    keySize:    32,
+    identifier: oidAES256Wrap,
+}
+
+func init() {
+    // x9.63
+    AddKeyAgree(oidDhSinglePassStdDHSHA1KDF, func() KeyAgree {
+        return KeyAgreeSHA1
+    })
+    AddKeyAgree(oidDhSinglePassStdDHSHA224KDF, func() KeyAgree {
+        return KeyAgreeSHA224
+    })
+    AddKeyAgree(oidDhSinglePassStdDHSHA256KDF, func() KeyAgree {
+        return KeyAgreeSHA256
+    })
+    AddKeyAgree(oidDhSinglePassStdDHSHA384KDF, func() KeyAgree {
+        return KeyAgreeSHA384
+    })
+    AddKeyAgree(oidDhSinglePassStdDHSHA512KDF, func() KeyAgree {
+        return KeyAgreeSHA512
+    })
+
+    // hkdf
+    AddKeyAgree(oidDhSinglePassStdDHHKDFSHA256, func() KeyAgree {
+        return KeyAgreeHKDFSHA256
+    })
+    AddKeyAgree(oidDhSinglePassStdDHHKDFSHA384, func() KeyAgree {
+        return KeyAgreeHKDFSHA384
+    })
+    AddKeyAgree(oidDhSinglePassStdDHHKDFSHA512, func() KeyAgree {
+        return KeyAgreeHKDFSHA512
+    })
+
+    // wrap
+    AddKeyWrap(oidAES128Wrap, func() KeyWrap {
+        return KeyWrapAES128
+    })
+    AddKeyWrap(oidAES192Wrap, func() KeyWrap {
+        return KeyWrapAES192
+    })
+    AddKeyWrap(oidAES256Wrap, func() KeyWrap {
+        return KeyWrapAES256
+    })
+}
@@ -0,0 +1,105 @@
+package encrypt
+
+import (
+    "errors"
+    "crypto/aes"
+    "crypto/subtle"
+    "encoding/asn1"
+    "encoding/binary"
+)
+
+// RFC 3394 默认 IV
+var defaultKeyWrapIV = []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+
+// aes 密钥包装
+type KeyWrapAES struct {
+    keySize    int
+    identifier asn1.ObjectIdentifier
+}
+
+// oid
+func (this KeyWrapAES) OID() asn1.ObjectIdentifier {
+    return this.identifier
+}
+
+// 值大小
+func (this KeyWrapAES) KeySize() int {
+    return this.keySize
+}
+
+// 包装
+func (this KeyWrapAES) Wrap(kek, key []byte) ([]byte, error) {
+    if len(kek) != this.keySize {
+        return nil, errors.New("pkcs7: invalid key wrap kek size")
+    }
+
+    if len(key) < 16 || len(key) % 8 != 0 {
+        return nil, errors.New("pkcs7: invalid key wrap key size")
+    }
+
+    block, err := aes.NewCipher(kek)
+    if err != nil {
+        return nil, err
+    }
+
+    n := len(key) / 8
+
+    out := make([]byte, len(key) + 8)
+    copy(out, defaultKeyWrapIV)
+    copy(out[8:], key)
+
+    buf := make([]byte, 16)
+    for j := 0; j < 6; j++ {
+        for i := 1; i <= n; i++ {
+            copy(buf, out[:8])
+            copy(buf[8:], out[i*8:i*8+8])
+            block.Encrypt(buf, buf)
+
+            t := uint64(n*j + i)
+            binary.BigEndian.PutUint64(out[:8], binary.BigEndian.Uint64(buf[:8]) ^ t)
+            copy(out[i*8:], buf[8:])
+        }
+    }
+
+    return out, nil
+}
+
+// 解包
+func (this KeyWrapAES) Unwrap(kek, wrapped []byte) ([]byte, error) {
+    if len(kek) != this.keySize {
+        return nil, errors.New("pkcs7: invalid key wrap kek size")
+    }
+
+    if len(wrapped) < 24 || len(wrapped) % 8 != 0 {
+        return nil, errors.New("pkcs7: invalid wrapped key size")
+    }
+
+    block, err := aes.NewCipher(kek)
+    if err != nil {
+        return nil, err
+    }
+
+    n := len(wrapped) / 8 - 1
+
+    out := make([]byte, len(wrapped))
+    copy(out, wrapped)
+
+    buf := make([]byte, 16)
+    for j := 5; j >= 0; j-- {
+        for i := n; i >= 1; i-- {
+            t := uint64(n*j + i)
+            binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(out[:8]) ^ t)
+            copy(buf[8:], out[i*8:i*8+8])
+            block.Decrypt(buf, buf)
+
+            copy(out[:8], buf[:8])
+            copy(out[i*8:], buf[8:])
+        }
+    }
+
+    if subtle.ConstantTimeCompare(out[:8], defaultKeyWrapIV) != 1 {
+        return nil, errors.New("pkcs7: key unwrap failed")
+    }
+
+    return out[8:], nil
+}
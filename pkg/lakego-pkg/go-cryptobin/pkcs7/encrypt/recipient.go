@@ -0,0 +1,580 @@
+package encrypt
+
+import (
+    "bytes"
+    "errors"
+    "crypto"
+    "crypto/rand"
+    "crypto/ecdh"
+    "crypto/ecdsa"
+    "crypto/cipher"
+    "crypto/subtle"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+
+    "github.com/deatil/go-cryptobin/pkcs8"
+    "github.com/deatil/go-cryptobin/dh/curve25519"
+)
+
+var (
+    // 公钥类型
+    oidPublicKeyECDSA  = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+    oidPublicKeyX25519 = asn1.ObjectIdentifier{1, 3, 101, 110}
+
+    // RFC 3211 密码加密密钥
+    oidPWRIKEK = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 3, 9}
+)
+
+// RecipientInfo 类型的 tag
+const (
+    recipientTagKARI = 1
+    recipientTagPWRI = 3
+)
+
+// KeyTransRecipientInfo，解析时使用
+type keyTransRecipientInfo struct {
+    Version                int
+    RID                    asn1.RawValue
+    KeyEncryptionAlgorithm pkix.AlgorithmIdentifier
+    EncryptedKey           []byte
+}
+
+// KeyAgreeRecipientInfo
+// Originator 为 [0] 显式标签，需要手动包装
+type keyAgreeRecipientInfo struct {
+    Version                int
+    Originator             asn1.RawValue
+    UKM                    []byte `asn1:"explicit,optional,tag:1"`
+    KeyEncryptionAlgorithm pkix.AlgorithmIdentifier
+    RecipientEncryptedKeys []recipientEncryptedKey
+}
+
+type recipientEncryptedKey struct {
+    RID          asn1.RawValue
+    EncryptedKey []byte
+}
+
+type originatorPublicKey struct {
+    Algorithm pkix.AlgorithmIdentifier
+    PublicKey asn1.BitString
+}
+
+type recipientKeyIdentifier struct {
+    SubjectKeyIdentifier []byte
+    Date                 asn1.RawValue `asn1:"optional"`
+    Other                asn1.RawValue `asn1:"optional"`
+}
+
+// PasswordRecipientInfo
+type passwordRecipientInfo struct {
+    Version                int
+    KeyDerivationAlgorithm pkix.AlgorithmIdentifier `asn1:"optional,tag:0"`
+    KeyEncryptionAlgorithm pkix.AlgorithmIdentifier
+    EncryptedKey           []byte
+}
+
+// 生成 KeyTransRecipientInfo
+func marshalKeyTransRecipient(cert *x509.Certificate, keyEncrypt KeyEncrypt, key []byte) (asn1.RawValue, error) {
+    encrypted, err := keyEncrypt.Encrypt(key, cert.PublicKey)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    ias, err := cert2issuerAndSerial(cert)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    info := recipientInfo{
+        Version:               0,
+        IssuerAndSerialNumber: ias,
+        KeyEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+            Algorithm: keyEncrypt.OID(),
+        },
+        EncryptedKey: encrypted,
+    }
+
+    der, err := asn1.Marshal(info)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    return asn1.RawValue{FullBytes: der}, nil
+}
+
+// 生成 KeyAgreeRecipientInfo，多个接收者使用同一个临时密钥
+func marshalKeyAgreeRecipient(
+    certs []*x509.Certificate,
+    pubs []*ecdh.PublicKey,
+    keyAgree KeyAgree,
+    keyWrap KeyWrap,
+    ukm []byte,
+    key []byte,
+) (asn1.RawValue, error) {
+    curve := pubs[0].Curve()
+
+    ephemeral, err := curve.GenerateKey(rand.Reader)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    sharedInfo, err := marshalSharedInfo(keyWrap.OID(), ukm, keyWrap.KeySize())
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    encryptedKeys := make([]recipientEncryptedKey, 0, len(certs))
+    for i, cert := range certs {
+        secret, err := ephemeral.ECDH(pubs[i])
+        if err != nil {
+            return asn1.RawValue{}, err
+        }
+
+        kek, err := keyAgree.DeriveKey(secret, ukm, sharedInfo, keyWrap.KeySize())
+        if err != nil {
+            return asn1.RawValue{}, err
+        }
+
+        wrapped, err := keyWrap.Wrap(kek, key)
+        if err != nil {
+            return asn1.RawValue{}, err
+        }
+
+        ias, err := cert2issuerAndSerial(cert)
+        if err != nil {
+            return asn1.RawValue{}, err
+        }
+
+        rid, err := asn1.Marshal(ias)
+        if err != nil {
+            return asn1.RawValue{}, err
+        }
+
+        encryptedKeys = append(encryptedKeys, recipientEncryptedKey{
+            RID:          asn1.RawValue{FullBytes: rid},
+            EncryptedKey: wrapped,
+        })
+    }
+
+    originator, err := marshalOriginatorKey(ephemeral.PublicKey())
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    wrapAlg, err := asn1.Marshal(pkix.AlgorithmIdentifier{
+        Algorithm: keyWrap.OID(),
+    })
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    info := keyAgreeRecipientInfo{
+        Version:    3,
+        Originator: asn1.RawValue{
+            Class:      asn1.ClassContextSpecific,
+            Tag:        0,
+            IsCompound: true,
+            Bytes:      originator,
+        },
+        UKM: ukm,
+        KeyEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+            Algorithm:  keyAgree.OID(),
+            Parameters: asn1.RawValue{
+                FullBytes: wrapAlg,
+            },
+        },
+        RecipientEncryptedKeys: encryptedKeys,
+    }
+
+    der, err := asn1.MarshalWithParams(info, "tag:1")
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    return asn1.RawValue{FullBytes: der}, nil
+}
+
+// 生成 PasswordRecipientInfo
+func marshalPasswordRecipient(password []byte, kdfOpts pkcs8.KDFOpts, keyCipher CipherCBC, key []byte) (asn1.RawValue, error) {
+    salt, err := genRandom(kdfOpts.GetSaltSize())
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    kek, kdfParams, err := kdfOpts.DeriveKey(password, salt, keyCipher.KeySize())
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    marshalledParams, err := asn1.Marshal(kdfParams)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    iv, err := genRandom(keyCipher.blockSize)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    encrypted, err := pwriWrap(keyCipher, kek, iv, key)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    ivBytes, err := asn1.Marshal(iv)
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    keyCipherAlg, err := asn1.Marshal(pkix.AlgorithmIdentifier{
+        Algorithm:  keyCipher.OID(),
+        Parameters: asn1.RawValue{
+            FullBytes: ivBytes,
+        },
+    })
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    info := passwordRecipientInfo{
+        Version: 0,
+        KeyDerivationAlgorithm: pkix.AlgorithmIdentifier{
+            Algorithm:  kdfOpts.OID(),
+            Parameters: asn1.RawValue{
+                FullBytes: marshalledParams,
+            },
+        },
+        KeyEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+            Algorithm:  oidPWRIKEK,
+            Parameters: asn1.RawValue{
+                FullBytes: keyCipherAlg,
+            },
+        },
+        EncryptedKey: encrypted,
+    }
+
+    der, err := asn1.MarshalWithParams(info, "tag:3")
+    if err != nil {
+        return asn1.RawValue{}, err
+    }
+
+    return asn1.RawValue{FullBytes: der}, nil
+}
+
+// 使用证书解出内容密钥
+func decryptRecipientKey(recipients []asn1.RawValue, cert *x509.Certificate, pkey crypto.PrivateKey) ([]byte, error) {
+    for _, recipient := range recipients {
+        switch {
+            case recipient.Class == asn1.ClassUniversal && recipient.Tag == asn1.TagSequence:
+                var ktri keyTransRecipientInfo
+                if _, err := asn1.Unmarshal(recipient.FullBytes, &ktri); err != nil {
+                    return nil, err
+                }
+
+                if !isCertMatchForRecipientIdentifier(cert, ktri.RID) {
+                    continue
+                }
+
+                keyEncrypt, err := parseKeyEncrypt(ktri.KeyEncryptionAlgorithm)
+                if err != nil {
+                    return nil, err
+                }
+
+                return keyEncrypt.Decrypt(ktri.EncryptedKey, pkey)
+
+            case recipient.Class == asn1.ClassContextSpecific && recipient.Tag == recipientTagKARI:
+                var kari keyAgreeRecipientInfo
+                if _, err := asn1.UnmarshalWithParams(recipient.FullBytes, &kari, "tag:1"); err != nil {
+                    return nil, err
+                }
+
+                for _, encryptedKey := range kari.RecipientEncryptedKeys {
+                    if !isCertMatchForRecipientIdentifier(cert, encryptedKey.RID) {
+                        continue
+                    }
+
+                    return decryptKeyAgreeKey(kari, encryptedKey.EncryptedKey, pkey)
+                }
+        }
+    }
+
+    return nil, errors.New("pkcs7: no enveloped recipient for provided certificate")
+}
+
+// 解出 KeyAgreeRecipientInfo 中的内容密钥
+func decryptKeyAgreeKey(kari keyAgreeRecipientInfo, encryptedKey []byte, pkey crypto.PrivateKey) ([]byte, error) {
+    priv, err := ecdhPrivateKey(pkey)
+    if err != nil {
+        return nil, err
+    }
+
+    var originator originatorPublicKey
+    if _, err := asn1.UnmarshalWithParams(kari.Originator.Bytes, &originator, "tag:1"); err != nil {
+        return nil, errors.New("pkcs7: unsupported key agreement originator")
+    }
+
+    ephemeral, err := priv.Curve().NewPublicKey(originator.PublicKey.RightAlign())
+    if err != nil {
+        return nil, errors.New("pkcs7: invalid key agreement originator key")
+    }
+
+    keyAgree, keyWrap, err := parseKeyAgree(kari.KeyEncryptionAlgorithm)
+    if err != nil {
+        return nil, err
+    }
+
+    secret, err := priv.ECDH(ephemeral)
+    if err != nil {
+        return nil, err
+    }
+
+    sharedInfo, err := marshalSharedInfo(keyWrap.OID(), kari.UKM, keyWrap.KeySize())
+    if err != nil {
+        return nil, err
+    }
+
+    kek, err := keyAgree.DeriveKey(secret, kari.UKM, sharedInfo, keyWrap.KeySize())
+    if err != nil {
+        return nil, err
+    }
+
+    return keyWrap.Unwrap(kek, encryptedKey)
+}
+
+// 使用密码解出内容密钥
+func decryptPasswordKey(recipients []asn1.RawValue, password []byte) ([][]byte, error) {
+    keys := make([][]byte, 0)
+
+    for _, recipient := range recipients {
+        if recipient.Class != asn1.ClassContextSpecific || recipient.Tag != recipientTagPWRI {
+            continue
+        }
+
+        var pwri passwordRecipientInfo
+        if _, err := asn1.UnmarshalWithParams(recipient.FullBytes, &pwri, "tag:3"); err != nil {
+            return nil, err
+        }
+
+        if len(pwri.KeyDerivationAlgorithm.Algorithm) == 0 {
+            return nil, errors.New("pkcs7: password recipient has no key derivation algorithm")
+        }
+
+        if !pwri.KeyEncryptionAlgorithm.Algorithm.Equal(oidPWRIKEK) {
+            return nil, errors.New("pkcs7: unsupported password key encryption algorithm")
+        }
+
+        var keyCipherAlg pkix.AlgorithmIdentifier
+        if _, err := asn1.Unmarshal(pwri.KeyEncryptionAlgorithm.Parameters.FullBytes, &keyCipherAlg); err != nil {
+            return nil, errors.New("pkcs7: invalid password key encryption parameters")
+        }
+
+        newCipher, params, err := parseEncryptionScheme(keyCipherAlg)
+        if err != nil {
+            return nil, err
+        }
+
+        keyCipher, ok := newCipher.(CipherCBC)
+        if !ok {
+            return nil, errors.New("pkcs7: password key encryption cipher must be in CBC mode")
+        }
+
+        var iv []byte
+        if _, err := asn1.Unmarshal(params, &iv); err != nil || len(iv) != keyCipher.blockSize {
+            return nil, errors.New("pkcs7: invalid iv parameters")
+        }
+
+        kdfParams, err := pkcs8.ParseKDFParameters(pwri.KeyDerivationAlgorithm)
+        if err != nil {
+            return nil, err
+        }
+
+        kek, err := kdfParams.DeriveKey(password, keyCipher.KeySize())
+        if err != nil {
+            return nil, err
+        }
+
+        // 密码错误时跳过
+        key, err := pwriUnwrap(keyCipher, kek, iv, pwri.EncryptedKey)
+        if err != nil {
+            continue
+        }
+
+        keys = append(keys, key)
+    }
+
+    if len(keys) == 0 {
+        return nil, errors.New("pkcs7: no password recipient matches the password")
+    }
+
+    return keys, nil
+}
+
+// 解析密钥协商方式
+func parseKeyAgree(keyEncryptionAlgorithm pkix.AlgorithmIdentifier) (KeyAgree, KeyWrap, error) {
+    fn, ok := keyAgrees[keyEncryptionAlgorithm.Algorithm.String()]
+    if !ok {
+        return nil, nil, errors.New("pkcs7: unsupported key agreement algorithm (OID: " + keyEncryptionAlgorithm.Algorithm.String() + ")")
+    }
+
+    var wrapAlg pkix.AlgorithmIdentifier
+    if _, err := asn1.Unmarshal(keyEncryptionAlgorithm.Parameters.FullBytes, &wrapAlg); err != nil {
+        return nil, nil, errors.New("pkcs7: invalid key wrap algorithm")
+    }
+
+    wrapFn, ok := keyWraps[wrapAlg.Algorithm.String()]
+    if !ok {
+        return nil, nil, errors.New("pkcs7: unsupported key wrap algorithm (OID: " + wrapAlg.Algorithm.String() + ")")
+    }
+
+    return fn(), wrapFn(), nil
+}
+
+// 生成 originatorKey
+func marshalOriginatorKey(pub *ecdh.PublicKey) ([]byte, error) {
+    alg := oidPublicKeyECDSA
+    if pub.Curve() == ecdh.X25519() {
+        alg = oidPublicKeyX25519
+    }
+
+    publicKey := pub.Bytes()
+
+    return asn1.MarshalWithParams(originatorPublicKey{
+        Algorithm: pkix.AlgorithmIdentifier{
+            Algorithm: alg,
+        },
+        PublicKey: asn1.BitString{
+            Bytes:     publicKey,
+            BitLength: len(publicKey) * 8,
+        },
+    }, "tag:1")
+}
+
+// 转换为 ecdh 公钥
+func ecdhPublicKey(pub crypto.PublicKey) (*ecdh.PublicKey, error) {
+    switch k := pub.(type) {
+        case *ecdh.PublicKey:
+            return k, nil
+        case *ecdsa.PublicKey:
+            return k.ECDH()
+        case *curve25519.PublicKey:
+            return ecdh.X25519().NewPublicKey(k.Y)
+    }
+
+    return nil, errors.New("pkcs7: PublicKey does not support key agreement")
+}
+
+// 转换为 ecdh 私钥
+func ecdhPrivateKey(pkey crypto.PrivateKey) (*ecdh.PrivateKey, error) {
+    switch k := pkey.(type) {
+        case *ecdh.PrivateKey:
+            return k, nil
+        case *ecdsa.PrivateKey:
+            return k.ECDH()
+        case *curve25519.PrivateKey:
+            return ecdh.X25519().NewPrivateKey(k.X)
+    }
+
+    return nil, errors.New("pkcs7: PrivateKey does not support key agreement")
+}
+
+// 检测接收者标识，支持 issuerAndSerialNumber 及 subjectKeyIdentifier
+func isCertMatchForRecipientIdentifier(cert *x509.Certificate, rid asn1.RawValue) bool {
+    switch {
+        case rid.Class == asn1.ClassUniversal && rid.Tag == asn1.TagSequence:
+            var ias issuerAndSerial
+            if _, err := asn1.Unmarshal(rid.FullBytes, &ias); err != nil {
+                return false
+            }
+
+            return isCertMatchForIssuerAndSerial(cert, ias)
+
+        case rid.Class == asn1.ClassContextSpecific && rid.Tag == 0:
+            ski := rid.Bytes
+
+            // KeyAgreeRecipientIdentifier 的 rKeyId
+            if rid.IsCompound {
+                var rki recipientKeyIdentifier
+                if _, err := asn1.UnmarshalWithParams(rid.FullBytes, &rki, "tag:0"); err != nil {
+                    return false
+                }
+
+                ski = rki.SubjectKeyIdentifier
+            }
+
+            return len(cert.SubjectKeyId) > 0 && bytes.Equal(cert.SubjectKeyId, ski)
+    }
+
+    return false
+}
+
+// RFC 3211 密钥包装
+func pwriWrap(keyCipher CipherCBC, kek, iv, key []byte) ([]byte, error) {
+    blockSize := keyCipher.blockSize
+
+    if len(key) < 3 || len(key) > 255 {
+        return nil, errors.New("pkcs7: invalid content key size")
+    }
+
+    // 最少为两个块
+    n := (len(key) + 4 + blockSize - 1) / blockSize * blockSize
+    if n < 2 * blockSize {
+        n = 2 * blockSize
+    }
+
+    buf, err := genRandom(n)
+    if err != nil {
+        return nil, err
+    }
+
+    buf[0] = byte(len(key))
+    buf[1] = ^key[0]
+    buf[2] = ^key[1]
+    buf[3] = ^key[2]
+    copy(buf[4:], key)
+
+    block, err := keyCipher.cipherFunc(kek)
+    if err != nil {
+        return nil, err
+    }
+
+    // 加密两次，第二次接着第一次的状态
+    enc := cipher.NewCBCEncrypter(block, iv)
+    enc.CryptBlocks(buf, buf)
+    enc.CryptBlocks(buf, buf)
+
+    return buf, nil
+}
+
+// RFC 3211 密钥解包
+func pwriUnwrap(keyCipher CipherCBC, kek, iv, wrapped []byte) ([]byte, error) {
+    blockSize := keyCipher.blockSize
+
+    n := len(wrapped)
+    if n < 2 * blockSize || n % blockSize != 0 {
+        return nil, errors.New("pkcs7: invalid wrapped key size")
+    }
+
+    block, err := keyCipher.cipherFunc(kek)
+    if err != nil {
+        return nil, err
+    }
+
+    // 先用倒数第二块解出最后一块，得到第一次加密的最后一块
+    lastIV := make([]byte, blockSize)
+    cipher.NewCBCDecrypter(block, wrapped[n-2*blockSize:n-blockSize]).
+        CryptBlocks(lastIV, wrapped[n-blockSize:])
+
+    buf := make([]byte, n)
+    cipher.NewCBCDecrypter(block, lastIV).CryptBlocks(buf, wrapped)
+    cipher.NewCBCDecrypter(block, iv).CryptBlocks(buf, buf)
+
+    check := (buf[1] ^ buf[4]) & (buf[2] ^ buf[5]) & (buf[3] ^ buf[6])
+    size := int(buf[0])
+
+    if subtle.ConstantTimeByteEq(check, 0xff) != 1 || size < 3 || size > n - 4 {
+        return nil, errors.New("pkcs7: password key unwrap failed")
+    }
+
+    return buf[4:4+size], nil
+}
@@ -12,9 +12,27 @@ var (
     // 添加签名数据
     NewSignedData = sign.NewSignedData
 
+    // 使用摘要添加分离签名数据
+    NewSignedDataWithDigest = sign.NewSignedDataWithDigest
+
+    // 流式读取数据添加分离签名数据
+    NewSignedDataFromReader = sign.NewSignedDataFromReader
+
     // DegenerateCertificate
     DegenerateCertificate = sign.DegenerateCertificate
 
+    // 时间戳请求
+    NewTimeStampRequest = sign.NewTimeStampRequest
+
+    // 解析时间戳令牌
+    ParseTimeStampToken = sign.ParseTimeStampToken
+
+    // 本地时间戳服务
+    NewTimeStampAuthority = sign.NewTimeStampAuthority
+
+    // HTTP 时间戳服务
+    NewHTTPTimeStamper = sign.NewHTTPTimeStamper
+
     // 加密
     Encrypt = encrypt.Encrypt
 
@@ -26,11 +44,29 @@ var (
 
     // 解密
     DecryptUsingPSK = encrypt.DecryptUsingPSK
+
+    // 密码解密
+    DecryptUsingPassword = encrypt.DecryptUsingPassword
+
+    // 多接收者加密
+    NewEnvelopedData = encrypt.NewEnvelopedData
+
+    // 多接收者认证加密
+    NewAuthEnvelopedData = encrypt.NewAuthEnvelopedData
 )
 
 type (
     // 额外信息
     SignerInfoConfig = sign.SignerInfoConfig
+
+    // 时间戳服务
+    TimeStamper = sign.TimeStamper
+
+    // 接收者配置
+    RecipientOpts = encrypt.RecipientOpts
+
+    // 密码接收者配置
+    PasswordOpts = encrypt.PasswordOpts
 )
 
 // 编码到 pem
@@ -0,0 +1,157 @@
+package sign
+
+import (
+    "io"
+    "fmt"
+    "hash"
+    "errors"
+    "crypto/x509"
+    "encoding/asn1"
+)
+
+// 摘要算法对应的默认签名算法
+var defaultEncryptionOids = map[string]asn1.ObjectIdentifier{
+    oidDigestAlgorithmSHA1.String():   oidDigestAlgorithmRSASHA1,
+    oidDigestAlgorithmSHA224.String(): oidDigestAlgorithmRSASHA224,
+    oidDigestAlgorithmSHA256.String(): oidDigestAlgorithmRSASHA256,
+    oidDigestAlgorithmSHA384.String(): oidDigestAlgorithmRSASHA384,
+    oidDigestAlgorithmSHA512.String(): oidDigestAlgorithmRSASHA512,
+    oidDigestAlgorithmSM3.String():    oidDigestAlgorithmSM2SM3,
+}
+
+// NewSignedDataWithDigest initializes a detached SignedData from a digest
+// computed by the caller, so the content never needs to be held in memory.
+// Only AddSigner/AddSignerChain can be used, as the signature covers the
+// authenticated attributes. The encryption algorithm defaults to RSA with
+// the same digest and can be changed by calling SetEncryptionAlgorithm.
+func NewSignedDataWithDigest(digestOid asn1.ObjectIdentifier, digest []byte) (*SignedData, error) {
+    hashFunc, err := parseHashFromOid(digestOid)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(digest) != len(hashFunc.Sum(nil)) {
+        return nil, errors.New("pkcs7: digest length does not match digest algorithm")
+    }
+
+    sd := signedData{
+        ContentInfo: contentInfo{ContentType: oidData},
+        Version:     1,
+    }
+
+    encryptionOid, ok := defaultEncryptionOids[digestOid.String()]
+    if !ok {
+        encryptionOid = oidDigestAlgorithmRSASHA1
+    }
+
+    return &SignedData{
+        sd:            sd,
+        messageDigest: digest,
+        digestOid:     digestOid,
+        encryptionOid: encryptionOid,
+        digestedOid:   digestOid,
+    }, nil
+}
+
+// NewSignedDataFromReader streams the content through the digest algorithm
+// and initializes a detached SignedData with the result.
+func NewSignedDataFromReader(r io.Reader, digestOid asn1.ObjectIdentifier) (*SignedData, error) {
+    digest, err := DigestReader(r, digestOid)
+    if err != nil {
+        return nil, err
+    }
+
+    return NewSignedDataWithDigest(digestOid, digest)
+}
+
+// DigestReader 流式计算摘要
+func DigestReader(r io.Reader, digestOid asn1.ObjectIdentifier) ([]byte, error) {
+    h, err := newHashFromOid(digestOid)
+    if err != nil {
+        return nil, err
+    }
+
+    if _, err := io.Copy(h, r); err != nil {
+        return nil, err
+    }
+
+    return h.Sum(nil), nil
+}
+
+// VerifyDetachedDigest checks the signatures of a detached PKCS7 object
+// against a digest computed by the caller. Every signer must use digestOid
+// and have authenticated attributes.
+func (this *PKCS7) VerifyDetachedDigest(digestOid asn1.ObjectIdentifier, digest []byte, truststore *x509.CertPool) error {
+    digests := map[string][]byte{
+        digestOid.String(): digest,
+    }
+
+    return this.verifyDetached(digests, truststore)
+}
+
+// VerifyDetachedReader checks the signatures of a detached PKCS7 object,
+// reading the content once from r and hashing it with every digest
+// algorithm used by the signers.
+func (this *PKCS7) VerifyDetachedReader(r io.Reader, truststore *x509.CertPool) error {
+    if len(this.Signers) == 0 {
+        return errors.New("pkcs7: Message has no signers")
+    }
+
+    hashers := make(map[string]hash.Hash)
+    writers := make([]io.Writer, 0)
+
+    for _, signer := range this.Signers {
+        oid := signer.DigestAlgorithm.Algorithm
+        if _, ok := hashers[oid.String()]; ok {
+            continue
+        }
+
+        h, err := newHashFromOid(oid)
+        if err != nil {
+            return err
+        }
+
+        hashers[oid.String()] = h
+        writers = append(writers, h)
+    }
+
+    if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+        return err
+    }
+
+    digests := make(map[string][]byte)
+    for oid, h := range hashers {
+        digests[oid] = h.Sum(nil)
+    }
+
+    return this.verifyDetached(digests, truststore)
+}
+
+func (this *PKCS7) verifyDetached(digests map[string][]byte, truststore *x509.CertPool) error {
+    if len(this.Signers) == 0 {
+        return errors.New("pkcs7: Message has no signers")
+    }
+
+    for _, signer := range this.Signers {
+        if err := verifySignature(this, signer, truststore, digests); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 获取可流式计算的摘要
+func newHashFromOid(digestOid asn1.ObjectIdentifier) (hash.Hash, error) {
+    hashFunc, err := parseHashFromOid(digestOid)
+    if err != nil {
+        return nil, err
+    }
+
+    newHash, ok := hashFunc.(interface{ New() hash.Hash })
+    if !ok {
+        return nil, fmt.Errorf("pkcs7: signHash (OID: %s) does not support streaming", digestOid)
+    }
+
+    return newHash.New(), nil
+}
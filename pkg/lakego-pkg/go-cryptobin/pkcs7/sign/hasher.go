@@ -25,3 +25,8 @@ func (this SignHashWithFunc) Sum(data []byte) []byte {
 
     return newData
 }
+
+// 流式摘要
+func (this SignHashWithFunc) New() hash.Hash {
+    return this.hashFunc()
+}
@@ -4,6 +4,7 @@ import (
     "fmt"
     "time"
     "bytes"
+    "errors"
     "math/big"
     "crypto"
     "crypto/x509"
@@ -18,6 +19,9 @@ type SignedData struct {
     data, messageDigest []byte
     digestOid           asn1.ObjectIdentifier
     encryptionOid       asn1.ObjectIdentifier
+
+    // 外部计算摘要使用的算法，为空时使用 data 计算摘要
+    digestedOid         asn1.ObjectIdentifier
 }
 
 // NewSignedData takes data and initializes a PKCS7 SignedData struct that is
@@ -149,12 +153,18 @@ func (this *SignedData) AddSignerChain(ee *x509.Certificate, pkey crypto.Private
         pkix.AlgorithmIdentifier{Algorithm: this.digestOid},
     )
 
-    hashFunc, err := parseHashFromOid(this.digestOid)
-    if err != nil {
-        return err
-    }
+    if this.digestedOid != nil {
+        if !this.digestedOid.Equal(this.digestOid) {
+            return fmt.Errorf("pkcs7: digest algorithm (OID: %s) is not same as detached digest (OID: %s)", this.digestOid, this.digestedOid)
+        }
+    } else {
+        hashFunc, err := parseHashFromOid(this.digestOid)
+        if err != nil {
+            return err
+        }
 
-    this.messageDigest = hashFunc.Sum(this.data)
+        this.messageDigest = hashFunc.Sum(this.data)
+    }
 
     attrs := &attributes{}
     attrs.Add(oidAttributeContentType, this.sd.ContentInfo.ContentType)
@@ -185,6 +195,9 @@ func (this *SignedData) AddSignerChain(ee *x509.Certificate, pkey crypto.Private
     }
 
     signFunc, err := parseSignFromOid(this.encryptionOid, this.digestOid)
+    if err != nil {
+        return err
+    }
 
     // create signature of signed attributes
     _, signature, err := signFunc.Sign(pkey, finalAttrsBytes)
@@ -219,11 +232,18 @@ func (this *SignedData) AddSignerChain(ee *x509.Certificate, pkey crypto.Private
 // shouldn't do unless you're maintaining backward compatibility for old
 // applications.
 func (this *SignedData) SignWithoutAttr(ee *x509.Certificate, pkey crypto.PrivateKey, config SignerInfoConfig) error {
+    if this.digestedOid != nil {
+        return errors.New("pkcs7: cannot sign detached digest without attributes")
+    }
+
     var signature []byte
     this.sd.DigestAlgorithmIdentifiers = append(this.sd.DigestAlgorithmIdentifiers, pkix.AlgorithmIdentifier{Algorithm: this.digestOid})
 
     // 签名
     signFunc, err := parseSignFromOid(this.encryptionOid, this.digestOid)
+    if err != nil {
+        return err
+    }
 
     // create signature of signed attributes
     hashData, signData, err := signFunc.Sign(pkey, this.data)
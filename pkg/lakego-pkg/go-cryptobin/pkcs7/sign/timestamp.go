@@ -0,0 +1,686 @@
+package sign
+
+import (
+    "fmt"
+    "sort"
+    "time"
+    "bytes"
+    "errors"
+    "math/big"
+    "crypto/rand"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "crypto/subtle"
+    "encoding/asn1"
+)
+
+var (
+    // RFC 3161 OIDs
+    oidTSTInfo                       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+    oidAttributeTimeStampToken       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 14}
+    oidAttributeSigningCertificate   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 12}
+    oidAttributeSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+)
+
+// PKIStatus
+const (
+    TimeStampGranted                = 0
+    TimeStampGrantedWithMods        = 1
+    TimeStampRejection              = 2
+    TimeStampWaiting                = 3
+    TimeStampRevocationWarning      = 4
+    TimeStampRevocationNotification = 5
+)
+
+// PKIFailureInfo 位
+const (
+    TimeStampFailBadAlg               = 0
+    TimeStampFailBadRequest           = 2
+    TimeStampFailBadDataFormat        = 5
+    TimeStampFailTimeNotAvailable     = 14
+    TimeStampFailUnacceptedPolicy     = 15
+    TimeStampFailUnacceptedExtension  = 16
+    TimeStampFailAddInfoNotAvailable  = 17
+    TimeStampFailSystemFailure        = 25
+)
+
+type messageImprint struct {
+    HashAlgorithm pkix.AlgorithmIdentifier
+    HashedMessage []byte
+}
+
+type timeStampReq struct {
+    Version        int
+    MessageImprint messageImprint
+    ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+    Nonce          *big.Int              `asn1:"optional"`
+    CertReq        bool                  `asn1:"optional"`
+    Extensions     []pkix.Extension      `asn1:"optional,tag:0"`
+}
+
+type pkiStatusInfo struct {
+    Status       int
+    StatusString []string       `asn1:"optional"`
+    FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+type timeStampResp struct {
+    Status         pkiStatusInfo
+    TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+type accuracy struct {
+    Seconds int `asn1:"optional"`
+    Millis  int `asn1:"optional,tag:0"`
+    Micros  int `asn1:"optional,tag:1"`
+}
+
+type tstInfo struct {
+    Version        int
+    Policy         asn1.ObjectIdentifier
+    MessageImprint messageImprint
+    SerialNumber   *big.Int
+    GenTime        asn1.RawValue
+    Accuracy       accuracy         `asn1:"optional"`
+    Ordering       bool             `asn1:"optional"`
+    Nonce          *big.Int         `asn1:"optional"`
+    TSA            asn1.RawValue    `asn1:"optional,explicit,tag:0"`
+    Extensions     []pkix.Extension `asn1:"optional,tag:1"`
+}
+
+type essCertID struct {
+    CertHash     []byte
+    IssuerSerial asn1.RawValue `asn1:"optional"`
+}
+
+type signingCertificate struct {
+    Certs    []essCertID
+    Policies asn1.RawValue `asn1:"optional"`
+}
+
+type essCertIDv2 struct {
+    HashAlgorithm pkix.AlgorithmIdentifier `asn1:"optional"`
+    CertHash      []byte
+    IssuerSerial  asn1.RawValue `asn1:"optional"`
+}
+
+type signingCertificateV2 struct {
+    Certs    []essCertIDv2
+    Policies asn1.RawValue `asn1:"optional"`
+}
+
+// TimeStampRequest is a RFC 3161 TimeStampReq
+type TimeStampRequest struct {
+    HashAlgorithm asn1.ObjectIdentifier
+    HashedMessage []byte
+    Policy        asn1.ObjectIdentifier
+    Nonce         *big.Int
+    CertReq       bool
+    Extensions    []pkix.Extension
+}
+
+// NewTimeStampRequest creates a request for a digest computed by the
+// caller, with a random nonce and the TSA certificate requested.
+func NewTimeStampRequest(hashOid asn1.ObjectIdentifier, digest []byte) (*TimeStampRequest, error) {
+    hashFunc, err := parseHashFromOid(hashOid)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(digest) != len(hashFunc.Sum(nil)) {
+        return nil, errors.New("pkcs7: digest length does not match digest algorithm")
+    }
+
+    nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+    if err != nil {
+        return nil, err
+    }
+
+    return &TimeStampRequest{
+        HashAlgorithm: hashOid,
+        HashedMessage: digest,
+        Nonce:         nonce,
+        CertReq:       true,
+    }, nil
+}
+
+// CreateTimeStampRequest hashes data and creates a request for it
+func CreateTimeStampRequest(data []byte, hashOid asn1.ObjectIdentifier) (*TimeStampRequest, error) {
+    hashFunc, err := parseHashFromOid(hashOid)
+    if err != nil {
+        return nil, err
+    }
+
+    return NewTimeStampRequest(hashOid, hashFunc.Sum(data))
+}
+
+// ParseTimeStampRequest decodes a DER encoded TimeStampReq
+func ParseTimeStampRequest(der []byte) (*TimeStampRequest, error) {
+    var req timeStampReq
+    rest, err := asn1.Unmarshal(der, &req)
+    if err != nil {
+        return nil, err
+    }
+    if len(rest) > 0 {
+        return nil, asn1.SyntaxError{Msg: "trailing data"}
+    }
+
+    if req.Version != 1 {
+        return nil, fmt.Errorf("pkcs7: unsupported timestamp request version %d", req.Version)
+    }
+
+    return &TimeStampRequest{
+        HashAlgorithm: req.MessageImprint.HashAlgorithm.Algorithm,
+        HashedMessage: req.MessageImprint.HashedMessage,
+        Policy:        req.ReqPolicy,
+        Nonce:         req.Nonce,
+        CertReq:       req.CertReq,
+        Extensions:    req.Extensions,
+    }, nil
+}
+
+// Marshal encodes the request to DER
+func (this *TimeStampRequest) Marshal() ([]byte, error) {
+    return asn1.Marshal(timeStampReq{
+        Version: 1,
+        MessageImprint: messageImprint{
+            HashAlgorithm: pkix.AlgorithmIdentifier{
+                Algorithm:  this.HashAlgorithm,
+                Parameters: asn1.NullRawValue,
+            },
+            HashedMessage: this.HashedMessage,
+        },
+        ReqPolicy:  this.Policy,
+        Nonce:      this.Nonce,
+        CertReq:    this.CertReq,
+        Extensions: this.Extensions,
+    })
+}
+
+// TimeStampResponse is a RFC 3161 TimeStampResp
+type TimeStampResponse struct {
+    Status       int
+    StatusString []string
+    FailInfo     asn1.BitString
+
+    // DER encoded ContentInfo of the timestamp token
+    Token        []byte
+}
+
+// ParseTimeStampResponse decodes a DER encoded TimeStampResp
+func ParseTimeStampResponse(der []byte) (*TimeStampResponse, error) {
+    var resp timeStampResp
+    rest, err := asn1.Unmarshal(der, &resp)
+    if err != nil {
+        return nil, err
+    }
+    if len(rest) > 0 {
+        return nil, asn1.SyntaxError{Msg: "trailing data"}
+    }
+
+    return &TimeStampResponse{
+        Status:       resp.Status.Status,
+        StatusString: resp.Status.StatusString,
+        FailInfo:     resp.Status.FailInfo,
+        Token:        resp.TimeStampToken.FullBytes,
+    }, nil
+}
+
+// Marshal encodes the response to DER
+func (this *TimeStampResponse) Marshal() ([]byte, error) {
+    resp := timeStampResp{
+        Status: pkiStatusInfo{
+            Status:       this.Status,
+            StatusString: this.StatusString,
+            FailInfo:     this.FailInfo,
+        },
+    }
+
+    if len(this.Token) > 0 {
+        resp.TimeStampToken = asn1.RawValue{FullBytes: this.Token}
+    }
+
+    return asn1.Marshal(resp)
+}
+
+// TimeStamp parses the token of a granted response
+func (this *TimeStampResponse) TimeStamp() (*TimeStamp, error) {
+    if this.Status != TimeStampGranted && this.Status != TimeStampGrantedWithMods {
+        return nil, &TimeStampStatusError{
+            Status:       this.Status,
+            StatusString: this.StatusString,
+            FailInfo:     this.FailInfo,
+        }
+    }
+
+    return ParseTimeStampToken(this.Token)
+}
+
+// TimeStampStatusError is returned when the TSA does not grant a request
+type TimeStampStatusError struct {
+    Status       int
+    StatusString []string
+    FailInfo     asn1.BitString
+}
+
+func (err *TimeStampStatusError) Error() string {
+    msg := fmt.Sprintf("pkcs7: timestamp request not granted, status %d", err.Status)
+    if len(err.StatusString) > 0 {
+        msg += fmt.Sprintf(" %q", err.StatusString)
+    }
+
+    for i := 0; i < err.FailInfo.BitLength; i++ {
+        if err.FailInfo.At(i) == 1 {
+            msg += fmt.Sprintf(", failure info bit %d", i)
+        }
+    }
+
+    return msg
+}
+
+// TimeStamp is a parsed RFC 3161 timestamp token
+type TimeStamp struct {
+    HashAlgorithm asn1.ObjectIdentifier
+    HashedMessage []byte
+    Time          time.Time
+    Accuracy      time.Duration
+    SerialNumber  *big.Int
+    Policy        asn1.ObjectIdentifier
+    Ordering      bool
+    Nonce         *big.Int
+    Extensions    []pkix.Extension
+
+    // Certificates included in the token
+    Certificates  []*x509.Certificate
+
+    // DER encoded ContentInfo of the token
+    RawToken      []byte
+
+    p7 *PKCS7
+}
+
+// ParseTimeStampToken decodes a DER encoded timestamp token. It does not
+// verify the signature, use Verify for that.
+func ParseTimeStampToken(token []byte) (*TimeStamp, error) {
+    p7, err := Parse(token)
+    if err != nil {
+        return nil, err
+    }
+
+    sd, ok := p7.raw.(signedData)
+    if !ok || !sd.ContentInfo.ContentType.Equal(oidTSTInfo) {
+        return nil, errors.New("pkcs7: token content is not TSTInfo")
+    }
+
+    var info tstInfo
+    rest, err := asn1.Unmarshal(p7.Content, &info)
+    if err != nil {
+        return nil, err
+    }
+    if len(rest) > 0 {
+        return nil, asn1.SyntaxError{Msg: "trailing data"}
+    }
+
+    if info.Version != 1 {
+        return nil, fmt.Errorf("pkcs7: unsupported TSTInfo version %d", info.Version)
+    }
+
+    genTime, err := parseGeneralizedTime(info.GenTime)
+    if err != nil {
+        return nil, err
+    }
+
+    acc := time.Duration(info.Accuracy.Seconds) * time.Second +
+        time.Duration(info.Accuracy.Millis) * time.Millisecond +
+        time.Duration(info.Accuracy.Micros) * time.Microsecond
+
+    return &TimeStamp{
+        HashAlgorithm: info.MessageImprint.HashAlgorithm.Algorithm,
+        HashedMessage: info.MessageImprint.HashedMessage,
+        Time:          genTime,
+        Accuracy:      acc,
+        SerialNumber:  info.SerialNumber,
+        Policy:        info.Policy,
+        Ordering:      info.Ordering,
+        Nonce:         info.Nonce,
+        Extensions:    info.Extensions,
+        Certificates:  p7.Certificates,
+        RawToken:      token,
+        p7:            p7,
+    }, nil
+}
+
+// Verify checks the signature of the token and that the signer certificate
+// is allowed to issue timestamps. If truststore is not nil, it also verifies
+// the chain of the signer certificate at the time of the timestamp.
+// Tokens issued without certificates need them added to Certificates first.
+func (this *TimeStamp) Verify(truststore *x509.CertPool) error {
+    if len(this.p7.Signers) != 1 {
+        return errors.New("pkcs7: timestamp token must have one signer")
+    }
+
+    signer := this.p7.Signers[0]
+    if len(signer.AuthenticatedAttributes) == 0 {
+        return errors.New("pkcs7: timestamp token has no authenticated attributes")
+    }
+
+    this.p7.Certificates = this.Certificates
+
+    ee := getCertFromCertsByIssuerAndSerial(this.Certificates, signer.IssuerAndSerialNumber)
+    if ee == nil {
+        return errors.New("pkcs7: No certificate for timestamp signer")
+    }
+
+    if err := checkSigningCertificate(signer, ee); err != nil {
+        return err
+    }
+
+    if err := this.p7.VerifyWithChainAtTime(nil, this.Time); err != nil {
+        return err
+    }
+
+    hasUsage := false
+    for _, usage := range ee.ExtKeyUsage {
+        if usage == x509.ExtKeyUsageTimeStamping {
+            hasUsage = true
+            break
+        }
+    }
+    if !hasUsage {
+        return errors.New("pkcs7: timestamp signer certificate has no timeStamping usage")
+    }
+
+    if truststore != nil {
+        intermediates := x509.NewCertPool()
+        for _, cert := range this.Certificates {
+            intermediates.AddCert(cert)
+        }
+
+        _, err := ee.Verify(x509.VerifyOptions{
+            Roots:         truststore,
+            Intermediates: intermediates,
+            KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+            CurrentTime:   this.Time,
+        })
+        if err != nil {
+            return fmt.Errorf("pkcs7: failed to verify timestamp certificate chain: %v", err)
+        }
+    }
+
+    return nil
+}
+
+// VerifyDigest checks that the token was issued for the digest
+func (this *TimeStamp) VerifyDigest(hashOid asn1.ObjectIdentifier, digest []byte) error {
+    if !this.HashAlgorithm.Equal(hashOid) {
+        return fmt.Errorf("pkcs7: timestamp hash algorithm (OID: %s) is not same as (OID: %s)", this.HashAlgorithm, hashOid)
+    }
+
+    if subtle.ConstantTimeCompare(this.HashedMessage, digest) != 1 {
+        return &MessageDigestMismatchError{
+            ExpectedDigest: this.HashedMessage,
+            ActualDigest:   digest,
+        }
+    }
+
+    return nil
+}
+
+// VerifyData hashes data and checks that the token was issued for it
+func (this *TimeStamp) VerifyData(data []byte) error {
+    hashFunc, err := parseHashFromOid(this.HashAlgorithm)
+    if err != nil {
+        return err
+    }
+
+    return this.VerifyDigest(this.HashAlgorithm, hashFunc.Sum(data))
+}
+
+// VerifyRequest checks that the token answers the request
+func (this *TimeStamp) VerifyRequest(req *TimeStampRequest) error {
+    if err := this.VerifyDigest(req.HashAlgorithm, req.HashedMessage); err != nil {
+        return err
+    }
+
+    if req.Nonce != nil && (this.Nonce == nil || req.Nonce.Cmp(this.Nonce) != 0) {
+        return errors.New("pkcs7: timestamp nonce does not match request")
+    }
+
+    if len(req.Policy) > 0 && !req.Policy.Equal(this.Policy) {
+        return errors.New("pkcs7: timestamp policy does not match request")
+    }
+
+    if req.CertReq && len(this.Certificates) == 0 {
+        return errors.New("pkcs7: timestamp token has no certificates")
+    }
+
+    return nil
+}
+
+// AddTimeStampToken adds a timestamp token of the signature value as an
+// unauthenticated attribute of the signer at index. An existing token of
+// the signer is replaced.
+func (this *SignedData) AddTimeStampToken(index int, token []byte) error {
+    if index < 0 || index >= len(this.sd.SignerInfos) {
+        return errors.New("pkcs7: signer index out of range")
+    }
+
+    ts, err := ParseTimeStampToken(token)
+    if err != nil {
+        return err
+    }
+
+    signer := &this.sd.SignerInfos[index]
+    if err := ts.VerifyData(signer.EncryptedDigest); err != nil {
+        return err
+    }
+
+    attrs := make([]attribute, 0, len(signer.UnauthenticatedAttributes) + 1)
+    for _, attr := range signer.UnauthenticatedAttributes {
+        if !attr.Type.Equal(oidAttributeTimeStampToken) {
+            attrs = append(attrs, attr)
+        }
+    }
+
+    attrs = append(attrs, attribute{
+        Type:  oidAttributeTimeStampToken,
+        Value: asn1.RawValue{Tag: 17, IsCompound: true, Bytes: token}, // 17 == SET tag
+    })
+
+    finalAttrs, err := sortAttributes(attrs)
+    if err != nil {
+        return err
+    }
+
+    signer.UnauthenticatedAttributes = finalAttrs
+    return nil
+}
+
+// TimeStampSigners requests a timestamp of every signature value from tsa
+// and adds the tokens to the signers. Call it after adding the signers and
+// before Finish.
+func (this *SignedData) TimeStampSigners(tsa TimeStamper) error {
+    if len(this.sd.SignerInfos) == 0 {
+        return errors.New("pkcs7: Message has no signers")
+    }
+
+    for i, signer := range this.sd.SignerInfos {
+        req, err := CreateTimeStampRequest(signer.EncryptedDigest, signer.DigestAlgorithm.Algorithm)
+        if err != nil {
+            return err
+        }
+
+        resp, err := tsa.TimeStamp(req)
+        if err != nil {
+            return err
+        }
+
+        ts, err := resp.TimeStamp()
+        if err != nil {
+            return err
+        }
+
+        if err := ts.VerifyRequest(req); err != nil {
+            return err
+        }
+
+        if err := this.AddTimeStampToken(i, ts.RawToken); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// TimeStamps returns the parsed timestamp tokens of the signers, in signer
+// order. The value is nil for signers without a token.
+func (this *PKCS7) TimeStamps() ([]*TimeStamp, error) {
+    stamps := make([]*TimeStamp, len(this.Signers))
+
+    for i, signer := range this.Signers {
+        var token asn1.RawValue
+        err := unmarshalAttribute(signer.UnauthenticatedAttributes, oidAttributeTimeStampToken, &token)
+        if err != nil {
+            continue
+        }
+
+        ts, err := ParseTimeStampToken(token.FullBytes)
+        if err != nil {
+            return nil, err
+        }
+
+        stamps[i] = ts
+    }
+
+    return stamps, nil
+}
+
+// VerifyTimeStamps checks that every signer has a timestamp token issued
+// for its signature value and verifies the tokens against tsaTruststore.
+func (this *PKCS7) VerifyTimeStamps(tsaTruststore *x509.CertPool) ([]*TimeStamp, error) {
+    if len(this.Signers) == 0 {
+        return nil, errors.New("pkcs7: Message has no signers")
+    }
+
+    stamps, err := this.TimeStamps()
+    if err != nil {
+        return nil, err
+    }
+
+    for i, ts := range stamps {
+        if ts == nil {
+            return nil, errors.New("pkcs7: signer has no timestamp token")
+        }
+
+        if err := ts.VerifyData(this.Signers[i].EncryptedDigest); err != nil {
+            return nil, err
+        }
+
+        if err := ts.Verify(tsaTruststore); err != nil {
+            return nil, err
+        }
+    }
+
+    return stamps, nil
+}
+
+// VerifyWithTimeStamp checks the timestamp tokens of all signers, then
+// verifies the signatures with the chain of trust of every signer checked
+// at the time of its token instead of now. This keeps signatures valid
+// after the signer certificate expired.
+func (this *PKCS7) VerifyWithTimeStamp(truststore, tsaTruststore *x509.CertPool) error {
+    stamps, err := this.VerifyTimeStamps(tsaTruststore)
+    if err != nil {
+        return err
+    }
+
+    for i, signer := range this.Signers {
+        if err := verifySignatureAtTime(this, signer, truststore, stamps[i].Time, nil); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 检测 ESS 签名证书属性，RFC 3161 令牌需要包含该属性
+func checkSigningCertificate(signer signerInfo, ee *x509.Certificate) error {
+    var v2 signingCertificateV2
+    if err := unmarshalAttribute(signer.AuthenticatedAttributes, oidAttributeSigningCertificateV2, &v2); err == nil {
+        if len(v2.Certs) == 0 {
+            return errors.New("pkcs7: empty signing certificate attribute")
+        }
+
+        hashOid := v2.Certs[0].HashAlgorithm.Algorithm
+        if len(hashOid) == 0 {
+            hashOid = oidDigestAlgorithmSHA256
+        }
+
+        hashFunc, err := parseHashFromOid(hashOid)
+        if err != nil {
+            return err
+        }
+
+        if !bytes.Equal(hashFunc.Sum(ee.Raw), v2.Certs[0].CertHash) {
+            return errors.New("pkcs7: signing certificate attribute does not match signer")
+        }
+
+        return nil
+    }
+
+    var v1 signingCertificate
+    if err := unmarshalAttribute(signer.AuthenticatedAttributes, oidAttributeSigningCertificate, &v1); err == nil {
+        if len(v1.Certs) == 0 {
+            return errors.New("pkcs7: empty signing certificate attribute")
+        }
+
+        if !bytes.Equal(SignHashWithSHA1.Sum(ee.Raw), v1.Certs[0].CertHash) {
+            return errors.New("pkcs7: signing certificate attribute does not match signer")
+        }
+
+        return nil
+    }
+
+    return errors.New("pkcs7: timestamp token has no signing certificate attribute")
+}
+
+// 已编码属性排序
+func sortAttributes(attrs []attribute) ([]attribute, error) {
+    sortables := make(attributeSet, len(attrs))
+    for i, attr := range attrs {
+        encoded, err := asn1.Marshal(attr)
+        if err != nil {
+            return nil, err
+        }
+
+        sortables[i] = sortableAttribute{
+            SortKey:   encoded,
+            Attribute: attr,
+        }
+    }
+
+    sort.Sort(sortables)
+    return sortables.Attributes(), nil
+}
+
+// GeneralizedTime 可带小数秒，encoding/asn1 不支持
+func parseGeneralizedTime(raw asn1.RawValue) (time.Time, error) {
+    if raw.Class != asn1.ClassUniversal || raw.Tag != asn1.TagGeneralizedTime {
+        return time.Time{}, errors.New("pkcs7: genTime is not GeneralizedTime")
+    }
+
+    t, err := time.Parse("20060102150405Z0700", string(raw.Bytes))
+    if err != nil {
+        return time.Time{}, fmt.Errorf("pkcs7: invalid genTime: %v", err)
+    }
+
+    return t, nil
+}
+
+func marshalGeneralizedTime(t time.Time) asn1.RawValue {
+    return asn1.RawValue{
+        Class: asn1.ClassUniversal,
+        Tag:   asn1.TagGeneralizedTime,
+        Bytes: []byte(t.UTC().Format("20060102150405.999999999Z")),
+    }
+}
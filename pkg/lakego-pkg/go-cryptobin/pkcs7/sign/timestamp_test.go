@@ -0,0 +1,331 @@
+package sign
+
+import (
+    "time"
+    "errors"
+    "testing"
+    "math/big"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/x509"
+    "crypto/elliptic"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+)
+
+var testTimeStampPolicy = asn1.ObjectIdentifier{1, 2, 3, 4, 1}
+
+// 生成证书，parent 为空时自签名
+func testCreateCert(t *testing.T, name string, isCA bool, usage []x509.ExtKeyUsage, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+    key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    serial, err := rand.Int(rand.Reader, big.NewInt(1 << 62))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tmpl := &x509.Certificate{
+        SerialNumber:          serial,
+        Subject:               pkix.Name{CommonName: name},
+        NotBefore:             time.Now().Add(-time.Hour),
+        NotAfter:              time.Now().Add(time.Hour),
+        KeyUsage:              x509.KeyUsageDigitalSignature,
+        ExtKeyUsage:           usage,
+        BasicConstraintsValid: true,
+        IsCA:                  isCA,
+    }
+    if isCA {
+        tmpl.KeyUsage |= x509.KeyUsageCertSign
+    }
+
+    if parent == nil {
+        parent, parentKey = tmpl, key
+    }
+
+    der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    cert, err := x509.ParseCertificate(der)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return cert, key
+}
+
+// 生成 CA 签发的本地时间戳服务
+func testCreateTSA(t *testing.T) (*TimeStampAuthority, *x509.CertPool) {
+    caCert, caKey := testCreateCert(t, "tsa root", true, nil, nil, nil)
+    tsaCert, tsaKey := testCreateCert(t, "tsa", false, []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping}, caCert, caKey)
+
+    tsa, err := NewTimeStampAuthority(tsaCert, tsaKey, testTimeStampPolicy)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    pool := x509.NewCertPool()
+    pool.AddCert(caCert)
+
+    return tsa, pool
+}
+
+func TestTimeStampRoundTrip(t *testing.T) {
+    tsa, pool := testCreateTSA(t)
+
+    data := []byte("timestamp data")
+
+    req, err := CreateTimeStampRequest(data, oidDigestAlgorithmSHA256)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    reqDer, err := req.Marshal()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    respDer, err := tsa.Respond(reqDer)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err := ParseTimeStampResponse(respDer)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ts, err := resp.TimeStamp()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ts.Verify(pool); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ts.VerifyRequest(req); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ts.VerifyDigest(req.HashAlgorithm, req.HashedMessage); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ts.VerifyData(data); err != nil {
+        t.Fatal(err)
+    }
+
+    if !ts.Policy.Equal(testTimeStampPolicy) {
+        t.Errorf("policy got %s, want %s", ts.Policy, testTimeStampPolicy)
+    }
+
+    if ts.Nonce == nil || ts.Nonce.Cmp(req.Nonce) != 0 {
+        t.Error("nonce does not match request")
+    }
+
+    // 重新解析令牌
+    parsed, err := ParseTimeStampToken(ts.RawToken)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := parsed.Verify(pool); err != nil {
+        t.Fatal(err)
+    }
+
+    // 其他根证书
+    _, otherPool := testCreateTSA(t)
+    if err := parsed.Verify(otherPool); err == nil {
+        t.Error("Verify should fail with another truststore")
+    }
+}
+
+func TestTimeStampTamperedDigest(t *testing.T) {
+    tsa, pool := testCreateTSA(t)
+
+    data := []byte("timestamp data")
+
+    req, err := CreateTimeStampRequest(data, oidDigestAlgorithmSHA256)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err := tsa.TimeStamp(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ts, err := resp.TimeStamp()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tampered := append([]byte{}, req.HashedMessage...)
+    tampered[0] ^= 0xff
+
+    var mismatch *MessageDigestMismatchError
+
+    err = ts.VerifyDigest(oidDigestAlgorithmSHA256, tampered)
+    if !errors.As(err, &mismatch) {
+        t.Errorf("VerifyDigest got %v, want digest mismatch", err)
+    }
+
+    err = ts.VerifyData([]byte("other data"))
+    if !errors.As(err, &mismatch) {
+        t.Errorf("VerifyData got %v, want digest mismatch", err)
+    }
+
+    // 请求摘要被修改
+    tamperedReq := *req
+    tamperedReq.HashedMessage = tampered
+    if err := ts.VerifyRequest(&tamperedReq); err == nil {
+        t.Error("VerifyRequest should fail with tampered digest")
+    }
+
+    // 令牌内容被修改
+    token := append([]byte{}, ts.RawToken...)
+    for i := 0; i+len(req.HashedMessage) <= len(token); i++ {
+        if string(token[i:i+len(req.HashedMessage)]) == string(req.HashedMessage) {
+            token[i] ^= 0xff
+            break
+        }
+    }
+
+    parsed, err := ParseTimeStampToken(token)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := parsed.Verify(pool); err == nil {
+        t.Error("Verify should fail with tampered token")
+    }
+}
+
+func TestTimeStampRejection(t *testing.T) {
+    tsa, _ := testCreateTSA(t)
+
+    req, err := CreateTimeStampRequest([]byte("timestamp data"), oidDigestAlgorithmSHA256)
+    if err != nil {
+        t.Fatal(err)
+    }
+    req.Policy = asn1.ObjectIdentifier{1, 2, 3, 4, 2}
+
+    resp, err := tsa.TimeStamp(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    _, err = resp.TimeStamp()
+
+    var statusErr *TimeStampStatusError
+    if !errors.As(err, &statusErr) {
+        t.Errorf("TimeStamp got %v, want status error", err)
+    }
+}
+
+func TestTimeStampSigners(t *testing.T) {
+    tsa, tsaPool := testCreateTSA(t)
+
+    caCert, caKey := testCreateCert(t, "root", true, nil, nil, nil)
+    signerCert, signerKey := testCreateCert(t, "signer", false, nil, caCert, caKey)
+
+    pool := x509.NewCertPool()
+    pool.AddCert(caCert)
+
+    data := []byte("signed data")
+
+    // 无时间戳的签名
+    sd, err := NewSignedData(data)
+    if err != nil {
+        t.Fatal(err)
+    }
+    sd.SetDigestAlgorithm(oidDigestAlgorithmSHA256)
+    sd.SetEncryptionAlgorithm(oidDigestAlgorithmECDSASHA256)
+
+    if err := sd.AddSignerChain(signerCert, signerKey, []*x509.Certificate{caCert}, SignerInfoConfig{}); err != nil {
+        t.Fatal(err)
+    }
+
+    unstamped, err := sd.Finish()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    p7, err := Parse(unstamped)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := p7.VerifyTimeStamps(tsaPool); err == nil {
+        t.Error("VerifyTimeStamps should fail without tokens")
+    }
+
+    // 签名时请求时间戳
+    if err := sd.TimeStampSigners(tsa); err != nil {
+        t.Fatal(err)
+    }
+
+    stamped, err := sd.Finish()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    p7, err = Parse(stamped)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    stamps, err := p7.TimeStamps()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(stamps) != 1 || stamps[0] == nil {
+        t.Fatalf("TimeStamps got %v, want one token", stamps)
+    }
+
+    if _, err := p7.VerifyTimeStamps(tsaPool); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := p7.VerifyWithTimeStamp(pool, tsaPool); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := p7.VerifyWithTimeStamp(pool, pool); err == nil {
+        t.Error("VerifyWithTimeStamp should fail with another TSA truststore")
+    }
+
+    // 其他签名值的令牌不能添加
+    req, err := CreateTimeStampRequest([]byte("other signature"), oidDigestAlgorithmSHA256)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    resp, err := tsa.TimeStamp(req)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ts, err := resp.TimeStamp()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := sd.AddTimeStampToken(0, ts.RawToken); err == nil {
+        t.Error("AddTimeStampToken should fail with token of other data")
+    }
+
+    if err := sd.AddTimeStampToken(1, stamps[0].RawToken); err == nil {
+        t.Error("AddTimeStampToken should fail with signer index out of range")
+    }
+
+    // 已有令牌可以重新添加
+    if err := sd.AddTimeStampToken(0, stamps[0].RawToken); err != nil {
+        t.Fatal(err)
+    }
+}
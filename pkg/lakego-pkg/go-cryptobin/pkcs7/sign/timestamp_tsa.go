@@ -0,0 +1,296 @@
+package sign
+
+import (
+    "io"
+    "time"
+    "bytes"
+    "errors"
+    "net/http"
+    "math/big"
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/ecdsa"
+    "crypto/sha256"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+
+    "github.com/tjfoc/gmsm/sm2"
+)
+
+// 请求及响应最大长度
+const maxTimeStampMessageSize = 1 << 20
+
+// TimeStamper issues timestamp tokens for requests
+type TimeStamper interface {
+    TimeStamp(req *TimeStampRequest) (*TimeStampResponse, error)
+}
+
+// HTTPTimeStamper requests timestamps from a RFC 3161 HTTP TSA
+type HTTPTimeStamper struct {
+    URL    string
+    Client *http.Client
+}
+
+// NewHTTPTimeStamper returns a TimeStamper posting requests to url
+func NewHTTPTimeStamper(url string) *HTTPTimeStamper {
+    return &HTTPTimeStamper{
+        URL:    url,
+        Client: http.DefaultClient,
+    }
+}
+
+// TimeStamp posts the request and parses the response
+func (this *HTTPTimeStamper) TimeStamp(req *TimeStampRequest) (*TimeStampResponse, error) {
+    der, err := req.Marshal()
+    if err != nil {
+        return nil, err
+    }
+
+    client := this.Client
+    if client == nil {
+        client = http.DefaultClient
+    }
+
+    httpResp, err := client.Post(this.URL, "application/timestamp-query", bytes.NewReader(der))
+    if err != nil {
+        return nil, err
+    }
+    defer httpResp.Body.Close()
+
+    if httpResp.StatusCode != http.StatusOK {
+        return nil, errors.New("pkcs7: timestamp server returned " + httpResp.Status)
+    }
+
+    body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxTimeStampMessageSize))
+    if err != nil {
+        return nil, err
+    }
+
+    return ParseTimeStampResponse(body)
+}
+
+/**
+ * 本地时间戳服务，可用于测试或者内部签发
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TimeStampAuthority struct {
+    // 签名证书，需要有 timeStamping 扩展用途
+    Certificate *x509.Certificate
+
+    // 签名私钥
+    PrivateKey crypto.PrivateKey
+
+    // 签名证书的上级证书
+    Parents []*x509.Certificate
+
+    // 时间戳策略
+    Policy asn1.ObjectIdentifier
+
+    // 令牌签名算法
+    DigestAlgorithm     asn1.ObjectIdentifier
+    EncryptionAlgorithm asn1.ObjectIdentifier
+
+    // 时间精度
+    Accuracy time.Duration
+
+    // 当前时间，为空时使用 time.Now
+    Now func() time.Time
+}
+
+// NewTimeStampAuthority creates a local TSA. The token signature algorithm
+// is chosen from the key and can be changed on the returned value.
+func NewTimeStampAuthority(cert *x509.Certificate, pkey crypto.PrivateKey, policy asn1.ObjectIdentifier) (*TimeStampAuthority, error) {
+    hasUsage := false
+    for _, usage := range cert.ExtKeyUsage {
+        if usage == x509.ExtKeyUsageTimeStamping {
+            hasUsage = true
+            break
+        }
+    }
+    if !hasUsage {
+        return nil, errors.New("pkcs7: TSA certificate has no timeStamping usage")
+    }
+
+    if len(policy) == 0 {
+        return nil, errors.New("pkcs7: TSA policy is empty")
+    }
+
+    tsa := &TimeStampAuthority{
+        Certificate: cert,
+        PrivateKey:  pkey,
+        Policy:      policy,
+        Accuracy:    time.Second,
+    }
+
+    switch pkey.(type) {
+        case *rsa.PrivateKey:
+            tsa.DigestAlgorithm = oidDigestAlgorithmSHA256
+            tsa.EncryptionAlgorithm = oidDigestAlgorithmRSASHA256
+        case *ecdsa.PrivateKey:
+            tsa.DigestAlgorithm = oidDigestAlgorithmSHA256
+            tsa.EncryptionAlgorithm = oidDigestAlgorithmECDSASHA256
+        case *sm2.PrivateKey:
+            tsa.DigestAlgorithm = oidDigestAlgorithmSM3
+            tsa.EncryptionAlgorithm = oidDigestAlgorithmSM2SM3
+        default:
+            return nil, errors.New("pkcs7: unsupported TSA private key")
+    }
+
+    return tsa, nil
+}
+
+// TimeStamp answers the request. Requests the TSA cannot serve get a
+// rejection response, the error is only set when signing fails.
+func (this *TimeStampAuthority) TimeStamp(req *TimeStampRequest) (*TimeStampResponse, error) {
+    hashFunc, err := parseHashFromOid(req.HashAlgorithm)
+    if err != nil || len(req.HashedMessage) != len(hashFunc.Sum(nil)) {
+        return rejectTimeStamp(TimeStampFailBadAlg, "unsupported hash algorithm"), nil
+    }
+
+    if len(req.Policy) > 0 && !req.Policy.Equal(this.Policy) {
+        return rejectTimeStamp(TimeStampFailUnacceptedPolicy, "unaccepted policy"), nil
+    }
+
+    if len(req.Extensions) > 0 {
+        return rejectTimeStamp(TimeStampFailUnacceptedExtension, "unaccepted extension"), nil
+    }
+
+    token, err := this.sign(req)
+    if err != nil {
+        return nil, err
+    }
+
+    return &TimeStampResponse{
+        Status: TimeStampGranted,
+        Token:  token,
+    }, nil
+}
+
+// Respond answers a DER encoded request with a DER encoded response
+func (this *TimeStampAuthority) Respond(der []byte) ([]byte, error) {
+    req, err := ParseTimeStampRequest(der)
+    if err != nil {
+        return rejectTimeStamp(TimeStampFailBadDataFormat, "bad request").Marshal()
+    }
+
+    resp, err := this.TimeStamp(req)
+    if err != nil {
+        return rejectTimeStamp(TimeStampFailSystemFailure, "system failure").Marshal()
+    }
+
+    return resp.Marshal()
+}
+
+// ServeHTTP serves RFC 3161 requests over HTTP
+func (this *TimeStampAuthority) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+
+    der, err := io.ReadAll(io.LimitReader(r.Body, maxTimeStampMessageSize))
+    if err != nil {
+        http.Error(w, "bad request", http.StatusBadRequest)
+        return
+    }
+
+    resp, err := this.Respond(der)
+    if err != nil {
+        http.Error(w, "internal error", http.StatusInternalServerError)
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/timestamp-reply")
+    w.Write(resp)
+}
+
+// 签发令牌
+func (this *TimeStampAuthority) sign(req *TimeStampRequest) ([]byte, error) {
+    now := time.Now
+    if this.Now != nil {
+        now = this.Now
+    }
+
+    serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+    if err != nil {
+        return nil, err
+    }
+
+    info := tstInfo{
+        Version: 1,
+        Policy:  this.Policy,
+        MessageImprint: messageImprint{
+            HashAlgorithm: pkix.AlgorithmIdentifier{
+                Algorithm:  req.HashAlgorithm,
+                Parameters: asn1.NullRawValue,
+            },
+            HashedMessage: req.HashedMessage,
+        },
+        SerialNumber: serial,
+        GenTime:      marshalGeneralizedTime(now()),
+        Accuracy:     accuracy{
+            Seconds: int(this.Accuracy / time.Second),
+            Millis:  int(this.Accuracy % time.Second / time.Millisecond),
+            Micros:  int(this.Accuracy % time.Millisecond / time.Microsecond),
+        },
+        Nonce: req.Nonce,
+    }
+
+    content, err := asn1.Marshal(info)
+    if err != nil {
+        return nil, err
+    }
+
+    certHash := sha256.Sum256(this.Certificate.Raw)
+    signingCert := signingCertificateV2{
+        Certs: []essCertIDv2{
+            {CertHash: certHash[:]},
+        },
+    }
+
+    sd, err := NewSignedData(content)
+    if err != nil {
+        return nil, err
+    }
+
+    sd.SetContentType(oidTSTInfo)
+    sd.SetDigestAlgorithm(this.DigestAlgorithm)
+    sd.SetEncryptionAlgorithm(this.EncryptionAlgorithm)
+
+    err = sd.AddSignerChain(this.Certificate, this.PrivateKey, this.Parents, SignerInfoConfig{
+        ExtraSignedAttributes: []Attribute{
+            {Type: oidAttributeSigningCertificateV2, Value: signingCert},
+        },
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    // RFC 5652, eContentType 不为 data 时版本为 3
+    sd.sd.Version = 3
+
+    if !req.CertReq {
+        sd.certs = nil
+    }
+
+    return sd.Finish()
+}
+
+// 拒绝响应
+func rejectTimeStamp(failInfo int, msg string) *TimeStampResponse {
+    bits := make([]byte, failInfo / 8 + 1)
+    bits[failInfo / 8] = 0x80 >> uint(failInfo % 8)
+
+    return &TimeStampResponse{
+        Status:       TimeStampRejection,
+        StatusString: []string{msg},
+        FailInfo:     asn1.BitString{
+            Bytes:     bits,
+            BitLength: failInfo + 1,
+        },
+    }
+}
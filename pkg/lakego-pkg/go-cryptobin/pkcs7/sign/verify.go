@@ -32,7 +32,7 @@ func (this *PKCS7) VerifyWithChain(truststore *x509.CertPool) (err error) {
     }
 
     for _, signer := range this.Signers {
-        if err := verifySignature(this, signer, truststore); err != nil {
+        if err := verifySignature(this, signer, truststore, nil); err != nil {
             return err
         }
     }
@@ -52,7 +52,7 @@ func (this *PKCS7) VerifyWithChainAtTime(truststore *x509.CertPool, currentTime
     }
 
     for _, signer := range this.Signers {
-        if err := verifySignatureAtTime(this, signer, truststore, currentTime); err != nil {
+        if err := verifySignatureAtTime(this, signer, truststore, currentTime, nil); err != nil {
             return err
         }
     }
@@ -60,13 +60,17 @@ func (this *PKCS7) VerifyWithChainAtTime(truststore *x509.CertPool, currentTime
     return nil
 }
 
-func verifySignatureAtTime(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool, currentTime time.Time) (err error) {
+func verifySignatureAtTime(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool, currentTime time.Time, digests map[string][]byte) (err error) {
     signedData := p7.Content
     ee := getCertFromCertsByIssuerAndSerial(p7.Certificates, signer.IssuerAndSerialNumber)
     if ee == nil {
         return errors.New("pkcs7: No certificate for signer")
     }
 
+    if len(signer.AuthenticatedAttributes) == 0 && digests != nil {
+        return errors.New("pkcs7: detached digest needs authenticated attributes")
+    }
+
     if len(signer.AuthenticatedAttributes) > 0 {
         var (
             digest      []byte
@@ -78,12 +82,10 @@ func verifySignatureAtTime(p7 *PKCS7, signer signerInfo, truststore *x509.CertPo
             return err
         }
 
-        hashFunc, err := parseHashFromOid(signer.DigestAlgorithm.Algorithm)
+        computed, err := signerDigest(p7, signer, digests)
         if err != nil {
             return err
         }
-
-        computed := hashFunc.Sum(p7.Content)
         if subtle.ConstantTimeCompare(digest, computed) != 1 {
             return &MessageDigestMismatchError{
                 ExpectedDigest: digest,
@@ -125,13 +127,17 @@ func verifySignatureAtTime(p7 *PKCS7, signer signerInfo, truststore *x509.CertPo
 
     checkStatus, err := signFunc.Verify(pkey, signedData, signer.EncryptedDigest)
     if !checkStatus {
+        if err == nil {
+            err = errors.New("pkcs7: signature verification failed")
+        }
+
         return err
     }
 
     return nil
 }
 
-func verifySignature(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool) (err error) {
+func verifySignature(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool, digests map[string][]byte) (err error) {
     signedData := p7.Content
     ee := getCertFromCertsByIssuerAndSerial(p7.Certificates, signer.IssuerAndSerialNumber)
     if ee == nil {
@@ -139,6 +145,10 @@ func verifySignature(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool) (e
     }
 
     signingTime := time.Now().UTC()
+    if len(signer.AuthenticatedAttributes) == 0 && digests != nil {
+        return errors.New("pkcs7: detached digest needs authenticated attributes")
+    }
+
     if len(signer.AuthenticatedAttributes) > 0 {
         var digest []byte
 
@@ -147,13 +157,11 @@ func verifySignature(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool) (e
             return err
         }
 
-        hashFunc, err := parseHashFromOid(signer.DigestAlgorithm.Algorithm)
+        computed, err := signerDigest(p7, signer, digests)
         if err != nil {
             return err
         }
 
-        computed := hashFunc.Sum(p7.Content)
-
         if subtle.ConstantTimeCompare(digest, computed) != 1 {
             return &MessageDigestMismatchError{
                 ExpectedDigest: digest,
@@ -195,12 +203,35 @@ func verifySignature(p7 *PKCS7, signer signerInfo, truststore *x509.CertPool) (e
 
     checkStatus, err := signFunc.Verify(pkey, signedData, signer.EncryptedDigest)
     if !checkStatus {
+        if err == nil {
+            err = errors.New("pkcs7: signature verification failed")
+        }
+
         return err
     }
 
     return nil
 }
 
+// 签名者摘要，设置外部摘要时使用外部摘要，否则使用 Content 计算
+func signerDigest(p7 *PKCS7, signer signerInfo, digests map[string][]byte) ([]byte, error) {
+    if digests != nil {
+        digest, ok := digests[signer.DigestAlgorithm.Algorithm.String()]
+        if !ok {
+            return nil, fmt.Errorf("pkcs7: no detached digest for signer (OID: %s)", signer.DigestAlgorithm.Algorithm)
+        }
+
+        return digest, nil
+    }
+
+    hashFunc, err := parseHashFromOid(signer.DigestAlgorithm.Algorithm)
+    if err != nil {
+        return nil, err
+    }
+
+    return hashFunc.Sum(p7.Content), nil
+}
+
 // GetOnlySigner returns an x509.Certificate for the first signer of the signed
 // data payload. If there are more or less than one signer, nil is returned
 func (this *PKCS7) GetOnlySigner() *x509.Certificate {
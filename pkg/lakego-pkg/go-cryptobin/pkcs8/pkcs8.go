@@ -199,6 +199,12 @@ func DecryptPEMBlock(block *pem.Block, password []byte) ([]byte, error) {
     return nil, errors.New("unsupported encrypted PEM")
 }
 
+// ParseKDFParameters parses the parameters of a key derivation algorithm
+// registered with AddKDF, for use by other password based formats.
+func ParseKDFParameters(keyDerivationFunc pkix.AlgorithmIdentifier) (KDFParameters, error) {
+    return parseKeyDerivationFunc(keyDerivationFunc)
+}
+
 func parseKeyDerivationFunc(keyDerivationFunc pkix.AlgorithmIdentifier) (KDFParameters, error) {
     oid := keyDerivationFunc.Algorithm.String()
     params, ok := kdfs[oid]
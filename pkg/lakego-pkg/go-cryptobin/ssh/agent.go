@@ -0,0 +1,540 @@
+package ssh
+
+import (
+    "io"
+    "sync"
+    "crypto"
+    "encoding/base64"
+    "encoding/binary"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+)
+
+// agent 协议消息
+// https://datatracker.ietf.org/doc/html/draft-miller-ssh-agent
+const (
+    agentFailure             = 5
+    agentSuccess             = 6
+    agentRequestIdentities   = 11
+    agentIdentitiesAnswer    = 12
+    agentSignRequest         = 13
+    agentSignResponse        = 14
+    agentAddIdentity         = 17
+    agentRemoveIdentity      = 18
+    agentRemoveAllIdentities = 19
+    agentLock                = 22
+    agentUnlock              = 23
+    agentAddIDConstrained    = 25
+)
+
+// 添加密钥的限制
+const (
+    agentConstrainLifetime = 1
+    agentConstrainConfirm  = 2
+)
+
+// 签名标记
+const (
+    SignatureFlagRsaSha256 = 2
+    SignatureFlagRsaSha512 = 4
+)
+
+// 消息最大长度
+const maxAgentResponseBytes = 256 << 10
+
+// 私钥字段数量，包括注释，public 为证书中已包含的公钥字段数量
+var agentKeyFields = map[string]struct{ public, total int }{
+    ssh.KeyAlgoRSA:      {2, 7},
+    ssh.KeyAlgoECDSA256: {2, 4},
+    ssh.KeyAlgoECDSA384: {2, 4},
+    ssh.KeyAlgoECDSA521: {2, 4},
+    ssh.KeyAlgoED25519:  {0, 3},
+    KeyAlgoSM2:          {1, 3},
+}
+
+// agent 操作失败
+var ErrAgentFailure = errors.New("ssh: agent operation failed")
+
+// Agent 接口
+type Agent interface {
+    // 列出密钥
+    List() ([]*AgentKey, error)
+
+    // 签名，flags 为 SignatureFlag 组合
+    Sign(key ssh.PublicKey, data []byte, flags uint32) (*ssh.Signature, error)
+
+    // 添加密钥
+    Add(key AddedKey) error
+
+    // 删除密钥
+    Remove(key ssh.PublicKey) error
+
+    // 删除全部密钥
+    RemoveAll() error
+
+    // 锁定
+    Lock(passphrase []byte) error
+
+    // 解锁
+    Unlock(passphrase []byte) error
+
+    // 签名器列表
+    Signers() ([]ssh.Signer, error)
+}
+
+// 添加到 agent 的密钥
+type AddedKey struct {
+    // 私钥，支持 RSA, ECDSA, EdDSA 及 SM2
+    PrivateKey crypto.PrivateKey
+
+    // 证书，可为空
+    Certificate *Certificate
+
+    // 注释
+    Comment string
+
+    // 有效时间，为 0 时不过期
+    LifetimeSecs uint32
+
+    // 使用前需要确认
+    ConfirmBeforeUse bool
+}
+
+// agent 中的公钥
+type AgentKey struct {
+    Format  string
+    Blob    []byte
+    Comment string
+}
+
+// 类型
+func (this *AgentKey) Type() string {
+    return this.Format
+}
+
+// 编码
+func (this *AgentKey) Marshal() []byte {
+    return this.Blob
+}
+
+// 验证
+func (this *AgentKey) Verify(data []byte, sig *ssh.Signature) error {
+    pub, err := ParsePublicKey(this.Blob)
+    if err != nil {
+        return err
+    }
+
+    return pub.Verify(data, sig)
+}
+
+// authorized_keys 格式
+func (this *AgentKey) String() string {
+    s := this.Format + " " + base64.StdEncoding.EncodeToString(this.Blob)
+    if this.Comment != "" {
+        s += " " + this.Comment
+    }
+
+    return s
+}
+
+// 编码添加密钥消息
+func marshalAddedKey(key AddedKey) ([]byte, error) {
+    newKey, err := ParseKeytype(GetStructName(key.PrivateKey))
+    if err != nil {
+        return nil, err
+    }
+
+    keyType, _, rest, err := newKey.Marshal(key.PrivateKey, key.Comment)
+    if err != nil {
+        return nil, err
+    }
+
+    var out []byte
+    if key.Certificate != nil {
+        // 证书已包含公钥字段
+        _, privRest, err := splitFields(rest, agentKeyFields[keyType].public)
+        if err != nil {
+            return nil, err
+        }
+
+        out = ssh.Marshal(struct {
+            KeyType string
+            Cert    []byte
+        }{
+            key.Certificate.Type(),
+            key.Certificate.Marshal(),
+        })
+        out = append(out, privRest...)
+    } else {
+        out = ssh.Marshal(struct {
+            KeyType string
+        }{keyType})
+        out = append(out, rest...)
+    }
+
+    var constraints []byte
+    if key.LifetimeSecs > 0 {
+        constraints = append(constraints, agentConstrainLifetime)
+        constraints = binary.BigEndian.AppendUint32(constraints, key.LifetimeSecs)
+    }
+    if key.ConfirmBeforeUse {
+        constraints = append(constraints, agentConstrainConfirm)
+    }
+
+    if len(constraints) > 0 {
+        return append(append([]byte{agentAddIDConstrained}, out...), constraints...), nil
+    }
+
+    return append([]byte{agentAddIdentity}, out...), nil
+}
+
+// 解析添加密钥消息
+func parseAddedKey(data []byte, constrained bool) (AddedKey, error) {
+    keyType, rest, ok := parseString(data)
+    if !ok {
+        return AddedKey{}, errors.New("ssh: short read")
+    }
+
+    var cert *Certificate
+    var err error
+
+    baseType := string(keyType)
+    if algo, ok := certKeyAlgos[baseType]; ok {
+        var blob []byte
+        if blob, rest, ok = parseString(rest); !ok {
+            return AddedKey{}, errors.New("ssh: short read")
+        }
+
+        if cert, err = ParseCertificate(blob); err != nil {
+            return AddedKey{}, err
+        }
+
+        baseType = algo
+    }
+
+    fields, ok := agentKeyFields[baseType]
+    if !ok {
+        return AddedKey{}, errors.Errorf("ssh: unsupported key type %s", baseType)
+    }
+
+    n := fields.total
+    if cert != nil {
+        n -= fields.public
+    }
+
+    keyRest, constraints, err := splitFields(rest, n)
+    if err != nil {
+        return AddedKey{}, err
+    }
+
+    if cert != nil {
+        keyRest = append(certPublicFields(cert.Key), keyRest...)
+    }
+
+    newKey, err := ParseKeytype(baseType)
+    if err != nil {
+        return AddedKey{}, err
+    }
+
+    priv, comment, err := newKey.Parse(keyRest)
+    if err != nil {
+        return AddedKey{}, err
+    }
+
+    key := AddedKey{
+        PrivateKey:  priv,
+        Certificate: cert,
+        Comment:     comment,
+    }
+
+    if !constrained && len(constraints) > 0 {
+        return AddedKey{}, errors.New("ssh: trailing data after key")
+    }
+
+    for len(constraints) > 0 {
+        switch constraints[0] {
+            case agentConstrainLifetime:
+                if len(constraints) < 5 {
+                    return AddedKey{}, errors.New("ssh: short read")
+                }
+
+                key.LifetimeSecs = binary.BigEndian.Uint32(constraints[1:5])
+                constraints = constraints[5:]
+            case agentConstrainConfirm:
+                key.ConfirmBeforeUse = true
+                constraints = constraints[1:]
+            default:
+                return AddedKey{}, errors.Errorf("ssh: unsupported constraint %d", constraints[0])
+        }
+    }
+
+    return key, nil
+}
+
+// 证书公钥转换为私钥格式中的公钥字段
+func certPublicFields(key ssh.PublicKey) []byte {
+    _, rest, _ := parseString(key.Marshal())
+
+    if key.Type() != ssh.KeyAlgoRSA {
+        public := agentKeyFields[key.Type()].public
+
+        fields, _, _ := splitFields(rest, public)
+        return fields
+    }
+
+    // rsa 公钥为 e, n，私钥为 n, e
+    e, rest, _ := splitFields(rest, 1)
+    n, _, _ := splitFields(rest, 1)
+
+    return append(append([]byte{}, n...), e...)
+}
+
+// 读取消息
+func readAgentMessage(r io.Reader) ([]byte, error) {
+    var length [4]byte
+    if _, err := io.ReadFull(r, length[:]); err != nil {
+        return nil, err
+    }
+
+    l := binary.BigEndian.Uint32(length[:])
+    if l == 0 {
+        return nil, errors.New("ssh: agent message is empty")
+    }
+    if l > maxAgentResponseBytes {
+        return nil, errors.Errorf("ssh: agent message too large: %d", l)
+    }
+
+    buf := make([]byte, l)
+    if _, err := io.ReadFull(r, buf); err != nil {
+        return nil, err
+    }
+
+    return buf, nil
+}
+
+// 写入消息
+func writeAgentMessage(w io.Writer, msg []byte) error {
+    buf := binary.BigEndian.AppendUint32(make([]byte, 0, 4 + len(msg)), uint32(len(msg)))
+    buf = append(buf, msg...)
+
+    _, err := w.Write(buf)
+    return err
+}
+
+/**
+ * agent 客户端
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type agentClient struct {
+    mu   sync.Mutex
+    conn io.ReadWriter
+}
+
+// agent 客户端，conn 可以为 SSH_AUTH_SOCK 的连接
+func NewAgentClient(conn io.ReadWriter) Agent {
+    return &agentClient{
+        conn: conn,
+    }
+}
+
+// 发送请求
+func (this *agentClient) call(req []byte) ([]byte, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if err := writeAgentMessage(this.conn, req); err != nil {
+        return nil, err
+    }
+
+    return readAgentMessage(this.conn)
+}
+
+// 发送请求，需要返回成功
+func (this *agentClient) simpleCall(req []byte) error {
+    reply, err := this.call(req)
+    if err != nil {
+        return err
+    }
+
+    if reply[0] != agentSuccess {
+        return ErrAgentFailure
+    }
+
+    return nil
+}
+
+// 列出密钥
+func (this *agentClient) List() ([]*AgentKey, error) {
+    reply, err := this.call([]byte{agentRequestIdentities})
+    if err != nil {
+        return nil, err
+    }
+
+    if reply[0] != agentIdentitiesAnswer {
+        return nil, ErrAgentFailure
+    }
+
+    if len(reply) < 5 {
+        return nil, errors.New("ssh: short read")
+    }
+
+    n := binary.BigEndian.Uint32(reply[1:5])
+    rest := reply[5:]
+
+    keys := make([]*AgentKey, 0)
+    for i := uint32(0); i < n; i++ {
+        blob, next, ok := parseString(rest)
+        if !ok {
+            return nil, errors.New("ssh: short read")
+        }
+
+        comment, next, ok := parseString(next)
+        if !ok {
+            return nil, errors.New("ssh: short read")
+        }
+
+        format, _, ok := parseString(blob)
+        if !ok {
+            return nil, errors.New("ssh: short read")
+        }
+
+        keys = append(keys, &AgentKey{
+            Format:  string(format),
+            Blob:    blob,
+            Comment: string(comment),
+        })
+
+        rest = next
+    }
+
+    return keys, nil
+}
+
+// 签名
+func (this *agentClient) Sign(key ssh.PublicKey, data []byte, flags uint32) (*ssh.Signature, error) {
+    req := ssh.Marshal(struct {
+        KeyBlob []byte
+        Data    []byte
+        Flags   uint32
+    }{
+        key.Marshal(), data, flags,
+    })
+
+    reply, err := this.call(append([]byte{agentSignRequest}, req...))
+    if err != nil {
+        return nil, err
+    }
+
+    if reply[0] != agentSignResponse {
+        return nil, ErrAgentFailure
+    }
+
+    sigBlob, _, ok := parseString(reply[1:])
+    if !ok {
+        return nil, errors.New("ssh: short read")
+    }
+
+    var sig ssh.Signature
+    if err := ssh.Unmarshal(sigBlob, &sig); err != nil {
+        return nil, err
+    }
+
+    return &sig, nil
+}
+
+// 添加密钥
+func (this *agentClient) Add(key AddedKey) error {
+    req, err := marshalAddedKey(key)
+    if err != nil {
+        return err
+    }
+
+    return this.simpleCall(req)
+}
+
+// 删除密钥
+func (this *agentClient) Remove(key ssh.PublicKey) error {
+    req := ssh.Marshal(struct {
+        KeyBlob []byte
+    }{key.Marshal()})
+
+    return this.simpleCall(append([]byte{agentRemoveIdentity}, req...))
+}
+
+// 删除全部密钥
+func (this *agentClient) RemoveAll() error {
+    return this.simpleCall([]byte{agentRemoveAllIdentities})
+}
+
+// 锁定
+func (this *agentClient) Lock(passphrase []byte) error {
+    req := ssh.Marshal(struct {
+        Passphrase []byte
+    }{passphrase})
+
+    return this.simpleCall(append([]byte{agentLock}, req...))
+}
+
+// 解锁
+func (this *agentClient) Unlock(passphrase []byte) error {
+    req := ssh.Marshal(struct {
+        Passphrase []byte
+    }{passphrase})
+
+    return this.simpleCall(append([]byte{agentUnlock}, req...))
+}
+
+// 签名器列表
+func (this *agentClient) Signers() ([]ssh.Signer, error) {
+    keys, err := this.List()
+    if err != nil {
+        return nil, err
+    }
+
+    signers := make([]ssh.Signer, 0, len(keys))
+    for _, key := range keys {
+        pub, err := ParsePublicKey(key.Blob)
+        if err != nil {
+            continue
+        }
+
+        signers = append(signers, &agentKeySigner{
+            agent: this,
+            pub:   pub,
+        })
+    }
+
+    return signers, nil
+}
+
+// 使用 agent 签名
+type agentKeySigner struct {
+    agent *agentClient
+    pub   ssh.PublicKey
+}
+
+// 公钥
+func (this *agentKeySigner) PublicKey() ssh.PublicKey {
+    return this.pub
+}
+
+// 签名
+func (this *agentKeySigner) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+    return this.agent.Sign(this.pub, data, 0)
+}
+
+// 指定算法签名
+func (this *agentKeySigner) SignWithAlgorithm(rand io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+    var flags uint32
+    switch algorithm {
+        case ssh.KeyAlgoRSASHA256:
+            flags = SignatureFlagRsaSha256
+        case ssh.KeyAlgoRSASHA512:
+            flags = SignatureFlagRsaSha512
+    }
+
+    return this.agent.Sign(this.pub, data, flags)
+}
@@ -0,0 +1,260 @@
+package ssh
+
+import (
+    "sync"
+    "time"
+    "bytes"
+    "crypto/rand"
+    "crypto/subtle"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+)
+
+// agent 已锁定
+var ErrAgentLocked = errors.New("ssh: agent is locked")
+
+// keyring 中的密钥
+type keyringKey struct {
+    signer  ssh.Signer
+    comment string
+    expire  *time.Time
+}
+
+/**
+ * 内存 agent
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type keyring struct {
+    mu   sync.Mutex
+    keys []keyringKey
+
+    locked     bool
+    passphrase []byte
+}
+
+// 内存 agent，使用添加的密钥
+func NewKeyring() Agent {
+    return &keyring{}
+}
+
+// 删除过期密钥
+func (this *keyring) expireKeys() {
+    now := time.Now()
+
+    keys := this.keys[:0]
+    for _, k := range this.keys {
+        if k.expire == nil || now.Before(*k.expire) {
+            keys = append(keys, k)
+        }
+    }
+
+    this.keys = keys
+}
+
+// 列出密钥
+func (this *keyring) List() ([]*AgentKey, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    // 锁定时返回空列表
+    if this.locked {
+        return nil, nil
+    }
+
+    this.expireKeys()
+
+    ids := make([]*AgentKey, 0, len(this.keys))
+    for _, k := range this.keys {
+        pub := k.signer.PublicKey()
+
+        ids = append(ids, &AgentKey{
+            Format:  pub.Type(),
+            Blob:    pub.Marshal(),
+            Comment: k.comment,
+        })
+    }
+
+    return ids, nil
+}
+
+// 签名
+func (this *keyring) Sign(key ssh.PublicKey, data []byte, flags uint32) (*ssh.Signature, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return nil, ErrAgentLocked
+    }
+
+    this.expireKeys()
+
+    wanted := key.Marshal()
+    for _, k := range this.keys {
+        if !bytes.Equal(k.signer.PublicKey().Marshal(), wanted) {
+            continue
+        }
+
+        // 签名标记只用于 RSA
+        keyType := k.signer.PublicKey().Type()
+        if flags == 0 || (keyType != ssh.KeyAlgoRSA && keyType != ssh.CertAlgoRSAv01) {
+            return k.signer.Sign(rand.Reader, data)
+        }
+
+        algoSigner, ok := k.signer.(ssh.AlgorithmSigner)
+        if !ok {
+            return nil, errors.Errorf("ssh: signature does not support non-default signature algorithm: %T", k.signer)
+        }
+
+        var algorithm string
+        switch flags {
+            case SignatureFlagRsaSha256:
+                algorithm = ssh.KeyAlgoRSASHA256
+            case SignatureFlagRsaSha512:
+                algorithm = ssh.KeyAlgoRSASHA512
+            default:
+                return nil, errors.Errorf("ssh: unsupported signature flags: %d", flags)
+        }
+
+        return algoSigner.SignWithAlgorithm(rand.Reader, data, algorithm)
+    }
+
+    return nil, errors.New("ssh: not found")
+}
+
+// 添加密钥，相同公钥的密钥会被替换
+func (this *keyring) Add(key AddedKey) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return ErrAgentLocked
+    }
+
+    if key.ConfirmBeforeUse {
+        return errors.New("ssh: confirm before use is not supported")
+    }
+
+    signer, err := NewSigner(key.PrivateKey)
+    if err != nil {
+        return err
+    }
+
+    if key.Certificate != nil {
+        signer, err = NewCertSigner(key.Certificate, signer)
+        if err != nil {
+            return err
+        }
+    }
+
+    k := keyringKey{
+        signer:  signer,
+        comment: key.Comment,
+    }
+
+    if key.LifetimeSecs > 0 {
+        t := time.Now().Add(time.Duration(key.LifetimeSecs) * time.Second)
+        k.expire = &t
+    }
+
+    blob := signer.PublicKey().Marshal()
+    for i, old := range this.keys {
+        if bytes.Equal(old.signer.PublicKey().Marshal(), blob) {
+            this.keys[i] = k
+            return nil
+        }
+    }
+
+    this.keys = append(this.keys, k)
+
+    return nil
+}
+
+// 删除密钥
+func (this *keyring) Remove(key ssh.PublicKey) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return ErrAgentLocked
+    }
+
+    want := key.Marshal()
+    for i, k := range this.keys {
+        if bytes.Equal(k.signer.PublicKey().Marshal(), want) {
+            this.keys = append(this.keys[:i], this.keys[i+1:]...)
+            return nil
+        }
+    }
+
+    return errors.New("ssh: key not found")
+}
+
+// 删除全部密钥
+func (this *keyring) RemoveAll() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return ErrAgentLocked
+    }
+
+    this.keys = nil
+
+    return nil
+}
+
+// 锁定
+func (this *keyring) Lock(passphrase []byte) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return ErrAgentLocked
+    }
+
+    this.locked = true
+    this.passphrase = append([]byte{}, passphrase...)
+
+    return nil
+}
+
+// 解锁
+func (this *keyring) Unlock(passphrase []byte) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if !this.locked {
+        return errors.New("ssh: agent is not locked")
+    }
+
+    if subtle.ConstantTimeCompare(passphrase, this.passphrase) != 1 {
+        return errors.New("ssh: incorrect passphrase")
+    }
+
+    this.locked = false
+    this.passphrase = nil
+
+    return nil
+}
+
+// 签名器列表
+func (this *keyring) Signers() ([]ssh.Signer, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.locked {
+        return nil, ErrAgentLocked
+    }
+
+    this.expireKeys()
+
+    signers := make([]ssh.Signer, 0, len(this.keys))
+    for _, k := range this.keys {
+        signers = append(signers, k.signer)
+    }
+
+    return signers, nil
+}
@@ -0,0 +1,168 @@
+package ssh
+
+import (
+    "io"
+    "net"
+    "encoding/binary"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+)
+
+// agent 服务，读取到 EOF 时结束
+func ServeAgent(agent Agent, c io.ReadWriter) error {
+    for {
+        req, err := readAgentMessage(c)
+        if err != nil {
+            if errors.Is(err, io.EOF) {
+                return nil
+            }
+
+            return err
+        }
+
+        reply, err := processAgentRequest(agent, req)
+        if err != nil {
+            reply = []byte{agentFailure}
+        }
+
+        if err := writeAgentMessage(c, reply); err != nil {
+            return err
+        }
+    }
+}
+
+// 监听 agent 服务，每个连接使用单独的协程处理，l 关闭时返回 nil
+func ServeAgentListener(agent Agent, l net.Listener) error {
+    for {
+        conn, err := l.Accept()
+        if err != nil {
+            if errors.Is(err, net.ErrClosed) {
+                return nil
+            }
+
+            return err
+        }
+
+        go func() {
+            defer conn.Close()
+
+            ServeAgent(agent, conn)
+        }()
+    }
+}
+
+// 处理请求
+func processAgentRequest(agent Agent, req []byte) ([]byte, error) {
+    data := req[1:]
+
+    switch req[0] {
+        case agentRequestIdentities:
+            keys, err := agent.List()
+            if err != nil {
+                return nil, err
+            }
+
+            out := binary.BigEndian.AppendUint32([]byte{agentIdentitiesAnswer}, uint32(len(keys)))
+            for _, key := range keys {
+                out = append(out, ssh.Marshal(struct {
+                    Blob    []byte
+                    Comment string
+                }{
+                    key.Blob, key.Comment,
+                })...)
+            }
+
+            return out, nil
+
+        case agentSignRequest:
+            var w struct {
+                KeyBlob []byte
+                Data    []byte
+                Flags   uint32
+            }
+            if err := ssh.Unmarshal(data, &w); err != nil {
+                return nil, err
+            }
+
+            format, _, ok := parseString(w.KeyBlob)
+            if !ok {
+                return nil, errors.New("ssh: short read")
+            }
+
+            key := &AgentKey{
+                Format: string(format),
+                Blob:   w.KeyBlob,
+            }
+
+            sig, err := agent.Sign(key, w.Data, w.Flags)
+            if err != nil {
+                return nil, err
+            }
+
+            return append([]byte{agentSignResponse}, ssh.Marshal(struct {
+                Signature []byte
+            }{ssh.Marshal(sig)})...), nil
+
+        case agentAddIdentity, agentAddIDConstrained:
+            key, err := parseAddedKey(data, req[0] == agentAddIDConstrained)
+            if err != nil {
+                return nil, err
+            }
+
+            if err := agent.Add(key); err != nil {
+                return nil, err
+            }
+
+        case agentRemoveIdentity:
+            var w struct {
+                KeyBlob []byte
+            }
+            if err := ssh.Unmarshal(data, &w); err != nil {
+                return nil, err
+            }
+
+            format, _, ok := parseString(w.KeyBlob)
+            if !ok {
+                return nil, errors.New("ssh: short read")
+            }
+
+            key := &AgentKey{
+                Format: string(format),
+                Blob:   w.KeyBlob,
+            }
+
+            if err := agent.Remove(key); err != nil {
+                return nil, err
+            }
+
+        case agentRemoveAllIdentities:
+            if err := agent.RemoveAll(); err != nil {
+                return nil, err
+            }
+
+        case agentLock, agentUnlock:
+            var w struct {
+                Passphrase []byte
+            }
+            if err := ssh.Unmarshal(data, &w); err != nil {
+                return nil, err
+            }
+
+            var err error
+            if req[0] == agentLock {
+                err = agent.Lock(w.Passphrase)
+            } else {
+                err = agent.Unlock(w.Passphrase)
+            }
+
+            if err != nil {
+                return nil, err
+            }
+
+        default:
+            return nil, errors.Errorf("ssh: unknown agent request %d", req[0])
+    }
+
+    return []byte{agentSuccess}, nil
+}
@@ -0,0 +1,119 @@
+package ssh
+
+import (
+    "net"
+    "bytes"
+    "testing"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/ed25519"
+    "path/filepath"
+)
+
+func newTestAgentClient(t *testing.T, agent Agent) Agent {
+    l, err := net.Listen("unix", filepath.Join(t.TempDir(), "agent.sock"))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    done := make(chan error, 1)
+    go func() {
+        done <- ServeAgentListener(agent, l)
+    }()
+
+    conn, err := net.Dial("unix", l.Addr().String())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    t.Cleanup(func() {
+        conn.Close()
+        l.Close()
+
+        if err := <-done; err != nil {
+            t.Errorf("ServeAgentListener: %v", err)
+        }
+    })
+
+    return NewAgentClient(conn)
+}
+
+func TestAgentUnixSocket(t *testing.T) {
+    _, edKey, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    keyring := NewKeyring()
+    if err := keyring.Add(AddedKey{PrivateKey: edKey, Comment: "ed25519"}); err != nil {
+        t.Fatal(err)
+    }
+
+    client := newTestAgentClient(t, keyring)
+
+    // 通过客户端添加密钥
+    if err := client.Add(AddedKey{PrivateKey: ecKey, Comment: "ecdsa"}); err != nil {
+        t.Fatal(err)
+    }
+
+    keys, err := client.List()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(keys) != 2 {
+        t.Fatalf("List got %d keys, want 2", len(keys))
+    }
+
+    data := []byte("agent sign data")
+    for _, key := range keys {
+        sig, err := client.Sign(key, data, 0)
+        if err != nil {
+            t.Fatalf("%s: %v", key.Comment, err)
+        }
+
+        pub, err := ParsePublicKey(key.Blob)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if err := pub.Verify(data, sig); err != nil {
+            t.Errorf("%s: Verify: %v", key.Comment, err)
+        }
+
+        if err := pub.Verify([]byte("other data"), sig); err == nil {
+            t.Errorf("%s: Verify other data should fail", key.Comment)
+        }
+    }
+
+    // 锁定后不能签名
+    if err := client.Lock([]byte("passphrase")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := client.Sign(keys[0], data, 0); err == nil {
+        t.Error("Sign with locked agent should fail")
+    }
+    if err := client.Unlock([]byte("wrong")); err == nil {
+        t.Error("Unlock with wrong passphrase should fail")
+    }
+    if err := client.Unlock([]byte("passphrase")); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := client.Remove(keys[0]); err != nil {
+        t.Fatal(err)
+    }
+
+    keys2, err := client.List()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(keys2) != 1 || bytes.Equal(keys2[0].Blob, keys[0].Blob) {
+        t.Errorf("Remove got %d keys", len(keys2))
+    }
+}
@@ -0,0 +1,420 @@
+package ssh
+
+import (
+    "io"
+    "sort"
+    "time"
+    "bytes"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+)
+
+// 证书类型
+const (
+    UserCert = 1
+    HostCert = 2
+)
+
+// 证书永久有效
+const CertTimeInfinity = 1<<64 - 1
+
+var (
+    // SM2 证书，和 ssh-sm2 一样为非标准类型
+    CertAlgoSM2v01 = "ssh-sm2-cert-v01"
+)
+
+// 证书类型对应的公钥类型
+var certKeyAlgos = map[string]string{
+    ssh.CertAlgoRSAv01:      ssh.KeyAlgoRSA,
+    ssh.CertAlgoECDSA256v01: ssh.KeyAlgoECDSA256,
+    ssh.CertAlgoECDSA384v01: ssh.KeyAlgoECDSA384,
+    ssh.CertAlgoECDSA521v01: ssh.KeyAlgoECDSA521,
+    ssh.CertAlgoED25519v01:  ssh.KeyAlgoED25519,
+    CertAlgoSM2v01:          KeyAlgoSM2,
+}
+
+/**
+ * OpenSSH 证书
+ * https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.certkeys
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Certificate struct {
+    Nonce           []byte
+    Key             ssh.PublicKey
+    Serial          uint64
+    CertType        uint32
+    KeyId           string
+    ValidPrincipals []string
+    ValidAfter      uint64
+    ValidBefore     uint64
+    CriticalOptions map[string]string
+    Extensions      map[string]string
+    Reserved        []byte
+    SignatureKey    ssh.PublicKey
+    Signature       *ssh.Signature
+}
+
+// 生成未签名的证书
+func NewCertificate(key ssh.PublicKey, certType uint32, keyId string, principals []string, validAfter, validBefore time.Time) *Certificate {
+    cert := &Certificate{
+        Key:             key,
+        CertType:        certType,
+        KeyId:           keyId,
+        ValidPrincipals: principals,
+        ValidAfter:      0,
+        ValidBefore:     CertTimeInfinity,
+        CriticalOptions: make(map[string]string),
+        Extensions:      make(map[string]string),
+    }
+
+    if !validAfter.IsZero() {
+        cert.ValidAfter = uint64(validAfter.Unix())
+    }
+    if !validBefore.IsZero() {
+        cert.ValidBefore = uint64(validBefore.Unix())
+    }
+
+    return cert
+}
+
+// 默认用户证书扩展
+func DefaultUserExtensions() map[string]string {
+    return map[string]string{
+        "permit-X11-forwarding":   "",
+        "permit-agent-forwarding": "",
+        "permit-port-forwarding":  "",
+        "permit-pty":              "",
+        "permit-user-rc":          "",
+    }
+}
+
+// 类型
+func (this *Certificate) Type() string {
+    for certAlgo, keyAlgo := range certKeyAlgos {
+        if keyAlgo == this.Key.Type() {
+            return certAlgo
+        }
+    }
+
+    return ""
+}
+
+// 编码
+func (this *Certificate) Marshal() []byte {
+    var sig []byte
+    if this.Signature != nil {
+        sig = ssh.Marshal(this.Signature)
+    }
+
+    return append(this.bytesForSigning(), ssh.Marshal(struct {
+        Signature []byte
+    }{sig})...)
+}
+
+// 使用证书公钥验证签名
+func (this *Certificate) Verify(data []byte, sig *ssh.Signature) error {
+    return this.Key.Verify(data, sig)
+}
+
+// 使用签发者签名证书，nonce 为空时随机生成，RSA 签发者使用 rsa-sha2-512 签名
+func (this *Certificate) SignCert(rand io.Reader, authority ssh.Signer) error {
+    if this.Key == nil {
+        return errors.New("ssh: certificate key is empty")
+    }
+
+    if this.Type() == "" {
+        return errors.Errorf("ssh: unsupported certificate key type %s", this.Key.Type())
+    }
+
+    if _, ok := authority.PublicKey().(*Certificate); ok {
+        return errors.New("ssh: certificate authority can not be a certificate")
+    }
+
+    if len(this.Nonce) == 0 {
+        this.Nonce = make([]byte, 32)
+        if _, err := io.ReadFull(rand, this.Nonce); err != nil {
+            return err
+        }
+    }
+
+    this.SignatureKey = authority.PublicKey()
+
+    var sig *ssh.Signature
+    var err error
+
+    algoSigner, ok := authority.(ssh.AlgorithmSigner)
+    if ok && this.SignatureKey.Type() == ssh.KeyAlgoRSA {
+        sig, err = algoSigner.SignWithAlgorithm(rand, this.bytesForSigning(), ssh.KeyAlgoRSASHA512)
+    } else {
+        sig, err = authority.Sign(rand, this.bytesForSigning())
+    }
+
+    if err != nil {
+        return err
+    }
+
+    this.Signature = sig
+
+    return nil
+}
+
+// 使用 SignatureKey 验证证书签名
+func (this *Certificate) VerifySignature() error {
+    if this.SignatureKey == nil || this.Signature == nil {
+        return errors.New("ssh: certificate is not signed")
+    }
+
+    if _, ok := this.SignatureKey.(*Certificate); ok {
+        return errors.New("ssh: certificate signature key can not be a certificate")
+    }
+
+    return this.SignatureKey.Verify(this.bytesForSigning(), this.Signature)
+}
+
+// 签名数据
+func (this *Certificate) bytesForSigning() []byte {
+    _, keyFields, _ := parseString(this.Key.Marshal())
+
+    var principals []byte
+    for _, principal := range this.ValidPrincipals {
+        principals = append(principals, ssh.Marshal(struct {
+            Principal string
+        }{principal})...)
+    }
+
+    out := ssh.Marshal(struct {
+        Type  string
+        Nonce []byte
+    }{
+        this.Type(), this.Nonce,
+    })
+    out = append(out, keyFields...)
+
+    var signatureKey []byte
+    if this.SignatureKey != nil {
+        signatureKey = this.SignatureKey.Marshal()
+    }
+
+    return append(out, ssh.Marshal(struct {
+        Serial          uint64
+        CertType        uint32
+        KeyId           string
+        ValidPrincipals []byte
+        ValidAfter      uint64
+        ValidBefore     uint64
+        CriticalOptions []byte
+        Extensions      []byte
+        Reserved        []byte
+        SignatureKey    []byte
+    }{
+        this.Serial,
+        this.CertType,
+        this.KeyId,
+        principals,
+        this.ValidAfter,
+        this.ValidBefore,
+        marshalTuples(this.CriticalOptions),
+        marshalTuples(this.Extensions),
+        this.Reserved,
+        signatureKey,
+    })...)
+}
+
+// 解析证书
+func ParseCertificate(in []byte) (*Certificate, error) {
+    algo, rest, ok := parseString(in)
+    if !ok {
+        return nil, errors.New("ssh: short read")
+    }
+
+    keyAlgo, ok := certKeyAlgos[string(algo)]
+    if !ok {
+        return nil, errors.Errorf("ssh: unsupported certificate type %s", algo)
+    }
+
+    nonce, rest, ok := parseString(rest)
+    if !ok {
+        return nil, errors.New("ssh: short read")
+    }
+
+    keyFields, rest, err := splitFields(rest, publicKeyFields[keyAlgo])
+    if err != nil {
+        return nil, err
+    }
+
+    keyData := ssh.Marshal(struct {
+        KeyType string
+    }{keyAlgo})
+    keyData = append(keyData, keyFields...)
+
+    key, err := ParsePublicKey(keyData)
+    if err != nil {
+        return nil, err
+    }
+
+    var w struct {
+        Serial          uint64
+        CertType        uint32
+        KeyId           string
+        ValidPrincipals []byte
+        ValidAfter      uint64
+        ValidBefore     uint64
+        CriticalOptions []byte
+        Extensions      []byte
+        Reserved        []byte
+        SignatureKey    []byte
+        Signature       []byte
+    }
+    if err := ssh.Unmarshal(rest, &w); err != nil {
+        return nil, err
+    }
+
+    cert := &Certificate{
+        Nonce:       nonce,
+        Key:         key,
+        Serial:      w.Serial,
+        CertType:    w.CertType,
+        KeyId:       w.KeyId,
+        ValidAfter:  w.ValidAfter,
+        ValidBefore: w.ValidBefore,
+        Reserved:    w.Reserved,
+    }
+
+    for principals := w.ValidPrincipals; len(principals) > 0; {
+        principal, next, ok := parseString(principals)
+        if !ok {
+            return nil, errors.New("ssh: invalid certificate principals")
+        }
+
+        cert.ValidPrincipals = append(cert.ValidPrincipals, string(principal))
+        principals = next
+    }
+
+    if cert.CriticalOptions, err = parseTuples(w.CriticalOptions); err != nil {
+        return nil, err
+    }
+
+    if cert.Extensions, err = parseTuples(w.Extensions); err != nil {
+        return nil, err
+    }
+
+    if cert.SignatureKey, err = ParsePublicKey(w.SignatureKey); err != nil {
+        return nil, err
+    }
+
+    cert.Signature = new(ssh.Signature)
+    if err := ssh.Unmarshal(w.Signature, cert.Signature); err != nil {
+        return nil, err
+    }
+
+    return cert, nil
+}
+
+// 证书签名，使用私钥签名，公钥为证书
+func NewCertSigner(cert *Certificate, signer ssh.Signer) (ssh.Signer, error) {
+    if !bytes.Equal(cert.Key.Marshal(), signer.PublicKey().Marshal()) {
+        return nil, errors.New("ssh: signer and certificate public key do not match")
+    }
+
+    return &certSigner{
+        cert:   cert,
+        signer: signer,
+    }, nil
+}
+
+// 证书签名
+type certSigner struct {
+    cert   *Certificate
+    signer ssh.Signer
+}
+
+// 公钥
+func (this *certSigner) PublicKey() ssh.PublicKey {
+    return this.cert
+}
+
+// 签名
+func (this *certSigner) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+    return this.signer.Sign(rand, data)
+}
+
+// 指定算法签名
+func (this *certSigner) SignWithAlgorithm(rand io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+    if algoSigner, ok := this.signer.(ssh.AlgorithmSigner); ok {
+        return algoSigner.SignWithAlgorithm(rand, data, algorithm)
+    }
+
+    if algorithm != "" && algorithm != this.signer.PublicKey().Type() {
+        return nil, errors.Errorf("ssh: unsupported signature algorithm %s", algorithm)
+    }
+
+    return this.signer.Sign(rand, data)
+}
+
+// 编码选项，按名称排序
+func marshalTuples(tuples map[string]string) []byte {
+    names := make([]string, 0, len(tuples))
+    for name := range tuples {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+
+    var out []byte
+    for _, name := range names {
+        var data []byte
+        if value := tuples[name]; len(value) > 0 {
+            data = ssh.Marshal(struct {
+                Value string
+            }{value})
+        }
+
+        out = append(out, ssh.Marshal(struct {
+            Name string
+            Data []byte
+        }{name, data})...)
+    }
+
+    return out
+}
+
+// 解析选项
+func parseTuples(in []byte) (map[string]string, error) {
+    tuples := make(map[string]string)
+
+    var prev string
+    for len(in) > 0 {
+        name, rest, ok := parseString(in)
+        if !ok {
+            return nil, errors.New("ssh: invalid certificate options")
+        }
+
+        data, rest, ok := parseString(rest)
+        if !ok {
+            return nil, errors.New("ssh: invalid certificate options")
+        }
+
+        // 需要按名称排序且不重复
+        if prev != "" && string(name) <= prev {
+            return nil, errors.New("ssh: certificate options are not in lexical order")
+        }
+        prev = string(name)
+
+        value := ""
+        if len(data) > 0 {
+            v, extra, ok := parseString(data)
+            if !ok || len(extra) > 0 {
+                return nil, errors.New("ssh: invalid certificate option value")
+            }
+
+            value = string(v)
+        }
+
+        tuples[string(name)] = value
+        in = rest
+    }
+
+    return tuples, nil
+}
@@ -0,0 +1,118 @@
+package ssh
+
+import (
+    "net"
+    "time"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+)
+
+/**
+ * 证书验证，验证用户证书及主机证书
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CertChecker struct {
+    // 支持的关键选项，证书中有其他关键选项时验证失败
+    SupportedCriticalOptions []string
+
+    // 检测用户证书签发者
+    IsUserAuthority func(auth ssh.PublicKey) bool
+
+    // 检测主机证书签发者
+    IsHostAuthority func(auth ssh.PublicKey, address string) bool
+
+    // 检测证书是否吊销
+    IsRevoked func(cert *Certificate) bool
+
+    // 当前时间，为空时使用 time.Now
+    Clock func() time.Time
+}
+
+// 验证用户证书
+func (this *CertChecker) CheckUserCert(principal string, cert *Certificate) error {
+    if cert.CertType != UserCert {
+        return errors.Errorf("ssh: cert has type %d, expected user cert", cert.CertType)
+    }
+
+    if this.IsUserAuthority == nil || !this.IsUserAuthority(cert.SignatureKey) {
+        return errors.New("ssh: certificate signed by unrecognized authority")
+    }
+
+    return this.CheckCert(principal, cert)
+}
+
+// 验证主机证书，匹配 principal 时忽略地址中的端口
+func (this *CertChecker) CheckHostCert(address string, cert *Certificate) error {
+    if cert.CertType != HostCert {
+        return errors.Errorf("ssh: cert has type %d, expected host cert", cert.CertType)
+    }
+
+    if this.IsHostAuthority == nil || !this.IsHostAuthority(cert.SignatureKey, address) {
+        return errors.Errorf("ssh: no authorities for hostname: %v", address)
+    }
+
+    hostname, _, err := net.SplitHostPort(address)
+    if err != nil {
+        hostname = address
+    }
+
+    return this.CheckCert(hostname, cert)
+}
+
+// 验证关键选项、principal、有效期、吊销及签名，不验证签发者
+func (this *CertChecker) CheckCert(principal string, cert *Certificate) error {
+    if this.IsRevoked != nil && this.IsRevoked(cert) {
+        return errors.Errorf("ssh: certificate serial %d revoked", cert.Serial)
+    }
+
+    for opt := range cert.CriticalOptions {
+        supported := false
+        for _, supp := range this.SupportedCriticalOptions {
+            if supp == opt {
+                supported = true
+                break
+            }
+        }
+
+        if !supported {
+            return errors.Errorf("ssh: unsupported critical option %q in certificate", opt)
+        }
+    }
+
+    if len(cert.ValidPrincipals) > 0 {
+        found := false
+        for _, p := range cert.ValidPrincipals {
+            if p == principal {
+                found = true
+                break
+            }
+        }
+
+        if !found {
+            return errors.Errorf("ssh: principal %q not in the set of valid principals for given certificate: %q", principal, cert.ValidPrincipals)
+        }
+    }
+
+    clock := time.Now
+    if this.Clock != nil {
+        clock = this.Clock
+    }
+
+    unixNow := clock().Unix()
+    if after := int64(cert.ValidAfter); after < 0 || unixNow < after {
+        return errors.New("ssh: cert is not yet valid")
+    }
+
+    if before := int64(cert.ValidBefore); cert.ValidBefore != uint64(CertTimeInfinity) && (unixNow >= before || before < 0) {
+        return errors.New("ssh: cert has expired")
+    }
+
+    if err := cert.VerifySignature(); err != nil {
+        return errors.Wrap(err, "ssh: certificate signature does not verify")
+    }
+
+    return nil
+}
@@ -0,0 +1,141 @@
+package ssh
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "time"
+    "crypto/rand"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/ed25519"
+
+    "golang.org/x/crypto/ssh"
+)
+
+func newTestCert(t *testing.T, validAfter, validBefore time.Time) (*Certificate, ssh.Signer) {
+    _, caKey, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ca, err := NewSigner(caKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    userKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    pub, err := NewPublicKey(&userKey.PublicKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    cert := NewCertificate(pub, UserCert, "user-id", []string{"alice"}, validAfter, validBefore)
+    cert.Serial = 1
+    cert.Extensions = DefaultUserExtensions()
+
+    if err := cert.SignCert(rand.Reader, ca); err != nil {
+        t.Fatal(err)
+    }
+
+    return cert, ca
+}
+
+func TestCertSignParse(t *testing.T) {
+    now := time.Now()
+    cert, ca := newTestCert(t, now.Add(-time.Hour), now.Add(time.Hour))
+
+    if err := cert.VerifySignature(); err != nil {
+        t.Fatal(err)
+    }
+
+    pub, err := ParsePublicKey(cert.Marshal())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    parsed, ok := pub.(*Certificate)
+    if !ok {
+        t.Fatalf("ParsePublicKey got %T", pub)
+    }
+
+    if !bytes.Equal(parsed.Marshal(), cert.Marshal()) {
+        t.Error("parsed certificate is not equal")
+    }
+    if !bytes.Equal(parsed.SignatureKey.Marshal(), ca.PublicKey().Marshal()) {
+        t.Error("parsed signature key is not equal")
+    }
+    if err := parsed.VerifySignature(); err != nil {
+        t.Error(err)
+    }
+
+    // 修改数据后签名验证失败
+    parsed.KeyId = "other-id"
+    if err := parsed.VerifySignature(); err == nil {
+        t.Error("VerifySignature with modified key id should fail")
+    }
+}
+
+func TestCertChecker(t *testing.T) {
+    now := time.Now()
+    cert, ca := newTestCert(t, now.Add(-time.Hour), now.Add(time.Hour))
+
+    checker := &CertChecker{
+        IsUserAuthority: func(auth ssh.PublicKey) bool {
+            return bytes.Equal(auth.Marshal(), ca.PublicKey().Marshal())
+        },
+    }
+
+    if err := checker.CheckUserCert("alice", cert); err != nil {
+        t.Fatal(err)
+    }
+
+    if err := checker.CheckUserCert("bob", cert); err == nil {
+        t.Error("CheckUserCert with other principal should fail")
+    }
+
+    if err := checker.CheckHostCert("alice:22", cert); err == nil {
+        t.Error("CheckHostCert with user cert should fail")
+    }
+
+    // 有效期之外
+    tests := []struct {
+        name  string
+        clock time.Time
+        err   string
+    }{
+        {"before", now.Add(-2 * time.Hour), "not yet valid"},
+        {"after", now.Add(2 * time.Hour), "expired"},
+    }
+
+    for _, test := range tests {
+        clock := test.clock
+        checker.Clock = func() time.Time {
+            return clock
+        }
+
+        err := checker.CheckUserCert("alice", cert)
+        if err == nil || !strings.Contains(err.Error(), test.err) {
+            t.Errorf("%s: got %v, want %q", test.name, err, test.err)
+        }
+    }
+    checker.Clock = nil
+
+    // 其他签发者
+    other, _ := newTestCert(t, now.Add(-time.Hour), now.Add(time.Hour))
+    if err := checker.CheckUserCert("alice", other); err == nil {
+        t.Error("CheckUserCert with other authority should fail")
+    }
+
+    // 吊销
+    checker.IsRevoked = func(c *Certificate) bool {
+        return c.Serial == 1
+    }
+    if err := checker.CheckUserCert("alice", cert); err == nil {
+        t.Error("CheckUserCert with revoked cert should fail")
+    }
+}
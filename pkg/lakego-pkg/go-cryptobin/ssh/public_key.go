@@ -0,0 +1,220 @@
+package ssh
+
+import (
+    "io"
+    "bytes"
+    "strings"
+    "math/big"
+    "crypto"
+    "crypto/elliptic"
+    "encoding/base64"
+    "encoding/binary"
+
+    "github.com/pkg/errors"
+    "golang.org/x/crypto/ssh"
+
+    "github.com/tjfoc/gmsm/sm2"
+)
+
+// 公钥字段数量，不包括类型名称
+var publicKeyFields = map[string]int{
+    ssh.KeyAlgoRSA:      2,
+    ssh.KeyAlgoECDSA256: 2,
+    ssh.KeyAlgoECDSA384: 2,
+    ssh.KeyAlgoECDSA521: 2,
+    ssh.KeyAlgoED25519:  1,
+    KeyAlgoSM2:          1,
+}
+
+// SM2 公钥
+type sm2PublicKey sm2.PublicKey
+
+// 类型
+func (this *sm2PublicKey) Type() string {
+    return KeyAlgoSM2
+}
+
+// 编码
+func (this *sm2PublicKey) Marshal() []byte {
+    pub := elliptic.Marshal(this.Curve, this.X, this.Y)
+
+    return ssh.Marshal(struct {
+        KeyType string
+        Pub     []byte
+    }{
+        KeyAlgoSM2, pub,
+    })
+}
+
+// 验证
+func (this *sm2PublicKey) Verify(data []byte, sig *ssh.Signature) error {
+    if sig.Format != KeyAlgoSM2 {
+        return errors.Errorf("ssh: signature type %s for key type %s", sig.Format, KeyAlgoSM2)
+    }
+
+    var rs struct {
+        R *big.Int
+        S *big.Int
+    }
+    if err := ssh.Unmarshal(sig.Blob, &rs); err != nil {
+        return err
+    }
+
+    if !sm2.Sm2Verify((*sm2.PublicKey)(this), data, nil, rs.R, rs.S) {
+        return errors.New("ssh: signature did not verify")
+    }
+
+    return nil
+}
+
+// 原始公钥
+func (this *sm2PublicKey) CryptoPublicKey() crypto.PublicKey {
+    return (*sm2.PublicKey)(this)
+}
+
+// SM2 签名
+type sm2Signer struct {
+    priv *sm2.PrivateKey
+}
+
+// 公钥
+func (this *sm2Signer) PublicKey() ssh.PublicKey {
+    return (*sm2PublicKey)(&this.priv.PublicKey)
+}
+
+// 签名
+func (this *sm2Signer) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+    r, s, err := sm2.Sm2Sign(this.priv, data, nil, rand)
+    if err != nil {
+        return nil, err
+    }
+
+    return &ssh.Signature{
+        Format: KeyAlgoSM2,
+        Blob:   ssh.Marshal(struct {
+            R *big.Int
+            S *big.Int
+        }{
+            r, s,
+        }),
+    }, nil
+}
+
+// 转换为 ssh.PublicKey，支持 x/crypto/ssh 的类型及 SM2
+func NewPublicKey(key crypto.PublicKey) (ssh.PublicKey, error) {
+    if k, ok := key.(*sm2.PublicKey); ok {
+        return (*sm2PublicKey)(k), nil
+    }
+
+    return ssh.NewPublicKey(key)
+}
+
+// 私钥签名，支持 x/crypto/ssh 的类型及 SM2
+func NewSigner(key crypto.PrivateKey) (ssh.Signer, error) {
+    if k, ok := key.(*sm2.PrivateKey); ok {
+        return &sm2Signer{k}, nil
+    }
+
+    return ssh.NewSignerFromKey(key)
+}
+
+// 解析公钥或者证书
+func ParsePublicKey(in []byte) (ssh.PublicKey, error) {
+    algo, _, ok := parseString(in)
+    if !ok {
+        return nil, errors.New("ssh: short read")
+    }
+
+    if _, ok := certKeyAlgos[string(algo)]; ok {
+        return ParseCertificate(in)
+    }
+
+    if string(algo) == KeyAlgoSM2 {
+        return parseSM2PublicKey(in)
+    }
+
+    return ssh.ParsePublicKey(in)
+}
+
+// 解析 authorized_keys 格式的公钥
+func ParseAuthorizedKey(in []byte) (ssh.PublicKey, string, error) {
+    in = bytes.TrimSpace(in)
+
+    fields := strings.Fields(string(in))
+    if len(fields) < 2 {
+        return nil, "", errors.New("ssh: no key found")
+    }
+
+    data, err := base64.StdEncoding.DecodeString(fields[1])
+    if err != nil {
+        return nil, "", err
+    }
+
+    pub, err := ParsePublicKey(data)
+    if err != nil {
+        return nil, "", err
+    }
+
+    if pub.Type() != fields[0] {
+        return nil, "", errors.New("ssh: key type does not match")
+    }
+
+    return pub, strings.Join(fields[2:], " "), nil
+}
+
+// MarshalAuthorizedKey 编码为 authorized_keys 格式
+func MarshalAuthorizedKey(key ssh.PublicKey) []byte {
+    return ssh.MarshalAuthorizedKey(key)
+}
+
+// 解析 SM2 公钥
+func parseSM2PublicKey(in []byte) (ssh.PublicKey, error) {
+    var w struct {
+        KeyType string
+        Pub     []byte
+    }
+    if err := ssh.Unmarshal(in, &w); err != nil {
+        return nil, err
+    }
+
+    curve := sm2.P256Sm2()
+
+    x, y := elliptic.Unmarshal(curve, w.Pub)
+    if x == nil || y == nil {
+        return nil, errors.New("ssh: invalid sm2 public key")
+    }
+
+    return &sm2PublicKey{
+        Curve: curve,
+        X:     x,
+        Y:     y,
+    }, nil
+}
+
+// 解析 string 字段
+func parseString(in []byte) ([]byte, []byte, bool) {
+    if len(in) < 4 {
+        return nil, nil, false
+    }
+
+    length := binary.BigEndian.Uint32(in)
+    in = in[4:]
+    if uint32(len(in)) < length {
+        return nil, nil, false
+    }
+
+    return in[:length], in[length:], true
+}
+
+// 分割 n 个 string 字段，返回字段原始数据及剩余数据
+func splitFields(in []byte, n int) ([]byte, []byte, error) {
+    rest := in
+    for i := 0; i < n; i++ {
+        var ok bool
+        if _, rest, ok = parseString(rest); !ok {
+            return nil, nil, errors.New("ssh: short read")
+        }
+    }
+
+    return in[:len(in)-len(rest)], rest, nil
+}
@@ -78,3 +78,20 @@ func TestFlow(t *testing.T) {
         t.Errorf("Expected to get '%v', got '%v'", string(text), string(res))
     }
 }
+
+func FuzzRoundTrip(f *testing.F) {
+    for _, test := range tests {
+        f.Add([]byte(test.text))
+    }
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        res, err := Decode(Encode(data))
+        if err != nil {
+            t.Fatalf("Unexpected error: %v", err)
+        }
+
+        if string(res) != string(data) {
+            t.Fatalf("Expected to get '%x', got '%x'", data, res)
+        }
+    })
+}
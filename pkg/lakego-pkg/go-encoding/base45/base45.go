@@ -194,7 +194,8 @@ func Encode(in string) string {
     var builder strings.Builder
     for i, pair := range pairs {
         res := encodeBase45(pair)
-        if i + 1 == len(pairs) && res[2] == 0 {
+        // 只有奇数长度时最后一个字节编码为两个字符
+        if i + 1 == len(pairs) && len(bytes) % 2 == 1 {
             for _, b := range res[:2] {
                 if c, ok := encodingMap[b]; ok {
                     builder.WriteRune(c)
@@ -220,6 +221,10 @@ func Encode(in string) string {
 //   at the ASCII values we get the string "ietf!".
 func Decode(in string) (string, error) {
     size := len(in)
+    if size == 0 {
+        return "", nil
+    }
+
     mod := size % 3
     if mod != 0 && mod != 2 {
         return "", InvalidLengthError{
@@ -330,6 +335,9 @@ func decodeTriplets(in [][]byte) ([]uint16, error) {
             c := uint16(chunk[0])
             d := uint16(chunk[1])
             n := c + (d * base)
+            if n > 255 {
+                return nil, IllegalBase45ByteError{position: pos}
+            }
             ret = append(ret, n)
         }
     }
@@ -101,4 +101,42 @@ func TestDecodeMalformed(t *testing.T) {
             testEqual(t, "Error(%s) = %s, want %s", p.error, err, p.error)
         }
     }
-}
\ No newline at end of file
+}
+func FuzzRoundTrip(f *testing.F) {
+    for _, p := range pairs {
+        f.Add(p.decoded)
+    }
+
+    f.Fuzz(func(t *testing.T, data string) {
+        got, err := Decode(Encode(data))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        testEqual(t, "Decode(Encode(%q)) = %q, want %q", data, got, data)
+    })
+}
+
+func TestDecodeEmptyAndOverflow(t *testing.T) {
+    got, err := Decode("")
+    if err != nil || got != "" {
+        t.Errorf("Decode(\"\") = %q, %v", got, err)
+    }
+
+    // "::" = 44 + 44*45 > 255
+    if _, err := Decode("::"); err == nil {
+        t.Error("Decode(\"::\") should return an error")
+    }
+}
+
+func TestEncodeSmallPair(t *testing.T) {
+    // 0x0030 = 48 = [3 1 0]，最后一组为两个字节时需要输出三个字符
+    got := Encode("\x000")
+    testEqual(t, "Encode(%q) = %q, want %q", "\x000", got, "310")
+
+    decoded, err := Decode(got)
+    if err != nil {
+        t.Error(err)
+    }
+    testEqual(t, "Decode(%q) = %q, want %q", got, decoded, "\x000")
+}
@@ -1,6 +1,7 @@
 package base58
 
 import (
+    "strconv"
     "math/big"
 )
 
@@ -20,22 +21,39 @@ var bigRadix = [...]*big.Int{
 
 var bigRadix10 = big.NewInt(58 * 58 * 58 * 58 * 58 * 58 * 58 * 58 * 58 * 58) // 58^10
 
-// 解析
+// A CorruptInputError is returned if invalid base58 data is encountered during decoding.
+type CorruptInputError int64
+
+func (e CorruptInputError) Error() string {
+    return "illegal base58 data at input byte " + strconv.FormatInt(int64(e), 10)
+}
+
+// 解析，数据错误时返回空
 func Decode(b string) []byte {
+    decoded, err := DecodeString(b)
+    if err != nil {
+        return []byte("")
+    }
+
+    return decoded
+}
+
+// DecodeString returns the bytes represented by the base58 string b
+func DecodeString(b string) ([]byte, error) {
     answer := big.NewInt(0)
     scratch := new(big.Int)
 
-    for t := b; len(t) > 0; {
-        n := len(t)
+    for i := 0; i < len(b); {
+        n := len(b) - i
         if n > 10 {
             n = 10
         }
 
         total := uint64(0)
-        for _, v := range t[:n] {
-            tmp := b58[v]
+        for j := i; j < i+n; j++ {
+            tmp := b58[b[j]]
             if tmp == 255 {
-                return []byte("")
+                return nil, CorruptInputError(j)
             }
             total = total*58 + uint64(tmp)
         }
@@ -44,7 +62,7 @@ func Decode(b string) []byte {
         scratch.SetUint64(total)
         answer.Add(answer, scratch)
 
-        t = t[n:]
+        i += n
     }
 
     tmpval := answer.Bytes()
@@ -59,7 +77,7 @@ func Decode(b string) []byte {
     val := make([]byte, flen)
     copy(val[numZeros:], tmpval)
 
-    return val
+    return val, nil
 }
 
 // 编码
@@ -0,0 +1,127 @@
+package base58
+
+import (
+    "io"
+    "bytes"
+    "testing"
+    "testing/iotest"
+)
+
+var pairs = []struct {
+    decoded []byte
+    encoded string
+}{
+    {[]byte(""), ""},
+    {[]byte{0}, "1"},
+    {[]byte{0, 0, 1}, "112"},
+    {[]byte("hello world"), "StV1DL6CwTryKyV"},
+}
+
+func TestEncodeDecode(t *testing.T) {
+    for _, p := range pairs {
+        encoded := Encode(p.decoded)
+        if encoded != p.encoded {
+            t.Errorf("Encode(%x) = %q, want %q", p.decoded, encoded, p.encoded)
+        }
+
+        decoded, err := DecodeString(p.encoded)
+        if err != nil {
+            t.Errorf("DecodeString(%q) error: %v", p.encoded, err)
+        }
+        if !bytes.Equal(decoded, p.decoded) {
+            t.Errorf("DecodeString(%q) = %x, want %x", p.encoded, decoded, p.decoded)
+        }
+    }
+}
+
+func TestDecodeStringError(t *testing.T) {
+    for _, s := range []string{"0", "abc0", "I", "l", "哈哈"} {
+        if _, err := DecodeString(s); err == nil {
+            t.Errorf("DecodeString(%q) should return an error", s)
+        }
+
+        if len(Decode(s)) != 0 {
+            t.Errorf("Decode(%q) should return empty", s)
+        }
+    }
+
+    _, err := DecodeString("abc0")
+    if err != CorruptInputError(3) {
+        t.Errorf("got %v, want %v", err, CorruptInputError(3))
+    }
+}
+
+func TestCheckDecode(t *testing.T) {
+    encoded := CheckEncode([]byte("data"), 1)
+
+    result, version, err := CheckDecode(encoded)
+    if err != nil || version != 1 || string(result) != "data" {
+        t.Errorf("CheckDecode = %q, %d, %v", result, version, err)
+    }
+
+    if _, _, err := CheckDecode(encoded + "0"); err == nil {
+        t.Error("CheckDecode should return an error for invalid data")
+    }
+}
+
+func FuzzRoundTrip(f *testing.F) {
+    for _, p := range pairs {
+        f.Add(p.decoded)
+    }
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        decoded, err := DecodeString(Encode(data))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
+
+func FuzzDecodeString(f *testing.F) {
+    f.Add("StV1DL6CwTryKyV")
+    f.Add("哈哈")
+
+    f.Fuzz(func(t *testing.T, s string) {
+        decoded, err := DecodeString(s)
+        if err != nil {
+            return
+        }
+
+        if Encode(decoded) != s {
+            t.Fatalf("Encode(DecodeString(%q)) = %q", s, Encode(decoded))
+        }
+    })
+}
+
+func FuzzStream(f *testing.F) {
+    f.Add([]byte(""))
+    f.Add(bytes.Repeat([]byte{0}, 70))
+    f.Add(bytes.Repeat([]byte("hello world"), 10))
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        var buf bytes.Buffer
+
+        w := NewEncoder(&buf)
+        for _, b := range data {
+            if _, err := w.Write([]byte{b}); err != nil {
+                t.Fatal(err)
+            }
+        }
+        if err := w.Close(); err != nil {
+            t.Fatal(err)
+        }
+
+        decoded, err := io.ReadAll(NewDecoder(iotest.OneByteReader(&buf)))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
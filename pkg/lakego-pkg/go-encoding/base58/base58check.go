@@ -30,7 +30,11 @@ func CheckEncode(input []byte, version byte) string {
 
 // 检测解码
 func CheckDecode(input string) (result []byte, version byte, err error) {
-    decoded := Decode(input)
+    decoded, err := DecodeString(input)
+    if err != nil {
+        return nil, 0, err
+    }
+
     if len(decoded) < 5 {
         return nil, 0, ErrInvalidFormat
     }
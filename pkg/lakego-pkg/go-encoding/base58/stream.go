@@ -0,0 +1,21 @@
+package base58
+
+import (
+    "io"
+
+    "github.com/deatil/go-encoding/v2/basex"
+)
+
+var streamEncoding = basex.NewEncoding(alphabet)
+
+// NewEncoder returns a stream encoder. Data is encoded in blocks of
+// basex.StreamBlockSize bytes, so the output differs from Encode and
+// can only be read back with NewDecoder
+func NewEncoder(w io.Writer) io.WriteCloser {
+    return basex.NewEncoder(streamEncoding, w)
+}
+
+// NewDecoder returns a stream decoder for data written by NewEncoder
+func NewDecoder(r io.Reader) io.Reader {
+    return basex.NewDecoder(streamEncoding, r)
+}
@@ -2,9 +2,7 @@ package base62
 
 import (
     "math"
-    "reflect"
     "strconv"
-    "unsafe"
 )
 
 /*
@@ -62,6 +60,14 @@ func (enc *Encoding) Encode(src []byte) []byte {
     // outside of the loop to speed up the encoder.
     _ = enc.encode
 
+    // Leading zero bytes are encoded as the first alphabet character
+    // so that they survive a round trip.
+    zeros := 0
+    for zeros < len(src) && src[zeros] == 0 {
+        zeros++
+    }
+    src = src[zeros:]
+
     rs := 0
     cs := int(math.Ceil(math.Log(256) / math.Log(62) * float64(len(src))))
     dst := make([]byte, cs)
@@ -80,7 +86,14 @@ func (enc *Encoding) Encode(src []byte) []byte {
         dst[i] = enc.encode[dst[i]]
     }
     if cs > rs {
-        return dst[cs-rs:]
+        dst = dst[cs-rs:]
+    }
+    if zeros > 0 {
+        prefix := make([]byte, zeros, zeros+len(dst))
+        for i := range prefix {
+            prefix[i] = enc.encode[0]
+        }
+        dst = append(prefix, dst...)
     }
     return dst
 }
@@ -115,6 +128,17 @@ func (enc *Encoding) Decode(src []byte) ([]byte, error) {
     // receiver can't be nil.
     _ = enc.decodeMap
 
+    zeros := 0
+    for i := range src {
+        if src[i] == '\n' || src[i] == '\r' {
+            continue
+        }
+        if src[i] != enc.encode[0] {
+            break
+        }
+        zeros++
+    }
+
     rs := 0
     cs := int(math.Ceil(math.Log(62) / math.Log(256) * float64(len(src))))
     dst := make([]byte, cs)
@@ -136,14 +160,15 @@ func (enc *Encoding) Decode(src []byte) ([]byte, error) {
         rs = c
     }
     if cs > rs {
-        return dst[cs-rs:], nil
+        dst = dst[cs-rs:]
+    }
+    if zeros > 0 {
+        dst = append(make([]byte, zeros, zeros+len(dst)), dst...)
     }
     return dst, nil
 }
 
 // DecodeString returns the bytes represented by the base62 string s.
 func (enc *Encoding) DecodeString(s string) ([]byte, error) {
-    sh := (*reflect.StringHeader)(unsafe.Pointer(&s))
-    bh := reflect.SliceHeader{Data: sh.Data, Len: sh.Len, Cap: sh.Len}
-    return enc.Decode(*(*[]byte)(unsafe.Pointer(&bh)))
+    return enc.Decode([]byte(s))
 }
@@ -1,8 +1,10 @@
 package base62
 
 import (
+    "io"
     "bytes"
     "testing"
+    "testing/iotest"
 )
 
 func TestEncode(t *testing.T) {
@@ -116,6 +118,67 @@ func TestDecodeWithCustomAlphabet(t *testing.T) {
     }
 }
 
+func TestLeadingZeros(t *testing.T) {
+    for _, s := range [][]byte{{0}, {0, 0}, {0, 0, 'f'}, {0, 1, 0}} {
+        encoded := StdEncoding.Encode(s)
+
+        decoded, err := StdEncoding.Decode(encoded)
+        if err != nil {
+            t.Error(err)
+            continue
+        }
+
+        if !bytes.Equal(decoded, s) {
+            t.Errorf("source: %x\tencoded: %s\tactual source: %x", s, encoded, decoded)
+        }
+    }
+}
+
+func FuzzRoundTrip(f *testing.F) {
+    for _, s := range SamplesStd {
+        f.Add(s.sourceBytes)
+    }
+    f.Add([]byte{0, 0, 1})
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        decoded, err := StdEncoding.DecodeString(StdEncoding.EncodeToString(data))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
+
+func FuzzStream(f *testing.F) {
+    f.Add([]byte(""))
+    f.Add(bytes.Repeat([]byte{0}, 70))
+    f.Add(bytes.Repeat([]byte("Hello, World!"), 10))
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        var buf bytes.Buffer
+
+        w := NewEncoder(StdEncoding, &buf)
+        if _, err := w.Write(data); err != nil {
+            t.Fatal(err)
+        }
+        if err := w.Close(); err != nil {
+            t.Fatal(err)
+        }
+
+        decoded, err := io.ReadAll(NewDecoder(StdEncoding, iotest.HalfReader(&buf)))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
+
 func NewSample(source, target string) *Sample {
     return &Sample{source: source, target: target, sourceBytes: []byte(source), targetBytes: []byte(target)}
 }
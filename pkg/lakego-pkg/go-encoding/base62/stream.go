@@ -0,0 +1,23 @@
+package base62
+
+import (
+    "io"
+
+    "github.com/deatil/go-encoding/v2/basex"
+)
+
+// NewEncoder returns a stream encoder using the alphabet of enc. Data is
+// encoded in blocks of basex.StreamBlockSize bytes, so the output differs
+// from Encode and can only be read back with NewDecoder
+func NewEncoder(enc *Encoding, w io.Writer) io.WriteCloser {
+    return basex.NewEncoder(enc.basex(), w)
+}
+
+// NewDecoder returns a stream decoder for data written by NewEncoder
+func NewDecoder(enc *Encoding, r io.Reader) io.Reader {
+    return basex.NewDecoder(enc.basex(), r)
+}
+
+func (enc *Encoding) basex() basex.Basex {
+    return basex.NewEncoding(string(enc.encode[:]))
+}
@@ -0,0 +1,103 @@
+package base91
+
+import (
+    "io"
+    "bytes"
+    "testing"
+    "testing/iotest"
+)
+
+var pairs = []struct {
+    decoded string
+    encoded string
+}{
+    {"", ""},
+    {"test", "fPNKd"},
+    {"Hello, World!", ">OwJh>}AQ;r@@Y?F"},
+}
+
+func TestEncodeDecode(t *testing.T) {
+    for _, p := range pairs {
+        encoded := StdEncoding.EncodeToString([]byte(p.decoded))
+        if encoded != p.encoded {
+            t.Errorf("EncodeToString(%q) = %q, want %q", p.decoded, encoded, p.encoded)
+        }
+
+        decoded, err := StdEncoding.DecodeString(p.encoded)
+        if err != nil {
+            t.Errorf("DecodeString(%q) error: %v", p.encoded, err)
+        }
+        if string(decoded) != p.decoded {
+            t.Errorf("DecodeString(%q) = %q, want %q", p.encoded, decoded, p.decoded)
+        }
+    }
+}
+
+func TestDecodeError(t *testing.T) {
+    _, err := StdEncoding.DecodeString("fP NKd")
+    if err != CorruptInputError(2) {
+        t.Errorf("got %v, want %v", err, CorruptInputError(2))
+    }
+
+    _, err = io.ReadAll(NewDecoder(StdEncoding, iotest.OneByteReader(bytes.NewReader([]byte("fP NKd")))))
+    if err != CorruptInputError(2) {
+        t.Errorf("stream got %v, want %v", err, CorruptInputError(2))
+    }
+}
+
+func FuzzRoundTrip(f *testing.F) {
+    for _, p := range pairs {
+        f.Add([]byte(p.decoded))
+    }
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        decoded, err := StdEncoding.DecodeString(StdEncoding.EncodeToString(data))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
+
+func FuzzStream(f *testing.F) {
+    for _, p := range pairs {
+        f.Add([]byte(p.decoded))
+    }
+    f.Add(bytes.Repeat([]byte{0xff}, 3000))
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        var buf bytes.Buffer
+
+        w := NewEncoder(StdEncoding, &buf)
+        for i := 0; i < len(data); i += 7 {
+            end := i + 7
+            if end > len(data) {
+                end = len(data)
+            }
+
+            if _, err := w.Write(data[i:end]); err != nil {
+                t.Fatal(err)
+            }
+        }
+        if err := w.Close(); err != nil {
+            t.Fatal(err)
+        }
+
+        // 流式编码结果和 EncodeToString 相同
+        if buf.String() != StdEncoding.EncodeToString(data) {
+            t.Fatalf("stream encoded %q, want %q", buf.String(), StdEncoding.EncodeToString(data))
+        }
+
+        decoded, err := io.ReadAll(NewDecoder(StdEncoding, iotest.OneByteReader(&buf)))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !bytes.Equal(decoded, data) {
+            t.Fatalf("got %x, want %x", decoded, data)
+        }
+    })
+}
@@ -0,0 +1,187 @@
+package base91
+
+import (
+    "io"
+)
+
+/*
+ * Stream encoder
+ */
+
+type encoder struct {
+    enc     *Encoding
+    w       io.Writer
+    queue   uint
+    numBits uint
+    out     [1024]byte
+    err     error
+}
+
+// NewEncoder returns a new base91 stream encoder. Data written to the
+// returned writer will be encoded using enc and then written to w. The
+// output is the same as EncodeToString. Base91 encodings operate on bit
+// queues, so the caller must Close the encoder to flush any partially
+// written bits.
+func NewEncoder(enc *Encoding, w io.Writer) io.WriteCloser {
+    return &encoder{enc: enc, w: w}
+}
+
+func (e *encoder) Write(p []byte) (n int, err error) {
+    if e.err != nil {
+        return 0, e.err
+    }
+
+    for len(p) > 0 {
+        // Each input byte produces at most two output bytes.
+        chunk := p
+        if len(chunk) > len(e.out)/2 {
+            chunk = chunk[:len(e.out)/2]
+        }
+
+        m := 0
+        for _, b := range chunk {
+            e.queue |= uint(b) << e.numBits
+            e.numBits += 8
+            if e.numBits > 13 {
+                var v uint = e.queue & 8191
+
+                if v > 88 {
+                    e.queue >>= 13
+                    e.numBits -= 13
+                } else {
+                    // We can take 14 bits.
+                    v = e.queue & 16383
+                    e.queue >>= 14
+                    e.numBits -= 14
+                }
+                e.out[m] = e.enc.encode[v%91]
+                m++
+                e.out[m] = e.enc.encode[v/91]
+                m++
+            }
+        }
+
+        if _, e.err = e.w.Write(e.out[:m]); e.err != nil {
+            return n, e.err
+        }
+
+        n += len(chunk)
+        p = p[len(chunk):]
+    }
+
+    return n, nil
+}
+
+// Close flushes any pending output from the encoder.
+// It is an error to call Write after calling Close.
+func (e *encoder) Close() error {
+    if e.err != nil {
+        return e.err
+    }
+
+    if e.numBits > 0 {
+        m := 0
+        e.out[m] = e.enc.encode[e.queue%91]
+        m++
+
+        if e.numBits > 7 || e.queue > 90 {
+            e.out[m] = e.enc.encode[e.queue/91]
+            m++
+        }
+
+        e.queue, e.numBits = 0, 0
+
+        _, e.err = e.w.Write(e.out[:m])
+    }
+
+    return e.err
+}
+
+/*
+ * Stream decoder
+ */
+
+type decoder struct {
+    enc     *Encoding
+    r       io.Reader
+    queue   uint
+    numBits uint
+    v       int
+    read    int64
+    buf     [1024]byte
+    out     []byte
+    outbuf  [1024 * 2]byte
+    err     error
+}
+
+// NewDecoder constructs a new base91 stream decoder.
+func NewDecoder(enc *Encoding, r io.Reader) io.Reader {
+    return &decoder{enc: enc, r: r, v: -1}
+}
+
+func (d *decoder) Read(p []byte) (n int, err error) {
+    for len(d.out) == 0 && d.err == nil {
+        d.fill()
+    }
+
+    n = copy(p, d.out)
+    d.out = d.out[n:]
+
+    if len(d.out) > 0 {
+        return n, nil
+    }
+
+    return n, d.err
+}
+
+// 读取并解码一段数据
+func (d *decoder) fill() {
+    nr, err := d.r.Read(d.buf[:])
+
+    m := 0
+    for i := 0; i < nr; i++ {
+        c := d.enc.decodeMap[d.buf[i]]
+        if c == 0xff {
+            // The character is not in the encoding alphabet.
+            d.out = d.outbuf[:m]
+            d.err = CorruptInputError(d.read + int64(i))
+            return
+        }
+
+        if d.v == -1 {
+            // Start the next value.
+            d.v = int(c)
+        } else {
+            d.v += int(c) * 91
+            d.queue |= uint(d.v) << d.numBits
+
+            if (d.v & 8191) > 88 {
+                d.numBits += 13
+            } else {
+                d.numBits += 14
+            }
+
+            for ok := true; ok; ok = (d.numBits > 7) {
+                d.outbuf[m] = byte(d.queue)
+                m++
+
+                d.queue >>= 8
+                d.numBits -= 8
+            }
+
+            // Mark this value complete.
+            d.v = -1
+        }
+    }
+
+    d.read += int64(nr)
+
+    if err == io.EOF && d.v != -1 {
+        d.outbuf[m] = byte(d.queue | uint(d.v)<<d.numBits)
+        m++
+        d.v = -1
+    }
+
+    d.out = d.outbuf[:m]
+    d.err = err
+}
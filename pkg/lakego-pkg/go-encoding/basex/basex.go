@@ -2,7 +2,6 @@ package basex
 
 import (
     "fmt"
-    "bytes"
     "errors"
     "strconv"
     "math/big"
@@ -20,6 +19,11 @@ const (
     Base62InvalidKey = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 )
 
+var (
+    ErrNonBaseCharacter  = errors.New("non Base Character")
+    ErrAmbiguousAlphabet = errors.New("Ambiguous alphabet.")
+)
+
 // Basex
 type Basex struct {
     base        *big.Int
@@ -35,40 +39,40 @@ func (this Basex) Encode(source []byte) string {
         return ""
     }
 
-    var (
-        res bytes.Buffer
-        k   = 0
-    )
-    for ; source[k] == 0 && k < len(source)-1; k++ {
-        res.WriteRune(this.alphabet[0])
+    // 前导 0 字节各对应一个首字符
+    zeros := 0
+    for zeros < len(source) && source[zeros] == 0 {
+        zeros++
     }
 
     var (
         mod big.Int
-        sourceInt = new(big.Int).SetBytes(source)
+        res = make([]rune, 0, len(source)*2)
+        sourceInt = new(big.Int).SetBytes(source[zeros:])
     )
 
-    for sourceInt.Uint64() > 0 {
+    for sourceInt.Sign() > 0 {
         sourceInt.DivMod(sourceInt, this.base, &mod)
-        res.WriteRune(this.alphabet[mod.Uint64()])
+        res = append(res, this.alphabet[mod.Uint64()])
     }
 
-    var (
-        buf = res.Bytes()
-        j   = len(buf) - 1
-    )
+    for i := 0; i < zeros; i++ {
+        res = append(res, this.alphabet[0])
+    }
 
-    for k < j {
-        buf[k], buf[j] = buf[j], buf[k]
-        k++
-        j--
+    for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+        res[i], res[j] = res[j], res[i]
     }
 
-    return string(buf)
+    return string(res)
 }
 
 // 解码
 func (this Basex) Decode(source string) ([]byte, error) {
+    if this.Error != nil {
+        return nil, this.Error
+    }
+
     if len(source) == 0 {
         return []byte{}, nil
     }
@@ -81,7 +85,7 @@ func (this Basex) Decode(source string) ([]byte, error) {
     for i := 0; i < len(data); i++ {
         value, ok := this.alphabetMap[data[i]]
         if !ok {
-            return nil, errors.New("non Base Character")
+            return nil, ErrNonBaseCharacter
         }
 
         dest.Mul(dest, this.base)
@@ -90,12 +94,13 @@ func (this Basex) Decode(source string) ([]byte, error) {
         }
     }
 
-    k := 0
-    for ; data[k] == this.alphabet[0] && k < len(data)-1; k++ {
+    zeros := 0
+    for zeros < len(data) && data[zeros] == this.alphabet[0] {
+        zeros++
     }
 
     buf := dest.Bytes()
-    res := make([]byte, k, k+len(buf))
+    res := make([]byte, zeros, zeros+len(buf))
 
     return append(res, buf...), nil
 }
@@ -125,7 +130,7 @@ func New(alphabet string) Basex {
 
     for i := 0; i < len(runes); i++ {
         if _, ok := runeMap[runes[i]]; ok {
-            basex.Error = errors.New("Ambiguous alphabet.")
+            basex.Error = ErrAmbiguousAlphabet
             return basex
         }
 
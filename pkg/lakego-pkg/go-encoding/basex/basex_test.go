@@ -0,0 +1,129 @@
+package basex
+
+import (
+    "io"
+    "bytes"
+    "testing"
+    "testing/iotest"
+)
+
+var encodings = []Basex{
+    Base2Encoding,
+    Base16Encoding,
+    Base32Encoding,
+    Base58Encoding,
+    Base62Encoding,
+    NewEncoding("αβγδεζηθ"),
+}
+
+func TestEncodeDecode(t *testing.T) {
+    tests := []struct {
+        enc     Basex
+        decoded []byte
+        encoded string
+    }{
+        {Base16Encoding, []byte{}, ""},
+        {Base16Encoding, []byte{0}, "0"},
+        {Base16Encoding, []byte{0, 0}, "00"},
+        {Base16Encoding, []byte{0, 0, 0xab}, "00AB"},
+        {Base16Encoding, []byte{1, 0, 0, 0, 0, 0, 0, 0, 0}, "10000000000000000"},
+        {Base58Encoding, []byte("hello world"), "StV1DL6CwTryKyV"},
+        {NewEncoding("αβγδεζηθ"), []byte{0, 9}, "αββ"},
+    }
+
+    for _, test := range tests {
+        encoded := test.enc.Encode(test.decoded)
+        if encoded != test.encoded {
+            t.Errorf("Encode(%x) = %q, want %q", test.decoded, encoded, test.encoded)
+        }
+
+        decoded, err := test.enc.Decode(test.encoded)
+        if err != nil {
+            t.Errorf("Decode(%q) error: %v", test.encoded, err)
+        }
+        if !bytes.Equal(decoded, test.decoded) {
+            t.Errorf("Decode(%q) = %x, want %x", test.encoded, decoded, test.decoded)
+        }
+    }
+}
+
+func TestDecodeError(t *testing.T) {
+    if _, err := Base16Encoding.Decode("0G"); err != ErrNonBaseCharacter {
+        t.Errorf("got %v, want %v", err, ErrNonBaseCharacter)
+    }
+
+    enc := NewEncoding("0120")
+    if enc.Error != ErrAmbiguousAlphabet {
+        t.Errorf("got %v, want %v", enc.Error, ErrAmbiguousAlphabet)
+    }
+    if _, err := enc.Decode("01"); err != ErrAmbiguousAlphabet {
+        t.Errorf("got %v, want %v", err, ErrAmbiguousAlphabet)
+    }
+}
+
+func TestStreamError(t *testing.T) {
+    // 无效的块长度
+    _, err := io.ReadAll(NewDecoder(Base16Encoding, bytes.NewReader([]byte("ABC"))))
+    if err != ErrInvalidBlock {
+        t.Errorf("got %v, want %v", err, ErrInvalidBlock)
+    }
+
+    // 块数据溢出
+    _, err = io.ReadAll(NewDecoder(Base62Encoding, bytes.NewReader([]byte("zz"))))
+    if err != ErrInvalidBlock {
+        t.Errorf("got %v, want %v", err, ErrInvalidBlock)
+    }
+
+    _, err = io.ReadAll(NewDecoder(Base16Encoding, bytes.NewReader([]byte("0G"))))
+    if err != ErrNonBaseCharacter {
+        t.Errorf("got %v, want %v", err, ErrNonBaseCharacter)
+    }
+}
+
+func FuzzRoundTrip(f *testing.F) {
+    f.Add([]byte{})
+    f.Add([]byte{0, 0, 1})
+    f.Add([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0})
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        for _, enc := range encodings {
+            decoded, err := enc.Decode(enc.Encode(data))
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            if !bytes.Equal(decoded, data) {
+                t.Fatalf("got %x, want %x", decoded, data)
+            }
+        }
+    })
+}
+
+func FuzzStream(f *testing.F) {
+    f.Add([]byte{})
+    f.Add(bytes.Repeat([]byte{0}, StreamBlockSize + 1))
+    f.Add(bytes.Repeat([]byte{0xff}, StreamBlockSize * 3 - 1))
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        for _, enc := range encodings {
+            var buf bytes.Buffer
+
+            w := NewEncoder(enc, &buf)
+            if _, err := w.Write(data); err != nil {
+                t.Fatal(err)
+            }
+            if err := w.Close(); err != nil {
+                t.Fatal(err)
+            }
+
+            decoded, err := io.ReadAll(NewDecoder(enc, iotest.OneByteReader(&buf)))
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            if !bytes.Equal(decoded, data) {
+                t.Fatalf("got %x, want %x", decoded, data)
+            }
+        }
+    })
+}
@@ -0,0 +1,198 @@
+package basex
+
+import (
+    "io"
+    "math"
+    "bufio"
+    "errors"
+    "math/big"
+)
+
+// 流式编码时每块的字节数
+const StreamBlockSize = 32
+
+var ErrInvalidBlock = errors.New("invalid block length")
+
+// 块编码后的长度，ceil(n * 8 / log2(base))
+func (this Basex) blockWidth(n int) int {
+    return int(math.Ceil(float64(n * 8) / math.Log2(float64(len(this.alphabet)))))
+}
+
+// 编码一块数据，不足长度时前面补首字符
+func (this Basex) encodeBlock(src []byte) []rune {
+    width := this.blockWidth(len(src))
+    res := make([]rune, width)
+
+    var mod big.Int
+    num := new(big.Int).SetBytes(src)
+
+    for i := width - 1; i >= 0; i-- {
+        num.DivMod(num, this.base, &mod)
+        res[i] = this.alphabet[mod.Uint64()]
+    }
+
+    return res
+}
+
+// 解码一块数据为 n 字节
+func (this Basex) decodeBlock(src []rune, n int) ([]byte, error) {
+    num := big.NewInt(0)
+
+    for _, r := range src {
+        value, ok := this.alphabetMap[r]
+        if !ok {
+            return nil, ErrNonBaseCharacter
+        }
+
+        num.Mul(num, this.base)
+        num.Add(num, big.NewInt(int64(value)))
+    }
+
+    if num.BitLen() > n * 8 {
+        return nil, ErrInvalidBlock
+    }
+
+    return num.FillBytes(make([]byte, n)), nil
+}
+
+// 最后一块编码长度对应的字节数
+func (this Basex) blockSize(width int) (int, error) {
+    for n := 1; n < StreamBlockSize; n++ {
+        if this.blockWidth(n) == width {
+            return n, nil
+        }
+    }
+
+    return 0, ErrInvalidBlock
+}
+
+// ====================
+
+type encoder struct {
+    enc  Basex
+    w    io.Writer
+    buf  [StreamBlockSize]byte
+    nbuf int
+    err  error
+}
+
+// NewEncoder returns a stream encoder. Data is encoded in blocks of
+// StreamBlockSize bytes, so the output differs from Encode and can only
+// be read back with NewDecoder
+func NewEncoder(enc Basex, w io.Writer) io.WriteCloser {
+    return &encoder{
+        enc: enc,
+        w:   w,
+        err: enc.Error,
+    }
+}
+
+func (this *encoder) Write(p []byte) (n int, err error) {
+    if this.err != nil {
+        return 0, this.err
+    }
+
+    for len(p) > 0 {
+        copied := copy(this.buf[this.nbuf:], p)
+        this.nbuf += copied
+        n += copied
+        p = p[copied:]
+
+        if this.nbuf < StreamBlockSize {
+            break
+        }
+
+        if this.err = this.flush(); this.err != nil {
+            return n, this.err
+        }
+    }
+
+    return n, nil
+}
+
+// Close flushes any pending data, it does not close the underlying writer
+func (this *encoder) Close() error {
+    if this.err == nil && this.nbuf > 0 {
+        this.err = this.flush()
+    }
+
+    return this.err
+}
+
+func (this *encoder) flush() error {
+    block := this.enc.encodeBlock(this.buf[:this.nbuf])
+    this.nbuf = 0
+
+    _, err := io.WriteString(this.w, string(block))
+    return err
+}
+
+// ====================
+
+type decoder struct {
+    enc Basex
+    r   *bufio.Reader
+    out []byte
+    err error
+}
+
+// NewDecoder returns a stream decoder for data written by NewEncoder
+func NewDecoder(enc Basex, r io.Reader) io.Reader {
+    return &decoder{
+        enc: enc,
+        r:   bufio.NewReader(r),
+        err: enc.Error,
+    }
+}
+
+func (this *decoder) Read(p []byte) (int, error) {
+    for len(this.out) == 0 && this.err == nil {
+        this.out, this.err = this.readBlock()
+    }
+
+    n := copy(p, this.out)
+    this.out = this.out[n:]
+
+    if len(this.out) > 0 {
+        return n, nil
+    }
+
+    return n, this.err
+}
+
+func (this *decoder) readBlock() ([]byte, error) {
+    width := this.enc.blockWidth(StreamBlockSize)
+    block := make([]rune, 0, width)
+
+    for len(block) < width {
+        r, _, err := this.r.ReadRune()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, err
+        }
+
+        block = append(block, r)
+    }
+
+    if len(block) == width {
+        return this.enc.decodeBlock(block, StreamBlockSize)
+    }
+
+    if len(block) == 0 {
+        return nil, io.EOF
+    }
+
+    n, err := this.enc.blockSize(len(block))
+    if err != nil {
+        return nil, err
+    }
+
+    out, err := this.enc.decodeBlock(block, n)
+    if err != nil {
+        return nil, err
+    }
+
+    return out, io.EOF
+}
@@ -29,9 +29,9 @@ func Asn1DecodeWithParams(src []byte, val any, params string) ([]byte, error) {
 // Asn1
 func (this Encoding) ForAsn1(data any, params ...string) Encoding {
     if len(params) > 0 {
-        this.data, this.Error = asn1.MarshalWithParams(data, params[0])
+        this.data, this.err = asn1.MarshalWithParams(data, params[0])
     } else {
-        this.data, this.Error = asn1.Marshal(data)
+        this.data, this.err = asn1.Marshal(data)
     }
 
     return this
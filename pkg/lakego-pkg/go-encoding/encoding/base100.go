@@ -1,7 +1,7 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/base100"
+    "github.com/deatil/go-encoding/v2/base100"
 )
 
 // 加密
@@ -11,19 +11,25 @@ func Base100Encode(str string) string {
 
 // 解密
 func Base100Decode(str string) string {
+    newStr, _ := Base100DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Base100DecodeE(str string) (string, error) {
     newStr, err := base100.Decode(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // ====================
 
 // Base100
 func (this Encoding) FromBase100String(data string) Encoding {
-    this.data, this.Error = base100.Decode(data)
+    this.data, this.err = base100.Decode(data)
 
     return this
 }
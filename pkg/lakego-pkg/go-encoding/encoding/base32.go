@@ -1,6 +1,7 @@
 package encoding
 
 import (
+    "io"
     "encoding/base32"
 )
 
@@ -18,12 +19,18 @@ func Base32Encode(str string) string {
 
 // Base32 解码
 func Base32Decode(str string) string {
+    newStr, _ := Base32DecodeE(str)
+    return newStr
+}
+
+// Base32 解码，返回错误
+func Base32DecodeE(str string) (string, error) {
     newStr, err := base32.StdEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // Base32Hex 编码
@@ -33,19 +40,35 @@ func Base32HexEncode(str string) string {
 
 // Base32Hex 解码
 func Base32HexDecode(str string) string {
+    newStr, _ := Base32HexDecodeE(str)
+    return newStr
+}
+
+// Base32Hex 解码，返回错误
+func Base32HexDecodeE(str string) (string, error) {
     newStr, err := base32.HexEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
+}
+
+// Base32 流式编码
+func NewBase32Encoder(w io.Writer) io.WriteCloser {
+    return base32.NewEncoder(base32.StdEncoding, w)
+}
+
+// Base32 流式解码
+func NewBase32Decoder(r io.Reader) io.Reader {
+    return base32.NewDecoder(base32.StdEncoding, r)
 }
 
 // ====================
 
 // Base32
 func (this Encoding) FromBase32String(data string) Encoding {
-    this.data, this.Error = base32.StdEncoding.DecodeString(data)
+    this.data, this.err = base32.StdEncoding.DecodeString(data)
 
     return this
 }
@@ -57,7 +80,7 @@ func FromBase32String(data string) Encoding {
 
 // Base32Hex
 func (this Encoding) FromBase32HexString(data string) Encoding {
-    this.data, this.Error = base32.HexEncoding.DecodeString(data)
+    this.data, this.err = base32.HexEncoding.DecodeString(data)
 
     return this
 }
@@ -69,7 +92,7 @@ func FromBase32HexString(data string) Encoding {
 
 // FromBase32EncoderString
 func (this Encoding) FromBase32EncoderString(data string, encoder string) Encoding {
-    this.data, this.Error = base32.NewEncoding(encoder).DecodeString(data)
+    this.data, this.err = base32.NewEncoding(encoder).DecodeString(data)
 
     return this
 }
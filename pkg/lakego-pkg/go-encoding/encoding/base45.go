@@ -1,7 +1,7 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/base45"
+    "github.com/deatil/go-encoding/v2/base45"
 )
 
 // Base45 编码
@@ -11,12 +11,18 @@ func Base45Encode(str string) string {
 
 // Base45 解码
 func Base45Decode(str string) string {
+    decoded, _ := Base45DecodeE(str)
+    return decoded
+}
+
+// Base45 解码，返回错误
+func Base45DecodeE(str string) (string, error) {
     decoded, err := base45.Decode(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return decoded
+    return decoded, nil
 }
 
 // ====================
@@ -26,7 +32,7 @@ func (this Encoding) FromBase45String(data string) Encoding {
     decoded, err := base45.Decode(data)
 
     this.data = []byte(decoded)
-    this.Error = err
+    this.err = err
 
     return this
 }
@@ -1,7 +1,9 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/base58"
+    "io"
+
+    "github.com/deatil/go-encoding/v2/base58"
 )
 
 // Base58 编码
@@ -11,18 +13,35 @@ func Base58Encode(str string) string {
 
 // Base58 解码
 func Base58Decode(str string) string {
-    decoded := base58.Decode(str)
+    decoded, _ := Base58DecodeE(str)
+    return decoded
+}
+
+// Base58 解码，返回错误
+func Base58DecodeE(str string) (string, error) {
+    decoded, err := base58.DecodeString(str)
+    if err != nil {
+        return "", err
+    }
+
+    return string(decoded), nil
+}
+
+// Base58 流式编码，分块编码，只能使用 NewBase58Decoder 解码
+func NewBase58Encoder(w io.Writer) io.WriteCloser {
+    return base58.NewEncoder(w)
+}
 
-    return string(decoded)
+// Base58 流式解码
+func NewBase58Decoder(r io.Reader) io.Reader {
+    return base58.NewDecoder(r)
 }
 
 // ====================
 
 // Base58
 func (this Encoding) FromBase58String(data string) Encoding {
-    data = Base58Decode(data)
-
-    this.data = []byte(data)
+    this.data, this.err = base58.DecodeString(data)
 
     return this
 }
@@ -1,7 +1,9 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/base62"
+    "io"
+
+    "github.com/deatil/go-encoding/v2/base62"
 )
 
 // 加密
@@ -11,19 +13,35 @@ func Base62Encode(str string) string {
 
 // 解密
 func Base62Decode(str string) string {
+    newStr, _ := Base62DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Base62DecodeE(str string) (string, error) {
     newStr, err := base62.StdEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
+}
+
+// 流式编码，分块编码，只能使用 NewBase62Decoder 解码
+func NewBase62Encoder(w io.Writer) io.WriteCloser {
+    return base62.NewEncoder(base62.StdEncoding, w)
+}
+
+// 流式解码
+func NewBase62Decoder(r io.Reader) io.Reader {
+    return base62.NewDecoder(base62.StdEncoding, r)
 }
 
 // ====================
 
 // Base62
 func (this Encoding) FromBase62String(data string) Encoding {
-    this.data, this.Error = base62.StdEncoding.DecodeString(data)
+    this.data, this.err = base62.StdEncoding.DecodeString(data)
 
     return this
 }
@@ -19,12 +19,18 @@ func Base64Encode(str string) string {
 
 // 解密
 func Base64Decode(str string) string {
+    newStr, _ := Base64DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Base64DecodeE(str string) (string, error) {
     newStr, err := base64.StdEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // URL 加密
@@ -34,12 +40,18 @@ func Base64URLEncode(str string) string {
 
 // URL 解密
 func Base64URLDecode(str string) string {
+    newStr, _ := Base64URLDecodeE(str)
+    return newStr
+}
+
+// URL 解密，返回错误
+func Base64URLDecodeE(str string) (string, error) {
     newStr, err := base64.URLEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // Raw 加密，无填充编码
@@ -49,12 +61,18 @@ func Base64RawEncode(str string) string {
 
 // Raw 解密，无填充编码
 func Base64RawDecode(str string) string {
+    newStr, _ := Base64RawDecodeE(str)
+    return newStr
+}
+
+// Raw 解密，无填充编码，返回错误
+func Base64RawDecodeE(str string) (string, error) {
     newStr, err := base64.RawStdEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // RawURL 加密，无填充编码
@@ -64,12 +82,18 @@ func Base64RawURLEncode(str string) string {
 
 // RawURL 解密，无填充编码
 func Base64RawURLDecode(str string) string {
+    newStr, _ := Base64RawURLDecodeE(str)
+    return newStr
+}
+
+// RawURL 解密，无填充编码，返回错误
+func Base64RawURLDecodeE(str string) (string, error) {
     newStr, err := base64.RawURLEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // URL
@@ -79,23 +103,29 @@ func Base64EncodeSegment(seg string) string {
 
 // URL
 func Base64DecodeSegment(seg string) string {
+    newStr, _ := Base64DecodeSegmentE(seg)
+    return newStr
+}
+
+// URL，返回错误
+func Base64DecodeSegmentE(seg string) (string, error) {
     if l := len(seg) % 4; l > 0 {
         seg += strings.Repeat("=", 4-l)
     }
 
-    newStr, err := base64.RawStdEncoding.DecodeString(seg)
+    newStr, err := base64.URLEncoding.DecodeString(seg)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // ====================
 
 // Base64
 func (this Encoding) FromBase64String(data string) Encoding {
-    this.data, this.Error = base64.StdEncoding.DecodeString(data)
+    this.data, this.err = base64.StdEncoding.DecodeString(data)
 
     return this
 }
@@ -107,7 +137,7 @@ func FromBase64String(data string) Encoding {
 
 // Base64URL
 func (this Encoding) FromBase64URLString(data string) Encoding {
-    this.data, this.Error = base64.URLEncoding.DecodeString(data)
+    this.data, this.err = base64.URLEncoding.DecodeString(data)
 
     return this
 }
@@ -119,7 +149,7 @@ func FromBase64URLString(data string) Encoding {
 
 // Base64Raw
 func (this Encoding) FromBase64RawString(data string) Encoding {
-    this.data, this.Error = base64.RawStdEncoding.DecodeString(data)
+    this.data, this.err = base64.RawStdEncoding.DecodeString(data)
 
     return this
 }
@@ -131,7 +161,7 @@ func FromBase64RawString(data string) Encoding {
 
 // Base64RawURL
 func (this Encoding) FromBase64RawURLString(data string) Encoding {
-    this.data, this.Error = base64.RawURLEncoding.DecodeString(data)
+    this.data, this.err = base64.RawURLEncoding.DecodeString(data)
 
     return this
 }
@@ -148,12 +178,12 @@ func (this Encoding) FromBase64SegmentString(data string, paddingAllowed ...bool
             data += strings.Repeat("=", 4-l)
         }
 
-        this.data, this.Error = base64.URLEncoding.DecodeString(data)
+        this.data, this.err = base64.URLEncoding.DecodeString(data)
 
         return this
     }
 
-    this.data, this.Error = base64.RawURLEncoding.DecodeString(data)
+    this.data, this.err = base64.RawURLEncoding.DecodeString(data)
 
     return this
 }
@@ -165,7 +195,7 @@ func FromBase64SegmentString(data string) Encoding {
 
 // FromBase64EncoderString
 func (this Encoding) FromBase64EncoderString(data string, encoder string) Encoding {
-    this.data, this.Error = base64.NewEncoding(encoder).DecodeString(data)
+    this.data, this.err = base64.NewEncoding(encoder).DecodeString(data)
 
     return this
 }
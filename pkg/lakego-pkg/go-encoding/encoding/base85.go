@@ -1,45 +1,65 @@
 package encoding
 
 import (
-    "bytes"
+    "io"
     "encoding/ascii85"
 )
 
 // Base85 编码
 func Base85Encode(src string) string {
-    text := []byte(src)
-
-    dest := make([]byte, ascii85.MaxEncodedLen(len(text)))
-    ascii85.Encode(dest, text)
-
-    return string(dest)
+    return string(base85Encode([]byte(src)))
 }
 
 // Base85 解码
 func Base85Decode(s string) string {
-    decodedText := make([]byte, len([]byte(s)))
-    decoded, _, _ := ascii85.Decode(decodedText, []byte(s), true)
-    decodedText = decodedText[:decoded]
+    decoded, _ := Base85DecodeE(s)
+    return decoded
+}
+
+// Base85 解码，返回错误
+func Base85DecodeE(s string) (string, error) {
+    decoded, err := base85Decode([]byte(s))
+    if err != nil {
+        return "", err
+    }
 
-    return string(bytes.Trim(decodedText, "\x00"))
+    return string(decoded), nil
 }
 
-// ====================
+// Base85 流式编码
+func NewBase85Encoder(w io.Writer) io.WriteCloser {
+    return ascii85.NewEncoder(w)
+}
 
-// Base85
-func (this Encoding) FromBase85String(data string) Encoding {
-    src := []byte(data)
+// Base85 流式解码
+func NewBase85Decoder(r io.Reader) io.Reader {
+    return ascii85.NewDecoder(r)
+}
 
-    decodedText := make([]byte, len(src))
-    decoded, _, err := ascii85.Decode(decodedText, src, true)
+// 编码，'z' 压缩时实际长度小于 MaxEncodedLen
+func base85Encode(src []byte) []byte {
+    dest := make([]byte, ascii85.MaxEncodedLen(len(src)))
+    n := ascii85.Encode(dest, src)
+
+    return dest[:n]
+}
+
+// 解码，'z' 最多解码为 4 字节
+func base85Decode(src []byte) ([]byte, error) {
+    dest := make([]byte, 4*len(src))
+    n, _, err := ascii85.Decode(dest, src, true)
     if err != nil {
-        this.Error = err
-        return this
+        return nil, err
     }
 
-    decodedText = decodedText[:decoded]
+    return dest[:n], nil
+}
+
+// ====================
 
-    this.data = bytes.Trim(decodedText, "\x00")
+// Base85
+func (this Encoding) FromBase85String(data string) Encoding {
+    this.data, this.err = base85Decode([]byte(data))
 
     return this
 }
@@ -51,10 +71,5 @@ func FromBase85String(data string) Encoding {
 
 // 输出 Base85
 func (this Encoding) ToBase85String() string {
-    text := this.data
-
-    dest := make([]byte, ascii85.MaxEncodedLen(len(text)))
-    ascii85.Encode(dest, text)
-
-    return string(dest)
+    return string(base85Encode(this.data))
 }
@@ -1,7 +1,9 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/base91"
+    "io"
+
+    "github.com/deatil/go-encoding/v2/base91"
 )
 
 // 加密
@@ -11,19 +13,35 @@ func Base91Encode(str string) string {
 
 // 解密
 func Base91Decode(str string) string {
+    newStr, _ := Base91DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Base91DecodeE(str string) (string, error) {
     newStr, err := base91.StdEncoding.DecodeString(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
+}
+
+// 流式编码
+func NewBase91Encoder(w io.Writer) io.WriteCloser {
+    return base91.NewEncoder(base91.StdEncoding, w)
+}
+
+// 流式解码
+func NewBase91Decoder(r io.Reader) io.Reader {
+    return base91.NewDecoder(base91.StdEncoding, r)
 }
 
 // ====================
 
 // Base91
 func (this Encoding) FromBase91String(data string) Encoding {
-    this.data, this.Error = base91.StdEncoding.DecodeString(data)
+    this.data, this.err = base91.StdEncoding.DecodeString(data)
 
     return this
 }
@@ -1,7 +1,9 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/basex"
+    "io"
+
+    "github.com/deatil/go-encoding/v2/basex"
 )
 
 // 加密
@@ -11,12 +13,18 @@ func Basex2Encode(str string) string {
 
 // 解密
 func Basex2Decode(str string) string {
+    newStr, _ := Basex2DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Basex2DecodeE(str string) (string, error) {
     newStr, err := basex.Base2Encoding.Decode(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // =============================
@@ -28,12 +36,18 @@ func Basex16Encode(str string) string {
 
 // 解密
 func Basex16Decode(str string) string {
+    newStr, _ := Basex16DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Basex16DecodeE(str string) (string, error) {
     newStr, err := basex.Base16Encoding.Decode(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
 }
 
 // =============================
@@ -45,19 +59,35 @@ func Basex62Encode(str string) string {
 
 // 解密
 func Basex62Decode(str string) string {
+    newStr, _ := Basex62DecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func Basex62DecodeE(str string) (string, error) {
     newStr, err := basex.Base62Encoding.Decode(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return string(newStr)
+    return string(newStr), nil
+}
+
+// 流式编码，分块编码，只能使用 NewBasexDecoder 解码
+func NewBasexEncoder(w io.Writer, encoder string) io.WriteCloser {
+    return basex.NewEncoder(basex.NewEncoding(encoder), w)
+}
+
+// 流式解码
+func NewBasexDecoder(r io.Reader, encoder string) io.Reader {
+    return basex.NewDecoder(basex.NewEncoding(encoder), r)
 }
 
 // ====================
 
 // Basex2
 func (this Encoding) FromBasex2String(data string) Encoding {
-    this.data, this.Error = basex.Base2Encoding.Decode(data)
+    this.data, this.err = basex.Base2Encoding.Decode(data)
 
     return this
 }
@@ -69,7 +99,7 @@ func FromBasex2String(data string) Encoding {
 
 // Basex16
 func (this Encoding) FromBasex16String(data string) Encoding {
-    this.data, this.Error = basex.Base16Encoding.Decode(data)
+    this.data, this.err = basex.Base16Encoding.Decode(data)
 
     return this
 }
@@ -81,7 +111,7 @@ func FromBasex16String(data string) Encoding {
 
 // Basex62
 func (this Encoding) FromBasex62String(data string) Encoding {
-    this.data, this.Error = basex.Base62Encoding.Decode(data)
+    this.data, this.err = basex.Base62Encoding.Decode(data)
 
     return this
 }
@@ -93,7 +123,7 @@ func FromBasex62String(data string) Encoding {
 
 // FromBasexEncoderString
 func (this Encoding) FromBasexEncoderString(data string, encoder string) Encoding {
-    this.data, this.Error = basex.NewEncoding(encoder).Decode(data)
+    this.data, this.err = basex.NewEncoding(encoder).Decode(data)
 
     return this
 }
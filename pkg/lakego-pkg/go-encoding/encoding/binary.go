@@ -32,7 +32,7 @@ func (this Encoding) ForBinary(data any) Encoding {
 
     err := binary.Write(buf, binary.LittleEndian, data)
     if err != nil {
-        this.Error = err
+        this.err = err
         return this
     }
 
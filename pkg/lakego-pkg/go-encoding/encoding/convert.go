@@ -83,11 +83,11 @@ func (this Encoding) FromConvert(input any, base int, bitSize ...int) Encoding {
         case string:
             number, err = strconv.ParseInt(input.(string), base, newBitSize)
             if err != nil {
-                this.Error = err
+                this.err = err
                 return this
             }
         default:
-            this.Error = errors.New("数据输入格式错误")
+            this.err = errors.New("数据输入格式错误")
             return this
     }
 
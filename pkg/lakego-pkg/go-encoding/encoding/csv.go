@@ -47,7 +47,7 @@ func (this Encoding) ForCsv(data [][]string) Encoding {
     w.WriteAll(data)
 
     if err := w.Error(); err != nil {
-        this.Error = err
+        this.err = err
         return this
     }
 
@@ -11,7 +11,7 @@ type Encoding struct {
     data []byte
 
     // 错误
-    Error error
+    err error
 }
 
 var defaultEncode Encoding
@@ -30,3 +30,8 @@ func NewEncoding() Encoding {
 func New() Encoding {
     return NewEncoding()
 }
+
+// 错误
+func (this Encoding) Error() error {
+    return this.err
+}
@@ -0,0 +1,205 @@
+package encoding
+
+import (
+    "io"
+    "bytes"
+    "testing"
+    "testing/iotest"
+
+    "github.com/deatil/go-encoding/v2/basex"
+)
+
+type codec struct {
+    name   string
+    encode func(string) string
+    decode func(string) (string, error)
+}
+
+var codecs = []codec{
+    {"Base32", Base32Encode, Base32DecodeE},
+    {"Base32Hex", Base32HexEncode, Base32HexDecodeE},
+    {"Base45", Base45Encode, Base45DecodeE},
+    {"Base58", Base58Encode, Base58DecodeE},
+    {"Base62", Base62Encode, Base62DecodeE},
+    {"Base64", Base64Encode, Base64DecodeE},
+    {"Base64URL", Base64URLEncode, Base64URLDecodeE},
+    {"Base64Raw", Base64RawEncode, Base64RawDecodeE},
+    {"Base64RawURL", Base64RawURLEncode, Base64RawURLDecodeE},
+    {"Base64Segment", Base64EncodeSegment, Base64DecodeSegmentE},
+    {"Base85", Base85Encode, Base85DecodeE},
+    {"Base91", Base91Encode, Base91DecodeE},
+    {"Base100", Base100Encode, Base100DecodeE},
+    {"Basex2", Basex2Encode, Basex2DecodeE},
+    {"Basex16", Basex16Encode, Basex16DecodeE},
+    {"Basex62", Basex62Encode, Basex62DecodeE},
+    {"Hex", HexEncode, HexDecodeE},
+}
+
+func TestDecodeE(t *testing.T) {
+    corrupted := map[string]string{
+        "Base32":        "MZXW6!==",
+        "Base32Hex":     "CPNMU!==",
+        "Base45":        "GGW",
+        "Base58":        "0OIl",
+        "Base62":        "abc-",
+        "Base64":        "Zm9v!",
+        "Base64URL":     "Zm9v+",
+        "Base64Raw":     "Zm9v=",
+        "Base64RawURL":  "Zm9v/",
+        "Base64Segment": "Zm9v/",
+        "Base85":        "~>abc",
+        "Base91":        "fP NKd",
+        "Base100":       "aaaa",
+        "Basex2":        "012",
+        "Basex16":       "0G",
+        "Basex62":       "abc-",
+        "Hex":           "0g",
+    }
+
+    for _, c := range codecs {
+        data, err := c.decode(corrupted[c.name])
+        if err == nil {
+            t.Errorf("%s: expected error, got %q", c.name, data)
+        }
+
+        if data != "" {
+            t.Errorf("%s: expected empty data, got %q", c.name, data)
+        }
+    }
+
+    if _, err := MorseITUDecodeE("-- ?.. ..."); err == nil {
+        t.Error("MorseITU: expected error")
+    }
+
+    if _, err := JsonEncodeE(func() {}); err == nil {
+        t.Error("Json: expected error")
+    }
+}
+
+func TestError(t *testing.T) {
+    if err := FromBase58String("0OIl").Error(); err == nil {
+        t.Error("FromBase58String: expected error")
+    }
+
+    if err := FromBase85String("~>abc").Error(); err == nil {
+        t.Error("FromBase85String: expected error")
+    }
+
+    data := FromBase64String("Zm9v")
+    if data.Error() != nil || data.ToString() != "foo" {
+        t.Errorf("FromBase64String: got %q, %v", data.ToString(), data.Error())
+    }
+}
+
+func TestBase85ZeroBytes(t *testing.T) {
+    src := "\x00\x00\x00\x00ab\x00"
+
+    encoded := Base85Encode(src)
+    if bytes.IndexByte([]byte(encoded), 0) >= 0 {
+        t.Errorf("Base85Encode(%q) = %q contains zero bytes", src, encoded)
+    }
+
+    decoded, err := Base85DecodeE(encoded)
+    if err != nil || decoded != src {
+        t.Errorf("Base85DecodeE(%q) = %q, %v, want %q", encoded, decoded, err, src)
+    }
+
+    if got := FromString(src).ToBase85String(); got != encoded {
+        t.Errorf("ToBase85String() = %q, want %q", got, encoded)
+    }
+
+    if got := FromBase85String(encoded).ToString(); got != src {
+        t.Errorf("FromBase85String(%q) = %q, want %q", encoded, got, src)
+    }
+}
+
+func FuzzRoundTrip(f *testing.F) {
+    f.Add("")
+    f.Add("useData")
+    f.Add("\x00\x00\x00\x00ab\x00")
+    f.Add("你好，世界！")
+
+    f.Fuzz(func(t *testing.T, data string) {
+        for _, c := range codecs {
+            decoded, err := c.decode(c.encode(data))
+            if err != nil {
+                t.Fatalf("%s: %v", c.name, err)
+            }
+
+            if decoded != data {
+                t.Fatalf("%s: got %q, want %q", c.name, decoded, data)
+            }
+        }
+    })
+}
+
+func FuzzDecode(f *testing.F) {
+    f.Add("")
+    f.Add("0OIl")
+    f.Add("~>abc")
+    f.Add("哈哈")
+
+    f.Fuzz(func(t *testing.T, data string) {
+        // 错误数据需要返回错误，不能 panic
+        for _, c := range codecs {
+            decoded, err := c.decode(data)
+            if err != nil && decoded != "" {
+                t.Fatalf("%s: got %q with error %v", c.name, decoded, err)
+            }
+        }
+
+        MorseITUDecodeE(data)
+    })
+}
+
+type streamCodec struct {
+    name    string
+    encoder func(io.Writer) io.WriteCloser
+    decoder func(io.Reader) io.Reader
+}
+
+var streamCodecs = []streamCodec{
+    {"Base32", NewBase32Encoder, NewBase32Decoder},
+    {"Base58", NewBase58Encoder, NewBase58Decoder},
+    {"Base62", NewBase62Encoder, NewBase62Decoder},
+    {"Base85", NewBase85Encoder, NewBase85Decoder},
+    {"Base91", NewBase91Encoder, NewBase91Decoder},
+    {
+        "Basex",
+        func(w io.Writer) io.WriteCloser {
+            return NewBasexEncoder(w, basex.Base62_2Key)
+        },
+        func(r io.Reader) io.Reader {
+            return NewBasexDecoder(r, basex.Base62_2Key)
+        },
+    },
+}
+
+func FuzzStream(f *testing.F) {
+    f.Add([]byte(""))
+    f.Add([]byte("useData"))
+    f.Add(bytes.Repeat([]byte{0}, 100))
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        for _, c := range streamCodecs {
+            var buf bytes.Buffer
+
+            w := c.encoder(&buf)
+            if _, err := w.Write(data); err != nil {
+                t.Fatalf("%s: %v", c.name, err)
+            }
+            if err := w.Close(); err != nil {
+                t.Fatalf("%s: %v", c.name, err)
+            }
+
+            decoded, err := io.ReadAll(c.decoder(iotest.HalfReader(&buf)))
+            if err != nil {
+                t.Fatalf("%s: %v", c.name, err)
+            }
+
+            if !bytes.Equal(decoded, data) {
+                t.Fatalf("%s: got %x, want %x", c.name, decoded, data)
+            }
+        }
+    })
+}
@@ -34,7 +34,7 @@ func (this Encoding) ForGob(data any) Encoding {
     enc := gob.NewEncoder(buf)
     err := enc.Encode(data)
     if err != nil {
-        this.Error = err
+        this.err = err
         return this
     }
 
@@ -11,15 +11,25 @@ func HexEncode(src string) string {
 
 // Hex 解码
 func HexDecode(s string) string {
-    data, _ := hex.DecodeString(s)
-    return string(data)
+    data, _ := HexDecodeE(s)
+    return data
+}
+
+// Hex 解码，返回错误
+func HexDecodeE(s string) (string, error) {
+    data, err := hex.DecodeString(s)
+    if err != nil {
+        return "", err
+    }
+
+    return string(data), nil
 }
 
 // ====================
 
 // Hex
 func (this Encoding) FromHexString(data string) Encoding {
-    this.data, this.Error = hex.DecodeString(data)
+    this.data, this.err = hex.DecodeString(data)
 
     return this
 }
@@ -9,9 +9,18 @@ import (
 
 // Json 编码
 func JsonEncode(src any) string {
-    data, _ := json.Marshal(src)
+    data, _ := JsonEncodeE(src)
+    return data
+}
+
+// Json 编码，返回错误
+func JsonEncodeE(src any) (string, error) {
+    data, err := json.Marshal(src)
+    if err != nil {
+        return "", err
+    }
 
-    return string(data)
+    return string(data), nil
 }
 
 // Json 解码
@@ -44,7 +53,7 @@ func NewDecoder(r io.Reader) *jsoniter.Decoder {
 
 // JSON
 func (this Encoding) ForJSON(data any) Encoding {
-    this.data, this.Error = json.Marshal(data)
+    this.data, this.err = json.Marshal(data)
 
     return this
 }
@@ -1,7 +1,7 @@
 package encoding
 
 import (
-    "github.com/deatil/go-encoding/morse"
+    "github.com/deatil/go-encoding/v2/morse"
 )
 
 // 加密
@@ -11,12 +11,18 @@ func MorseITUEncode(str string) string {
 
 // 解密
 func MorseITUDecode(str string) string {
+    newStr, _ := MorseITUDecodeE(str)
+    return newStr
+}
+
+// 解密，返回错误
+func MorseITUDecodeE(str string) (string, error) {
     newStr, err := morse.DecodeITU(str)
     if err != nil {
-        return ""
+        return "", err
     }
 
-    return newStr
+    return newStr, nil
 }
 
 // ====================
@@ -26,7 +32,7 @@ func (this Encoding) FromMorseITUString(data string) Encoding {
     data, err := morse.DecodeITU(data)
 
     this.data = []byte(data)
-    this.Error = err
+    this.err = err
 
     return this
 }
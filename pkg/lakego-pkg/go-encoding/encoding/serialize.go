@@ -93,7 +93,7 @@ func Unserialize(data []byte, ptr any) (err error) {
 
 // 序列化
 func (this Encoding) ForSerialize(data any) Encoding {
-    this.data, this.Error = Serialize(data)
+    this.data, this.err = Serialize(data)
 
     return this
 }
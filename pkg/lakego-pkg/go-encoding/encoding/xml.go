@@ -34,7 +34,7 @@ func (this Encoding) ForXML(data any) Encoding {
     enc := xml.NewEncoder(buf)
     err := enc.Encode(data)
     if err != nil {
-        this.Error = err
+        this.err = err
         return this
     }
 
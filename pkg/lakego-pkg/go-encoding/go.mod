@@ -1,4 +1,4 @@
-module github.com/deatil/go-encoding
+module github.com/deatil/go-encoding/v2
 
 go 1.18
 
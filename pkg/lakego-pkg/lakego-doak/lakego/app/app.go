@@ -10,6 +10,7 @@ import (
     "sync"
     "errors"
     "context"
+    "crypto/tls"
 
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-jwt/jwt"
@@ -21,6 +22,14 @@ import (
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/middleware/recovery"
+    "github.com/deatil/lakego-doak/lakego/middleware/locale"
+    "github.com/deatil/lakego-doak/lakego/middleware/tracing"
+    "github.com/deatil/lakego-doak/lakego/middleware/requestid"
+    "github.com/deatil/lakego-doak/lakego/middleware/accesslog"
+    "github.com/deatil/lakego-doak/lakego/middleware/clientcert"
+    facadeLang "github.com/deatil/lakego-doak/lakego/facade/lang"
+    facadeTrace "github.com/deatil/lakego-doak/lakego/facade/trace"
+    lakego_tls "github.com/deatil/lakego-doak/lakego/tls"
     iprovider "github.com/deatil/lakego-doak/lakego/provider/interfaces"
 )
 
@@ -141,6 +150,9 @@ func (this *App) Run() {
     // 导入环境变量
     this.loadEnv()
 
+    // 验证配置
+    this.loadConfig()
+
     // 初始化容器
     this.initDI()
 
@@ -302,6 +314,14 @@ func (this *App) runApp() {
                     )
                 }))
 
+                // 使用默认处理机制
+                r.Use(router.Recovery())
+            } else if logShowType == "json" {
+                router.SetMode(router.DebugMode)
+
+                // 路由
+                r = router.New()
+
                 // 使用默认处理机制
                 r.Use(router.Recovery())
             } else {
@@ -344,9 +364,36 @@ func (this *App) runApp() {
     // 全局中间件
     r.Use(recovery.Handler())
 
+    // 请求ID
+    r.Use(requestid.Handler(facadeTrace.GetRequestIDHeader()))
+
+    // 链路追踪
+    r.Use(tracing.Handler(facadeTrace.New()))
+
+    // 语言协商
+    r.Use(locale.Handler(facadeLang.Options()))
+
+    // json 格式访问日志
+    if !this.RunInConsole && serverConf.GetString("log-show-type") == "json" {
+        r.Use(accesslog.Handler())
+    }
+
+    // 客户端证书信息
+    if this.hasClientAuth() {
+        r.Use(clientcert.Handler())
+    }
+
     // 缓存路由信息
     router.NewRoute().With(r)
 
+    // 签名链接密钥，服务运行时必须设置
+    signKey := serverConf.GetString("signed-url.key")
+    if signKey == "" && !this.RunInConsole {
+        log.Fatal("签名链接密钥为空，请设置 LAKEGO_SERVER_SIGNED_URL_KEY 或运行 lakego-admin:install 生成")
+    }
+
+    router.WithSignKey([]byte(signKey))
+
     // 绑定路由
     this.RouteEngine = r
 
@@ -430,17 +477,7 @@ func (this *App) serverRun() {
             }
 
         case "tls":
-            // 运行端口
-            addr := conf.GetString("types.tls.addr")
-
-            certFile := conf.GetString("types.tls.cert-file")
-            keyFile := conf.GetString("types.tls.key-file")
-
-            // 格式化
-            certFile = this.formatPath(certFile)
-            keyFile = this.formatPath(keyFile)
-
-            err = this.RouteEngine.RunTLS(addr, certFile, keyFile)
+            err = this.tlsRun()
 
         case "unix":
             // 文件
@@ -510,9 +547,84 @@ func (this *App) graceRun(address string) {
         log.Fatal("Server Shutdown:", err)
     }
 
+    // 发送剩余链路数据
+    facadeTrace.New().Shutdown(ctx)
+
     log.Println("Server exiting")
 }
 
+// 是否有服务开启客户端证书验证
+func (this *App) hasClientAuth() bool {
+    types := this.Config.GetStringMap("types")
+
+    for name := range types {
+        clientAuth := this.Config.GetString(fmt.Sprintf("types.%s.client-auth", name))
+
+        typ, err := lakego_tls.ParseClientAuth(clientAuth)
+        if err == nil && typ != tls.NoClientCert {
+            return true
+        }
+    }
+
+    return false
+}
+
+// tls 运行
+func (this *App) tlsRun() error {
+    conf := this.Config
+
+    // 运行端口
+    addr := conf.GetString("types.tls.addr")
+
+    certFile := this.formatPath(conf.GetString("types.tls.cert-file"))
+    keyFile := this.formatPath(conf.GetString("types.tls.key-file"))
+
+    // 开发模式自动生成自签名证书
+    if conf.GetBool("types.tls.self-signed.enable") {
+        err := lakego_tls.EnsureSelfSignedCert(
+            certFile,
+            keyFile,
+            conf.GetStringSlice("types.tls.self-signed.hosts"),
+            conf.GetInt("types.tls.self-signed.expire"),
+        )
+        if err != nil {
+            return err
+        }
+    }
+
+    clientCAFile := conf.GetString("types.tls.client-ca-file")
+    if clientCAFile != "" {
+        clientCAFile = this.formatPath(clientCAFile)
+    }
+
+    tlsConfig, err := lakego_tls.New(lakego_tls.Options{
+        CertFile:       certFile,
+        KeyFile:        keyFile,
+        ClientCAFile:   clientCAFile,
+        ClientAuth:     conf.GetString("types.tls.client-auth"),
+        MinVersion:     conf.GetString("types.tls.min-version"),
+        MaxVersion:     conf.GetString("types.tls.max-version"),
+        CipherSuites:   conf.GetStringSlice("types.tls.cipher-suites"),
+        ReloadInterval: conf.GetDuration("types.tls.reload-interval"),
+    }).Config()
+    if err != nil {
+        return err
+    }
+
+    srv := &http.Server{
+        Addr:      addr,
+        Handler:   this.RouteEngine,
+        TLSConfig: tlsConfig,
+    }
+
+    // 关闭 http2
+    if !conf.GetBool("types.tls.http2") {
+        srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+    }
+
+    return srv.ListenAndServeTLS("", "")
+}
+
 /**
  * 初始化容器
  */
@@ -535,10 +647,27 @@ func (this *App) loadEnv() {
     // 环境变量
     err := env.Load()
     if err != nil {
+        // 解密失败时不能继续运行
+        if errors.Is(err, env.ErrDecrypt) {
+            log.Fatal("环境变量导入失败，原因为：" + err.Error())
+        }
+
         log.Println("环境变量导入失败，原因为：" + err.Error())
     }
 }
 
+// 导入并验证已注册的配置段
+func (this *App) loadConfig() {
+    // 配置解密失败时不能继续运行
+    if err := config.Check(); err != nil {
+        log.Fatal("配置导入失败，原因为：" + err.Error())
+    }
+
+    if err := config.LoadSections(); err != nil {
+        log.Fatal("配置验证失败，原因为：" + err.Error())
+    }
+}
+
 // 格式化文件路径
 func (this *App) formatPath(file string) string {
     filename := path.FormatPath(file)
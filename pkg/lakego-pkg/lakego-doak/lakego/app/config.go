@@ -0,0 +1,67 @@
+package app
+
+import (
+    "time"
+
+    "github.com/go-playground/validator/v10"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 服务配置，启动时验证
+var ServerSection = config.NewSection[ServerConfig]("server")
+
+// 旧版本发布时自带的签名密钥，已公开不能使用
+var publicSignKeys = []string{
+    "d8a6c2e9b04f4e61a7b3c5d2e1f09a87",
+}
+
+func init() {
+    config.RegisterValidation("sign-key", validateSignKey)
+    config.RegisterSection(ServerSection)
+}
+
+// 验证签名密钥，为空时在服务运行时检测
+func validateSignKey(fl validator.FieldLevel) bool {
+    key := fl.Field().String()
+    if key == "" {
+        return true
+    }
+
+    for _, k := range publicSignKeys {
+        if key == k {
+            return false
+        }
+    }
+
+    return len(key) >= 32
+}
+
+/**
+ * 服务配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ServerConfig struct {
+    // 配置模式
+    Mode string `mapstructure:"mode" validate:"oneof=dev release"`
+
+    // 日志记录方式
+    LogType string `mapstructure:"log-type" validate:"oneof=file stdout"`
+
+    // 日志显示方式
+    LogShowType string `mapstructure:"log-show-type" validate:"oneof=lakego gin json"`
+
+    // 签名链接，密钥不能使用自带的密钥且不少于 32 位，为空时在服务运行时检测
+    SignedURL struct {
+        Key    string        `mapstructure:"key" validate:"sign-key"`
+        Expire time.Duration `mapstructure:"expire" validate:"gt=0"`
+    } `mapstructure:"signed-url"`
+
+    // 运行方式
+    Default string `mapstructure:"default" validate:"oneof=http tls unix fd"`
+
+    // 运行方式配置
+    Types map[string]map[string]any `mapstructure:"types" validate:"required"`
+}
@@ -6,7 +6,7 @@ import (
     "time"
     "errors"
 
-    "github.com/deatil/go-encoding/encoding"
+    "github.com/deatil/go-encoding/v2/encoding"
     "github.com/deatil/go-cryptobin/cryptobin/crypto"
 
     "github.com/deatil/lakego-jwt/jwt"
@@ -30,3 +30,16 @@ func (this *Captcha) Make() (string, string, error) {
     return this.Generate()
 }
 
+// 验证
+func (this Captcha) Verify(id, answer string, clear bool) bool {
+    if verifier, ok := this.Driver.(interfaces.Verifier); ok {
+        value := this.Store.Get(id, clear)
+        if value == "" {
+            return false
+        }
+
+        return verifier.VerifyAnswer(value, answer)
+    }
+
+    return this.Captcha.Verify(id, answer, clear)
+}
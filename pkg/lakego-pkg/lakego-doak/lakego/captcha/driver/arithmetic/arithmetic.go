@@ -0,0 +1,109 @@
+package arithmetic
+
+import (
+    "fmt"
+    "math/rand"
+    "image/color"
+
+    "github.com/mojocn/base64Captcha"
+)
+
+// 构造函数
+func New(config Config) *Arithmetic {
+    math := base64Captcha.NewDriverMath(
+        config.Height,
+        config.Width,
+        config.NoiseCount,
+        config.ShowLineOptions,
+        config.BgColor,
+        nil,
+        config.Fonts,
+    )
+
+    operators := config.Operators
+    if len(operators) == 0 {
+        operators = []string{"+", "-", "x"}
+    }
+
+    max := config.Max
+    if max <= 0 {
+        max = 20
+    }
+
+    return &Arithmetic{
+        math:      math.ConvertFonts(),
+        operators: operators,
+        max:       max,
+    }
+}
+
+// 配置
+type Config struct {
+    Height          int
+    Width           int
+    NoiseCount      int
+    ShowLineOptions int
+    BgColor         *color.RGBA
+    Fonts           []string
+
+    // 运算符 + | - | x
+    Operators []string
+
+    // 运算数最大值
+    Max int
+}
+
+/**
+ * 算术验证码
+ *
+ * 可设置运算符及运算数范围，减法结果不为负数
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Arithmetic struct {
+    // 画图
+    math *base64Captcha.DriverMath
+
+    // 运算符
+    operators []string
+
+    // 运算数最大值
+    max int
+}
+
+// 画图
+func (this *Arithmetic) DrawCaptcha(content string) (base64Captcha.Item, error) {
+    return this.math.DrawCaptcha(content)
+}
+
+// 生成验证码
+func (this *Arithmetic) GenerateIdQuestionAnswer() (id, q, a string) {
+    id = base64Captcha.RandomId()
+
+    x := rand.Intn(this.max + 1)
+    y := rand.Intn(this.max + 1)
+
+    var result int
+
+    operator := this.operators[rand.Intn(len(this.operators))]
+    switch operator {
+        case "-":
+            if x < y {
+                x, y = y, x
+            }
+
+            result = x - y
+        case "x", "*":
+            operator = "x"
+            result = x * y
+        default:
+            operator = "+"
+            result = x + y
+    }
+
+    q = fmt.Sprintf("%d%s%d=?", x, operator, y)
+    a = fmt.Sprintf("%d", result)
+
+    return
+}
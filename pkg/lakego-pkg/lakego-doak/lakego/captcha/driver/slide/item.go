@@ -0,0 +1,130 @@
+package slide
+
+import (
+    "io"
+    "bytes"
+    "image"
+    "math/rand"
+    "image/png"
+    "image/color"
+    "encoding/json"
+    "encoding/base64"
+)
+
+// 生成图片
+func NewItem(width, height, size, x, y int) *Item {
+    bg := image.NewRGBA(image.Rect(0, 0, width, height))
+
+    // 渐变背景
+    from := randColor()
+    to := randColor()
+    for i := 0; i < width; i++ {
+        c := mixColor(from, to, float64(i)/float64(width))
+        for j := 0; j < height; j++ {
+            bg.SetRGBA(i, j, c)
+        }
+    }
+
+    // 干扰块
+    for n := 0; n < 12; n++ {
+        drawRect(bg, rand.Intn(width), rand.Intn(height), size/2+rand.Intn(size), size/2+rand.Intn(size), randColor())
+    }
+
+    // 滑块
+    piece := image.NewRGBA(image.Rect(0, 0, size, size))
+    for i := 0; i < size; i++ {
+        for j := 0; j < size; j++ {
+            piece.SetRGBA(i, j, bg.RGBAAt(x+i, y+j))
+        }
+    }
+
+    // 缺口
+    for i := 0; i < size; i++ {
+        for j := 0; j < size; j++ {
+            c := bg.RGBAAt(x+i, y+j)
+            bg.SetRGBA(x+i, y+j, color.RGBA{c.R / 3, c.G / 3, c.B / 3, 255})
+        }
+    }
+
+    return &Item{
+        Background: bg,
+        Piece:      piece,
+        Y:          y,
+    }
+}
+
+/**
+ * 滑动验证码图片
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Item struct {
+    // 背景
+    Background *image.RGBA
+
+    // 滑块
+    Piece *image.RGBA
+
+    // 滑块纵坐标
+    Y int
+}
+
+// 写入背景图片
+func (this *Item) WriteTo(w io.Writer) (int64, error) {
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, this.Background); err != nil {
+        return 0, err
+    }
+
+    return buf.WriteTo(w)
+}
+
+// 返回 json 字符，包含背景、滑块及滑块纵坐标
+func (this *Item) EncodeB64string() string {
+    data, _ := json.Marshal(map[string]any{
+        "background": encodeImage(this.Background),
+        "piece":      encodeImage(this.Piece),
+        "y":          this.Y,
+    })
+
+    return string(data)
+}
+
+// 图片编码
+func encodeImage(img image.Image) string {
+    var buf bytes.Buffer
+    png.Encode(&buf, img)
+
+    return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// 画块
+func drawRect(img *image.RGBA, x, y, w, h int, c color.RGBA) {
+    bounds := img.Bounds()
+    for i := x; i < x+w && i < bounds.Max.X; i++ {
+        for j := y; j < y+h && j < bounds.Max.Y; j++ {
+            old := img.RGBAAt(i, j)
+            img.SetRGBA(i, j, mixColor(old, c, 0.35))
+        }
+    }
+}
+
+// 随机颜色
+func randColor() color.RGBA {
+    return color.RGBA{
+        uint8(60 + rand.Intn(180)),
+        uint8(60 + rand.Intn(180)),
+        uint8(60 + rand.Intn(180)),
+        255,
+    }
+}
+
+// 混合颜色
+func mixColor(a, b color.RGBA, t float64) color.RGBA {
+    mix := func(x, y uint8) uint8 {
+        return uint8(float64(x)*(1-t) + float64(y)*t)
+    }
+
+    return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
@@ -0,0 +1,135 @@
+package slide
+
+import (
+    "fmt"
+    "errors"
+    "strconv"
+    "strings"
+    "math/rand"
+
+    "github.com/mojocn/base64Captcha"
+)
+
+// 构造函数
+func New(config Config) *Slide {
+    if config.Width <= 0 {
+        config.Width = 300
+    }
+
+    if config.Height <= 0 {
+        config.Height = 150
+    }
+
+    if config.BlockSize <= 0 {
+        config.BlockSize = 40
+    }
+
+    if config.Tolerance <= 0 {
+        config.Tolerance = 4
+    }
+
+    return &Slide{
+        config: config,
+    }
+}
+
+// 配置
+type Config struct {
+    // 背景宽度
+    Width int
+
+    // 背景高度
+    Height int
+
+    // 滑块大小
+    BlockSize int
+
+    // 允许误差，像素
+    Tolerance int
+}
+
+/**
+ * 滑动验证码
+ *
+ * 存储的答案为滑块横坐标，提交的横坐标在误差范围内即验证通过
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Slide struct {
+    config Config
+}
+
+// 画图，content 格式为 "x,y"
+func (this *Slide) DrawCaptcha(content string) (base64Captcha.Item, error) {
+    x, y, err := parsePoint(content)
+    if err != nil {
+        return nil, err
+    }
+
+    return NewItem(this.config.Width, this.config.Height, this.config.BlockSize, x, y), nil
+}
+
+// 生成验证码
+func (this *Slide) GenerateIdQuestionAnswer() (id, q, a string) {
+    id = base64Captcha.RandomId()
+
+    size := this.config.BlockSize
+
+    // 滑块不与左侧起点重叠
+    x := size*2 + rand.Intn(max(this.config.Width - size*3, 1))
+    y := rand.Intn(max(this.config.Height - size, 1))
+
+    q = fmt.Sprintf("%d,%d", x, y)
+    a = strconv.Itoa(x)
+
+    return
+}
+
+// 验证
+func (this *Slide) VerifyAnswer(answer string, input string) bool {
+    x, err := strconv.Atoi(answer)
+    if err != nil {
+        return false
+    }
+
+    offset, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+    if err != nil {
+        return false
+    }
+
+    diff := int(offset) - x
+    if diff < 0 {
+        diff = -diff
+    }
+
+    return diff <= this.config.Tolerance
+}
+
+// 解析坐标
+func parsePoint(content string) (int, int, error) {
+    parts := strings.Split(content, ",")
+    if len(parts) != 2 {
+        return 0, 0, errors.New("slide: content is invalid")
+    }
+
+    x, err := strconv.Atoi(parts[0])
+    if err != nil {
+        return 0, 0, err
+    }
+
+    y, err := strconv.Atoi(parts[1])
+    if err != nil {
+        return 0, 0, err
+    }
+
+    return x, y, nil
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
+    }
+
+    return b
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+/**
+ * 自定义验证接口
+ *
+ * 驱动实现该接口时使用驱动的验证方式，比如滑动验证码的误差范围
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Verifier interface {
+    // 验证，answer 为存储的答案，input 为提交的数据
+    VerifyAnswer(answer string, input string) bool
+}
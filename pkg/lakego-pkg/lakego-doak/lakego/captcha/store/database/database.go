@@ -0,0 +1,118 @@
+package database
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/deatil/lakego-doak/lakego/captcha/store"
+    "github.com/deatil/lakego-doak/lakego/captcha/interfaces"
+)
+
+// 构造函数
+func New(config Config) interfaces.Store {
+    table := config.Table
+    if table == "" {
+        table = "captcha"
+    }
+
+    return &Database{
+        db:    config.DB,
+        table: table,
+        ttl:   config.TTL,
+    }
+}
+
+// 配置
+type Config struct {
+    // 数据库连接
+    DB *gorm.DB
+
+    // 表名，不含前缀
+    Table string
+
+    // 过期时间，单位秒
+    TTL int
+}
+
+// 数据表结构
+type Captcha struct {
+    ID         string `gorm:"column:id;size:50;not null;primaryKey;"`
+    Value      string `gorm:"column:value;size:255;not null;"`
+    ExpireTime int64  `gorm:"column:expire_time;not null;index;"`
+}
+
+/**
+ * 数据库存储
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 继承默认
+    store.Store
+
+    // 数据库
+    db *gorm.DB
+
+    // 表名
+    table string
+
+    // 过期时间
+    ttl int
+}
+
+// 设置
+func (this *Database) Set(id string, value string) error {
+    now := time.Now().Unix()
+
+    // 清除过期数据
+    this.query().
+        Where("expire_time < ?", now).
+        Delete(&Captcha{})
+
+    return this.query().
+        Clauses(clause.OnConflict{
+            UpdateAll: true,
+        }).
+        Create(&Captcha{
+            ID:         id,
+            Value:      value,
+            ExpireTime: now + int64(this.ttl),
+        }).
+        Error
+}
+
+// 获取
+func (this *Database) Get(id string, clear bool) string {
+    var data Captcha
+
+    err := this.query().
+        Where("id = ?", id).
+        Where("expire_time >= ?", time.Now().Unix()).
+        First(&data).
+        Error
+    if err != nil {
+        return ""
+    }
+
+    if clear {
+        this.query().
+            Where("id = ?", id).
+            Delete(&Captcha{})
+    }
+
+    return data.Value
+}
+
+// 验证
+func (this *Database) Verify(id, answer string, clear bool) bool {
+    v := this.Get(id, clear)
+    return v != "" && v == answer
+}
+
+// 查询，表名会添加数据库配置的前缀
+func (this *Database) query() *gorm.DB {
+    return this.db.Table(this.db.NamingStrategy.TableName(this.table))
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+    "sync"
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/captcha/store"
+    "github.com/deatil/lakego-doak/lakego/captcha/interfaces"
+)
+
+// 构造函数
+func New(config Config) interfaces.Store {
+    return &Memory{
+        ttl:   config.TTL,
+        limit: config.Limit,
+        items: make(map[string]item),
+    }
+}
+
+// 配置
+type Config struct {
+    // 过期时间
+    TTL time.Duration
+
+    // 最大存储数量，超过时清除过期数据
+    Limit int
+}
+
+// 存储数据
+type item struct {
+    value    string
+    expireAt time.Time
+}
+
+/**
+ * 内存存储
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 继承默认
+    store.Store
+
+    // 锁
+    mu sync.Mutex
+
+    // 数据
+    items map[string]item
+
+    // 过期时间
+    ttl time.Duration
+
+    // 最大数量
+    limit int
+}
+
+// 设置
+func (this *Memory) Set(id string, value string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.limit > 0 && len(this.items) >= this.limit {
+        this.collect()
+    }
+
+    this.items[id] = item{
+        value:    value,
+        expireAt: time.Now().Add(this.ttl),
+    }
+
+    return nil
+}
+
+// 获取
+func (this *Memory) Get(id string, clear bool) string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    data, ok := this.items[id]
+    if !ok {
+        return ""
+    }
+
+    if clear || data.expired() {
+        delete(this.items, id)
+    }
+
+    if data.expired() {
+        return ""
+    }
+
+    return data.value
+}
+
+// 验证
+func (this *Memory) Verify(id, answer string, clear bool) bool {
+    v := this.Get(id, clear)
+    return v != "" && v == answer
+}
+
+// 清除过期数据
+func (this *Memory) collect() {
+    for id, data := range this.items {
+        if data.expired() {
+            delete(this.items, id)
+        }
+    }
+}
+
+// 是否过期
+func (this item) expired() bool {
+    return time.Now().After(this.expireAt)
+}
@@ -127,8 +127,28 @@ func (this *Adapter) GetSizeInBytes(keyName string) uint {
     panic("方法没有实现")
 }
 
+// 全部配置
+func (this *Adapter) AllSettings() map[string]any {
+    panic("方法没有实现")
+}
+
+// 解析到结构体
+func (this *Adapter) Unmarshal(out any) error {
+    panic("方法没有实现")
+}
+
+// 解析指定键到结构体
+func (this *Adapter) UnmarshalKey(keyName string, out any) error {
+    panic("方法没有实现")
+}
+
 // 事件
 func (this *Adapter) OnConfigChange(f func(string)) {
     panic("方法没有实现")
 }
 
+// 导入错误
+func (this *Adapter) Error() error {
+    return nil
+}
+
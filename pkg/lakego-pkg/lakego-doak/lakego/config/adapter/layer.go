@@ -0,0 +1,93 @@
+package adapter
+
+import (
+    "sync"
+)
+
+var layerInstance *Layer
+var layerOnce sync.Once
+
+// 单例
+func InstanceLayer() *Layer {
+    layerOnce.Do(func() {
+        layerInstance = &Layer{
+            defaults:  make(map[string]map[string]any),
+            overrides: make(map[string]map[string]any),
+        }
+    })
+
+    return layerInstance
+}
+
+/**
+ * 配置层级数据
+ *
+ * 默认值 < 配置文件 < config/{env}/ 配置文件 < 环境变量 < 命令行参数
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Layer struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 默认值 name => key => value
+    defaults map[string]map[string]any
+
+    // 命令行覆盖值 name => key => value
+    overrides map[string]map[string]any
+}
+
+// 添加默认值
+func (this *Layer) WithDefault(name string, key string, value any) *Layer {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.defaults[name]; !ok {
+        this.defaults[name] = make(map[string]any)
+    }
+
+    this.defaults[name][key] = value
+
+    return this
+}
+
+// 获取默认值
+func (this *Layer) GetDefaults(name string) map[string]any {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return copyMap(this.defaults[name])
+}
+
+// 添加覆盖值
+func (this *Layer) WithOverride(name string, key string, value any) *Layer {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.overrides[name]; !ok {
+        this.overrides[name] = make(map[string]any)
+    }
+
+    this.overrides[name][key] = value
+
+    return this
+}
+
+// 获取覆盖值
+func (this *Layer) GetOverrides(name string) map[string]any {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return copyMap(this.overrides[name])
+}
+
+// 复制
+func copyMap(src map[string]any) map[string]any {
+    data := make(map[string]any, len(src))
+    for k, v := range src {
+        data[k] = v
+    }
+
+    return data
+}
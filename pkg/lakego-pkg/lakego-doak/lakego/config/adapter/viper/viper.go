@@ -1,16 +1,26 @@
 package viper
 
 import (
+    "os"
+    "log"
+    "sync"
     "time"
+    "bytes"
+    "errors"
     "strings"
+    "path/filepath"
 
     "github.com/spf13/viper"
     "github.com/fsnotify/fsnotify"
 
     "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/secret"
     "github.com/deatil/lakego-doak/lakego/config/adapter"
 )
 
+// 环境名称的环境变量，设置后会合并 config/{env}/ 下的同名配置
+const EnvName = "LAKEGO_ENV"
+
 // 构造函数
 func New() *Viper {
     conf := &Viper{}
@@ -22,6 +32,11 @@ func New() *Viper {
 /**
  * Viper 适配器
  *
+ * 配置优先级：
+ * 默认值 < 模块配置 < 配置文件 < config/{env}/ 配置文件 < 环境变量 < 命令行参数
+ *
+ * 环境变量格式为 {前缀}_{文件名}_{键名}，如 LAKEGO_SERVER_LOG_SHOW_TYPE
+ *
  * @create 2021-9-25
  * @author deatil
  */
@@ -32,18 +47,34 @@ type Viper struct {
 
     // 路径
     path string
+
+    // 配置名称
+    name string
+
+    // 配置类型
+    typ string
+
+    // 环境变量前缀
+    envPrefix string
+
+    // 是否使用环境变量
+    automaticEnv bool
+
+    // 监听文件
+    watchOnce sync.Once
+
+    // 导入错误
+    err error
 }
 
 // 环境变量前缀
 func (this *Viper) SetEnvPrefix(prefix string) {
-    this.conf.SetEnvPrefix(prefix)
-    this.conf.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+    this.envPrefix = prefix
 }
 
 // 环境变量
 func (this *Viper) AutomaticEnv() {
-    this.conf.AutomaticEnv()
-    this.conf.AllowEmptyEnv(true)
+    this.automaticEnv = true
 }
 
 // 设置文件夹
@@ -76,35 +107,182 @@ func (this *Viper) WithConfigFile(file string) {
 
 // 要读取的文件
 func (this *Viper) WithFile(fileName ...string) {
-    /*
-    // 配置里读取
-    if err := this.conf.ReadInConfig(); err != nil {
-        panic("配置初始化失败：" + err.Error())
+    if len(fileName) == 0 {
+        return
     }
-    */
+
+    this.name = fileName[0]
 
     // 设置配置文件类型(后缀)为 yml
-    nameType := ""
     if len(fileName) > 1 {
-        nameType = fileName[1]
+        this.typ = fileName[1]
     } else {
-        nameType = "yml"
+        this.typ = "yml"
     }
 
-    if len(fileName) > 0 {
-        // 导入自定义配置
-        configFiles := adapter.InstancePath().GetPath(fileName[0])
-        if len(configFiles) > 0 {
-            for _, configFile := range configFiles {
-                // 指定配置文件路径
-                this.WithConfigFile(path.FormatPath(configFile))
-            }
+    this.err = this.load()
+}
+
+// 导入错误，解密失败时不为空
+func (this *Viper) Error() error {
+    return this.err
+}
+
+// 按层级导入配置
+func (this *Viper) load() error {
+    // 清空已有配置
+    this.conf.SetConfigType(this.typ)
+    this.conf.ReadConfig(bytes.NewReader(nil))
+
+    layer := adapter.InstanceLayer()
+
+    // 默认值
+    this.mergeValues(layer.GetDefaults(this.name))
+
+    // 模块配置
+    for _, configFile := range adapter.InstancePath().GetPath(this.name) {
+        this.mergeFile(path.FormatPath(configFile))
+    }
+
+    // 配置文件
+    mainFile := filepath.Join(this.path, this.name + "." + this.typ)
+    this.mergeFile(mainFile)
+
+    // 环境配置
+    if env := os.Getenv(EnvName); env != "" {
+        this.mergeFile(filepath.Join(this.path, env, this.name + "." + this.typ))
+    }
+
+    // 环境变量
+    this.mergeEnv()
+
+    // 命令行参数
+    this.mergeValues(layer.GetOverrides(this.name))
+
+    // 监听主配置文件
+    this.conf.SetConfigFile(mainFile)
+    this.conf.SetConfigType(this.typ)
+
+    // 解密 enc: 开头的数据
+    return this.decryptValues()
+}
+
+// 合并文件
+func (this *Viper) mergeFile(file string) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return
+    }
+
+    typ := strings.TrimPrefix(filepath.Ext(file), ".")
+    if typ == "" {
+        typ = this.typ
+    }
+
+    this.conf.SetConfigType(typ)
+    this.conf.MergeConfig(bytes.NewReader(data))
+}
+
+// 合并环境变量
+func (this *Viper) mergeEnv() {
+    if !this.automaticEnv {
+        return
+    }
+
+    prefix := this.EnvKey("")
+    replacer := strings.NewReplacer(".", "_", "-", "_")
+
+    this.conf.SetEnvPrefix(strings.TrimSuffix(prefix, "_"))
+    this.conf.SetEnvKeyReplacer(replacer)
+    this.conf.AutomaticEnv()
+    this.conf.AllowEmptyEnv(true)
+
+    values := make(map[string]any)
+    for _, key := range this.conf.AllKeys() {
+        if value, ok := os.LookupEnv(this.EnvKey(key)); ok {
+            values[key] = value
+        }
+    }
+
+    this.mergeValues(values)
+}
+
+// 解密数据
+func (this *Viper) decryptValues() error {
+    msgs := make([]string, 0)
+
+    values := make(map[string]any)
+    for _, key := range this.conf.AllKeys() {
+        switch value := this.conf.Get(key).(type) {
+            case string:
+                if !secret.IsEncrypted(value) {
+                    continue
+                }
+
+                dec, err := secret.Decrypt(value)
+                if err != nil {
+                    msgs = append(msgs, "配置[" + this.name + "." + key + "]解密失败：" + err.Error())
+                    continue
+                }
+
+                values[key] = dec
+
+            case []any:
+                if !secret.ContainsEncrypted(value) {
+                    continue
+                }
+
+                dec, err := secret.DecryptAny(value)
+                if err != nil {
+                    msgs = append(msgs, "配置[" + this.name + "." + key + "]解密失败：" + err.Error())
+                    continue
+                }
+
+                values[key] = dec
+        }
+    }
+
+    this.mergeValues(values)
+
+    if len(msgs) > 0 {
+        return errors.New(strings.Join(msgs, "; "))
+    }
+
+    return nil
+}
+
+// 合并键值，键名为 a.b.c 格式
+func (this *Viper) mergeValues(values map[string]any) {
+    if len(values) == 0 {
+        return
+    }
+
+    data := make(map[string]any)
+    for key, value := range values {
+        setNested(data, strings.Split(key, "."), value)
+    }
+
+    this.conf.MergeConfigMap(data)
+}
+
+// 键名对应的环境变量名称
+// log-show-type => LAKEGO_SERVER_LOG_SHOW_TYPE
+func (this *Viper) EnvKey(keyName string) string {
+    replacer := strings.NewReplacer(".", "_", "-", "_")
+
+    parts := make([]string, 0, 3)
+    for _, part := range []string{this.envPrefix, this.name, keyName} {
+        if part != "" {
+            parts = append(parts, part)
         }
+    }
 
-        this.WithConfigName(this.path, fileName[0], nameType)
+    key := strings.Join(parts, "_")
+    if keyName == "" {
+        key += "_"
     }
 
-    this.conf.WatchConfig()
+    return strings.ToUpper(replacer.Replace(key))
 }
 
 // 获取
@@ -235,14 +413,47 @@ func (this *Viper) GetSizeInBytes(keyName string) uint {
     return value
 }
 
-// 事件
+// 全部配置
+func (this *Viper) AllSettings() map[string]any {
+    return this.conf.AllSettings()
+}
+
+// 解析到结构体
+func (this *Viper) Unmarshal(out any) error {
+    if this.err != nil {
+        return this.err
+    }
+
+    return this.conf.Unmarshal(out)
+}
+
+// 解析指定键到结构体
+func (this *Viper) UnmarshalKey(keyName string, out any) error {
+    if this.err != nil {
+        return this.err
+    }
+
+    return this.conf.UnmarshalKey(keyName, out)
+}
+
+// 事件，设置后开始监听配置文件
 func (this *Viper) OnConfigChange(f func(string)) {
     // 事件
     this.conf.OnConfigChange(func(changeEvent fsnotify.Event) {
+        // 重新按层级导入
+        this.err = this.load()
+        if this.err != nil {
+            log.Print(this.err.Error())
+        }
+
         opString := changeEvent.Op.String()
         f(opString)
     })
 
+    this.watchOnce.Do(func() {
+        this.conf.WatchConfig()
+    })
+
     /*
     this.conf.OnConfigChange(func(changeEvent fsnotify.Event) {
         if changeEvent.Op.String() == "WRITE" {
@@ -251,3 +462,19 @@ func (this *Viper) OnConfigChange(f func(string)) {
     })
     */
 }
+
+// 设置多级数据
+func setNested(data map[string]any, keys []string, value any) {
+    if len(keys) == 1 {
+        data[keys[0]] = value
+        return
+    }
+
+    child, ok := data[keys[0]].(map[string]any)
+    if !ok {
+        child = make(map[string]any)
+        data[keys[0]] = child
+    }
+
+    setNested(child, keys[1:], value)
+}
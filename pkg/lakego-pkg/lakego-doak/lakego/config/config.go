@@ -159,6 +159,21 @@ func (this *Config) GetSizeInBytes(keyName string) uint {
     return this.adapter.GetSizeInBytes(keyName)
 }
 
+// 全部配置
+func (this *Config) AllSettings() map[string]any {
+    return this.adapter.AllSettings()
+}
+
+// 解析到结构体，字段使用 mapstructure 标签
+func (this *Config) Unmarshal(out any) error {
+    return this.adapter.Unmarshal(out)
+}
+
+// 解析指定键到结构体
+func (this *Config) UnmarshalKey(keyName string, out any) error {
+    return this.adapter.UnmarshalKey(keyName, out)
+}
+
 // 事件
 func (this *Config) OnConfigChange(f func(string)) *Config {
     // 事件
@@ -167,3 +182,8 @@ func (this *Config) OnConfigChange(f func(string)) *Config {
     return this
 }
 
+
+// 导入错误，配置解密失败时不为空
+func (this *Config) Error() error {
+    return this.adapter.Error()
+}
@@ -59,5 +59,14 @@ type Adapter interface {
 
     GetSizeInBytes(keyName string) uint
 
+    AllSettings() map[string]any
+
+    Unmarshal(out any) error
+
+    UnmarshalKey(keyName string, out any) error
+
     OnConfigChange(f func(string))
+
+    // 导入错误
+    Error() error
 }
@@ -0,0 +1,208 @@
+package config
+
+import (
+    "os"
+    "fmt"
+    "sort"
+    "strings"
+    "path/filepath"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 隐藏值
+const maskValue = "******"
+
+// 需要隐藏的键名
+var secretKeys = []string{
+    "password",
+    "passwd",
+    "secret",
+    "token",
+    "private",
+    "credential",
+    "dsn",
+}
+
+/**
+ * 显示合并后的配置，敏感数据会被隐藏
+ *
+ * > ./main lakego:config-show [name] [--key=keyname] [--json] [--show-secrets]
+ * > main.exe lakego:config-show [name] [--key=keyname] [--json] [--show-secrets]
+ * > go run main.go lakego:config-show [name] [--key=keyname] [--json] [--show-secrets]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ConfigShowCmd = &command.Command{
+    Use: "lakego:config-show",
+    Aliases: []string{"config:show"},
+    Short: "显示合并后的配置.",
+    Example: "{execfile} lakego:config-show server --key=types.http",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+        ConfigShow(args)
+    },
+}
+
+// 参数
+var pKey string
+var pJson bool
+var pShowSecrets bool
+
+func init() {
+    pf := ConfigShowCmd.Flags()
+    pf.StringVarP(&pKey, "key", "k", "", "只显示指定键名")
+    pf.BoolVarP(&pJson, "json", "j", false, "以 json 格式显示")
+    pf.BoolVarP(&pShowSecrets, "show-secrets", "", false, "显示敏感数据")
+}
+
+// 显示配置
+func ConfigShow(names []string) {
+    if len(names) == 0 {
+        names = configNames()
+    }
+
+    data := make(map[string]any)
+    for _, name := range names {
+        conf := config.New(name)
+
+        var value any
+        if pKey != "" {
+            if !conf.IsSet(pKey) {
+                continue
+            }
+
+            value = conf.Get(pKey)
+        } else {
+            value = conf.AllSettings()
+        }
+
+        if !pShowSecrets {
+            value = Mask(pKey, value)
+        }
+
+        data[name] = value
+    }
+
+    if len(data) == 0 {
+        color.Redln("没有找到配置")
+        return
+    }
+
+    if pJson {
+        out, err := json.MarshalIndent(data, "", "    ")
+        if err != nil {
+            color.Redln("配置格式化失败：" + err.Error())
+            return
+        }
+
+        fmt.Println(string(out))
+        return
+    }
+
+    lines := make(map[string]any)
+    for name, value := range data {
+        prefix := name
+        if pKey != "" {
+            prefix += "." + pKey
+        }
+
+        flatten(prefix, value, lines)
+    }
+
+    keys := make([]string, 0, len(lines))
+    for k := range lines {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    for _, k := range keys {
+        value, _ := json.Marshal(lines[k])
+        fmt.Printf("%s = %s\n", k, value)
+    }
+}
+
+// 隐藏敏感数据
+func Mask(key string, value any) any {
+    switch v := value.(type) {
+        case map[string]any:
+            data := make(map[string]any, len(v))
+            for k, item := range v {
+                data[k] = Mask(k, item)
+            }
+
+            return data
+
+        case []any:
+            data := make([]any, 0, len(v))
+            for _, item := range v {
+                data = append(data, Mask(key, item))
+            }
+
+            return data
+    }
+
+    if IsSecretKey(key) && fmt.Sprint(value) != "" {
+        return maskValue
+    }
+
+    return value
+}
+
+// 是否为敏感键名
+func IsSecretKey(key string) bool {
+    if idx := strings.LastIndex(key, "."); idx >= 0 {
+        key = key[idx+1:]
+    }
+
+    key = strings.ToLower(key)
+
+    if key == "key" || strings.HasSuffix(key, "-key") || strings.HasSuffix(key, "_key") {
+        return true
+    }
+
+    for _, secret := range secretKeys {
+        if strings.Contains(key, secret) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 多级数据转为一级
+func flatten(prefix string, value any, data map[string]any) {
+    if m, ok := value.(map[string]any); ok && len(m) > 0 {
+        for k, v := range m {
+            flatten(prefix + "." + k, v, data)
+        }
+
+        return
+    }
+
+    data[prefix] = value
+}
+
+// 配置目录下的配置文件名
+func configNames() []string {
+    files, _ := filepath.Glob(path.ConfigPath("/*.yml"))
+
+    names := make([]string, 0, len(files))
+    for _, file := range files {
+        info, err := os.Stat(file)
+        if err != nil || info.IsDir() {
+            continue
+        }
+
+        names = append(names, strings.TrimSuffix(filepath.Base(file), ".yml"))
+    }
+
+    return names
+}
@@ -0,0 +1,358 @@
+package secrets
+
+import (
+    "os"
+    "fmt"
+    "os/exec"
+    "runtime"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/secret"
+    "github.com/deatil/lakego-doak/lakego/command"
+)
+
+/**
+ * 生成主密钥
+ *
+ * > go run main.go lakego:secrets-key
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var SecretsKeyCmd = &command.Command{
+    Use: "lakego:secrets-key",
+    Aliases: []string{"secrets:key"},
+    Short: "生成配置加密主密钥.",
+    Example: "{execfile} lakego:secrets-key",
+    SilenceUsage: true,
+    Run: func(cmd *command.Command, args []string) {
+        key, err := secret.GenerateKey()
+        if err != nil {
+            color.Redln("密钥生成失败：" + err.Error())
+            return
+        }
+
+        fmt.Println(secret.MasterKeyEnv + "=" + key)
+
+        if os.Getenv(secret.MasterKeyEnv) != "" {
+            color.Yellowln("轮换密钥时将原密钥放入 " + secret.PreviousKeysEnv + " 后运行 lakego:secrets-rotate")
+        }
+    },
+}
+
+/**
+ * 加密数据
+ *
+ * > go run main.go lakego:secrets-encrypt "123456"
+ * > go run main.go lakego:secrets-encrypt --file=config/database.yml
+ *
+ * 文件中 dec: 开头的值会被加密，如 password: "dec:123456"
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var SecretsEncryptCmd = &command.Command{
+    Use: "lakego:secrets-encrypt",
+    Aliases: []string{"secrets:encrypt"},
+    Short: "加密配置数据.",
+    Example: "{execfile} lakego:secrets-encrypt --file=config/database.yml",
+    SilenceUsage: true,
+    Run: func(cmd *command.Command, args []string) {
+        s, ok := getSecret()
+        if !ok {
+            return
+        }
+
+        for _, value := range args {
+            enc, err := s.Encrypt(value)
+            if err != nil {
+                color.Redln("加密失败：" + err.Error())
+                return
+            }
+
+            fmt.Println(enc)
+        }
+
+        for _, file := range encryptFiles {
+            updateFile(file, s.EncryptContent, "加密")
+        }
+    },
+}
+
+/**
+ * 解密数据
+ *
+ * > go run main.go lakego:secrets-decrypt "enc:v1:..."
+ * > go run main.go lakego:secrets-decrypt --file=config/database.yml
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var SecretsDecryptCmd = &command.Command{
+    Use: "lakego:secrets-decrypt",
+    Aliases: []string{"secrets:decrypt"},
+    Short: "解密配置数据.",
+    Example: "{execfile} lakego:secrets-decrypt --file=config/database.yml",
+    SilenceUsage: true,
+    Run: func(cmd *command.Command, args []string) {
+        s, ok := getSecret()
+        if !ok {
+            return
+        }
+
+        for _, value := range args {
+            dec, err := s.Decrypt(value)
+            if err != nil {
+                color.Redln("解密失败：" + err.Error())
+                return
+            }
+
+            fmt.Println(dec)
+        }
+
+        for _, file := range decryptFiles {
+            if decryptWrite {
+                updateFile(file, s.DecryptContent, "解密")
+                continue
+            }
+
+            content, err := os.ReadFile(formatFile(file))
+            if err != nil {
+                color.Redln("文件读取失败：" + err.Error())
+                return
+            }
+
+            dec, _, err := s.DecryptContent(string(content))
+            if err != nil {
+                color.Redln("解密失败：" + err.Error())
+                return
+            }
+
+            fmt.Print(dec)
+        }
+    },
+}
+
+/**
+ * 编辑加密文件
+ *
+ * 使用 $EDITOR 编辑解密后的文件，加密数据显示为 dec: 开头，保存后重新加密
+ *
+ * > go run main.go lakego:secrets-edit config/database.yml
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var SecretsEditCmd = &command.Command{
+    Use: "lakego:secrets-edit",
+    Aliases: []string{"secrets:edit"},
+    Short: "编辑加密配置文件.",
+    Example: "{execfile} lakego:secrets-edit config/database.yml",
+    SilenceUsage: true,
+    Args: command.ExactArgs(1),
+    Run: func(cmd *command.Command, args []string) {
+        s, ok := getSecret()
+        if !ok {
+            return
+        }
+
+        if err := edit(s, formatFile(args[0])); err != nil {
+            color.Redln("编辑失败：" + err.Error())
+            return
+        }
+
+        color.Greenln("文件已保存")
+    },
+}
+
+/**
+ * 使用当前主密钥重新加密
+ *
+ * > go run main.go lakego:secrets-rotate
+ * > go run main.go lakego:secrets-rotate --file=.env --file=config/database.yml
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var SecretsRotateCmd = &command.Command{
+    Use: "lakego:secrets-rotate",
+    Aliases: []string{"secrets:rotate"},
+    Short: "使用当前主密钥重新加密配置数据.",
+    Example: "{execfile} lakego:secrets-rotate",
+    SilenceUsage: true,
+    Run: func(cmd *command.Command, args []string) {
+        s, ok := getSecret()
+        if !ok {
+            return
+        }
+
+        files := rotateFiles
+        if len(files) == 0 {
+            files = defaultFiles()
+        }
+
+        for _, file := range files {
+            updateFile(file, s.RotateContent, "重新加密")
+        }
+    },
+}
+
+// 参数
+var encryptFiles []string
+var decryptFiles []string
+var decryptWrite bool
+var editEditor string
+var rotateFiles []string
+
+func init() {
+    SecretsEncryptCmd.Flags().StringArrayVarP(&encryptFiles, "file", "f", nil, "加密文件中 dec: 开头的数据")
+
+    SecretsDecryptCmd.Flags().StringArrayVarP(&decryptFiles, "file", "f", nil, "解密文件中的加密数据")
+    SecretsDecryptCmd.Flags().BoolVarP(&decryptWrite, "write", "w", false, "解密结果写入文件")
+
+    SecretsEditCmd.Flags().StringVarP(&editEditor, "editor", "e", "", "编辑器，默认使用 $EDITOR")
+
+    SecretsRotateCmd.Flags().StringArrayVarP(&rotateFiles, "file", "f", nil, "需要重新加密的文件，默认为 .env 及配置目录")
+}
+
+// 获取加密对象
+func getSecret() (*secret.Secret, bool) {
+    s, err := secret.FromEnv()
+    if err != nil {
+        color.Redln("主密钥错误：" + err.Error())
+        color.Yellowln("可以使用 lakego:secrets-key 生成密钥后设置 " + secret.MasterKeyEnv)
+        return nil, false
+    }
+
+    return s, true
+}
+
+// 更新文件
+func updateFile(file string, fn func(string) (string, int, error), action string) {
+    file = formatFile(file)
+
+    info, err := os.Stat(file)
+    if err != nil {
+        color.Redln("文件读取失败：" + err.Error())
+        return
+    }
+
+    content, err := os.ReadFile(file)
+    if err != nil {
+        color.Redln("文件读取失败：" + err.Error())
+        return
+    }
+
+    data, count, err := fn(string(content))
+    if err != nil {
+        color.Redln(file + " " + action + "失败：" + err.Error())
+        return
+    }
+
+    if count == 0 {
+        return
+    }
+
+    if err := os.WriteFile(file, []byte(data), info.Mode().Perm()); err != nil {
+        color.Redln("文件写入失败：" + err.Error())
+        return
+    }
+
+    color.Greenln(fmt.Sprintf("%s %s %d 个数据", file, action, count))
+}
+
+// 编辑
+func edit(s *secret.Secret, file string) error {
+    info, err := os.Stat(file)
+    if err != nil {
+        return err
+    }
+
+    content, err := os.ReadFile(file)
+    if err != nil {
+        return err
+    }
+
+    dec, _, err := s.DecryptContent(string(content))
+    if err != nil {
+        return err
+    }
+
+    tmp, err := os.CreateTemp("", "lakego-secrets-*" + filepath.Ext(file))
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+
+    if _, err := tmp.WriteString(dec); err != nil {
+        tmp.Close()
+        return err
+    }
+    tmp.Close()
+
+    editor := editEditor
+    if editor == "" {
+        editor = defaultEditor()
+    }
+
+    c := exec.Command(editor, tmp.Name())
+    c.Stdin = os.Stdin
+    c.Stdout = os.Stdout
+    c.Stderr = os.Stderr
+    if err := c.Run(); err != nil {
+        return err
+    }
+
+    edited, err := os.ReadFile(tmp.Name())
+    if err != nil {
+        return err
+    }
+
+    enc, _, err := s.EncryptContent(string(edited))
+    if err != nil {
+        return err
+    }
+
+    return os.WriteFile(file, []byte(enc), info.Mode().Perm())
+}
+
+// 默认编辑器
+func defaultEditor() string {
+    if editor := os.Getenv("EDITOR"); editor != "" {
+        return editor
+    }
+
+    if runtime.GOOS == "windows" {
+        return "notepad"
+    }
+
+    return "vi"
+}
+
+// 默认文件
+func defaultFiles() []string {
+    files := make([]string, 0)
+
+    if _, err := os.Stat(path.RootPath("/.env")); err == nil {
+        files = append(files, path.RootPath("/.env"))
+    }
+
+    for _, pattern := range []string{"/*.yml", "/*/*.yml"} {
+        matches, _ := filepath.Glob(path.ConfigPath(pattern))
+        files = append(files, matches...)
+    }
+
+    return files
+}
+
+// 格式化文件路径
+func formatFile(file string) string {
+    file = path.FormatPath(file)
+    if filepath.IsAbs(file) {
+        return file
+    }
+
+    return path.RootPath("/" + file)
+}
@@ -7,6 +7,7 @@ import (
     "gorm.io/gorm"
     "gorm.io/gorm/schema"
 
+    "github.com/deatil/lakego-doak/lakego/database/plugin"
     "github.com/deatil/lakego-doak/lakego/database/interfaces"
 )
 
@@ -84,6 +85,11 @@ func (this *Driver) CreateOpenConnection(dia gorm.Dialector) {
         log.Printf("Error to open database connection: %v", err)
     }
 
+    // 链路追踪
+    if db != nil {
+        db.Use(plugin.NewTracing())
+    }
+
     // 连接池设置, *sql.DB (database/sql)
     sqlDB, _ := db.DB()
 
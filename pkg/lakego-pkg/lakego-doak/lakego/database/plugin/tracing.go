@@ -0,0 +1,122 @@
+package plugin
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 节点存储名称
+const traceSpanKey = "lakego:trace_span"
+
+// 构造函数
+func NewTracing() *Tracing {
+    return &Tracing{}
+}
+
+/**
+ * 数据库链路追踪
+ *
+ * 使用 db.WithContext(ctx) 时，如果 ctx 中有链路节点则记录 sql 执行
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Tracing struct {}
+
+// 名称
+func (this *Tracing) Name() string {
+    return "lakego:tracing"
+}
+
+// 初始化
+func (this *Tracing) Initialize(db *gorm.DB) error {
+    cb := db.Callback()
+
+    if err := cb.Create().Before("gorm:create").Register("lakego:trace_before_create", this.before("create")); err != nil {
+        return err
+    }
+    if err := cb.Create().After("gorm:create").Register("lakego:trace_after_create", this.after); err != nil {
+        return err
+    }
+
+    if err := cb.Query().Before("gorm:query").Register("lakego:trace_before_query", this.before("query")); err != nil {
+        return err
+    }
+    if err := cb.Query().After("gorm:query").Register("lakego:trace_after_query", this.after); err != nil {
+        return err
+    }
+
+    if err := cb.Update().Before("gorm:update").Register("lakego:trace_before_update", this.before("update")); err != nil {
+        return err
+    }
+    if err := cb.Update().After("gorm:update").Register("lakego:trace_after_update", this.after); err != nil {
+        return err
+    }
+
+    if err := cb.Delete().Before("gorm:delete").Register("lakego:trace_before_delete", this.before("delete")); err != nil {
+        return err
+    }
+    if err := cb.Delete().After("gorm:delete").Register("lakego:trace_after_delete", this.after); err != nil {
+        return err
+    }
+
+    if err := cb.Row().Before("gorm:row").Register("lakego:trace_before_row", this.before("row")); err != nil {
+        return err
+    }
+    if err := cb.Row().After("gorm:row").Register("lakego:trace_after_row", this.after); err != nil {
+        return err
+    }
+
+    if err := cb.Raw().Before("gorm:raw").Register("lakego:trace_before_raw", this.before("raw")); err != nil {
+        return err
+    }
+    if err := cb.Raw().After("gorm:raw").Register("lakego:trace_after_raw", this.after); err != nil {
+        return err
+    }
+
+    return nil
+}
+
+// 执行前
+func (this *Tracing) before(operation string) func(*gorm.DB) {
+    return func(db *gorm.DB) {
+        ctx := db.Statement.Context
+        if trace.SpanFromContext(ctx) == nil {
+            return
+        }
+
+        _, span := trace.Start(ctx, "gorm." + operation, trace.SpanKindClient)
+        span.SetAttribute("db.operation", operation)
+
+        if requestID := trace.RequestIDFromContext(ctx); requestID != "" {
+            span.SetAttribute("http.request_id", requestID)
+        }
+
+        db.InstanceSet(traceSpanKey, span)
+    }
+}
+
+// 执行后
+func (this *Tracing) after(db *gorm.DB) {
+    data, ok := db.InstanceGet(traceSpanKey)
+    if !ok {
+        return
+    }
+
+    span, ok := data.(*trace.Span)
+    if !ok {
+        return
+    }
+
+    span.SetAttribute("db.system", db.Dialector.Name())
+    span.SetAttribute("db.statement", db.Statement.SQL.String())
+    span.SetAttribute("db.sql.table", db.Statement.Table)
+    span.SetAttribute("db.rows_affected", db.Statement.RowsAffected)
+
+    if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+        span.RecordError(db.Error)
+    }
+
+    span.End()
+}
@@ -2,23 +2,62 @@ package env
 
 import (
     "os"
+    "fmt"
     "errors"
     "strings"
 
     "github.com/joho/godotenv"
+
+    "github.com/deatil/lakego-doak/lakego/secret"
 )
 
-// 导入
-// Load(filenames ...string) (err error)
-var Load = godotenv.Load
+// 解密失败
+var ErrDecrypt = errors.New("env: decrypt failed")
+
+// 导入，enc: 开头的值会被解密，解密失败时返回 ErrDecrypt
+func Load(filenames ...string) error {
+    err := godotenv.Load(filenames...)
+
+    if decErr := decryptEnviron(); decErr != nil {
+        return decErr
+    }
+
+    return err
+}
+
+// 覆盖导入，enc: 开头的值会被解密，解密失败时返回 ErrDecrypt
+func Overload(filenames ...string) error {
+    err := godotenv.Overload(filenames...)
+
+    if decErr := decryptEnviron(); decErr != nil {
+        return decErr
+    }
+
+    return err
+}
+
+// 读取，enc: 开头的值会被解密
+func Read(filenames ...string) (map[string]string, error) {
+    envMap, err := godotenv.Read(filenames...)
+    if err != nil {
+        return envMap, err
+    }
 
-// 覆盖导入
-// Overload(filenames ...string) (err error)
-var Overload = godotenv.Overload
+    for k, v := range envMap {
+        if !secret.IsEncrypted(v) {
+            continue
+        }
 
-// 读取
-// Read(filenames ...string) (envMap map[string]string, err error)
-var Read = godotenv.Read
+        value, err := secret.Decrypt(v)
+        if err != nil {
+            return envMap, errors.New(k + ": " + err.Error())
+        }
+
+        envMap[k] = value
+    }
+
+    return envMap, nil
+}
 
 // 解析
 // Parse(r io.Reader) (envMap map[string]string, err error)
@@ -193,3 +232,27 @@ func FormatToArray(data map[string]string) []string {
     return array
 }
 
+// 解密环境变量中 enc: 开头的值
+func decryptEnviron() error {
+    msgs := make([]string, 0)
+
+    for k, v := range Map() {
+        if !secret.IsEncrypted(v) {
+            continue
+        }
+
+        value, err := secret.Decrypt(v)
+        if err != nil {
+            msgs = append(msgs, "环境变量[" + k + "]解密失败：" + err.Error())
+            continue
+        }
+
+        os.Setenv(k, value)
+    }
+
+    if len(msgs) > 0 {
+        return fmt.Errorf("%w: %s", ErrDecrypt, strings.Join(msgs, "; "))
+    }
+
+    return nil
+}
@@ -6,14 +6,20 @@ import (
     "strings"
     "image/color"
 
+    "gorm.io/gorm"
     "github.com/mojocn/base64Captcha"
 
     "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
     "github.com/deatil/lakego-doak/lakego/captcha"
     "github.com/deatil/lakego-doak/lakego/captcha/interfaces"
     redisStore "github.com/deatil/lakego-doak/lakego/captcha/store/redis"
+    memoryStore "github.com/deatil/lakego-doak/lakego/captcha/store/memory"
+    databaseStore "github.com/deatil/lakego-doak/lakego/captcha/store/database"
+    slideDriver "github.com/deatil/lakego-doak/lakego/captcha/driver/slide"
+    arithmeticDriver "github.com/deatil/lakego-doak/lakego/captcha/driver/arithmetic"
 )
 
 var once sync.Once
@@ -159,13 +165,36 @@ func Register() {
                     return syncmap
                 },
                 "memory": func(conf map[string]any) any {
-                    collectNum := conf["collect-num"].(int)
-                    expiration := time.Minute * time.Duration(int64(conf["expiration"].(int)))
+                    collectNum := array.ArrGetWithGoch(conf, "collect-num").ToInt()
+                    expiration := array.ArrGetWithGoch(conf, "expiration", 10).ToInt()
 
-                    memory := base64Captcha.NewMemoryStore(collectNum, expiration)
+                    memory := memoryStore.New(memoryStore.Config{
+                        TTL:   time.Minute * time.Duration(expiration),
+                        Limit: collectNum,
+                    })
 
                     return memory
                 },
+                "database": func(conf map[string]any) any {
+                    connection := array.ArrGetWithGoch(conf, "connection").ToString()
+                    table := array.ArrGetWithGoch(conf, "table").ToString()
+                    ttl := array.ArrGetWithGoch(conf, "ttl", 600).ToInt()
+
+                    var db *gorm.DB
+                    if connection != "" {
+                        db = database.NewWithType(connection)
+                    } else {
+                        db = database.New()
+                    }
+
+                    store := databaseStore.New(databaseStore.Config{
+                        DB:    db,
+                        Table: table,
+                        TTL:   ttl,
+                    })
+
+                    return store
+                },
             })
 
         // 注册驱动
@@ -275,6 +304,45 @@ func Register() {
 
                     return driver
                 },
+                // 算术
+                "arithmetic": func(conf map[string]any) any {
+                    bgColor := conf["bgcolor"].(map[string]any)
+
+                    fonts := conf["fonts"].([]any)
+                    newFonts := make([]string, 0)
+                    for _, font := range fonts {
+                        newFonts = append(newFonts, font.(string))
+                    }
+
+                    driver := arithmeticDriver.New(arithmeticDriver.Config{
+                        Height:          conf["height"].(int),
+                        Width:           conf["width"].(int),
+                        NoiseCount:      conf["noise-count"].(int),
+                        ShowLineOptions: conf["showline-options"].(int),
+                        BgColor:         &color.RGBA{
+                            R: uint8(bgColor["r"].(int)),
+                            G: uint8(bgColor["g"].(int)),
+                            B: uint8(bgColor["b"].(int)),
+                            A: uint8(bgColor["a"].(int)),
+                        },
+                        Fonts:           newFonts,
+                        Operators:       array.ArrGetWithGoch(conf, "operators").ToStringSlice(),
+                        Max:             array.ArrGetWithGoch(conf, "max").ToInt(),
+                    })
+
+                    return driver
+                },
+                // 滑动
+                "slide": func(conf map[string]any) any {
+                    driver := slideDriver.New(slideDriver.Config{
+                        Width:     array.ArrGetWithGoch(conf, "width").ToInt(),
+                        Height:    array.ArrGetWithGoch(conf, "height").ToInt(),
+                        BlockSize: array.ArrGetWithGoch(conf, "block-size").ToInt(),
+                        Tolerance: array.ArrGetWithGoch(conf, "tolerance").ToInt(),
+                    })
+
+                    return driver
+                },
                 // 音频
                 "audio": func(conf map[string]any) any {
                     driver := base64Captcha.NewDriverAudio(
@@ -0,0 +1,49 @@
+package captcha
+
+import (
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/facade/cache"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+/**
+ * 验证码生成次数限制
+ *
+ * if !captcha.AllowIssue(ip) {
+ *     // 超出次数
+ * }
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func AllowIssue(key string) bool {
+    conf := config.New("captcha")
+
+    if !conf.GetBool("limit.open") {
+        return true
+    }
+
+    max := conf.GetInt("limit.max")
+    if max <= 0 {
+        return true
+    }
+
+    c := cache.New()
+    cacheKey := "captcha-limit:" + key
+
+    data, err := c.Get(cacheKey)
+    if err != nil || data == nil {
+        c.Put(cacheKey, 1, conf.GetInt("limit.ttl"))
+        return true
+    }
+
+    if goch.New(data).ToInt() >= max {
+        return false
+    }
+
+    // 自增不改变过期时间
+    c.Increment(cacheKey)
+
+    return true
+}
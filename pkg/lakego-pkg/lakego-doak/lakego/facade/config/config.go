@@ -2,11 +2,15 @@ package config
 
 import (
     "sync"
+    "errors"
+    "strings"
+    "path/filepath"
 
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/path"
 
     "github.com/deatil/lakego-doak/lakego/config"
+    "github.com/deatil/lakego-doak/lakego/config/adapter"
     "github.com/deatil/lakego-doak/lakego/config/interfaces"
     viper_adapter "github.com/deatil/lakego-doak/lakego/config/adapter/viper"
 )
@@ -38,6 +42,34 @@ func New(name ...string) *config.Config {
     return NewConfig(adapter)
 }
 
+// 检测全部配置文件，解密失败时返回错误
+func Check() error {
+    names := make(map[string]bool)
+
+    files, _ := filepath.Glob(path.FormatPath("{root}/config/*.yml"))
+    for _, file := range files {
+        names[strings.TrimSuffix(filepath.Base(file), ".yml")] = true
+    }
+
+    // 模块配置
+    for name := range adapter.InstancePath().Pathes {
+        names[name] = true
+    }
+
+    msgs := make([]string, 0)
+    for name := range names {
+        if err := New(name).Error(); err != nil {
+            msgs = append(msgs, err.Error())
+        }
+    }
+
+    if len(msgs) > 0 {
+        return errors.New(strings.Join(msgs, "; "))
+    }
+
+    return nil
+}
+
 // 实例化
 func NewWithAdapter(name string, adapter string) *config.Config {
     return NewConfig(adapter).WithFile(name)
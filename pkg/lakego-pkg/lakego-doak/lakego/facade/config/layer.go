@@ -0,0 +1,91 @@
+package config
+
+import (
+    "errors"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/config/adapter"
+)
+
+// 命令行参数名称
+const OverrideFlag = "config"
+
+// 设置默认值，优先级最低
+// config.SetDefault("server", "mode", "dev")
+func SetDefault(name string, key string, value any) {
+    adapter.InstanceLayer().WithDefault(name, key, value)
+}
+
+// 设置覆盖值，优先级最高
+// config.SetOverride("server", "mode", "release")
+func SetOverride(name string, key string, value any) {
+    adapter.InstanceLayer().WithOverride(name, key, value)
+}
+
+// 解析 文件名.键名=值 格式的覆盖值
+// config.ParseOverride("server.log-show-type=json")
+func ParseOverride(data string) error {
+    key, value, ok := strings.Cut(data, "=")
+    if !ok {
+        return errors.New("config: override must be in name.key=value format")
+    }
+
+    name, keyName, ok := strings.Cut(strings.TrimSpace(key), ".")
+    if !ok || name == "" || keyName == "" {
+        return errors.New("config: override must be in name.key=value format")
+    }
+
+    SetOverride(name, keyName, value)
+
+    return nil
+}
+
+// 从命令行参数解析覆盖值，支持 --config a.b=c 及 --config=a.b=c 格式
+func ParseArgs(args []string) error {
+    for i := 0; i < len(args); i++ {
+        arg := args[i]
+
+        var value string
+        switch {
+            case arg == "--" + OverrideFlag || arg == "-" + OverrideFlag:
+                if i + 1 >= len(args) {
+                    return errors.New("config: missing value for --" + OverrideFlag)
+                }
+
+                i++
+                value = args[i]
+
+            case strings.HasPrefix(arg, "--" + OverrideFlag + "="):
+                value = strings.TrimPrefix(arg, "--" + OverrideFlag + "=")
+
+            case strings.HasPrefix(arg, "-" + OverrideFlag + "="):
+                value = strings.TrimPrefix(arg, "-" + OverrideFlag + "=")
+
+            default:
+                continue
+        }
+
+        if err := ParseOverride(value); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 用于 flag 包的覆盖值参数
+type OverrideValue []string
+
+func (this *OverrideValue) String() string {
+    return strings.Join(*this, ",")
+}
+
+func (this *OverrideValue) Set(value string) error {
+    if err := ParseOverride(value); err != nil {
+        return err
+    }
+
+    *this = append(*this, value)
+
+    return nil
+}
@@ -0,0 +1,320 @@
+package config
+
+import (
+    "fmt"
+    "log"
+    "sync"
+    "errors"
+    "reflect"
+    "strings"
+
+    "github.com/go-playground/validator/v10"
+
+    "github.com/deatil/lakego-doak/lakego/config"
+)
+
+// 已注册的配置段
+var sections []sectionLoader
+var sectionsMu sync.Mutex
+
+// 监听
+var watchers = make(map[string]*watcher)
+var watchersMu sync.Mutex
+
+// 配置段导入接口
+type sectionLoader interface {
+    Name() string
+    Load() error
+}
+
+// 注册配置段，启动时统一验证
+func RegisterSection(section sectionLoader) {
+    sectionsMu.Lock()
+    defer sectionsMu.Unlock()
+
+    sections = append(sections, section)
+}
+
+// 导入并验证已注册的配置段
+func LoadSections() error {
+    sectionsMu.Lock()
+    defer sectionsMu.Unlock()
+
+    msgs := make([]string, 0)
+    for _, section := range sections {
+        if err := section.Load(); err != nil {
+            msgs = append(msgs, err.Error())
+        }
+    }
+
+    if len(msgs) > 0 {
+        return errors.New(strings.Join(msgs, "; "))
+    }
+
+    return nil
+}
+
+// 配置文件变动时通知，同一配置文件共用一个监听
+// config.OnChange("server", func(conf *config.Config) {})
+func OnChange(name string, f func(*config.Config)) {
+    watchersMu.Lock()
+    defer watchersMu.Unlock()
+
+    w, ok := watchers[name]
+    if !ok {
+        w = &watcher{
+            conf: New(name),
+        }
+
+        w.conf.OnConfigChange(func(string) {
+            w.notify()
+        })
+
+        watchers[name] = w
+    }
+
+    w.add(f)
+}
+
+// 配置监听
+type watcher struct {
+    mu          sync.RWMutex
+    conf        *config.Config
+    subscribers []func(*config.Config)
+}
+
+// 添加订阅
+func (this *watcher) add(f func(*config.Config)) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.subscribers = append(this.subscribers, f)
+}
+
+// 通知
+func (this *watcher) notify() {
+    this.mu.RLock()
+    subscribers := append([]func(*config.Config){}, this.subscribers...)
+    this.mu.RUnlock()
+
+    for _, f := range subscribers {
+        f(this.conf)
+    }
+}
+
+// 构造函数
+// server := config.NewSection[ServerConfig]("server")
+// trace := config.NewSection[TracingConfig]("trace", "tracing")
+func NewSection[T any](name string, key ...string) *Section[T] {
+    s := &Section[T]{
+        name: name,
+    }
+
+    if len(key) > 0 {
+        s.key = key[0]
+    }
+
+    return s
+}
+
+/**
+ * 类型化配置段
+ *
+ * 使用 mapstructure 标签映射字段，validate 标签验证
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Section[T any] struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 配置文件名
+    name string
+
+    // 键名，为空时为整个文件
+    key string
+
+    // 数据
+    value  T
+    loaded bool
+
+    // 变动回调
+    listeners []func(T)
+
+    // 监听
+    watchOnce sync.Once
+}
+
+// 名称
+func (this *Section[T]) Name() string {
+    if this.key == "" {
+        return this.name
+    }
+
+    return this.name + "." + this.key
+}
+
+// 导入并验证
+func (this *Section[T]) Load() error {
+    value, err := this.decode(New(this.name))
+    if err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    this.value = value
+    this.loaded = true
+    this.mu.Unlock()
+
+    return nil
+}
+
+// 获取数据，没有导入时先导入
+func (this *Section[T]) Get() T {
+    this.mu.RLock()
+    loaded := this.loaded
+    this.mu.RUnlock()
+
+    if !loaded {
+        if err := this.Load(); err != nil {
+            log.Print(err.Error())
+        }
+    }
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.value
+}
+
+// 配置文件变动时重新导入，验证失败时保留旧数据
+func (this *Section[T]) Watch(f ...func(T)) *Section[T] {
+    this.mu.Lock()
+    this.listeners = append(this.listeners, f...)
+    this.mu.Unlock()
+
+    this.watchOnce.Do(func() {
+        OnChange(this.name, func(conf *config.Config) {
+            value, err := this.decode(conf)
+            if err != nil {
+                log.Print(err.Error())
+                return
+            }
+
+            this.mu.Lock()
+            this.value = value
+            this.loaded = true
+            listeners := append([]func(T){}, this.listeners...)
+            this.mu.Unlock()
+
+            for _, listener := range listeners {
+                listener(value)
+            }
+        })
+    })
+
+    return this
+}
+
+// 解析并验证
+func (this *Section[T]) decode(conf *config.Config) (T, error) {
+    var value T
+
+    var err error
+    if this.key == "" {
+        err = conf.Unmarshal(&value)
+    } else {
+        err = conf.UnmarshalKey(this.key, &value)
+    }
+
+    if err != nil {
+        return value, fmt.Errorf("config %s: %w", this.Name(), err)
+    }
+
+    if err := ValidateStruct(value); err != nil {
+        return value, fmt.Errorf("config %s: %w", this.Name(), err)
+    }
+
+    return value, nil
+}
+
+// 验证器
+var sectionValidate = newSectionValidate()
+
+// 验证器，错误信息使用 mapstructure 名称
+func newSectionValidate() *validator.Validate {
+    v := validator.New()
+
+    v.RegisterTagNameFunc(func(field reflect.StructField) string {
+        name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+        if name == "" || name == "-" {
+            return field.Name
+        }
+
+        return name
+    })
+
+    return v
+}
+
+// 注册自定义验证规则
+func RegisterValidation(tag string, fn validator.Func) error {
+    return sectionValidate.RegisterValidation(tag, fn)
+}
+
+// 密钥类字段，错误信息不显示值
+func isSecretField(name string) bool {
+    name = strings.ToLower(name)
+
+    for _, s := range []string{"key", "secret", "password"} {
+        if strings.HasSuffix(name, s) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 验证结构体
+func ValidateStruct(value any) error {
+    rv := reflect.ValueOf(value)
+    for rv.Kind() == reflect.Ptr {
+        rv = rv.Elem()
+    }
+
+    if rv.Kind() != reflect.Struct {
+        return nil
+    }
+
+    err := sectionValidate.Struct(value)
+    if err == nil {
+        return nil
+    }
+
+    var verrs validator.ValidationErrors
+    if !errors.As(err, &verrs) {
+        return err
+    }
+
+    msgs := make([]string, 0, len(verrs))
+    for _, e := range verrs {
+        field := e.Namespace()
+        if _, after, ok := strings.Cut(field, "."); ok {
+            field = after
+        }
+
+        var got any = e.Value()
+        if isSecretField(e.Field()) {
+            got = "******"
+        }
+
+        if e.Param() != "" {
+            msgs = append(msgs, fmt.Sprintf("%s failed on %s=%s, got %v", field, e.Tag(), e.Param(), got))
+        } else {
+            msgs = append(msgs, fmt.Sprintf("%s failed on %s, got %v", field, e.Tag(), got))
+        }
+    }
+
+    return errors.New(strings.Join(msgs, ", "))
+}
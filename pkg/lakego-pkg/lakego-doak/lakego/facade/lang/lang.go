@@ -0,0 +1,79 @@
+package lang
+
+import (
+    "sync"
+    "context"
+
+    "github.com/deatil/lakego-filesystem/filesystem"
+
+    "github.com/deatil/lakego-doak/lakego/lang"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/middleware/locale"
+)
+
+var once sync.Once
+
+/**
+ * 多语言
+ *
+ * lang.T(ctx, "获取成功")
+ * lang.T(ctx, "欢迎 :name", map[string]any{"name": "lakego"})
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func New() *lang.Lang {
+    once.Do(func() {
+        conf := config.New("lang")
+
+        l := lang.Instance()
+
+        if locale := conf.GetString("default"); locale != "" {
+            l.WithLocale(locale)
+        }
+
+        if fallback := conf.GetString("fallback"); fallback != "" {
+            l.WithFallback(fallback)
+        }
+
+        // 应用语言包
+        appPath := path.ResourcesPath("/lang")
+        if filesystem.New().Exists(appPath) {
+            l.LoadPath(appPath)
+        }
+    })
+
+    return lang.Instance()
+}
+
+// 翻译，使用上下文中的语言
+func T(ctx context.Context, key string, replace ...map[string]any) string {
+    return New().Get(Locale(ctx), key, replace...)
+}
+
+// 翻译，指定语言
+func Trans(locale string, key string, replace ...map[string]any) string {
+    return New().Get(locale, key, replace...)
+}
+
+// 当前语言，上下文中没有时使用默认语言
+func Locale(ctx context.Context) string {
+    if locale := lang.LocaleFromContext(ctx); locale != "" {
+        return locale
+    }
+
+    return New().GetLocale()
+}
+
+// 语言协商中间件配置
+func Options() locale.Options {
+    conf := config.New("lang")
+
+    return locale.Options{
+        Header:  conf.GetString("header"),
+        Query:   conf.GetString("query"),
+        Locales: conf.GetStringSlice("locales"),
+        Default: conf.GetString("default"),
+    }
+}
@@ -2,13 +2,16 @@ package logger
 
 import (
     "log"
+    "context"
     "sync"
     "strings"
 
+    "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/logger"
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+    stackDriver "github.com/deatil/lakego-doak/lakego/logger/driver/stack"
     logrusDriver "github.com/deatil/lakego-doak/lakego/logger/driver/logrus"
 )
 
@@ -50,8 +53,13 @@ func NewLogger(driverName string, once ...bool) *logger.Logger {
         log.Print("日志驱动[" + driverName + "]配置不存在")
     }
 
-    // 驱动配置
-    driverConf := driverConfig.(map[string]any)
+    // 驱动配置，复制一份避免修改配置数据
+    driverConf := make(map[string]any)
+    for k, v := range driverConfig.(map[string]any) {
+        driverConf[k] = v
+    }
+
+    driverConf["name"] = driverName
 
     driverType := driverConf["type"].(string)
     driver := register.
@@ -64,6 +72,12 @@ func NewLogger(driverName string, once ...bool) *logger.Logger {
     return logger.New(driver.(interfaces.Driver))
 }
 
+// 带请求ID及链路信息的日志
+// logger.WithContext(ctx).Error("logger test")
+func WithContext(ctx context.Context) *logger.Logger {
+    return New().WithContext(ctx)
+}
+
 // 自定义数据
 // import "github.com/deatil/lakego-doak/lakego/facade/logger"
 // logger.LogrusWithField(logger.New(), "system", "lakego").Info("logger test")
@@ -76,6 +90,12 @@ func LogrusWithFields(log *logger.Logger, fields map[string]any) *logrusDriver.E
     return log.WithFields(fields).(*logrusDriver.Entry)
 }
 
+// 指定通道的日志
+// logger.Channel("error").Error("logger test")
+func Channel(name string) *logger.Logger {
+    return NewLogger(name)
+}
+
 // 默认驱动
 func GetDefaultDriver() string {
     return config.New("logger").GetString("default")
@@ -96,8 +116,38 @@ func Register() {
 
                     return driver
                 },
+
+                // 多通道日志
+                "stack": func(conf map[string]any) any {
+                    return newStack(conf)
+                },
             })
     })
 }
 
+// 创建多通道日志，不支持嵌套 stack 通道
+func newStack(conf map[string]any) *stackDriver.Stack {
+    drivers := config.New("logger").GetStringMap("drivers")
 
+    stack := stackDriver.New()
+
+    channels := array.ArrGetWithGoch(conf, "channels").ToStringSlice()
+    for _, channel := range channels {
+        channel = strings.ToLower(channel)
+
+        channelConf, ok := drivers[channel].(map[string]any)
+        if !ok {
+            log.Print("日志通道[" + channel + "]配置不存在")
+            continue
+        }
+
+        if channel == conf["name"] || channelConf["type"] == "stack" {
+            log.Print("日志通道[" + channel + "]不能为 stack 类型")
+            continue
+        }
+
+        stack.WithDriver(NewLogger(channel).GetDriver())
+    }
+
+    return stack
+}
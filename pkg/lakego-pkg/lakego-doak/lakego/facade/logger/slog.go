@@ -0,0 +1,21 @@
+//go:build go1.21
+
+package logger
+
+import (
+    "github.com/deatil/lakego-doak/lakego/register"
+    slogDriver "github.com/deatil/lakego-doak/lakego/logger/driver/slog"
+)
+
+// slog 需要 go1.21 及以上版本
+func init() {
+    register.
+        NewManagerWithPrefix("logger").
+        Register("slog", func(conf map[string]any) any {
+            driver := slogDriver.New()
+
+            driver.WithConfig(conf)
+
+            return driver
+        })
+}
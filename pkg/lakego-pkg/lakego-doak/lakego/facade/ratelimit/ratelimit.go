@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+    "sync"
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+    "github.com/deatil/lakego-doak/lakego/ratelimit/interfaces"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    memoryDriver "github.com/deatil/lakego-doak/lakego/ratelimit/driver/memory"
+    redisDriver "github.com/deatil/lakego-doak/lakego/ratelimit/driver/redis"
+)
+
+var once sync.Once
+
+// 已创建的限流，内存驱动需要共用数据
+var limits sync.Map
+
+// 初始化
+func init() {
+    // 注册默认
+    Register()
+}
+
+/**
+ * 限流
+ *
+ * engine.Use(ratelimit.New("default").Handler())
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func New(name ...string) *ratelimit.RateLimit {
+    limitName := GetDefaultLimiter()
+    if len(name) > 0 && name[0] != "" {
+        limitName = name[0]
+    }
+
+    return RateLimit(limitName)
+}
+
+// 限流
+func RateLimit(name string) *ratelimit.RateLimit {
+    // 转为小写
+    name = strings.ToLower(name)
+
+    if limit, ok := limits.Load(name); ok {
+        return limit.(*ratelimit.RateLimit)
+    }
+
+    // 限流列表
+    limiters := config.New("ratelimit").GetStringMap("limiters")
+
+    // 获取配置
+    limiterConfig, ok := limiters[name]
+    if !ok {
+        panic("限流配置[" + name + "]不存在")
+    }
+
+    // 配置
+    limiterConf := goch.ToStringMap(limiterConfig)
+
+    if array.ArrGetWithGoch(limiterConf, "limit").ToInt() <= 0 {
+        panic("限流配置[" + name + "]的 limit 需要大于 0")
+    }
+
+    storeType := array.ArrGetWithGoch(limiterConf, "store", "memory").ToString()
+    limiter := register.
+        NewManagerWithPrefix("ratelimit-store").
+        GetRegister(storeType, limiterConf)
+    if limiter == nil {
+        panic("限流存储驱动[" + storeType + "]没有被注册")
+    }
+
+    keyFunc := ratelimit.ParseKeyFunc(array.ArrGetWithGoch(limiterConf, "key").ToString())
+
+    limit := ratelimit.New(limiter.(interfaces.Limiter), keyFunc).WithPrefix(name)
+
+    actual, _ := limits.LoadOrStore(name, limit)
+
+    return actual.(*ratelimit.RateLimit)
+}
+
+// 中间件
+func Handler(name string, denied ...ratelimit.DeniedFunc) router.HandlerFunc {
+    return New(name).Handler(denied...)
+}
+
+// 默认限流
+func GetDefaultLimiter() string {
+    return config.New("ratelimit").GetString("default")
+}
+
+// 注册
+func Register() {
+    once.Do(func() {
+        register.
+            NewManagerWithPrefix("ratelimit-store").
+            RegisterMany(map[string]func(map[string]any) any {
+                "memory": func(conf map[string]any) any {
+                    limit := array.ArrGetWithGoch(conf, "limit").ToInt()
+                    period := array.ArrGetWithGoch(conf, "period").ToDuration()
+                    burst := array.ArrGetWithGoch(conf, "burst").ToInt()
+
+                    switch array.ArrGetWithGoch(conf, "algorithm").ToString() {
+                        case "sliding-window":
+                            return memoryDriver.NewSlidingWindow(limit, period)
+                        default:
+                            return memoryDriver.NewTokenBucket(limit, period, burst)
+                    }
+                },
+                "redis": func(conf map[string]any) any {
+                    limit := array.ArrGetWithGoch(conf, "limit").ToInt()
+                    period := array.ArrGetWithGoch(conf, "period").ToDuration()
+                    burst := array.ArrGetWithGoch(conf, "burst").ToInt()
+                    prefix := array.ArrGetWithGoch(conf, "prefix").ToString()
+
+                    connect := make([]string, 0)
+                    if name := array.ArrGetWithGoch(conf, "connect").ToString(); name != "" {
+                        connect = append(connect, name)
+                    }
+
+                    client := redis.New(connect...).GetClient()
+
+                    switch array.ArrGetWithGoch(conf, "algorithm").ToString() {
+                        case "sliding-window":
+                            return redisDriver.NewSlidingWindow(client, prefix, limit, period)
+                        default:
+                            return redisDriver.NewTokenBucket(client, prefix, limit, period, burst)
+                    }
+                },
+            })
+    })
+}
@@ -0,0 +1,110 @@
+package trace
+
+import (
+    "sync"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    otlpExporter "github.com/deatil/lakego-doak/lakego/trace/exporter/otlp"
+)
+
+var once sync.Once
+var onceTracer sync.Once
+
+// 初始化
+func init() {
+    // 注册默认
+    Register()
+}
+
+/**
+ * 链路追踪
+ *
+ * ctx, span := trace.New().Start(ctx, "query", trace.SpanKindInternal)
+ * defer span.End()
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func New() *trace.Tracer {
+    onceTracer.Do(func() {
+        trace.SetDefault(Tracer())
+    })
+
+    return trace.Default()
+}
+
+// 根据配置生成
+func Tracer() *trace.Tracer {
+    conf := config.New("trace")
+
+    serviceName := conf.GetString("tracing.service-name")
+
+    var exporter trace.Exporter
+    if conf.GetBool("tracing.enable") {
+        exporterName := conf.GetString("tracing.exporter")
+
+        exporterConf := map[string]any{
+            "service-name": serviceName,
+        }
+        for k, v := range goch.ToStringMap(conf.Get("tracing.exporters." + exporterName)) {
+            exporterConf[k] = v
+        }
+
+        driver := register.
+            NewManagerWithPrefix("trace-exporter").
+            GetRegister(exporterName, exporterConf)
+        if driver == nil {
+            panic("链路追踪导出驱动[" + exporterName + "]没有被注册")
+        }
+
+        exporter = driver.(trace.Exporter)
+    }
+
+    sampleRate := 1.0
+    if conf.Get("tracing.sample-rate") != nil {
+        sampleRate = conf.GetFloat64("tracing.sample-rate")
+    }
+
+    return trace.NewTracer(serviceName, exporter).WithSampleRate(sampleRate)
+}
+
+// 是否开启
+func Enabled() bool {
+    return config.New("trace").GetBool("tracing.enable")
+}
+
+// 请求ID请求头
+func GetRequestIDHeader() string {
+    return config.New("trace").GetString("request-id.header")
+}
+
+// 注册
+func Register() {
+    once.Do(func() {
+        register.
+            NewManagerWithPrefix("trace-exporter").
+            RegisterMany(map[string]func(map[string]any) any {
+                "otlp": func(conf map[string]any) any {
+                    headers := make(map[string]string)
+                    for k, v := range goch.ToStringMap(conf["headers"]) {
+                        headers[k] = goch.ToString(v)
+                    }
+
+                    return otlpExporter.New(otlpExporter.Config{
+                        Endpoint:      array.ArrGetWithGoch(conf, "endpoint").ToString(),
+                        Headers:       headers,
+                        ServiceName:   array.ArrGetWithGoch(conf, "service-name").ToString(),
+                        BatchSize:     array.ArrGetWithGoch(conf, "batch-size").ToInt(),
+                        FlushInterval: array.ArrGetWithGoch(conf, "flush-interval").ToDuration(),
+                        Timeout:       array.ArrGetWithGoch(conf, "timeout").ToDuration(),
+                        QueueSize:     array.ArrGetWithGoch(conf, "queue-size").ToInt(),
+                    })
+                },
+            })
+    })
+}
@@ -4,6 +4,9 @@ import (
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
+// 验证器
+type Validator = validate.Validator
+
 /**
  * 添加验证器
  *
@@ -65,3 +68,15 @@ func ValidateMapReturnOneError(data map[string]any, rules map[string]any, messag
 func Var(data string, rule string) (bool, error) {
     return validate.CustomValidator.Var(data, rule)
 }
+
+/**
+ * 使用指定语言的验证器
+ *
+ * validate.Locale("en").ValidateMap(data, rules, message)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Locale(locale string) *Validator {
+    return validate.CustomValidator.WithLocale(locale)
+}
@@ -5,6 +5,7 @@ import (
     "strings"
     "net/http"
 
+    "github.com/deatil/lakego-doak/lakego/lang"
     "github.com/deatil/lakego-doak/lakego/router"
     viewFinder "github.com/deatil/lakego-doak/lakego/view/finder"
 )
@@ -94,7 +95,28 @@ func (this *Response) Fetch(template string, obj any) {
         template = viewFinder.Instance().Find(template)
     }
 
-    this.ctx.HTML(this.httpCode, template, obj)
+    this.ctx.HTML(this.httpCode, template, this.withLocale(obj))
+}
+
+// 模板数据添加当前请求语言，模板中使用 {{ __ "获取成功" .locale }}
+func (this *Response) withLocale(obj any) any {
+    locale := lang.LocaleFromContext(this.ctx)
+    if locale == "" {
+        return obj
+    }
+
+    switch data := obj.(type) {
+        case router.H:
+            if _, ok := data[lang.ContextKey]; !ok {
+                data[lang.ContextKey] = locale
+            }
+        case map[string]any:
+            if _, ok := data[lang.ContextKey]; !ok {
+                data[lang.ContextKey] = locale
+            }
+    }
+
+    return obj
 }
 
 // 下载
@@ -2,11 +2,13 @@ package kernel
 
 import (
     "os"
+    "log"
     "net"
     "flag"
 
     "github.com/deatil/lakego-doak/lakego/app"
     "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/provider"
     "github.com/deatil/lakego-doak/lakego/provider/interfaces"
     "github.com/deatil/lakego-doak/lakego/service_provider"
@@ -38,6 +40,11 @@ var rootCmd = &command.Command{
     },
 }
 
+func init() {
+    // 配置覆盖参数，已在启动时解析
+    rootCmd.PersistentFlags().StringArray(config.OverrideFlag, nil, "覆盖配置，如 --config server.mode=release")
+}
+
 /**
  * 核心
  *
@@ -54,13 +61,18 @@ type Kernel struct {
 
 // 执行
 func (this *Kernel) Terminate() {
-    args := os.Args
+    // 命令行配置覆盖参数需在读取配置前解析
+    // > go run main.go --config server.log-show-type=json
+    if err := config.ParseArgs(os.Args[1:]); err != nil {
+        log.Fatal(err.Error())
+    }
 
     // 系统启动参数
     startName := flag.String("lakego", "", "系统启动参数")
+    flag.Var(new(config.OverrideValue), config.OverrideFlag, "覆盖配置，如 --config server.mode=release")
     flag.Parse()
 
-    if len(args) == 1 || *startName == "start" {
+    if flag.NArg() == 0 || *startName == "start" {
         this.RunServer()
     } else {
         this.RunCmd()
@@ -0,0 +1,37 @@
+package lang
+
+import (
+    "context"
+    "net/http"
+)
+
+// 上下文名称
+const ContextKey = "locale"
+
+// 语言
+type localeKey struct{}
+
+// 设置语言
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+    return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// 获取语言
+func LocaleFromContext(ctx context.Context) string {
+    if ctx == nil {
+        return ""
+    }
+
+    if locale, ok := ctx.Value(localeKey{}).(string); ok {
+        return locale
+    }
+
+    // gin 的 Context.Value(0) 返回当前请求
+    if req, ok := ctx.Value(0).(*http.Request); ok && req != nil {
+        if locale, ok := req.Context().Value(localeKey{}).(string); ok {
+            return locale
+        }
+    }
+
+    return ""
+}
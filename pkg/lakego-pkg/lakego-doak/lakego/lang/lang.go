@@ -0,0 +1,297 @@
+package lang
+
+import (
+    "os"
+    "fmt"
+    "sync"
+    "sort"
+    "strings"
+    "path/filepath"
+    "encoding/json"
+)
+
+var instance *Lang
+var once sync.Once
+
+// 单例
+func Instance() *Lang {
+    once.Do(func() {
+        instance = New()
+    })
+
+    return instance
+}
+
+// 构造函数
+func New() *Lang {
+    return &Lang{
+        locale:     "zh-CN",
+        fallback:   "zh-CN",
+        catalogs:   make(map[string]map[string]map[string]string),
+        namespaces: make([]string, 0),
+    }
+}
+
+/**
+ * 多语言
+ *
+ * 语言包为 {locale}.json 文件，键为原文，值为翻译后内容。
+ * 带命名空间的键使用 "namespace::key" 格式，
+ * 没有命名空间的键先查找应用语言包，再按注册顺序查找各模块语言包
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Lang struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 默认语言
+    locale string
+
+    // 备用语言
+    fallback string
+
+    // 语言包 namespace => locale => key => value
+    catalogs map[string]map[string]map[string]string
+
+    // 命名空间注册顺序
+    namespaces []string
+}
+
+// 设置默认语言
+func (this *Lang) WithLocale(locale string) *Lang {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.locale = NormalizeLocale(locale)
+
+    return this
+}
+
+// 默认语言
+func (this *Lang) GetLocale() string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.locale
+}
+
+// 设置备用语言
+func (this *Lang) WithFallback(locale string) *Lang {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.fallback = NormalizeLocale(locale)
+
+    return this
+}
+
+// 备用语言
+func (this *Lang) GetFallback() string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.fallback
+}
+
+// 添加翻译
+func (this *Lang) AddLines(locale string, lines map[string]string, namespace ...string) *Lang {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    ns := ""
+    if len(namespace) > 0 {
+        ns = namespace[0]
+    }
+
+    locale = NormalizeLocale(locale)
+
+    if _, ok := this.catalogs[ns]; !ok {
+        this.catalogs[ns] = make(map[string]map[string]string)
+
+        if ns != "" {
+            this.namespaces = append(this.namespaces, ns)
+        }
+    }
+
+    if _, ok := this.catalogs[ns][locale]; !ok {
+        this.catalogs[ns][locale] = make(map[string]string)
+    }
+
+    for k, v := range lines {
+        this.catalogs[ns][locale][k] = v
+    }
+
+    return this
+}
+
+// 从文件夹导入 {locale}.json 语言包
+func (this *Lang) LoadPath(path string, namespace ...string) error {
+    files, err := filepath.Glob(filepath.Join(path, "*.json"))
+    if err != nil {
+        return err
+    }
+
+    for _, file := range files {
+        data, err := os.ReadFile(file)
+        if err != nil {
+            return err
+        }
+
+        lines := make(map[string]string)
+        if err := json.Unmarshal(data, &lines); err != nil {
+            return fmt.Errorf("lang: parse %s: %w", file, err)
+        }
+
+        locale := strings.TrimSuffix(filepath.Base(file), ".json")
+
+        this.AddLines(locale, lines, namespace...)
+    }
+
+    return nil
+}
+
+// 是否有翻译
+func (this *Lang) Has(locale string, key string) bool {
+    _, ok := this.find(NormalizeLocale(locale), key)
+
+    return ok
+}
+
+// 翻译，没有找到时返回原文
+// Get("zh-CN", "欢迎 :name", map[string]any{"name": "lakego"})
+func (this *Lang) Get(locale string, key string, replace ...map[string]any) string {
+    if locale == "" {
+        locale = this.GetLocale()
+    }
+
+    locale = NormalizeLocale(locale)
+
+    line, ok := this.find(locale, key)
+    if !ok {
+        line, ok = this.find(this.GetFallback(), key)
+    }
+
+    if !ok {
+        line = key
+
+        // 去除命名空间
+        if _, k, found := strings.Cut(key, "::"); found {
+            line = k
+        }
+    }
+
+    if len(replace) > 0 {
+        line = MakeReplacements(line, replace[0])
+    }
+
+    return line
+}
+
+// 已加载的语言
+func (this *Lang) GetLocales() []string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    locales := make(map[string]bool)
+    for _, catalog := range this.catalogs {
+        for locale := range catalog {
+            locales[locale] = true
+        }
+    }
+
+    data := make([]string, 0, len(locales))
+    for locale := range locales {
+        data = append(data, locale)
+    }
+
+    sort.Strings(data)
+
+    return data
+}
+
+// 查找
+func (this *Lang) find(locale string, key string) (string, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if ns, k, found := strings.Cut(key, "::"); found {
+        return this.findIn(ns, locale, k)
+    }
+
+    if line, ok := this.findIn("", locale, key); ok {
+        return line, ok
+    }
+
+    for _, ns := range this.namespaces {
+        if line, ok := this.findIn(ns, locale, key); ok {
+            return line, ok
+        }
+    }
+
+    return "", false
+}
+
+// 在命名空间中查找，没有找到时使用基础语言，如 en-US 使用 en
+func (this *Lang) findIn(ns string, locale string, key string) (string, bool) {
+    catalog, ok := this.catalogs[ns]
+    if !ok {
+        return "", false
+    }
+
+    if line, ok := catalog[locale][key]; ok {
+        return line, true
+    }
+
+    if base := BaseLocale(locale); base != locale {
+        if line, ok := catalog[base][key]; ok {
+            return line, true
+        }
+    }
+
+    return "", false
+}
+
+// 替换 :name 格式的变量，长的变量名优先替换
+func MakeReplacements(line string, replace map[string]any) string {
+    keys := make([]string, 0, len(replace))
+    for k := range replace {
+        keys = append(keys, k)
+    }
+
+    sort.Slice(keys, func(i, j int) bool {
+        return len(keys[i]) > len(keys[j])
+    })
+
+    for _, k := range keys {
+        line = strings.ReplaceAll(line, ":" + k, fmt.Sprint(replace[k]))
+    }
+
+    return line
+}
+
+// 格式化语言名称，如 zh_cn 格式化为 zh-CN
+func NormalizeLocale(locale string) string {
+    locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+
+    parts := strings.Split(locale, "-")
+    for i, part := range parts {
+        if i == 0 {
+            parts[i] = strings.ToLower(part)
+        } else if len(part) == 2 {
+            parts[i] = strings.ToUpper(part)
+        } else if len(part) == 4 {
+            parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+        }
+    }
+
+    return strings.Join(parts, "-")
+}
+
+// 基础语言，如 zh-CN 返回 zh
+func BaseLocale(locale string) string {
+    base, _, _ := strings.Cut(locale, "-")
+
+    return base
+}
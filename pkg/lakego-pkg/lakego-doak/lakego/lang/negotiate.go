@@ -0,0 +1,80 @@
+package lang
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// 语言权重
+type acceptLanguage struct {
+    locale string
+    q      float64
+}
+
+// 解析 Accept-Language
+// zh-CN,zh;q=0.9,en;q=0.8
+func ParseAcceptLanguage(header string) []string {
+    items := make([]acceptLanguage, 0)
+
+    for _, part := range strings.Split(header, ",") {
+        part = strings.TrimSpace(part)
+        if part == "" {
+            continue
+        }
+
+        locale, params, _ := strings.Cut(part, ";")
+
+        q := 1.0
+        if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+            if v, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err == nil {
+                q = v
+            }
+        }
+
+        locale = strings.TrimSpace(locale)
+        if locale == "" || locale == "*" || q <= 0 {
+            continue
+        }
+
+        items = append(items, acceptLanguage{
+            locale: NormalizeLocale(locale),
+            q:      q,
+        })
+    }
+
+    sort.SliceStable(items, func(i, j int) bool {
+        return items[i].q > items[j].q
+    })
+
+    locales := make([]string, 0, len(items))
+    for _, item := range items {
+        locales = append(locales, item.locale)
+    }
+
+    return locales
+}
+
+// 从候选语言中匹配支持的语言
+func Match(candidates []string, supported []string) (string, bool) {
+    for _, candidate := range candidates {
+        candidate = NormalizeLocale(candidate)
+
+        // 完全匹配
+        for _, locale := range supported {
+            if NormalizeLocale(locale) == candidate {
+                return NormalizeLocale(locale), true
+            }
+        }
+
+        // 基础语言匹配，如 en-US 匹配 en，zh 匹配 zh-CN
+        base := BaseLocale(candidate)
+        for _, locale := range supported {
+            if BaseLocale(NormalizeLocale(locale)) == base {
+                return NormalizeLocale(locale), true
+            }
+        }
+    }
+
+    return "", false
+}
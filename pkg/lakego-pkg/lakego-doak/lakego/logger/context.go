@@ -0,0 +1,159 @@
+package logger
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 带字段的日志对象，如 logrus.Entry
+type entryLogger interface {
+    Trace(...any)
+    Debug(...any)
+    Info(...any)
+    Warn(...any)
+    Warning(...any)
+    Error(...any)
+    Fatal(...any)
+    Panic(...any)
+
+    Tracef(string, ...any)
+    Debugf(string, ...any)
+    Infof(string, ...any)
+    Warnf(string, ...any)
+    Warningf(string, ...any)
+    Errorf(string, ...any)
+    Fatalf(string, ...any)
+    Panicf(string, ...any)
+}
+
+// 添加上下文中的请求ID及链路信息
+// logger.New().WithContext(ctx).Error("error")
+func (this *Logger) WithContext(ctx context.Context) *Logger {
+    fields := trace.Fields(ctx)
+    if len(fields) == 0 {
+        return this
+    }
+
+    return New(newFieldsDriver(this.Driver, fields))
+}
+
+// 构造函数
+func newFieldsDriver(driver interfaces.Driver, fields map[string]any) *fieldsDriver {
+    return &fieldsDriver{
+        driver: driver,
+        fields: fields,
+    }
+}
+
+/**
+ * 默认带字段的驱动
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type fieldsDriver struct {
+    // 驱动
+    driver interfaces.Driver
+
+    // 字段
+    fields map[string]any
+}
+
+// 批量设置自定义变量
+func (this *fieldsDriver) WithFields(fields map[string]any) any {
+    data := make(map[string]any, len(this.fields) + len(fields))
+    for k, v := range this.fields {
+        data[k] = v
+    }
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return this.driver.WithFields(data)
+}
+
+// 设置自定义变量
+func (this *fieldsDriver) WithField(key string, value any) any {
+    return this.WithFields(map[string]any{
+        key: value,
+    })
+}
+
+// 带字段的日志对象
+func (this *fieldsDriver) entry() entryLogger {
+    if entry, ok := this.driver.WithFields(this.fields).(entryLogger); ok {
+        return entry
+    }
+
+    return this.driver
+}
+
+// ========
+
+func (this *fieldsDriver) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *fieldsDriver) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *fieldsDriver) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *fieldsDriver) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *fieldsDriver) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *fieldsDriver) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *fieldsDriver) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *fieldsDriver) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *fieldsDriver) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *fieldsDriver) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *fieldsDriver) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *fieldsDriver) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *fieldsDriver) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *fieldsDriver) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *fieldsDriver) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *fieldsDriver) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
@@ -1,15 +1,11 @@
 package logrus
 
 import (
-    "fmt"
-    "time"
-    logger "log"
+    "sync"
 
-    "github.com/pkg/errors"
     "github.com/sirupsen/logrus"
-    "github.com/lestrrat/go-file-rotatelogs"
 
-    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/logger/output"
     "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter"
 )
 
@@ -38,6 +34,10 @@ type (
 type Logrus struct {
     // 配置
     Config map[string]any
+
+    // 日志
+    logger *logrus.Logger
+    once   sync.Once
 }
 
 // 设置配置
@@ -216,8 +216,17 @@ func (this *Logrus) GetLevel() logrus.Level {
     return this.getLogger().GetLevel()
 }
 
-// 设置
+// 获取日志，同一个驱动只创建一次
 func (this *Logrus) getLogger() *logrus.Logger {
+    this.once.Do(func() {
+        this.logger = this.newLogger()
+    })
+
+    return this.logger
+}
+
+// 创建日志
+func (this *Logrus) newLogger() *logrus.Logger {
     // 配置
     conf := this.Config
 
@@ -227,7 +236,7 @@ func (this *Logrus) getLogger() *logrus.Logger {
 
     var useFormatter logrus.Formatter
 
-    formatterType := conf["formatter"].(string)
+    formatterType, _ := conf["formatter"].(string)
     switch formatterType {
         case "json":
             // json 格式
@@ -245,33 +254,12 @@ func (this *Logrus) getLogger() *logrus.Logger {
     // 设置输出样式
     log.SetFormatter(useFormatter)
 
-    // 日志目录
-    filepath := conf["filepath"].(string)
-
-    // 日志文件
-    // log_%Y%m%d.log
-    logPath := path.FormatPath(filepath)
-
-    maxAge := time.Duration(int64(conf["max-age"].(int)))
-    rotationTime := time.Duration(int64(conf["rotation-time"].(int)))
-
-    writer, err := rotatelogs.New(
-        logPath,
-        // rotatelogs.WithLinkName(baseLogPath), // 生成软链，指向最新日志文件
-        rotatelogs.WithMaxAge(maxAge * time.Hour), // 文件最大保存时间
-        rotatelogs.WithRotationTime(rotationTime * time.Hour), // 日志切割时间间隔
-    )
-    if err != nil {
-        logger.Print(fmt.Sprintf("日志配置错误：%v", errors.WithStack(err)))
-    }
-
-    // os.Stdout || os.Stderr
-    // 设置output,默认为stderr,可以为任何io.Writer，比如文件*os.File
-    // file, _ := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-    log.SetOutput(writer)
+    // 输出位置，默认为按时间切割的文件
+    // output: file | stdout | stderr | syslog
+    log.SetOutput(output.New(conf))
 
     // 日志等级
-    level := conf["level"].(string)
+    level, _ := conf["level"].(string)
 
     // 设置最低 loglevel
     switch level {
@@ -287,7 +275,7 @@ func (this *Logrus) getLogger() *logrus.Logger {
             // error 等级
             log.SetLevel(logrus.ErrorLevel)
 
-        case "warning":
+        case "warning", "warn":
             // warning 等级
             log.SetLevel(logrus.WarnLevel)
 
@@ -306,4 +294,3 @@ func (this *Logrus) getLogger() *logrus.Logger {
 
     return log
 }
-
@@ -0,0 +1,328 @@
+//go:build go1.21
+
+package slog
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "sync"
+    "context"
+    "strings"
+    "log/slog"
+
+    "github.com/deatil/lakego-doak/lakego/logger/output"
+    "github.com/deatil/lakego-doak/lakego/logger/syslog"
+)
+
+// slog 没有的等级
+const (
+    LevelTrace = slog.Level(-8)
+    LevelFatal = slog.Level(12)
+    LevelPanic = slog.Level(16)
+)
+
+// 构造方法
+func New() *Slog {
+    return &Slog{}
+}
+
+/**
+ * 日志 slog 驱动
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Slog struct {
+    // 配置
+    Config map[string]any
+
+    // 日志
+    logger *slog.Logger
+    once   sync.Once
+}
+
+// 设置配置
+func (this *Slog) WithConfig(config map[string]any) {
+    this.Config = config
+}
+
+// 获取 slog 日志，同一个驱动只创建一次
+func (this *Slog) Logger() *slog.Logger {
+    this.once.Do(func() {
+        this.logger = slog.New(this.newHandler())
+    })
+
+    return this.logger
+}
+
+// 批量设置自定义变量
+// *slog.Entry
+func (this *Slog) WithFields(fields map[string]any) any {
+    return this.entry().WithFields(fields)
+}
+
+// 设置自定义变量
+func (this *Slog) WithField(key string, value any) any {
+    return this.entry().WithField(key, value)
+}
+
+// ========
+
+func (this *Slog) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *Slog) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *Slog) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *Slog) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *Slog) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *Slog) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *Slog) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *Slog) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *Slog) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *Slog) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *Slog) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *Slog) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *Slog) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *Slog) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *Slog) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *Slog) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
+
+// 不带字段的日志对象
+func (this *Slog) entry() *Entry {
+    return &Entry{
+        logger: this.Logger(),
+    }
+}
+
+// 创建处理器
+func (this *Slog) newHandler() slog.Handler {
+    conf := this.Config
+
+    level, _ := conf["level"].(string)
+    formatter, _ := conf["formatter"].(string)
+
+    opts := &slog.HandlerOptions{
+        Level:       ParseLevel(level),
+        ReplaceAttr: replaceLevel,
+    }
+
+    newHandler := func(w io.Writer) slog.Handler {
+        if formatter == "text" {
+            return slog.NewTextHandler(w, opts)
+        }
+
+        return slog.NewJSONHandler(w, opts)
+    }
+
+    // 输出位置
+    // output: file | stdout | stderr | syslog
+    writer := output.New(conf)
+    if sw, ok := writer.(*syslog.Writer); ok {
+        return NewSyslogHandler(sw, newHandler, opts.Level)
+    }
+
+    return newHandler(writer)
+}
+
+// 解析等级
+func ParseLevel(level string) slog.Level {
+    switch strings.ToLower(level) {
+        case "panic":
+            return LevelPanic
+        case "fatal":
+            return LevelFatal
+        case "error":
+            return slog.LevelError
+        case "warning", "warn":
+            return slog.LevelWarn
+        case "info":
+            return slog.LevelInfo
+        case "debug":
+            return slog.LevelDebug
+        default:
+            return LevelTrace
+    }
+}
+
+// 等级名称
+func LevelName(level slog.Level) string {
+    switch {
+        case level >= LevelPanic:
+            return "PANIC"
+        case level >= LevelFatal:
+            return "FATAL"
+        case level < slog.LevelDebug:
+            return "TRACE"
+        default:
+            return level.String()
+    }
+}
+
+// 替换自定义等级名称
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+    if len(groups) == 0 && a.Key == slog.LevelKey {
+        if level, ok := a.Value.Any().(slog.Level); ok {
+            a.Value = slog.StringValue(LevelName(level))
+        }
+    }
+
+    return a
+}
+
+/**
+ * 带字段的日志对象
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Entry struct {
+    logger *slog.Logger
+}
+
+// 批量设置自定义变量
+func (this *Entry) WithFields(fields map[string]any) any {
+    args := make([]any, 0, len(fields))
+    for k, v := range fields {
+        args = append(args, slog.Any(k, v))
+    }
+
+    return &Entry{
+        logger: this.logger.With(args...),
+    }
+}
+
+// 设置自定义变量
+func (this *Entry) WithField(key string, value any) any {
+    return &Entry{
+        logger: this.logger.With(slog.Any(key, value)),
+    }
+}
+
+// ========
+
+func (this *Entry) Trace(args ...any) {
+    this.log(LevelTrace, fmt.Sprint(args...))
+}
+
+func (this *Entry) Debug(args ...any) {
+    this.log(slog.LevelDebug, fmt.Sprint(args...))
+}
+
+func (this *Entry) Info(args ...any) {
+    this.log(slog.LevelInfo, fmt.Sprint(args...))
+}
+
+func (this *Entry) Warn(args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Entry) Warning(args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Entry) Error(args ...any) {
+    this.log(slog.LevelError, fmt.Sprint(args...))
+}
+
+func (this *Entry) Fatal(args ...any) {
+    this.log(LevelFatal, fmt.Sprint(args...))
+    os.Exit(1)
+}
+
+func (this *Entry) Panic(args ...any) {
+    msg := fmt.Sprint(args...)
+
+    this.log(LevelPanic, msg)
+    panic(msg)
+}
+
+// ========
+
+func (this *Entry) Tracef(template string, args ...any) {
+    this.log(LevelTrace, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Debugf(template string, args ...any) {
+    this.log(slog.LevelDebug, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Infof(template string, args ...any) {
+    this.log(slog.LevelInfo, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Warnf(template string, args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Warningf(template string, args ...any) {
+    this.log(slog.LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Errorf(template string, args ...any) {
+    this.log(slog.LevelError, fmt.Sprintf(template, args...))
+}
+
+func (this *Entry) Fatalf(template string, args ...any) {
+    this.log(LevelFatal, fmt.Sprintf(template, args...))
+    os.Exit(1)
+}
+
+func (this *Entry) Panicf(template string, args ...any) {
+    msg := fmt.Sprintf(template, args...)
+
+    this.log(LevelPanic, msg)
+    panic(msg)
+}
+
+// 记录
+func (this *Entry) log(level slog.Level, msg string) {
+    this.logger.Log(context.Background(), level, msg)
+}
@@ -0,0 +1,114 @@
+//go:build go1.21
+
+package slog
+
+import (
+    "io"
+    "bytes"
+    "context"
+    "log/slog"
+
+    "github.com/deatil/lakego-doak/lakego/logger/syslog"
+)
+
+// 构造函数
+func NewSyslogHandler(
+    writer *syslog.Writer,
+    newHandler func(io.Writer) slog.Handler,
+    level slog.Leveler,
+) *SyslogHandler {
+    return &SyslogHandler{
+        writer:     writer,
+        newHandler: newHandler,
+        level:      level,
+    }
+}
+
+/**
+ * syslog 处理器，按日志等级设置 syslog 等级
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SyslogHandler struct {
+    // 写入
+    writer *syslog.Writer
+
+    // 格式化处理器
+    newHandler func(io.Writer) slog.Handler
+
+    // 最低等级
+    level slog.Leveler
+
+    // 字段及分组
+    wraps []func(slog.Handler) slog.Handler
+}
+
+// 是否记录
+func (this *SyslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+    return level >= this.level.Level()
+}
+
+// 记录
+func (this *SyslogHandler) Handle(ctx context.Context, r slog.Record) error {
+    buf := &bytes.Buffer{}
+
+    h := this.newHandler(buf)
+    for _, wrap := range this.wraps {
+        h = wrap(h)
+    }
+
+    if err := h.Handle(ctx, r); err != nil {
+        return err
+    }
+
+    _, err := this.writer.WriteLevel(severity(r.Level), buf.Bytes())
+
+    return err
+}
+
+// 添加字段
+func (this *SyslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    return this.with(func(h slog.Handler) slog.Handler {
+        return h.WithAttrs(attrs)
+    })
+}
+
+// 添加分组
+func (this *SyslogHandler) WithGroup(name string) slog.Handler {
+    return this.with(func(h slog.Handler) slog.Handler {
+        return h.WithGroup(name)
+    })
+}
+
+// 复制
+func (this *SyslogHandler) with(wrap func(slog.Handler) slog.Handler) *SyslogHandler {
+    wraps := make([]func(slog.Handler) slog.Handler, 0, len(this.wraps) + 1)
+    wraps = append(wraps, this.wraps...)
+    wraps = append(wraps, wrap)
+
+    return &SyslogHandler{
+        writer:     this.writer,
+        newHandler: this.newHandler,
+        level:      this.level,
+        wraps:      wraps,
+    }
+}
+
+// 日志等级转为 syslog 等级
+func severity(level slog.Level) syslog.Severity {
+    switch {
+        case level >= LevelPanic:
+            return syslog.SeverityAlert
+        case level >= LevelFatal:
+            return syslog.SeverityCrit
+        case level >= slog.LevelError:
+            return syslog.SeverityErr
+        case level >= slog.LevelWarn:
+            return syslog.SeverityWarning
+        case level >= slog.LevelInfo:
+            return syslog.SeverityInfo
+        default:
+            return syslog.SeverityDebug
+    }
+}
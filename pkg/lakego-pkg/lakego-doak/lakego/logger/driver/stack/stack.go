@@ -0,0 +1,269 @@
+package stack
+
+import (
+    "os"
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 日志对象，驱动及驱动返回的带字段对象都需实现
+type Logger interface {
+    Trace(...any)
+    Debug(...any)
+    Info(...any)
+    Warn(...any)
+    Warning(...any)
+    Error(...any)
+    Fatal(...any)
+    Panic(...any)
+
+    Tracef(string, ...any)
+    Debugf(string, ...any)
+    Infof(string, ...any)
+    Warnf(string, ...any)
+    Warningf(string, ...any)
+    Errorf(string, ...any)
+    Fatalf(string, ...any)
+    Panicf(string, ...any)
+}
+
+// 构造方法
+func New(drivers ...interfaces.Driver) *Stack {
+    return &Stack{
+        drivers: drivers,
+    }
+}
+
+/**
+ * 日志 stack 驱动，同时写入多个通道
+ *
+ * 各通道按自身的等级过滤
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Stack struct {
+    // 通道
+    drivers []interfaces.Driver
+}
+
+// 添加通道
+func (this *Stack) WithDriver(driver interfaces.Driver) *Stack {
+    this.drivers = append(this.drivers, driver)
+
+    return this
+}
+
+// 通道
+func (this *Stack) GetDrivers() []interfaces.Driver {
+    return this.drivers
+}
+
+// 批量设置自定义变量
+// *stack.Entry
+func (this *Stack) WithFields(fields map[string]any) any {
+    loggers := make([]Logger, 0, len(this.drivers))
+    for _, driver := range this.drivers {
+        if entry, ok := driver.WithFields(fields).(Logger); ok {
+            loggers = append(loggers, entry)
+        } else {
+            loggers = append(loggers, driver)
+        }
+    }
+
+    return &Entry{
+        loggers: loggers,
+    }
+}
+
+// 设置自定义变量
+func (this *Stack) WithField(key string, value any) any {
+    return this.WithFields(map[string]any{
+        key: value,
+    })
+}
+
+// 不带字段的日志对象
+func (this *Stack) entry() *Entry {
+    loggers := make([]Logger, 0, len(this.drivers))
+    for _, driver := range this.drivers {
+        loggers = append(loggers, driver)
+    }
+
+    return &Entry{
+        loggers: loggers,
+    }
+}
+
+// ========
+
+func (this *Stack) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *Stack) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *Stack) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *Stack) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *Stack) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *Stack) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *Stack) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *Stack) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *Stack) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *Stack) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *Stack) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *Stack) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *Stack) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *Stack) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *Stack) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *Stack) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
+
+/**
+ * 带字段的日志对象
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Entry struct {
+    loggers []Logger
+}
+
+// ========
+
+func (this *Entry) Trace(args ...any) {
+    this.each(func(l Logger) { l.Trace(args...) })
+}
+
+func (this *Entry) Debug(args ...any) {
+    this.each(func(l Logger) { l.Debug(args...) })
+}
+
+func (this *Entry) Info(args ...any) {
+    this.each(func(l Logger) { l.Info(args...) })
+}
+
+func (this *Entry) Warn(args ...any) {
+    this.each(func(l Logger) { l.Warn(args...) })
+}
+
+func (this *Entry) Warning(args ...any) {
+    this.each(func(l Logger) { l.Warning(args...) })
+}
+
+func (this *Entry) Error(args ...any) {
+    this.each(func(l Logger) { l.Error(args...) })
+}
+
+// 通道的 Fatal 会直接退出，这里以 Error 写入全部通道后再退出
+func (this *Entry) Fatal(args ...any) {
+    this.each(func(l Logger) { l.Error(args...) })
+    os.Exit(1)
+}
+
+// 全部通道写入后再 panic
+func (this *Entry) Panic(args ...any) {
+    this.panics(func(l Logger) { l.Panic(args...) })
+    panic(fmt.Sprint(args...))
+}
+
+// ========
+
+func (this *Entry) Tracef(template string, args ...any) {
+    this.each(func(l Logger) { l.Tracef(template, args...) })
+}
+
+func (this *Entry) Debugf(template string, args ...any) {
+    this.each(func(l Logger) { l.Debugf(template, args...) })
+}
+
+func (this *Entry) Infof(template string, args ...any) {
+    this.each(func(l Logger) { l.Infof(template, args...) })
+}
+
+func (this *Entry) Warnf(template string, args ...any) {
+    this.each(func(l Logger) { l.Warnf(template, args...) })
+}
+
+func (this *Entry) Warningf(template string, args ...any) {
+    this.each(func(l Logger) { l.Warningf(template, args...) })
+}
+
+func (this *Entry) Errorf(template string, args ...any) {
+    this.each(func(l Logger) { l.Errorf(template, args...) })
+}
+
+func (this *Entry) Fatalf(template string, args ...any) {
+    this.each(func(l Logger) { l.Errorf(template, args...) })
+    os.Exit(1)
+}
+
+func (this *Entry) Panicf(template string, args ...any) {
+    this.panics(func(l Logger) { l.Panicf(template, args...) })
+    panic(fmt.Sprintf(template, args...))
+}
+
+// 依次写入
+func (this *Entry) each(fn func(Logger)) {
+    for _, l := range this.loggers {
+        fn(l)
+    }
+}
+
+// 依次写入，忽略各通道的 panic
+func (this *Entry) panics(fn func(Logger)) {
+    for _, l := range this.loggers {
+        func() {
+            defer func() {
+                recover()
+            }()
+
+            fn(l)
+        }()
+    }
+}
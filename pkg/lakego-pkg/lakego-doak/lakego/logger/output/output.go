@@ -0,0 +1,70 @@
+package output
+
+import (
+    "io"
+    "os"
+    "time"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/logger/rotate"
+    "github.com/deatil/lakego-doak/lakego/logger/syslog"
+)
+
+/**
+ * 根据驱动配置获取日志输出
+ *
+ * output: file | stdout | stderr | syslog
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func New(conf map[string]any) io.Writer {
+    output := strings.ToLower(array.ArrGetWithGoch(conf, "output").ToString())
+
+    switch output {
+        case "stdout":
+            return os.Stdout
+
+        case "stderr":
+            return os.Stderr
+
+        case "syslog":
+            return Syslog(conf)
+
+        default:
+            return File(conf)
+    }
+}
+
+// 文件输出
+func File(conf map[string]any) *rotate.Writer {
+    filename := array.ArrGetWithGoch(conf, "filepath").ToString()
+
+    // 单位：小时
+    maxAge := array.ArrGetWithGoch(conf, "max-age").ToInt64()
+    rotationTime := array.ArrGetWithGoch(conf, "rotation-time").ToInt64()
+
+    // 单位：MB
+    maxSize := array.ArrGetWithGoch(conf, "max-size").ToInt64()
+
+    return rotate.Open(rotate.Config{
+        Filename:     path.FormatPath(filename),
+        RotationTime: time.Duration(rotationTime) * time.Hour,
+        MaxSize:      maxSize * 1024 * 1024,
+        MaxAge:       time.Duration(maxAge) * time.Hour,
+        MaxBackups:   array.ArrGetWithGoch(conf, "max-backups").ToInt(),
+        Compress:     array.ArrGetWithGoch(conf, "compress").ToBool(),
+    })
+}
+
+// syslog 输出
+func Syslog(conf map[string]any) *syslog.Writer {
+    return syslog.Open(syslog.Config{
+        Network:  array.ArrGetWithGoch(conf, "syslog.network").ToString(),
+        Address:  array.ArrGetWithGoch(conf, "syslog.address").ToString(),
+        Tag:      array.ArrGetWithGoch(conf, "syslog.tag").ToString(),
+        Facility: array.ArrGetWithGoch(conf, "syslog.facility").ToString(),
+    })
+}
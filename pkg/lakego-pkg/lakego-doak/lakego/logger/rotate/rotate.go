@@ -0,0 +1,423 @@
+package rotate
+
+import (
+    "os"
+    "io"
+    "fmt"
+    "sync"
+    "sort"
+    "time"
+    "strings"
+    "path/filepath"
+    "compress/gzip"
+)
+
+// 备份文件时间格式
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// 已打开的文件
+var writers sync.Map
+
+// 配置
+type Config struct {
+    // 文件名，支持 %Y %m %d %H %M %S 时间格式，如 log_%Y%m%d.log
+    Filename string
+
+    // 按时间切割间隔，为 0 时按文件名时间格式切割
+    RotationTime time.Duration
+
+    // 单个文件最大字节数，为 0 时不按大小切割
+    MaxSize int64
+
+    // 日志保留时间，为 0 时不清除
+    MaxAge time.Duration
+
+    // 最多保留的旧文件数量，为 0 时不限制
+    MaxBackups int
+
+    // 是否 gzip 压缩旧文件
+    Compress bool
+}
+
+// 打开文件，相同文件名共用同一个写入对象
+func Open(conf Config) *Writer {
+    if w, ok := writers.Load(conf.Filename); ok {
+        return w.(*Writer)
+    }
+
+    w, _ := writers.LoadOrStore(conf.Filename, New(conf))
+
+    return w.(*Writer)
+}
+
+// 构造函数
+func New(conf Config) *Writer {
+    return &Writer{
+        conf: conf,
+        now:  time.Now,
+    }
+}
+
+/**
+ * 日志文件切割
+ *
+ * 支持按时间及文件大小切割，切割后清除过期文件及压缩旧文件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Writer struct {
+    // 锁
+    mu sync.Mutex
+
+    // 配置
+    conf Config
+
+    // 当前文件
+    file *os.File
+
+    // 当前文件名
+    filename string
+
+    // 当前文件大小
+    size int64
+
+    // 时间
+    now func() time.Time
+
+    // 清除任务
+    millCh   chan struct{}
+    millOnce sync.Once
+}
+
+// 写入
+func (this *Writer) Write(p []byte) (int, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    filename := this.currentFilename()
+
+    if this.file == nil || filename != this.filename {
+        if err := this.openFile(filename); err != nil {
+            return 0, err
+        }
+    }
+
+    if this.conf.MaxSize > 0 && this.size > 0 && this.size + int64(len(p)) > this.conf.MaxSize {
+        if err := this.rotate(); err != nil {
+            return 0, err
+        }
+    }
+
+    n, err := this.file.Write(p)
+    this.size += int64(n)
+
+    return n, err
+}
+
+// 手动切割
+func (this *Writer) Rotate() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.file == nil {
+        return nil
+    }
+
+    return this.rotate()
+}
+
+// 关闭
+func (this *Writer) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.closeFile()
+}
+
+// 当前文件名
+func (this *Writer) Filename() string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.filename
+}
+
+// 切割，当前文件重命名为备份文件
+func (this *Writer) rotate() error {
+    if err := this.closeFile(); err != nil {
+        return err
+    }
+
+    ext := filepath.Ext(this.filename)
+    backup := fmt.Sprintf(
+        "%s-%s%s",
+        strings.TrimSuffix(this.filename, ext),
+        this.now().Format(backupTimeFormat),
+        ext,
+    )
+
+    if err := os.Rename(this.filename, backup); err != nil && !os.IsNotExist(err) {
+        return err
+    }
+
+    return this.openFile(this.filename)
+}
+
+// 打开文件
+func (this *Writer) openFile(filename string) error {
+    if err := this.closeFile(); err != nil {
+        return err
+    }
+
+    if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+        return err
+    }
+
+    file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+    if err != nil {
+        return err
+    }
+
+    info, err := file.Stat()
+    if err != nil {
+        file.Close()
+        return err
+    }
+
+    this.file = file
+    this.filename = filename
+    this.size = info.Size()
+
+    this.mill()
+
+    return nil
+}
+
+// 关闭文件
+func (this *Writer) closeFile() error {
+    if this.file == nil {
+        return nil
+    }
+
+    err := this.file.Close()
+    this.file = nil
+
+    return err
+}
+
+// 当前时间对应的文件名
+func (this *Writer) currentFilename() string {
+    t := this.now()
+
+    // 按本地时间切割
+    if this.conf.RotationTime > 0 {
+        _, offset := t.Zone()
+        zone := time.Duration(offset) * time.Second
+
+        t = t.Add(zone).Truncate(this.conf.RotationTime).Add(-zone)
+    }
+
+    return Strftime(this.conf.Filename, t)
+}
+
+// 通知清除任务
+func (this *Writer) mill() {
+    if this.conf.MaxAge <= 0 && this.conf.MaxBackups <= 0 && !this.conf.Compress {
+        return
+    }
+
+    this.millOnce.Do(func() {
+        this.millCh = make(chan struct{}, 1)
+
+        go func() {
+            for range this.millCh {
+                this.millRun()
+            }
+        }()
+    })
+
+    select {
+        case this.millCh <- struct{}{}:
+        default:
+    }
+}
+
+// 清除过期文件及压缩
+func (this *Writer) millRun() {
+    this.mu.Lock()
+    current := this.filename
+    this.mu.Unlock()
+
+    files, err := this.oldFiles(current)
+    if err != nil {
+        return
+    }
+
+    remove := make(map[string]bool)
+
+    if this.conf.MaxBackups > 0 && len(files) > this.conf.MaxBackups {
+        for _, f := range files[this.conf.MaxBackups:] {
+            remove[f.path] = true
+        }
+    }
+
+    if this.conf.MaxAge > 0 {
+        cutoff := this.now().Add(-this.conf.MaxAge)
+        for _, f := range files {
+            if f.modTime.Before(cutoff) {
+                remove[f.path] = true
+            }
+        }
+    }
+
+    for _, f := range files {
+        if remove[f.path] {
+            os.Remove(f.path)
+            continue
+        }
+
+        if this.conf.Compress && !strings.HasSuffix(f.path, ".gz") {
+            compressFile(f.path)
+        }
+    }
+}
+
+// 旧文件
+type oldFile struct {
+    path    string
+    modTime time.Time
+}
+
+// 获取旧文件，新的在前
+func (this *Writer) oldFiles(current string) ([]oldFile, error) {
+    pattern := this.globPattern()
+
+    paths, err := filepath.Glob(pattern)
+    if err != nil {
+        return nil, err
+    }
+
+    gzPaths, err := filepath.Glob(pattern + ".gz")
+    if err != nil {
+        return nil, err
+    }
+
+    files := make([]oldFile, 0, len(paths) + len(gzPaths))
+    for _, path := range append(paths, gzPaths...) {
+        if path == current {
+            continue
+        }
+
+        info, err := os.Stat(path)
+        if err != nil || info.IsDir() {
+            continue
+        }
+
+        files = append(files, oldFile{
+            path:    path,
+            modTime: info.ModTime(),
+        })
+    }
+
+    sort.Slice(files, func(i, j int) bool {
+        return files[i].modTime.After(files[j].modTime)
+    })
+
+    return files, nil
+}
+
+// 匹配日志文件及备份文件的规则
+func (this *Writer) globPattern() string {
+    filename := this.conf.Filename
+    ext := filepath.Ext(filename)
+
+    var b strings.Builder
+    for i := 0; i < len(filename); i++ {
+        if filename[i] == '%' && i + 1 < len(filename) {
+            b.WriteString("*")
+            i++
+            continue
+        }
+
+        b.WriteByte(filename[i])
+    }
+
+    return strings.TrimSuffix(b.String(), ext) + "*" + ext
+}
+
+// 压缩文件
+func compressFile(src string) error {
+    f, err := os.Open(src)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    dst := src + ".gz"
+
+    gzf, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+    if err != nil {
+        return err
+    }
+
+    gz := gzip.NewWriter(gzf)
+    if _, err := io.Copy(gz, f); err != nil {
+        gz.Close()
+        gzf.Close()
+        os.Remove(dst)
+        return err
+    }
+
+    if err := gz.Close(); err != nil {
+        gzf.Close()
+        os.Remove(dst)
+        return err
+    }
+
+    if err := gzf.Close(); err != nil {
+        return err
+    }
+
+    f.Close()
+
+    return os.Remove(src)
+}
+
+// 格式化文件名中的时间
+// log_%Y%m%d.log => log_20261019.log
+func Strftime(format string, t time.Time) string {
+    var b strings.Builder
+
+    for i := 0; i < len(format); i++ {
+        if format[i] != '%' || i + 1 >= len(format) {
+            b.WriteByte(format[i])
+            continue
+        }
+
+        i++
+        switch format[i] {
+            case 'Y':
+                b.WriteString(fmt.Sprintf("%04d", t.Year()))
+            case 'y':
+                b.WriteString(fmt.Sprintf("%02d", t.Year() % 100))
+            case 'm':
+                b.WriteString(fmt.Sprintf("%02d", int(t.Month())))
+            case 'd':
+                b.WriteString(fmt.Sprintf("%02d", t.Day()))
+            case 'H':
+                b.WriteString(fmt.Sprintf("%02d", t.Hour()))
+            case 'M':
+                b.WriteString(fmt.Sprintf("%02d", t.Minute()))
+            case 'S':
+                b.WriteString(fmt.Sprintf("%02d", t.Second()))
+            case '%':
+                b.WriteByte('%')
+            default:
+                b.WriteByte('%')
+                b.WriteByte(format[i])
+        }
+    }
+
+    return b.String()
+}
@@ -0,0 +1,233 @@
+package syslog
+
+import (
+    "os"
+    "fmt"
+    "net"
+    "sync"
+    "time"
+    "errors"
+    "strings"
+    "path/filepath"
+)
+
+// 日志等级
+type Severity int
+
+const (
+    SeverityEmerg Severity = iota
+    SeverityAlert
+    SeverityCrit
+    SeverityErr
+    SeverityWarning
+    SeverityNotice
+    SeverityInfo
+    SeverityDebug
+)
+
+// 本地 syslog 地址
+var localAddresses = []string{
+    "/dev/log",
+    "/var/run/syslog",
+    "/var/run/log",
+}
+
+// 设施
+var facilities = map[string]int{
+    "kern":   0,
+    "user":   1,
+    "mail":   2,
+    "daemon": 3,
+    "auth":   4,
+    "syslog": 5,
+    "lpr":    6,
+    "news":   7,
+    "uucp":   8,
+    "cron":   9,
+    "local0": 16,
+    "local1": 17,
+    "local2": 18,
+    "local3": 19,
+    "local4": 20,
+    "local5": 21,
+    "local6": 22,
+    "local7": 23,
+}
+
+// 已打开的连接
+var writers sync.Map
+
+// 配置
+type Config struct {
+    // 网络类型，为空时使用本地 unix socket
+    // unixgram, unix, udp, tcp
+    Network string
+
+    // 地址，为空时自动查找本地 socket
+    Address string
+
+    // 标签，默认为程序名称
+    Tag string
+
+    // 设施，如 user, local0
+    Facility string
+}
+
+// 打开连接，相同配置共用同一个写入对象
+func Open(conf Config) *Writer {
+    key := conf.Network + "|" + conf.Address + "|" + conf.Tag + "|" + conf.Facility
+
+    if w, ok := writers.Load(key); ok {
+        return w.(*Writer)
+    }
+
+    w, _ := writers.LoadOrStore(key, New(conf))
+
+    return w.(*Writer)
+}
+
+// 构造函数
+func New(conf Config) *Writer {
+    if conf.Tag == "" {
+        conf.Tag = filepath.Base(os.Args[0])
+    }
+
+    facility, ok := facilities[strings.ToLower(conf.Facility)]
+    if !ok {
+        facility = facilities["user"]
+    }
+
+    hostname, _ := os.Hostname()
+
+    return &Writer{
+        conf:     conf,
+        facility: facility,
+        hostname: hostname,
+    }
+}
+
+/**
+ * syslog 写入
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Writer struct {
+    // 锁
+    mu sync.Mutex
+
+    // 配置
+    conf Config
+
+    // 设施
+    facility int
+
+    // 主机名
+    hostname string
+
+    // 连接
+    conn net.Conn
+}
+
+// 以 info 等级写入
+func (this *Writer) Write(p []byte) (int, error) {
+    return this.WriteLevel(SeverityInfo, p)
+}
+
+// 写入
+func (this *Writer) WriteLevel(severity Severity, p []byte) (int, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    msg := this.format(severity, p)
+
+    // 失败时重连一次
+    for i := 0; i < 2; i++ {
+        if this.conn == nil {
+            if err := this.connect(); err != nil {
+                return 0, err
+            }
+        }
+
+        if _, err := this.conn.Write(msg); err == nil {
+            return len(p), nil
+        }
+
+        this.conn.Close()
+        this.conn = nil
+    }
+
+    return 0, errors.New("syslog: write failed")
+}
+
+// 关闭
+func (this *Writer) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.conn == nil {
+        return nil
+    }
+
+    err := this.conn.Close()
+    this.conn = nil
+
+    return err
+}
+
+// 格式化，本地 socket 不带主机名
+func (this *Writer) format(severity Severity, p []byte) []byte {
+    msg := strings.TrimRight(string(p), "\n")
+    priority := this.facility * 8 + int(severity)
+
+    if this.isLocal() {
+        return []byte(fmt.Sprintf(
+            "<%d>%s %s[%d]: %s\n",
+            priority,
+            time.Now().Format(time.Stamp),
+            this.conf.Tag, os.Getpid(), msg,
+        ))
+    }
+
+    return []byte(fmt.Sprintf(
+        "<%d>%s %s %s[%d]: %s\n",
+        priority,
+        time.Now().Format(time.RFC3339),
+        this.hostname, this.conf.Tag, os.Getpid(), msg,
+    ))
+}
+
+// 是否为本地连接
+func (this *Writer) isLocal() bool {
+    return this.conf.Network == "" || strings.HasPrefix(this.conf.Network, "unix")
+}
+
+// 连接
+func (this *Writer) connect() error {
+    if this.conf.Network != "" {
+        conn, err := net.Dial(this.conf.Network, this.conf.Address)
+        if err != nil {
+            return err
+        }
+
+        this.conn = conn
+        return nil
+    }
+
+    addresses := localAddresses
+    if this.conf.Address != "" {
+        addresses = []string{this.conf.Address}
+    }
+
+    for _, network := range []string{"unixgram", "unix"} {
+        for _, address := range addresses {
+            conn, err := net.Dial(network, address)
+            if err == nil {
+                this.conn = conn
+                return nil
+            }
+        }
+    }
+
+    return errors.New("syslog: local syslog server not found")
+}
@@ -0,0 +1,89 @@
+package accesslog
+
+import (
+    "io"
+    "time"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 日志数据
+type Entry struct {
+    Time      string  `json:"time"`
+    RequestID string  `json:"request_id,omitempty"`
+    TraceID   string  `json:"trace_id,omitempty"`
+    SpanID    string  `json:"span_id,omitempty"`
+    Method    string  `json:"method"`
+    Path      string  `json:"path"`
+    Route     string  `json:"route,omitempty"`
+    Query     string  `json:"query,omitempty"`
+    Proto     string  `json:"proto"`
+    Status    int     `json:"status"`
+    Latency   float64 `json:"latency_ms"`
+    Size      int     `json:"size"`
+    IP        string  `json:"ip"`
+    UserAgent string  `json:"user_agent"`
+    AdminID   string  `json:"admin_id,omitempty"`
+    Error     string  `json:"error,omitempty"`
+}
+
+/**
+ * JSON 格式访问日志
+ *
+ * 没有传入 out 时使用 router.DefaultWriter
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(out ...io.Writer) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        start := time.Now()
+
+        ctx.Next()
+
+        entry := Entry{
+            Time:      start.Format(time.RFC3339Nano),
+            Method:    ctx.Request.Method,
+            Path:      ctx.Request.URL.Path,
+            Route:     ctx.FullPath(),
+            Query:     ctx.Request.URL.RawQuery,
+            Proto:     ctx.Request.Proto,
+            Status:    ctx.Writer.Status(),
+            Latency:   float64(time.Since(start).Microseconds()) / 1000,
+            Size:      ctx.Writer.Size(),
+            IP:        router.GetRequestIp(ctx),
+            UserAgent: ctx.Request.UserAgent(),
+            AdminID:   ctx.GetString("admin_id"),
+            Error:     ctx.Errors.ByType(router.ErrorTypePrivate).String(),
+        }
+
+        reqCtx := ctx.Request.Context()
+
+        entry.RequestID = trace.RequestIDFromContext(reqCtx)
+        if span := trace.SpanFromContext(reqCtx); span != nil {
+            entry.TraceID = span.SpanContext.TraceID.String()
+            entry.SpanID = span.SpanContext.SpanID.String()
+        }
+
+        // 未写入内容时为 -1
+        if entry.Size < 0 {
+            entry.Size = 0
+        }
+
+        data, err := json.Marshal(entry)
+        if err != nil {
+            return
+        }
+
+        var writer io.Writer
+        if len(out) > 0 && out[0] != nil {
+            writer = out[0]
+        } else {
+            writer = *router.DefaultWriter
+        }
+
+        writer.Write(append(data, '\n'))
+    }
+}
@@ -0,0 +1,52 @@
+package clientcert
+
+import (
+    "crypto/x509"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+const (
+    // 客户端证书
+    CertKey = "lakego.tls-client-cert"
+
+    // 客户端证书 Subject
+    SubjectKey = "lakego.tls-client-subject"
+)
+
+/**
+ * 客户端证书信息
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        state := ctx.Request.TLS
+
+        if state != nil && len(state.PeerCertificates) > 0 {
+            cert := state.PeerCertificates[0]
+
+            ctx.Set(CertKey, cert)
+            ctx.Set(SubjectKey, cert.Subject.String())
+        }
+
+        ctx.Next()
+    }
+}
+
+// 获取客户端证书
+func GetCert(ctx *router.Context) *x509.Certificate {
+    if cert, ok := ctx.Get(CertKey); ok {
+        if c, ok := cert.(*x509.Certificate); ok {
+            return c
+        }
+    }
+
+    return nil
+}
+
+// 获取客户端证书 Subject
+func GetSubject(ctx *router.Context) string {
+    return ctx.GetString(SubjectKey)
+}
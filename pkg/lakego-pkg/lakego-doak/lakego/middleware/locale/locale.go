@@ -0,0 +1,66 @@
+package locale
+
+import (
+    "github.com/deatil/lakego-doak/lakego/lang"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 配置
+type Options struct {
+    // 指定语言的请求头
+    Header string
+
+    // 指定语言的 query 参数
+    Query string
+
+    // 支持的语言
+    Locales []string
+
+    // 默认语言
+    Default string
+}
+
+/**
+ * 语言协商
+ *
+ * 依次使用 query 参数，指定请求头，Accept-Language 请求头，默认语言
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(opts Options) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        candidates := make([]string, 0)
+
+        if opts.Query != "" {
+            if locale := ctx.Query(opts.Query); locale != "" {
+                candidates = append(candidates, locale)
+            }
+        }
+
+        if opts.Header != "" {
+            if locale := ctx.GetHeader(opts.Header); locale != "" {
+                candidates = append(candidates, locale)
+            }
+        }
+
+        candidates = append(candidates, lang.ParseAcceptLanguage(ctx.GetHeader("Accept-Language"))...)
+
+        locale, ok := lang.Match(candidates, opts.Locales)
+        if !ok {
+            locale = lang.NormalizeLocale(opts.Default)
+        }
+
+        ctx.Set(lang.ContextKey, locale)
+        ctx.Request = ctx.Request.WithContext(lang.ContextWithLocale(ctx.Request.Context(), locale))
+
+        ctx.Header("Content-Language", locale)
+
+        ctx.Next()
+    }
+}
+
+// 获取当前语言
+func Get(ctx *router.Context) string {
+    return ctx.GetString(lang.ContextKey)
+}
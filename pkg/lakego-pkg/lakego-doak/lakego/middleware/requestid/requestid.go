@@ -0,0 +1,64 @@
+package requestid
+
+import (
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+const (
+    // 默认请求头
+    DefaultHeader = "X-Request-ID"
+
+    // 上下文名称
+    ContextKey = "request_id"
+)
+
+/**
+ * 请求ID
+ *
+ * 请求头中有合法的请求ID时使用，否则生成新的请求ID
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(header ...string) router.HandlerFunc {
+    headerName := DefaultHeader
+    if len(header) > 0 && header[0] != "" {
+        headerName = header[0]
+    }
+
+    return func(ctx *router.Context) {
+        id := ctx.GetHeader(headerName)
+        if !isValid(id) {
+            id = uuid.ToUUIDString()
+        }
+
+        ctx.Set(ContextKey, id)
+        ctx.Request = ctx.Request.WithContext(trace.ContextWithRequestID(ctx.Request.Context(), id))
+
+        ctx.Header(headerName, id)
+
+        ctx.Next()
+    }
+}
+
+// 获取请求ID
+func Get(ctx *router.Context) string {
+    return ctx.GetString(ContextKey)
+}
+
+// 只允许可见字符，防止日志注入
+func isValid(id string) bool {
+    if id == "" || len(id) > 128 {
+        return false
+    }
+
+    for i := 0; i < len(id); i++ {
+        if id[i] < 0x21 || id[i] > 0x7e {
+            return false
+        }
+    }
+
+    return true
+}
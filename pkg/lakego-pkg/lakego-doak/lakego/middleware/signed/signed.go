@@ -0,0 +1,38 @@
+package signed
+
+import (
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 验证失败处理
+type DeniedFunc = func(*router.Context, error)
+
+/**
+ * 签名链接验证
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(denied ...DeniedFunc) router.HandlerFunc {
+    onDenied := DefaultDenied
+    if len(denied) > 0 && denied[0] != nil {
+        onDenied = denied[0]
+    }
+
+    return func(ctx *router.Context) {
+        if err := router.CheckSignature(ctx.Request.URL); err != nil {
+            onDenied(ctx, err)
+            ctx.Abort()
+            return
+        }
+
+        ctx.Next()
+    }
+}
+
+// 默认验证失败处理
+func DefaultDenied(ctx *router.Context, err error) {
+    ctx.String(http.StatusForbidden, http.StatusText(http.StatusForbidden))
+}
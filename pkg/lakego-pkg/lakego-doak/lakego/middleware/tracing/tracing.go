@@ -0,0 +1,60 @@
+package tracing
+
+import (
+    "strconv"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+/**
+ * 链路追踪
+ *
+ * 从 traceparent 请求头继续链路，并在响应头中返回当前节点
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(tracer ...*trace.Tracer) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        t := trace.Default()
+        if len(tracer) > 0 && tracer[0] != nil {
+            t = tracer[0]
+        }
+
+        parent, _ := trace.ParseTraceparent(ctx.GetHeader(trace.TraceparentHeader))
+
+        route := ctx.FullPath()
+        if route == "" {
+            route = ctx.Request.URL.Path
+        }
+
+        span := t.StartWithParent(parent, ctx.Request.Method + " " + route, trace.SpanKindServer)
+
+        ctx.Request = ctx.Request.WithContext(trace.ContextWithSpan(ctx.Request.Context(), span))
+        ctx.Set("trace_id", span.SpanContext.TraceID.String())
+
+        ctx.Header(trace.TraceparentHeader, span.SpanContext.Traceparent())
+
+        ctx.Next()
+
+        status := ctx.Writer.Status()
+
+        span.SetAttribute("http.method", ctx.Request.Method)
+        span.SetAttribute("http.route", route)
+        span.SetAttribute("http.target", ctx.Request.URL.RequestURI())
+        span.SetAttribute("http.status_code", status)
+        span.SetAttribute("http.user_agent", ctx.Request.UserAgent())
+        span.SetAttribute("net.peer.ip", router.GetRequestIp(ctx))
+
+        if id := trace.RequestIDFromContext(ctx.Request.Context()); id != "" {
+            span.SetAttribute("http.request_id", id)
+        }
+
+        if status >= 500 {
+            span.SetStatus(trace.StatusError, "HTTP " + strconv.Itoa(status))
+        }
+
+        span.End()
+    }
+}
@@ -8,6 +8,7 @@ import (
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/config/adapter"
+    langFacade "github.com/deatil/lakego-doak/lakego/facade/lang"
     pathTool "github.com/deatil/lakego-doak/lakego/path"
     viewFunc "github.com/deatil/lakego-doak/lakego/view/funcs"
     viewFinder "github.com/deatil/lakego-doak/lakego/view/finder"
@@ -161,6 +162,21 @@ func (this *ServiceProvider) LoadViewsFrom(path string, namespace string) {
     viewFinder.AddNamespace(namespace, []string{path})
 }
 
+// 注册语言包，已推送到 resources/lang/{namespace} 的语言包优先
+func (this *ServiceProvider) LoadTranslationsFrom(path string, namespace string) {
+    l := langFacade.New()
+
+    // 格式化路径
+    path = pathTool.FormatPath(path)
+
+    l.LoadPath(path, namespace)
+
+    publishPath := pathTool.ResourcesPath("/lang/" + namespace)
+    if filesystem.New().Exists(publishPath) {
+        l.LoadPath(publishPath, namespace)
+    }
+}
+
 // 添加视图用方法
 func (this *ServiceProvider) AddViewFunc(name string, fn any) {
     viewFunc.AddFunc(name, fn)
@@ -0,0 +1,125 @@
+package memory
+
+import (
+    "sync"
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/ratelimit/interfaces"
+)
+
+// 滑动窗口，limit 小于等于 0 时为 60，period 小于等于 0 时为 1 分钟
+func NewSlidingWindow(limit int, period time.Duration) *SlidingWindow {
+    if limit <= 0 {
+        limit = 60
+    }
+
+    if period <= 0 {
+        period = time.Minute
+    }
+
+    return &SlidingWindow{
+        limit:   limit,
+        period:  period,
+        windows: make(map[string]*window),
+    }
+}
+
+// 窗口数据
+type window struct {
+    start time.Time
+    prev  int
+    curr  int
+}
+
+/**
+ * 内存滑动窗口限流
+ *
+ * 使用上一个窗口的加权数量与当前窗口数量估算
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SlidingWindow struct {
+    // 锁
+    mu sync.Mutex
+
+    // 周期内数量
+    limit int
+
+    // 周期
+    period time.Duration
+
+    // 数据
+    windows map[string]*window
+
+    // 最后清理时间
+    cleanedAt time.Time
+}
+
+// 获取一次请求
+func (this *SlidingWindow) Take(key string) (interfaces.Result, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := time.Now()
+    this.cleanup(now)
+
+    start := now.Truncate(this.period)
+
+    w, ok := this.windows[key]
+    if !ok {
+        w = &window{start: start}
+        this.windows[key] = w
+    }
+
+    // 窗口移动
+    if !w.start.Equal(start) {
+        if start.Sub(w.start) == this.period {
+            w.prev = w.curr
+        } else {
+            w.prev = 0
+        }
+
+        w.curr = 0
+        w.start = start
+    }
+
+    elapsed := now.Sub(start)
+    weight := 1 - float64(elapsed) / float64(this.period)
+
+    count := float64(w.prev) * weight + float64(w.curr)
+
+    allowed := false
+    if count < float64(this.limit) {
+        w.curr++
+        count++
+        allowed = true
+    }
+
+    remaining := this.limit - int(count)
+    if remaining < 0 {
+        remaining = 0
+    }
+
+    return interfaces.Result{
+        Allowed:   allowed,
+        Limit:     this.limit,
+        Remaining: remaining,
+        Reset:     this.period - elapsed,
+    }, nil
+}
+
+// 清理过期窗口
+func (this *SlidingWindow) cleanup(now time.Time) {
+    if now.Sub(this.cleanedAt) < this.period {
+        return
+    }
+
+    this.cleanedAt = now
+
+    for key, w := range this.windows {
+        if now.Sub(w.start) >= this.period * 2 {
+            delete(this.windows, key)
+        }
+    }
+}
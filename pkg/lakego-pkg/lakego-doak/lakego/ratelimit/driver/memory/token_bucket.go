@@ -0,0 +1,132 @@
+package memory
+
+import (
+    "sync"
+    "time"
+    "math"
+
+    "github.com/deatil/lakego-doak/lakego/ratelimit/interfaces"
+)
+
+// 令牌桶，limit 小于等于 0 时为 60，period 小于等于 0 时为 1 分钟
+func NewTokenBucket(limit int, period time.Duration, burst int) *TokenBucket {
+    if limit <= 0 {
+        limit = 60
+    }
+
+    if period <= 0 {
+        period = time.Minute
+    }
+
+    if burst <= 0 {
+        burst = limit
+    }
+
+    return &TokenBucket{
+        limit:   limit,
+        burst:   burst,
+        rate:    float64(limit) / period.Seconds(),
+        period:  period,
+        buckets: make(map[string]*bucket),
+    }
+}
+
+// 令牌桶数据
+type bucket struct {
+    tokens float64
+    last   time.Time
+}
+
+/**
+ * 内存令牌桶限流
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TokenBucket struct {
+    // 锁
+    mu sync.Mutex
+
+    // 周期内数量
+    limit int
+
+    // 桶容量
+    burst int
+
+    // 每秒生成令牌数
+    rate float64
+
+    // 周期
+    period time.Duration
+
+    // 数据
+    buckets map[string]*bucket
+
+    // 最后清理时间
+    cleanedAt time.Time
+}
+
+// 获取一次请求
+func (this *TokenBucket) Take(key string) (interfaces.Result, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := time.Now()
+    this.cleanup(now)
+
+    b, ok := this.buckets[key]
+    if !ok {
+        b = &bucket{
+            tokens: float64(this.burst),
+            last:   now,
+        }
+        this.buckets[key] = b
+    }
+
+    // 补充令牌
+    b.tokens = math.Min(float64(this.burst), b.tokens + now.Sub(b.last).Seconds() * this.rate)
+    b.last = now
+
+    allowed := false
+    if b.tokens >= 1 {
+        b.tokens--
+        allowed = true
+    }
+
+    return tokenBucketResult(allowed, b.tokens, this.burst, this.rate), nil
+}
+
+// 清理已满的桶
+func (this *TokenBucket) cleanup(now time.Time) {
+    if now.Sub(this.cleanedAt) < this.period {
+        return
+    }
+
+    this.cleanedAt = now
+
+    full := float64(this.burst) / this.rate
+    for key, b := range this.buckets {
+        if now.Sub(b.last).Seconds() >= full {
+            delete(this.buckets, key)
+        }
+    }
+}
+
+// 令牌桶结果
+func tokenBucketResult(allowed bool, tokens float64, burst int, rate float64) interfaces.Result {
+    var reset float64
+    if allowed {
+        // 桶满需要的时间
+        reset = (float64(burst) - tokens) / rate
+    } else {
+        // 下一个令牌需要的时间
+        reset = (1 - tokens) / rate
+    }
+
+    return interfaces.Result{
+        Allowed:   allowed,
+        Limit:     burst,
+        Remaining: int(math.Floor(tokens)),
+        Reset:     time.Duration(reset * float64(time.Second)),
+    }
+}
@@ -0,0 +1,245 @@
+package redis
+
+import (
+    "fmt"
+    "math"
+    "time"
+    "context"
+    "strconv"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/ratelimit/interfaces"
+)
+
+// 令牌桶脚本
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+    tokens = burst
+    ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`)
+
+// 滑动窗口脚本
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local weight = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local curr = tonumber(redis.call("GET", KEYS[1]) or "0")
+local prev = tonumber(redis.call("GET", KEYS[2]) or "0")
+
+local count = prev * weight + curr
+if count >= limit then
+    return {0, tostring(count)}
+end
+
+curr = redis.call("INCR", KEYS[1])
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {1, tostring(prev * weight + curr)}
+`)
+
+// 令牌桶，limit 小于等于 0 时为 60，period 小于等于 0 时为 1 分钟
+func NewTokenBucket(client *redis.Client, prefix string, limit int, period time.Duration, burst int) *TokenBucket {
+    if limit <= 0 {
+        limit = 60
+    }
+
+    if period <= 0 {
+        period = time.Minute
+    }
+
+    if burst <= 0 {
+        burst = limit
+    }
+
+    return &TokenBucket{
+        client: client,
+        prefix: prefix,
+        burst:  burst,
+        rate:   float64(limit) / float64(period.Milliseconds()),
+    }
+}
+
+/**
+ * redis 令牌桶限流
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TokenBucket struct {
+    // 客户端
+    client *redis.Client
+
+    // 前缀
+    prefix string
+
+    // 桶容量
+    burst int
+
+    // 每毫秒生成令牌数
+    rate float64
+}
+
+// 获取一次请求
+func (this *TokenBucket) Take(key string) (interfaces.Result, error) {
+    now := time.Now().UnixMilli()
+
+    // 桶满需要的时间作为过期时间
+    ttl := int64(math.Ceil(float64(this.burst) / this.rate)) + 1000
+
+    res, err := tokenBucketScript.Run(
+        context.Background(),
+        this.client,
+        []string{formatKey(this.prefix, "tb:" + key)},
+        this.rate, this.burst, now, ttl,
+    ).Slice()
+    if err != nil {
+        return interfaces.Result{Allowed: true}, err
+    }
+
+    allowed, tokens, err := parseScriptResult(res)
+    if err != nil {
+        return interfaces.Result{Allowed: true}, err
+    }
+
+    var reset float64
+    if allowed {
+        reset = (float64(this.burst) - tokens) / this.rate
+    } else {
+        reset = (1 - tokens) / this.rate
+    }
+
+    return interfaces.Result{
+        Allowed:   allowed,
+        Limit:     this.burst,
+        Remaining: int(math.Floor(tokens)),
+        Reset:     time.Duration(reset * float64(time.Millisecond)),
+    }, nil
+}
+
+// 滑动窗口，limit 小于等于 0 时为 60，period 小于等于 0 时为 1 分钟
+func NewSlidingWindow(client *redis.Client, prefix string, limit int, period time.Duration) *SlidingWindow {
+    if limit <= 0 {
+        limit = 60
+    }
+
+    if period <= 0 {
+        period = time.Minute
+    }
+
+    return &SlidingWindow{
+        client: client,
+        prefix: prefix,
+        limit:  limit,
+        period: period,
+    }
+}
+
+/**
+ * redis 滑动窗口限流
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SlidingWindow struct {
+    // 客户端
+    client *redis.Client
+
+    // 前缀
+    prefix string
+
+    // 周期内数量
+    limit int
+
+    // 周期
+    period time.Duration
+}
+
+// 获取一次请求
+func (this *SlidingWindow) Take(key string) (interfaces.Result, error) {
+    now := time.Now()
+    start := now.Truncate(this.period)
+
+    index := start.UnixNano() / int64(this.period)
+    elapsed := now.Sub(start)
+    weight := 1 - float64(elapsed) / float64(this.period)
+
+    currKey := formatKey(this.prefix, fmt.Sprintf("sw:%s:%d", key, index))
+    prevKey := formatKey(this.prefix, fmt.Sprintf("sw:%s:%d", key, index - 1))
+
+    res, err := slidingWindowScript.Run(
+        context.Background(),
+        this.client,
+        []string{currKey, prevKey},
+        this.limit, weight, (this.period * 2).Milliseconds(),
+    ).Slice()
+    if err != nil {
+        return interfaces.Result{Allowed: true}, err
+    }
+
+    allowed, count, err := parseScriptResult(res)
+    if err != nil {
+        return interfaces.Result{Allowed: true}, err
+    }
+
+    remaining := this.limit - int(count)
+    if remaining < 0 {
+        remaining = 0
+    }
+
+    return interfaces.Result{
+        Allowed:   allowed,
+        Limit:     this.limit,
+        Remaining: remaining,
+        Reset:     this.period - elapsed,
+    }, nil
+}
+
+// 解析脚本结果
+func parseScriptResult(res []any) (bool, float64, error) {
+    if len(res) != 2 {
+        return false, 0, fmt.Errorf("ratelimit: unexpected script result: %v", res)
+    }
+
+    allowed, _ := res[0].(int64)
+
+    value, _ := res[1].(string)
+    number, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        return false, 0, err
+    }
+
+    return allowed == 1, number, nil
+}
+
+// 格式化 key
+func formatKey(prefix string, key string) string {
+    if prefix == "" {
+        return key
+    }
+
+    return prefix + ":" + key
+}
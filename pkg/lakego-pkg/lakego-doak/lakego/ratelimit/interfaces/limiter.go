@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+    "time"
+)
+
+// 限流结果
+type Result struct {
+    // 是否允许
+    Allowed bool
+
+    // 周期内总数量
+    Limit int
+
+    // 剩余数量
+    Remaining int
+
+    // 距离重置的时间
+    Reset time.Duration
+}
+
+/**
+ * 限流驱动接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Limiter interface {
+    // 获取一次请求
+    Take(key string) (Result, error)
+}
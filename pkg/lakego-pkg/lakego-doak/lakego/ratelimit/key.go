@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+type (
+    // 获取限流 key
+    KeyFunc = func(*router.Context) string
+)
+
+// 请求 IP
+func KeyByIP(ctx *router.Context) string {
+    return "ip:" + router.GetRequestIp(ctx)
+}
+
+// 路由
+func KeyByRoute(ctx *router.Context) string {
+    path := ctx.FullPath()
+    if path == "" {
+        path = ctx.Request.URL.Path
+    }
+
+    return "route:" + ctx.Request.Method + ":" + path
+}
+
+// 路由及 IP
+func KeyByRouteIP(ctx *router.Context) string {
+    return KeyByRoute(ctx) + ":" + KeyByIP(ctx)
+}
+
+// header 数据
+func KeyByHeader(name string) KeyFunc {
+    return func(ctx *router.Context) string {
+        value := ctx.GetHeader(name)
+        if value == "" {
+            return ""
+        }
+
+        return "header:" + name + ":" + value
+    }
+}
+
+// 上下文数据，比如中间件设置的 admin_id
+func KeyByContext(name string) KeyFunc {
+    return func(ctx *router.Context) string {
+        value := ctx.GetString(name)
+        if value == "" {
+            return ""
+        }
+
+        return "context:" + name + ":" + value
+    }
+}
+
+// 依次获取，返回第一个不为空的 key
+func KeyByFirst(funcs ...KeyFunc) KeyFunc {
+    return func(ctx *router.Context) string {
+        for _, fn := range funcs {
+            if key := fn(ctx); key != "" {
+                return key
+            }
+        }
+
+        return ""
+    }
+}
+
+// 解析 key 配置
+// 可用 [ip | route | route-ip | header:<name> | context:<name>]
+// 多个使用 "|" 分隔，返回第一个不为空的 key
+func ParseKeyFunc(spec string) KeyFunc {
+    funcs := make([]KeyFunc, 0)
+
+    for _, item := range strings.Split(spec, "|") {
+        item = strings.TrimSpace(item)
+
+        switch {
+            case item == "route":
+                funcs = append(funcs, KeyByRoute)
+            case item == "route-ip":
+                funcs = append(funcs, KeyByRouteIP)
+            case strings.HasPrefix(item, "header:"):
+                funcs = append(funcs, KeyByHeader(strings.TrimPrefix(item, "header:")))
+            case strings.HasPrefix(item, "context:"):
+                funcs = append(funcs, KeyByContext(strings.TrimPrefix(item, "context:")))
+            case item == "ip":
+                funcs = append(funcs, KeyByIP)
+        }
+    }
+
+    if len(funcs) == 0 {
+        return KeyByIP
+    }
+
+    return KeyByFirst(funcs...)
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+    "math"
+    "strconv"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/ratelimit/interfaces"
+)
+
+type (
+    // 限流结果
+    Result = interfaces.Result
+
+    // 超出限制时处理
+    DeniedFunc = func(*router.Context, Result)
+)
+
+// 构造函数
+func New(limiter interfaces.Limiter, keyFunc KeyFunc) *RateLimit {
+    if keyFunc == nil {
+        keyFunc = KeyByIP
+    }
+
+    return &RateLimit{
+        limiter: limiter,
+        keyFunc: keyFunc,
+    }
+}
+
+/**
+ * 限流
+ *
+ * r := ratelimit.New(memory.NewTokenBucket(60, time.Minute, 0), ratelimit.KeyByIP)
+ * engine.Use(r.Handler())
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type RateLimit struct {
+    // 驱动
+    limiter interfaces.Limiter
+
+    // 限流 key
+    keyFunc KeyFunc
+
+    // key 前缀
+    prefix string
+}
+
+// 设置 key 前缀，用于多个中间件共用驱动
+func (this *RateLimit) WithPrefix(prefix string) *RateLimit {
+    this.prefix = prefix
+
+    return this
+}
+
+// 获取驱动
+func (this *RateLimit) GetLimiter() interfaces.Limiter {
+    return this.limiter
+}
+
+// 获取一次请求，key 为空时不限流
+func (this *RateLimit) Take(ctx *router.Context) (Result, bool) {
+    key := this.keyFunc(ctx)
+    if key == "" {
+        return Result{Allowed: true}, false
+    }
+
+    if this.prefix != "" {
+        key = this.prefix + ":" + key
+    }
+
+    result, err := this.limiter.Take(key)
+    if err != nil {
+        // 存储出错时不限制请求，记录错误方便排查
+        logger.WithContext(ctx.Request.Context()).Error("[ratelimit]" + err.Error())
+
+        return Result{Allowed: true}, false
+    }
+
+    return result, true
+}
+
+// 中间件
+func (this *RateLimit) Handler(denied ...DeniedFunc) router.HandlerFunc {
+    onDenied := DefaultDenied
+    if len(denied) > 0 && denied[0] != nil {
+        onDenied = denied[0]
+    }
+
+    return func(ctx *router.Context) {
+        result, ok := this.Take(ctx)
+        if !ok {
+            ctx.Next()
+            return
+        }
+
+        SetHeaders(ctx, result)
+
+        if !result.Allowed {
+            onDenied(ctx, result)
+            ctx.Abort()
+            return
+        }
+
+        ctx.Next()
+    }
+}
+
+// 设置 RateLimit-* 响应头
+func SetHeaders(ctx *router.Context, result Result) {
+    reset := strconv.Itoa(int(math.Ceil(result.Reset.Seconds())))
+
+    ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+    ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+    ctx.Header("RateLimit-Reset", reset)
+
+    if !result.Allowed {
+        ctx.Header("Retry-After", reset)
+    }
+}
+
+// 默认超出限制处理
+func DefaultDenied(ctx *router.Context, result Result) {
+    ctx.String(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+}
@@ -38,6 +38,9 @@ type RouterInfo struct {
 
     // 别名
     Name string
+
+    // 中间件
+    Middlewares HandlersChain
 }
 
 // 存储别名
@@ -78,8 +81,8 @@ func (this *RouteName) SetName(name string) *RouteName {
     route := NewRoute().GetLastRoute()
 
     this.routes[name] = RouterInfo{
-        route,
-        name,
+        RouteInfo: route,
+        Name:      name,
     }
 
     return this
@@ -87,14 +90,44 @@ func (this *RouteName) SetName(name string) *RouteName {
 
 // 获取全部
 func (this *RouteName) GetRoutes() RouterInfoMap {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
     return this.routes
 }
 
 // 获取单个
 func (this *RouteName) GetRoute(name string) RouterInfo {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
     if route, ok := this.routes[name]; ok {
         return route
     }
 
     return RouterInfo{}
 }
+
+// 是否存在
+func (this *RouteName) HasRoute(name string) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    _, ok := this.routes[name]
+
+    return ok
+}
+
+// 根据请求方式和路径获取
+func (this *RouteName) FindRoute(method string, path string) (RouterInfo, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    for _, route := range this.routes {
+        if route.Method == method && route.Path == path {
+            return route, true
+        }
+    }
+
+    return RouterInfo{}, false
+}
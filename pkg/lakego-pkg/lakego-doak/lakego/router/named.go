@@ -0,0 +1,155 @@
+package router
+
+import (
+    "path"
+    "reflect"
+    "runtime"
+    "strings"
+    "net/http"
+)
+
+// 带名称注册路由
+func Named(group IRouter, name string) *NamedRoute {
+    return &NamedRoute{
+        group: group,
+        name:  name,
+    }
+}
+
+/**
+ * 带名称注册路由
+ *
+ * router.Named(engine, "admin.attachment.download").
+ *     GET("/attachment/download/:code", attachmentController.Download)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type NamedRoute struct {
+    // 路由分组
+    group IRouter
+
+    // 名称
+    name string
+}
+
+// 注册
+func (this *NamedRoute) Handle(method string, relativePath string, handlers ...HandlerFunc) IRoutes {
+    routes := this.group.Handle(method, relativePath, handlers...)
+
+    chain := make(HandlersChain, 0)
+    chain = append(chain, groupHandlers(this.group)...)
+    chain = append(chain, handlers...)
+
+    route := RouteInfo{
+        Method: method,
+        Path:   joinPaths(groupBasePath(this.group), relativePath),
+    }
+
+    if len(handlers) > 0 {
+        route.HandlerFunc = handlers[len(handlers) - 1]
+        route.Handler = HandlerName(route.HandlerFunc)
+    }
+
+    NewName().SetRouteName(this.name, RouterInfo{
+        RouteInfo:   route,
+        Name:        this.name,
+        Middlewares: chain,
+    })
+
+    return routes
+}
+
+// GET
+func (this *NamedRoute) GET(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodGet, relativePath, handlers...)
+}
+
+// POST
+func (this *NamedRoute) POST(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodPost, relativePath, handlers...)
+}
+
+// PUT
+func (this *NamedRoute) PUT(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodPut, relativePath, handlers...)
+}
+
+// PATCH
+func (this *NamedRoute) PATCH(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodPatch, relativePath, handlers...)
+}
+
+// DELETE
+func (this *NamedRoute) DELETE(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodDelete, relativePath, handlers...)
+}
+
+// OPTIONS
+func (this *NamedRoute) OPTIONS(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodOptions, relativePath, handlers...)
+}
+
+// HEAD
+func (this *NamedRoute) HEAD(relativePath string, handlers ...HandlerFunc) IRoutes {
+    return this.Handle(http.MethodHead, relativePath, handlers...)
+}
+
+// 获取处理函数名称，已注册别名的中间件返回别名
+func HandlerName(handler HandlerFunc) string {
+    if handler == nil {
+        return ""
+    }
+
+    pointer := reflect.ValueOf(handler).Pointer()
+
+    for name, middleware := range InstanceMiddleware().GetAlias().GetAll() {
+        if fn, ok := middleware.(HandlerFunc); ok && fn != nil {
+            if reflect.ValueOf(fn).Pointer() == pointer {
+                return name
+            }
+        }
+    }
+
+    fn := runtime.FuncForPC(pointer)
+    if fn == nil {
+        return ""
+    }
+
+    return fn.Name()
+}
+
+// 分组前缀
+func groupBasePath(group IRouter) string {
+    if g, ok := group.(interface{ BasePath() string }); ok {
+        return g.BasePath()
+    }
+
+    return "/"
+}
+
+// 分组中间件
+func groupHandlers(group IRouter) HandlersChain {
+    switch g := group.(type) {
+        case *RouterGroup:
+            return g.Handlers
+        case *Engine:
+            return g.RouterGroup.Handlers
+    }
+
+    return nil
+}
+
+// 拼接路径
+func joinPaths(absolutePath, relativePath string) string {
+    if relativePath == "" {
+        return absolutePath
+    }
+
+    finalPath := path.Join(absolutePath, relativePath)
+    if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+        return finalPath + "/"
+    }
+
+    return finalPath
+}
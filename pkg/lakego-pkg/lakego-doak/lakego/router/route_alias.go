@@ -20,6 +20,9 @@ const (
 
     // 测试模式
     TestMode = gin.TestMode
+
+    // 私有错误
+    ErrorTypePrivate = gin.ErrorTypePrivate
 )
 
 // 默认写入
@@ -0,0 +1,291 @@
+package router
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "errors"
+    "strconv"
+    "strings"
+    "net/url"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+)
+
+const (
+    // 签名链接过期时间参数
+    SignatureExpiresKey = "expires"
+
+    // 签名链接签名参数
+    SignatureKey = "signature"
+)
+
+var (
+    ErrRouteNotFound     = errors.New("router: route name not found")
+    ErrSignKeyEmpty      = errors.New("router: sign key is empty")
+    ErrSignatureInvalid  = errors.New("router: signature is invalid")
+    ErrSignatureExpired  = errors.New("router: signature is expired")
+    ErrSignExpireInvalid = errors.New("router: sign expire must be greater than 0")
+)
+
+// 签名密钥
+var signKey []byte
+var signKeyMu sync.RWMutex
+
+// 路由路径解析缓存
+var segmentCache sync.Map
+
+// 路径片段
+type pathSegment struct {
+    // 参数名称，为空时为固定片段
+    param string
+
+    // 固定片段
+    value string
+
+    // 通配参数
+    wildcard bool
+}
+
+// 设置签名密钥
+func WithSignKey(key []byte) {
+    signKeyMu.Lock()
+    defer signKeyMu.Unlock()
+
+    signKey = key
+}
+
+// 生成链接
+// url, err := router.URL("admin.attachment.download", map[string]any{"code": "xxx"}, nil)
+func URL(name string, params map[string]any, query map[string]any) (string, error) {
+    return NewName().URL(name, params, query)
+}
+
+// 生成签名链接
+func SignedURL(name string, params map[string]any, query map[string]any, expire time.Duration) (string, error) {
+    return NewName().SignedURL(name, params, query, expire)
+}
+
+// 视图用生成链接，参数为键值对，非路由参数作为 query
+// {{ route_url("admin.attachment.download", "code", code) }}
+func ViewURL(name string, pairs ...any) (string, error) {
+    params := make(map[string]any)
+    for i := 0; i+1 < len(pairs); i += 2 {
+        params[toString(pairs[i])] = pairs[i+1]
+    }
+
+    return URL(name, params, nil)
+}
+
+// 生成链接
+func (this *RouteName) URL(name string, params map[string]any, query map[string]any) (string, error) {
+    route, ok := this.getRouteInfo(name)
+    if !ok {
+        return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+    }
+
+    segments := parseRoutePath(name, route.Path)
+
+    used := make(map[string]bool)
+
+    var sb strings.Builder
+    for _, segment := range segments {
+        if segment.param == "" {
+            sb.WriteString(segment.value)
+            continue
+        }
+
+        value, ok := params[segment.param]
+        if !ok {
+            if segment.wildcard {
+                continue
+            }
+
+            return "", fmt.Errorf("router: route [%s] missing param [%s]", name, segment.param)
+        }
+
+        used[segment.param] = true
+
+        val := toString(value)
+        if segment.wildcard {
+            sb.WriteString(strings.TrimPrefix(val, "/"))
+        } else {
+            sb.WriteString(url.PathEscape(val))
+        }
+    }
+
+    values := url.Values{}
+
+    // 未使用的路由参数作为 query
+    for k, v := range params {
+        if !used[k] {
+            values.Set(k, toString(v))
+        }
+    }
+
+    for k, v := range query {
+        values.Set(k, toString(v))
+    }
+
+    link := sb.String()
+    if len(values) > 0 {
+        link += "?" + values.Encode()
+    }
+
+    return link, nil
+}
+
+// 生成签名链接
+func (this *RouteName) SignedURL(name string, params map[string]any, query map[string]any, expire time.Duration) (string, error) {
+    link, err := this.URL(name, params, query)
+    if err != nil {
+        return "", err
+    }
+
+    return Sign(link, expire)
+}
+
+// 为链接添加签名，expire 需要大于 0
+func Sign(link string, expire time.Duration) (string, error) {
+    if expire <= 0 {
+        return "", ErrSignExpireInvalid
+    }
+
+    u, err := url.Parse(link)
+    if err != nil {
+        return "", err
+    }
+
+    values := u.Query()
+    values.Del(SignatureKey)
+    values.Del(SignatureExpiresKey)
+
+    expires := time.Now().Add(expire).Unix()
+    values.Set(SignatureExpiresKey, strconv.FormatInt(expires, 10))
+
+    signature, err := makeSignature(u.Path, values)
+    if err != nil {
+        return "", err
+    }
+
+    values.Set(SignatureKey, signature)
+    u.RawQuery = values.Encode()
+
+    return u.String(), nil
+}
+
+// 验证链接签名
+func CheckSignature(u *url.URL) error {
+    values := u.Query()
+
+    signature := values.Get(SignatureKey)
+    if signature == "" {
+        return ErrSignatureInvalid
+    }
+
+    values.Del(SignatureKey)
+
+    check, err := makeSignature(u.Path, values)
+    if err != nil {
+        return err
+    }
+
+    if !hmac.Equal([]byte(signature), []byte(check)) {
+        return ErrSignatureInvalid
+    }
+
+    // 签名链接都需要过期时间
+    timestamp, err := strconv.ParseInt(values.Get(SignatureExpiresKey), 10, 64)
+    if err != nil {
+        return ErrSignatureInvalid
+    }
+
+    if time.Now().Unix() > timestamp {
+        return ErrSignatureExpired
+    }
+
+    return nil
+}
+
+// 验证请求签名
+func HasValidSignature(ctx *Context) bool {
+    return CheckSignature(ctx.Request.URL) == nil
+}
+
+// 生成签名
+func makeSignature(path string, values url.Values) (string, error) {
+    signKeyMu.RLock()
+    key := signKey
+    signKeyMu.RUnlock()
+
+    if len(key) == 0 {
+        return "", ErrSignKeyEmpty
+    }
+
+    h := hmac.New(sha256.New, key)
+    h.Write([]byte(path))
+    h.Write([]byte("?"))
+    h.Write([]byte(values.Encode()))
+
+    return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// 获取路由信息
+func (this *RouteName) getRouteInfo(name string) (RouterInfo, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    route, ok := this.routes[name]
+
+    return route, ok
+}
+
+// 解析路由路径
+func parseRoutePath(name string, routePath string) []pathSegment {
+    cacheKey := name + "|" + routePath
+    if data, ok := segmentCache.Load(cacheKey); ok {
+        return data.([]pathSegment)
+    }
+
+    segments := make([]pathSegment, 0)
+
+    parts := strings.Split(routePath, "/")
+    for i, part := range parts {
+        if i > 0 {
+            segments = append(segments, pathSegment{value: "/"})
+        }
+
+        switch {
+            case strings.HasPrefix(part, ":"):
+                segments = append(segments, pathSegment{
+                    param: part[1:],
+                })
+            case strings.HasPrefix(part, "*"):
+                segments = append(segments, pathSegment{
+                    param:    part[1:],
+                    wildcard: true,
+                })
+            case part != "":
+                segments = append(segments, pathSegment{value: part})
+        }
+    }
+
+    segmentCache.Store(cacheKey, segments)
+
+    return segments
+}
+
+// 转为字符串
+func toString(value any) string {
+    switch v := value.(type) {
+        case string:
+            return v
+        case []byte:
+            return string(v)
+        case fmt.Stringer:
+            return v.String()
+    }
+
+    return fmt.Sprint(value)
+}
@@ -0,0 +1,103 @@
+package secret
+
+import (
+    "regexp"
+    "strings"
+)
+
+// 编辑时明文数据前缀
+const PlainPrefix = "dec:"
+
+var (
+    // 加密数据
+    encryptedPattern = regexp.MustCompile(`enc:v1:[a-z0-9-]+:[0-9a-f]{8}:[A-Za-z0-9_-]+`)
+
+    // 待加密数据，带引号的可包含空格
+    plainPatterns = []*regexp.Regexp{
+        regexp.MustCompile(`"dec:([^"\r\n]*)"`),
+        regexp.MustCompile(`'dec:([^'\r\n]*)'`),
+        regexp.MustCompile(`dec:([^\s"'#]+)`),
+    }
+)
+
+// 加密文件内容中 dec: 开头的数据
+// password: "dec:123456" => password: "enc:v1:..."
+func (this *Secret) EncryptContent(content string) (string, int, error) {
+    count := 0
+
+    var err error
+    for _, pattern := range plainPatterns {
+        content = pattern.ReplaceAllStringFunc(content, func(match string) string {
+            if err != nil {
+                return match
+            }
+
+            sub := pattern.FindStringSubmatch(match)
+
+            var enc string
+            enc, err = this.Encrypt(sub[1])
+            if err != nil {
+                return match
+            }
+
+            count++
+
+            return strings.Replace(match, PlainPrefix + sub[1], enc, 1)
+        })
+
+        if err != nil {
+            return "", 0, err
+        }
+    }
+
+    return content, count, nil
+}
+
+// 解密文件内容中的加密数据，解密后使用 dec: 前缀标记
+func (this *Secret) DecryptContent(content string) (string, int, error) {
+    return this.replaceEncrypted(content, func(value string) (string, error) {
+        plaintext, err := this.Decrypt(value)
+        if err != nil {
+            return "", err
+        }
+
+        return PlainPrefix + plaintext, nil
+    })
+}
+
+// 使用当前密钥重新加密文件内容中的加密数据
+func (this *Secret) RotateContent(content string) (string, int, error) {
+    return this.replaceEncrypted(content, func(value string) (string, error) {
+        return this.Rotate(value)
+    })
+}
+
+// 替换加密数据
+func (this *Secret) replaceEncrypted(content string, fn func(string) (string, error)) (string, int, error) {
+    count := 0
+
+    var err error
+    content = encryptedPattern.ReplaceAllStringFunc(content, func(match string) string {
+        if err != nil {
+            return match
+        }
+
+        var value string
+        value, err = fn(match)
+        if err != nil {
+            return match
+        }
+
+        if value != match {
+            count++
+        }
+
+        return value
+    })
+
+    if err != nil {
+        return "", 0, err
+    }
+
+    return content, count, nil
+}
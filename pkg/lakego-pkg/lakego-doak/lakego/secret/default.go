@@ -0,0 +1,97 @@
+package secret
+
+import (
+    "os"
+    "sync"
+)
+
+// 默认
+var (
+    defaultMu     sync.Mutex
+    defaultSecret *Secret
+    defaultEnv    string
+)
+
+// 使用环境变量配置的默认对象，环境变量变动后重新创建
+func Default() (*Secret, error) {
+    env := os.Getenv(MasterKeyEnv) + "|" + os.Getenv(PreviousKeysEnv) + "|" + os.Getenv(CipherEnv)
+
+    defaultMu.Lock()
+    defer defaultMu.Unlock()
+
+    if defaultSecret != nil && defaultEnv == env {
+        return defaultSecret, nil
+    }
+
+    s, err := FromEnv()
+    if err != nil {
+        return nil, err
+    }
+
+    defaultSecret = s
+    defaultEnv = env
+
+    return s, nil
+}
+
+// 使用默认对象解密，不是加密数据时直接返回
+func Decrypt(value string) (string, error) {
+    if !IsEncrypted(value) {
+        return value, nil
+    }
+
+    s, err := Default()
+    if err != nil {
+        return "", err
+    }
+
+    return s.Decrypt(value)
+}
+
+// 使用默认对象加密
+func Encrypt(plaintext string) (string, error) {
+    s, err := Default()
+    if err != nil {
+        return "", err
+    }
+
+    return s.Encrypt(plaintext)
+}
+
+// 使用默认对象递归解密 map 及 slice 中的数据
+func DecryptAny(value any) (any, error) {
+    if !ContainsEncrypted(value) {
+        return value, nil
+    }
+
+    s, err := Default()
+    if err != nil {
+        return nil, err
+    }
+
+    return s.DecryptAny(value)
+}
+
+// 是否包含加密数据
+func ContainsEncrypted(value any) bool {
+    switch v := value.(type) {
+        case string:
+            return IsEncrypted(v)
+
+        case map[string]any:
+            for _, item := range v {
+                if ContainsEncrypted(item) {
+                    return true
+                }
+            }
+
+        case []any:
+            for _, item := range v {
+                if ContainsEncrypted(item) {
+                    return true
+                }
+            }
+    }
+
+    return false
+}
@@ -0,0 +1,328 @@
+package secret
+
+import (
+    "os"
+    "fmt"
+    "errors"
+    "strings"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/base64"
+
+    "github.com/deatil/go-cryptobin/cryptobin/crypto"
+)
+
+// 加密数据前缀
+const Prefix = "enc:"
+
+// 数据格式版本
+const version = "v1"
+
+// 环境变量
+const (
+    // 主密钥，base64 编码的 32 字节
+    MasterKeyEnv = "LAKEGO_MASTER_KEY"
+
+    // 轮换前的旧密钥，多个用逗号分隔，只用于解密
+    PreviousKeysEnv = "LAKEGO_MASTER_KEY_PREVIOUS"
+
+    // 加密方式 aes-gcm | chacha20-poly1305
+    CipherEnv = "LAKEGO_SECRETS_CIPHER"
+)
+
+// 加密方式
+const (
+    AESGCM           = "aes-gcm"
+    ChaCha20Poly1305 = "chacha20-poly1305"
+)
+
+var (
+    ErrKeyEmpty       = errors.New("secret: master key is empty")
+    ErrKeyInvalid     = errors.New("secret: master key must be 32 bytes")
+    ErrKeyNotFound    = errors.New("secret: no key found for encrypted value")
+    ErrCipherInvalid  = errors.New("secret: cipher is not supported")
+    ErrValueInvalid   = errors.New("secret: encrypted value is invalid")
+    ErrDecryptFailed  = errors.New("secret: decrypt failed")
+)
+
+// 密钥
+type key struct {
+    id  string
+    raw []byte
+}
+
+// 构造函数，第一个密钥用于加密，其他密钥只用于解密
+func New(cipher string, keys ...[]byte) (*Secret, error) {
+    if cipher == "" {
+        cipher = AESGCM
+    }
+
+    if cipher != AESGCM && cipher != ChaCha20Poly1305 {
+        return nil, ErrCipherInvalid
+    }
+
+    if len(keys) == 0 {
+        return nil, ErrKeyEmpty
+    }
+
+    s := &Secret{
+        cipher: cipher,
+    }
+
+    for _, raw := range keys {
+        if len(raw) != 32 {
+            return nil, ErrKeyInvalid
+        }
+
+        s.keys = append(s.keys, key{
+            id:  KeyID(raw),
+            raw: raw,
+        })
+    }
+
+    return s, nil
+}
+
+// 从环境变量创建
+func FromEnv() (*Secret, error) {
+    master := strings.TrimSpace(os.Getenv(MasterKeyEnv))
+    if master == "" {
+        return nil, ErrKeyEmpty
+    }
+
+    encoded := []string{master}
+    for _, k := range strings.Split(os.Getenv(PreviousKeysEnv), ",") {
+        if k = strings.TrimSpace(k); k != "" {
+            encoded = append(encoded, k)
+        }
+    }
+
+    keys := make([][]byte, 0, len(encoded))
+    for _, k := range encoded {
+        raw, err := ParseKey(k)
+        if err != nil {
+            return nil, err
+        }
+
+        keys = append(keys, raw)
+    }
+
+    return New(strings.ToLower(os.Getenv(CipherEnv)), keys...)
+}
+
+/**
+ * 配置加密
+ *
+ * 加密后格式为 enc:v1:{cipher}:{key-id}:{base64(nonce + 密文)}
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Secret struct {
+    // 加密方式
+    cipher string
+
+    // 密钥
+    keys []key
+}
+
+// 加密方式
+func (this *Secret) Cipher() string {
+    return this.cipher
+}
+
+// 当前密钥 ID
+func (this *Secret) KeyID() string {
+    return this.keys[0].id
+}
+
+// 加密
+func (this *Secret) Encrypt(plaintext string) (string, error) {
+    k := this.keys[0]
+
+    nonce := make([]byte, 12)
+    if _, err := rand.Read(nonce); err != nil {
+        return "", err
+    }
+
+    header := strings.Join([]string{version, this.cipher, k.id}, ":")
+
+    ciphertext, err := seal(this.cipher, k.raw, nonce, []byte(plaintext), header)
+    if err != nil {
+        return "", err
+    }
+
+    data := append(nonce, ciphertext...)
+
+    return Prefix + header + ":" + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// 解密
+func (this *Secret) Decrypt(value string) (string, error) {
+    if !IsEncrypted(value) {
+        return value, nil
+    }
+
+    parts := strings.Split(strings.TrimPrefix(value, Prefix), ":")
+    if len(parts) != 4 || parts[0] != version {
+        return "", ErrValueInvalid
+    }
+
+    cipher, kid := parts[1], parts[2]
+
+    data, err := base64.RawURLEncoding.DecodeString(parts[3])
+    if err != nil || len(data) < 12 {
+        return "", ErrValueInvalid
+    }
+
+    for _, k := range this.keys {
+        if k.id != kid {
+            continue
+        }
+
+        header := strings.Join(parts[:3], ":")
+
+        plaintext, err := open(cipher, k.raw, data[:12], data[12:], header)
+        if err != nil {
+            return "", err
+        }
+
+        return string(plaintext), nil
+    }
+
+    return "", ErrKeyNotFound
+}
+
+// 使用当前密钥重新加密，已使用当前密钥及加密方式时不变
+func (this *Secret) Rotate(value string) (string, error) {
+    if !IsEncrypted(value) {
+        return value, nil
+    }
+
+    prefix := Prefix + strings.Join([]string{version, this.cipher, this.KeyID()}, ":") + ":"
+    if strings.HasPrefix(value, prefix) {
+        return value, nil
+    }
+
+    plaintext, err := this.Decrypt(value)
+    if err != nil {
+        return "", err
+    }
+
+    return this.Encrypt(plaintext)
+}
+
+// 递归解密 map 及 slice 中的数据
+func (this *Secret) DecryptAny(value any) (any, error) {
+    switch v := value.(type) {
+        case string:
+            return this.Decrypt(v)
+
+        case map[string]any:
+            data := make(map[string]any, len(v))
+            for k, item := range v {
+                dec, err := this.DecryptAny(item)
+                if err != nil {
+                    return nil, fmt.Errorf("%s: %w", k, err)
+                }
+
+                data[k] = dec
+            }
+
+            return data, nil
+
+        case []any:
+            data := make([]any, 0, len(v))
+            for _, item := range v {
+                dec, err := this.DecryptAny(item)
+                if err != nil {
+                    return nil, err
+                }
+
+                data = append(data, dec)
+            }
+
+            return data, nil
+    }
+
+    return value, nil
+}
+
+// 是否为加密数据
+func IsEncrypted(value string) bool {
+    return strings.HasPrefix(value, Prefix)
+}
+
+// 生成 base64 编码的主密钥
+func GenerateKey() (string, error) {
+    raw := make([]byte, 32)
+    if _, err := rand.Read(raw); err != nil {
+        return "", err
+    }
+
+    return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// 解析 base64 编码的主密钥
+func ParseKey(encoded string) ([]byte, error) {
+    raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+    if err != nil {
+        return nil, ErrKeyInvalid
+    }
+
+    if len(raw) != 32 {
+        return nil, ErrKeyInvalid
+    }
+
+    return raw, nil
+}
+
+// 密钥 ID
+func KeyID(raw []byte) string {
+    sum := sha256.Sum256(raw)
+
+    return hex.EncodeToString(sum[:4])
+}
+
+// 加密
+func seal(cipher string, key, nonce, plaintext []byte, additional string) ([]byte, error) {
+    c := crypto.FromBytes(plaintext).WithKey(key)
+
+    switch cipher {
+        case AESGCM:
+            c = c.Aes().GCM(string(nonce), additional)
+        case ChaCha20Poly1305:
+            c = c.Chacha20poly1305(string(nonce), additional)
+        default:
+            return nil, ErrCipherInvalid
+    }
+
+    c = c.Encrypt()
+    if errs := c.GetErrors(); len(errs) > 0 {
+        return nil, errs[0]
+    }
+
+    return c.ToBytes(), nil
+}
+
+// 解密
+func open(cipher string, key, nonce, ciphertext []byte, additional string) ([]byte, error) {
+    c := crypto.FromBytes(ciphertext).WithKey(key)
+
+    switch cipher {
+        case AESGCM:
+            c = c.Aes().GCM(string(nonce), additional)
+        case ChaCha20Poly1305:
+            c = c.Chacha20poly1305(string(nonce), additional)
+        default:
+            return nil, ErrCipherInvalid
+    }
+
+    c = c.Decrypt()
+    if errs := c.GetErrors(); len(errs) > 0 {
+        return nil, ErrDecryptFailed
+    }
+
+    return c.ToBytes(), nil
+}
@@ -1,16 +1,22 @@
 package service_provider
 
 import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/provider"
 
     // 脚本
+    configCmd "github.com/deatil/lakego-doak/lakego/console/config"
     publishCmd "github.com/deatil/lakego-doak/lakego/console/publish"
+    secretsCmd "github.com/deatil/lakego-doak/lakego/console/secrets"
     storageCmd "github.com/deatil/lakego-doak/lakego/console/storage"
     scheduleCmd "github.com/deatil/lakego-doak/lakego/console/schedule"
 
     // 视图
     "github.com/deatil/lakego-doak/lakego/facade/view"
+    "github.com/deatil/lakego-doak/lakego/facade/lang"
 )
 
 // 构造函数
@@ -35,6 +41,9 @@ func (this *Lakego) Boot() {
 
     // 模板渲染
     this.loadHtmlRender()
+
+    // 视图方法
+    this.loadViewFunc()
 }
 
 /**
@@ -46,6 +55,16 @@ func (this *Lakego) loadCommand() {
 
     // 创建软连接
     this.AddCommand(storageCmd.StorageLinkCmd)
+
+    // 显示配置
+    this.AddCommand(configCmd.ConfigShowCmd)
+
+    // 配置加密
+    this.AddCommand(secretsCmd.SecretsKeyCmd)
+    this.AddCommand(secretsCmd.SecretsEncryptCmd)
+    this.AddCommand(secretsCmd.SecretsDecryptCmd)
+    this.AddCommand(secretsCmd.SecretsEditCmd)
+    this.AddCommand(secretsCmd.SecretsRotateCmd)
 }
 
 // 计划任务
@@ -60,3 +79,31 @@ func (this *Lakego) Schedule(s *schedule.Schedule) {
 func (this *Lakego) loadHtmlRender() {
     this.GetRoute().HTMLRender = view.New().GetRender()
 }
+
+/**
+ * 导入视图方法
+ */
+func (this *Lakego) loadViewFunc() {
+    // 路由链接
+    this.AddViewFunc("route_url", router.ViewURL)
+
+    // 翻译，语言使用模板数据中的请求语言
+    // {{ __ "获取成功" .locale }} 或 {{ __ "获取成功" "en" }}
+    this.AddViewFunc("__", func(key string, args ...any) string {
+        locale := ""
+        replace := map[string]any{}
+
+        for _, arg := range args {
+            switch v := arg.(type) {
+                case string:
+                    locale = v
+                case context.Context:
+                    locale = lang.Locale(v)
+                case map[string]any:
+                    replace = v
+            }
+        }
+
+        return lang.Trans(locale, key, replace)
+    })
+}
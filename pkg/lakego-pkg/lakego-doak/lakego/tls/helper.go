@@ -0,0 +1,78 @@
+package tls
+
+import (
+    "fmt"
+    "strings"
+    crypto_tls "crypto/tls"
+)
+
+// 客户端验证方式
+var clientAuthTypes = map[string]crypto_tls.ClientAuthType{
+    "":                   crypto_tls.NoClientCert,
+    "none":               crypto_tls.NoClientCert,
+    "request":            crypto_tls.RequestClientCert,
+    "require":            crypto_tls.RequireAnyClientCert,
+    "verify-if-given":    crypto_tls.VerifyClientCertIfGiven,
+    "require-and-verify": crypto_tls.RequireAndVerifyClientCert,
+}
+
+// 版本
+var versions = map[string]uint16{
+    "1.0": crypto_tls.VersionTLS10,
+    "1.1": crypto_tls.VersionTLS11,
+    "1.2": crypto_tls.VersionTLS12,
+    "1.3": crypto_tls.VersionTLS13,
+}
+
+// 解析客户端验证方式
+func ParseClientAuth(name string) (crypto_tls.ClientAuthType, error) {
+    name = strings.ToLower(strings.TrimSpace(name))
+
+    if typ, ok := clientAuthTypes[name]; ok {
+        return typ, nil
+    }
+
+    return crypto_tls.NoClientCert, fmt.Errorf("tls: unknown client auth type: %s", name)
+}
+
+// 解析版本，为空返回 0 使用默认
+func ParseVersion(version string) (uint16, error) {
+    version = strings.TrimSpace(version)
+    if version == "" {
+        return 0, nil
+    }
+
+    version = strings.TrimPrefix(strings.ToLower(version), "tls")
+    if v, ok := versions[version]; ok {
+        return v, nil
+    }
+
+    return 0, fmt.Errorf("tls: unknown version: %s", version)
+}
+
+// 解析加密套件名称
+func ParseCipherSuites(names []string) ([]uint16, error) {
+    if len(names) == 0 {
+        return nil, nil
+    }
+
+    suites := make(map[string]uint16)
+    for _, suite := range crypto_tls.CipherSuites() {
+        suites[suite.Name] = suite.ID
+    }
+    for _, suite := range crypto_tls.InsecureCipherSuites() {
+        suites[suite.Name] = suite.ID
+    }
+
+    ids := make([]uint16, 0, len(names))
+    for _, name := range names {
+        id, ok := suites[strings.ToUpper(strings.TrimSpace(name))]
+        if !ok {
+            return nil, fmt.Errorf("tls: unknown cipher suite: %s", name)
+        }
+
+        ids = append(ids, id)
+    }
+
+    return ids, nil
+}
@@ -0,0 +1,97 @@
+package tls
+
+import (
+    "os"
+    "net"
+    "errors"
+    "path/filepath"
+
+    "github.com/deatil/go-cryptobin/cryptobin/ca"
+)
+
+// 过期时间错误
+var ErrInvalidExpire = errors.New("tls: self-signed expire must be greater than 0")
+
+// 生成自签名证书
+// hosts 可为域名或者 IP
+func MakeSelfSignedCert(hosts []string, expire int) (certPEM []byte, keyPEM []byte, err error) {
+    if expire <= 0 {
+        return nil, nil, ErrInvalidExpire
+    }
+
+    var dns []string
+    var ips []net.IP
+
+    for _, host := range hosts {
+        if ip := net.ParseIP(host); ip != nil {
+            ips = append(ips, ip)
+        } else if host != "" {
+            dns = append(dns, host)
+        }
+    }
+
+    commonName := "localhost"
+    if len(dns) > 0 {
+        commonName = dns[0]
+    }
+
+    subject := &ca.CAPkixName{
+        Organization: []string{"lakego-admin"},
+        CommonName:   commonName,
+    }
+
+    key := ca.New().GenerateEcdsaKey("P256")
+
+    cert := key.MakeCert(subject, expire, dns, ips, "ECDSAWithSHA256")
+
+    certData := cert.CreateCert(cert.GetCert())
+    if len(certData.Errors) > 0 {
+        return nil, nil, certData.Error()
+    }
+
+    keyData := key.CreatePrivateKey()
+    if len(keyData.Errors) > 0 {
+        return nil, nil, keyData.Error()
+    }
+
+    return certData.ToKeyBytes(), keyData.ToKeyBytes(), nil
+}
+
+// 证书文件不存在时生成自签名证书
+func EnsureSelfSignedCert(certFile string, keyFile string, hosts []string, expire int) error {
+    if fileExists(certFile) && fileExists(keyFile) {
+        return nil
+    }
+
+    if certFile == "" || keyFile == "" {
+        return errors.New("tls: cert-file and key-file is required")
+    }
+
+    certPEM, keyPEM, err := MakeSelfSignedCert(hosts, expire)
+    if err != nil {
+        return err
+    }
+
+    for _, file := range []string{certFile, keyFile} {
+        if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+            return err
+        }
+    }
+
+    if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+        return err
+    }
+
+    return os.WriteFile(keyFile, keyPEM, 0600)
+}
+
+// 文件是否存在
+func fileExists(file string) bool {
+    if file == "" {
+        return false
+    }
+
+    _, err := os.Stat(file)
+
+    return err == nil
+}
@@ -0,0 +1,250 @@
+package tls
+
+import (
+    "os"
+    "sync"
+    "time"
+    "errors"
+    "crypto/x509"
+    crypto_tls "crypto/tls"
+)
+
+// 配置
+type Options struct {
+    // 证书文件
+    CertFile string
+
+    // 私钥文件
+    KeyFile string
+
+    // 客户端证书 CA 文件
+    ClientCAFile string
+
+    // 客户端验证方式
+    // none | request | require | verify-if-given | require-and-verify
+    ClientAuth string
+
+    // 最低版本 1.0 | 1.1 | 1.2 | 1.3
+    MinVersion string
+
+    // 最高版本
+    MaxVersion string
+
+    // 加密套件名称，为空使用默认
+    CipherSuites []string
+
+    // 证书更新检测间隔，为 0 时不检测
+    ReloadInterval time.Duration
+}
+
+// 构造函数
+func New(opts Options) *TLS {
+    return &TLS{
+        opts: opts,
+        mu:   new(sync.RWMutex),
+    }
+}
+
+/**
+ * TLS 服务配置
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type TLS struct {
+    // 配置
+    opts Options
+
+    // 锁
+    mu *sync.RWMutex
+
+    // 当前证书
+    cert *crypto_tls.Certificate
+
+    // 证书文件修改时间
+    certModTime time.Time
+
+    // 私钥文件修改时间
+    keyModTime time.Time
+
+    // 客户端证书 CA 池
+    caPool *x509.CertPool
+
+    // 客户端证书 CA 文件修改时间
+    caModTime time.Time
+
+    // 最后检测时间
+    checkedAt time.Time
+}
+
+// 生成 tls 配置
+func (this *TLS) Config() (*crypto_tls.Config, error) {
+    if err := this.Reload(); err != nil {
+        return nil, err
+    }
+
+    clientAuth, err := ParseClientAuth(this.opts.ClientAuth)
+    if err != nil {
+        return nil, err
+    }
+
+    minVersion, err := ParseVersion(this.opts.MinVersion)
+    if err != nil {
+        return nil, err
+    }
+
+    maxVersion, err := ParseVersion(this.opts.MaxVersion)
+    if err != nil {
+        return nil, err
+    }
+
+    cipherSuites, err := ParseCipherSuites(this.opts.CipherSuites)
+    if err != nil {
+        return nil, err
+    }
+
+    conf := &crypto_tls.Config{
+        MinVersion:     minVersion,
+        MaxVersion:     maxVersion,
+        CipherSuites:   cipherSuites,
+        ClientAuth:     clientAuth,
+        GetCertificate: this.GetCertificate,
+    }
+
+    if this.opts.ClientCAFile != "" {
+        conf.ClientCAs = this.ClientCAs()
+
+        // 握手时使用最新的 CA 池
+        conf.GetConfigForClient = func(*crypto_tls.ClientHelloInfo) (*crypto_tls.Config, error) {
+            this.check()
+
+            clientConf := conf.Clone()
+            clientConf.GetConfigForClient = nil
+            clientConf.ClientCAs = this.ClientCAs()
+
+            return clientConf, nil
+        }
+    } else if clientAuth >= crypto_tls.VerifyClientCertIfGiven {
+        return nil, errors.New("tls: client-ca-file is required when verify client cert")
+    }
+
+    return conf, nil
+}
+
+// 获取客户端证书 CA 池
+func (this *TLS) ClientCAs() *x509.CertPool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.caPool
+}
+
+// 到达检测间隔时检测文件更新
+func (this *TLS) check() {
+    if this.opts.ReloadInterval <= 0 {
+        return
+    }
+
+    this.mu.RLock()
+    checkedAt := this.checkedAt
+    this.mu.RUnlock()
+
+    if time.Since(checkedAt) >= this.opts.ReloadInterval {
+        // 更新失败时继续使用旧证书
+        this.Reload()
+    }
+}
+
+// 握手时获取证书
+func (this *TLS) GetCertificate(*crypto_tls.ClientHelloInfo) (*crypto_tls.Certificate, error) {
+    this.check()
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.cert == nil {
+        return nil, errors.New("tls: certificate not loaded")
+    }
+
+    return this.cert, nil
+}
+
+// 证书文件有更新时重新导入
+func (this *TLS) Reload() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.checkedAt = time.Now()
+
+    if err := this.reloadClientCAs(); err != nil {
+        return err
+    }
+
+    certInfo, err := os.Stat(this.opts.CertFile)
+    if err != nil {
+        return err
+    }
+
+    keyInfo, err := os.Stat(this.opts.KeyFile)
+    if err != nil {
+        return err
+    }
+
+    if this.cert != nil &&
+        certInfo.ModTime().Equal(this.certModTime) &&
+        keyInfo.ModTime().Equal(this.keyModTime) {
+        return nil
+    }
+
+    cert, err := crypto_tls.LoadX509KeyPair(this.opts.CertFile, this.opts.KeyFile)
+    if err != nil {
+        return err
+    }
+
+    this.cert = &cert
+    this.certModTime = certInfo.ModTime()
+    this.keyModTime = keyInfo.ModTime()
+
+    return nil
+}
+
+// 客户端证书 CA 文件有更新时重新导入
+func (this *TLS) reloadClientCAs() error {
+    if this.opts.ClientCAFile == "" {
+        return nil
+    }
+
+    info, err := os.Stat(this.opts.ClientCAFile)
+    if err != nil {
+        return err
+    }
+
+    if this.caPool != nil && info.ModTime().Equal(this.caModTime) {
+        return nil
+    }
+
+    pool, err := LoadCertPool(this.opts.ClientCAFile)
+    if err != nil {
+        return err
+    }
+
+    this.caPool = pool
+    this.caModTime = info.ModTime()
+
+    return nil
+}
+
+// 导入 CA 证书池
+func LoadCertPool(file string) (*x509.CertPool, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+
+    pool := x509.NewCertPool()
+    if !pool.AppendCertsFromPEM(data) {
+        return nil, errors.New("tls: failed to parse client ca file")
+    }
+
+    return pool, nil
+}
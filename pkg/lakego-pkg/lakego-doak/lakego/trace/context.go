@@ -0,0 +1,88 @@
+package trace
+
+import (
+    "context"
+    "net/http"
+)
+
+type (
+    // 请求ID
+    requestIDKey struct{}
+
+    // 链路节点
+    spanKey struct{}
+)
+
+// 设置请求ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+    return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// 获取请求ID
+func RequestIDFromContext(ctx context.Context) string {
+    if ctx == nil {
+        return ""
+    }
+
+    if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+        return id
+    }
+
+    // gin 的上下文需要从请求中获取
+    if req := requestFromContext(ctx); req != nil {
+        if id, ok := req.Context().Value(requestIDKey{}).(string); ok {
+            return id
+        }
+    }
+
+    return ""
+}
+
+// 设置链路节点
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+    return context.WithValue(ctx, spanKey{}, span)
+}
+
+// 获取链路节点
+func SpanFromContext(ctx context.Context) *Span {
+    if ctx == nil {
+        return nil
+    }
+
+    if span, ok := ctx.Value(spanKey{}).(*Span); ok {
+        return span
+    }
+
+    if req := requestFromContext(ctx); req != nil {
+        if span, ok := req.Context().Value(spanKey{}).(*Span); ok {
+            return span
+        }
+    }
+
+    return nil
+}
+
+// 日志用字段
+func Fields(ctx context.Context) map[string]any {
+    fields := make(map[string]any)
+
+    if id := RequestIDFromContext(ctx); id != "" {
+        fields["request_id"] = id
+    }
+
+    if span := SpanFromContext(ctx); span != nil {
+        fields["trace_id"] = span.SpanContext.TraceID.String()
+        fields["span_id"] = span.SpanContext.SpanID.String()
+    }
+
+    return fields
+}
+
+// gin 的 Context.Value(0) 返回当前请求
+func requestFromContext(ctx context.Context) *http.Request {
+    if req, ok := ctx.Value(0).(*http.Request); ok {
+        return req
+    }
+
+    return nil
+}
@@ -0,0 +1,270 @@
+package otlp
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "bytes"
+    "context"
+    "net/http"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 配置
+type Config struct {
+    // 接收地址，如 http://127.0.0.1:4318/v1/traces
+    Endpoint string
+
+    // 额外请求头
+    Headers map[string]string
+
+    // 服务名称
+    ServiceName string
+
+    // 单次发送数量
+    BatchSize int
+
+    // 发送间隔
+    FlushInterval time.Duration
+
+    // 请求超时
+    Timeout time.Duration
+
+    // 队列长度，队列满时丢弃
+    QueueSize int
+}
+
+// 构造函数
+func New(conf Config) *Exporter {
+    if conf.BatchSize <= 0 {
+        conf.BatchSize = 100
+    }
+
+    if conf.FlushInterval <= 0 {
+        conf.FlushInterval = 5 * time.Second
+    }
+
+    if conf.Timeout <= 0 {
+        conf.Timeout = 10 * time.Second
+    }
+
+    if conf.QueueSize <= 0 {
+        conf.QueueSize = conf.BatchSize * 20
+    }
+
+    e := &Exporter{
+        conf:   conf,
+        client: &http.Client{Timeout: conf.Timeout},
+        queue:  make(chan *trace.Span, conf.QueueSize),
+        done:   make(chan struct{}),
+    }
+
+    e.wg.Add(1)
+    go e.run()
+
+    return e
+}
+
+/**
+ * OTLP/HTTP JSON 导出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Exporter struct {
+    // 配置
+    conf Config
+
+    // 客户端
+    client *http.Client
+
+    // 队列
+    queue chan *trace.Span
+
+    // 关闭
+    done chan struct{}
+    once sync.Once
+    wg   sync.WaitGroup
+}
+
+// 导出节点
+func (this *Exporter) ExportSpan(span *trace.Span) {
+    select {
+        case <-this.done:
+        case this.queue <- span:
+        default:
+            // 队列已满丢弃
+    }
+}
+
+// 关闭并发送剩余数据
+func (this *Exporter) Shutdown(ctx context.Context) error {
+    this.once.Do(func() {
+        close(this.done)
+    })
+
+    finished := make(chan struct{})
+    go func() {
+        this.wg.Wait()
+        close(finished)
+    }()
+
+    select {
+        case <-finished:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 发送循环
+func (this *Exporter) run() {
+    defer this.wg.Done()
+
+    ticker := time.NewTicker(this.conf.FlushInterval)
+    defer ticker.Stop()
+
+    batch := make([]*trace.Span, 0, this.conf.BatchSize)
+
+    flush := func() {
+        if len(batch) == 0 {
+            return
+        }
+
+        this.send(batch)
+        batch = make([]*trace.Span, 0, this.conf.BatchSize)
+    }
+
+    for {
+        select {
+            case span := <-this.queue:
+                batch = append(batch, span)
+                if len(batch) >= this.conf.BatchSize {
+                    flush()
+                }
+            case <-ticker.C:
+                flush()
+            case <-this.done:
+                // 取出剩余数据
+                for {
+                    select {
+                        case span := <-this.queue:
+                            batch = append(batch, span)
+                        default:
+                            flush()
+                            return
+                    }
+                }
+        }
+    }
+}
+
+// 发送
+func (this *Exporter) send(spans []*trace.Span) error {
+    body, err := json.Marshal(this.encode(spans))
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequest(http.MethodPost, this.conf.Endpoint, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+    for k, v := range this.conf.Headers {
+        req.Header.Set(k, v)
+    }
+
+    resp, err := this.client.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("otlp: export failed with status %d", resp.StatusCode)
+    }
+
+    return nil
+}
+
+// 编码为 OTLP JSON 格式
+func (this *Exporter) encode(spans []*trace.Span) map[string]any {
+    items := make([]map[string]any, 0, len(spans))
+
+    for _, span := range spans {
+        item := map[string]any{
+            "traceId":           span.SpanContext.TraceID.String(),
+            "spanId":            span.SpanContext.SpanID.String(),
+            "name":              span.Name,
+            "kind":              int(span.Kind),
+            "startTimeUnixNano": fmt.Sprintf("%d", span.StartTime.UnixNano()),
+            "endTimeUnixNano":   fmt.Sprintf("%d", span.EndTime.UnixNano()),
+            "attributes":        encodeAttributes(span.Attributes),
+            "status": map[string]any{
+                "code":    int(span.StatusCode),
+                "message": span.StatusMessage,
+            },
+        }
+
+        if span.Parent.IsValid() {
+            item["parentSpanId"] = span.Parent.SpanID.String()
+        }
+
+        items = append(items, item)
+    }
+
+    return map[string]any{
+        "resourceSpans": []any{
+            map[string]any{
+                "resource": map[string]any{
+                    "attributes": encodeAttributes(map[string]any{
+                        "service.name": this.conf.ServiceName,
+                    }),
+                },
+                "scopeSpans": []any{
+                    map[string]any{
+                        "scope": map[string]any{
+                            "name": "lakego",
+                        },
+                        "spans": items,
+                    },
+                },
+            },
+        },
+    }
+}
+
+// 编码属性
+func encodeAttributes(attrs map[string]any) []any {
+    data := make([]any, 0, len(attrs))
+
+    for k, v := range attrs {
+        var value map[string]any
+
+        switch val := v.(type) {
+            case string:
+                value = map[string]any{"stringValue": val}
+            case bool:
+                value = map[string]any{"boolValue": val}
+            case int:
+                value = map[string]any{"intValue": fmt.Sprintf("%d", val)}
+            case int64:
+                value = map[string]any{"intValue": fmt.Sprintf("%d", val)}
+            case float64:
+                value = map[string]any{"doubleValue": val}
+            default:
+                value = map[string]any{"stringValue": fmt.Sprint(val)}
+        }
+
+        data = append(data, map[string]any{
+            "key":   k,
+            "value": value,
+        })
+    }
+
+    return data
+}
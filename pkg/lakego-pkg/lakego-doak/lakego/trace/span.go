@@ -0,0 +1,132 @@
+package trace
+
+import (
+    "sync"
+    "time"
+)
+
+// 节点类型
+type SpanKind int
+
+const (
+    SpanKindInternal SpanKind = 1
+    SpanKindServer   SpanKind = 2
+    SpanKindClient   SpanKind = 3
+)
+
+// 状态
+type StatusCode int
+
+const (
+    StatusUnset StatusCode = 0
+    StatusOk    StatusCode = 1
+    StatusError StatusCode = 2
+)
+
+/**
+ * 链路节点
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Span struct {
+    // 锁
+    mu sync.Mutex
+
+    // 名称
+    Name string
+
+    // 类型
+    Kind SpanKind
+
+    // 当前节点
+    SpanContext SpanContext
+
+    // 父级节点
+    Parent SpanContext
+
+    // 开始时间
+    StartTime time.Time
+
+    // 结束时间
+    EndTime time.Time
+
+    // 属性
+    Attributes map[string]any
+
+    // 状态
+    StatusCode StatusCode
+
+    // 状态信息
+    StatusMessage string
+
+    // 所属 tracer
+    tracer *Tracer
+
+    // 是否已结束
+    ended bool
+}
+
+// 设置属性
+func (this *Span) SetAttribute(key string, value any) *Span {
+    if this == nil {
+        return this
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.Attributes[key] = value
+
+    return this
+}
+
+// 设置状态
+func (this *Span) SetStatus(code StatusCode, message string) *Span {
+    if this == nil {
+        return this
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.StatusCode = code
+    this.StatusMessage = message
+
+    return this
+}
+
+// 记录错误
+func (this *Span) RecordError(err error) *Span {
+    if this == nil || err == nil {
+        return this
+    }
+
+    return this.SetStatus(StatusError, err.Error())
+}
+
+// 结束
+func (this *Span) End() {
+    if this == nil {
+        return
+    }
+
+    this.mu.Lock()
+    if this.ended {
+        this.mu.Unlock()
+        return
+    }
+
+    this.ended = true
+    this.EndTime = time.Now()
+    this.mu.Unlock()
+
+    if this.tracer != nil && this.SpanContext.IsSampled() {
+        this.tracer.export(this)
+    }
+}
+
+// 持续时间
+func (this *Span) Duration() time.Duration {
+    return this.EndTime.Sub(this.StartTime)
+}
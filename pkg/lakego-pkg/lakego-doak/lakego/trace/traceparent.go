@@ -0,0 +1,142 @@
+package trace
+
+import (
+    "errors"
+    "strings"
+    "crypto/rand"
+    "encoding/hex"
+)
+
+// 请求头名称
+const TraceparentHeader = "traceparent"
+
+// 采样标识
+const FlagsSampled byte = 0x01
+
+var ErrInvalidTraceparent = errors.New("trace: invalid traceparent")
+
+type (
+    // 链路ID
+    TraceID [16]byte
+
+    // 节点ID
+    SpanID [8]byte
+)
+
+// 是否有效
+func (this TraceID) IsValid() bool {
+    return this != TraceID{}
+}
+
+// 字符串
+func (this TraceID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+// 是否有效
+func (this SpanID) IsValid() bool {
+    return this != SpanID{}
+}
+
+// 字符串
+func (this SpanID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+/**
+ * W3C Trace Context
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type SpanContext struct {
+    // 链路ID
+    TraceID TraceID
+
+    // 节点ID
+    SpanID SpanID
+
+    // 标识
+    Flags byte
+
+    // 是否来自上游
+    Remote bool
+}
+
+// 是否有效
+func (this SpanContext) IsValid() bool {
+    return this.TraceID.IsValid() && this.SpanID.IsValid()
+}
+
+// 是否采样
+func (this SpanContext) IsSampled() bool {
+    return this.Flags & FlagsSampled == FlagsSampled
+}
+
+// 生成 traceparent
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (this SpanContext) Traceparent() string {
+    return "00-" + this.TraceID.String() + "-" + this.SpanID.String() + "-" + hex.EncodeToString([]byte{this.Flags})
+}
+
+// 解析 traceparent
+func ParseTraceparent(value string) (SpanContext, error) {
+    parts := strings.Split(strings.TrimSpace(value), "-")
+    if len(parts) < 4 {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    version := parts[0]
+    if len(version) != 2 || version == "ff" {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    // 00 版本只能有 4 部分
+    if version == "00" && len(parts) != 4 {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    var sc SpanContext
+
+    if !decodeHex(parts[1], sc.TraceID[:]) ||
+        !decodeHex(parts[2], sc.SpanID[:]) {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    var flags [1]byte
+    if !decodeHex(parts[3], flags[:]) {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    sc.Flags = flags[0]
+    sc.Remote = true
+
+    if !sc.IsValid() {
+        return SpanContext{}, ErrInvalidTraceparent
+    }
+
+    return sc, nil
+}
+
+// 生成链路ID
+func NewTraceID() (id TraceID) {
+    rand.Read(id[:])
+    return
+}
+
+// 生成节点ID
+func NewSpanID() (id SpanID) {
+    rand.Read(id[:])
+    return
+}
+
+// 解析小写16进制
+func decodeHex(s string, dst []byte) bool {
+    if len(s) != len(dst) * 2 || strings.ToLower(s) != s {
+        return false
+    }
+
+    _, err := hex.Decode(dst, []byte(s))
+
+    return err == nil
+}
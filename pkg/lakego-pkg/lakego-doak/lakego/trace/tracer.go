@@ -0,0 +1,166 @@
+package trace
+
+import (
+    "sync"
+    "time"
+    "context"
+    "math/rand"
+)
+
+// 默认
+var defaultTracer = NewTracer("lakego", nil)
+var defaultMu sync.RWMutex
+
+/**
+ * 导出接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Exporter interface {
+    // 导出节点，不能阻塞
+    ExportSpan(span *Span)
+
+    // 关闭并发送剩余数据
+    Shutdown(ctx context.Context) error
+}
+
+// 构造函数
+func NewTracer(serviceName string, exporter Exporter) *Tracer {
+    return &Tracer{
+        serviceName: serviceName,
+        exporter:    exporter,
+        sampleRate:  1,
+    }
+}
+
+/**
+ * 链路追踪
+ *
+ * ctx, span := trace.Default().Start(ctx, "query", trace.SpanKindInternal)
+ * defer span.End()
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Tracer struct {
+    // 服务名称
+    serviceName string
+
+    // 导出
+    exporter Exporter
+
+    // 采样率 0-1
+    sampleRate float64
+}
+
+// 设置采样率
+func (this *Tracer) WithSampleRate(rate float64) *Tracer {
+    this.sampleRate = rate
+
+    return this
+}
+
+// 服务名称
+func (this *Tracer) ServiceName() string {
+    return this.serviceName
+}
+
+// 导出
+func (this *Tracer) Exporter() Exporter {
+    return this.exporter
+}
+
+// 开始节点，父级从 ctx 获取
+func (this *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+    var parent SpanContext
+    if span := SpanFromContext(ctx); span != nil {
+        parent = span.SpanContext
+    }
+
+    span := this.StartWithParent(parent, name, kind)
+
+    return ContextWithSpan(ctx, span), span
+}
+
+// 开始节点
+func (this *Tracer) StartWithParent(parent SpanContext, name string, kind SpanKind) *Span {
+    sc := SpanContext{
+        SpanID: NewSpanID(),
+    }
+
+    if parent.IsValid() {
+        sc.TraceID = parent.TraceID
+        sc.Flags = parent.Flags
+    } else {
+        sc.TraceID = NewTraceID()
+        if this.sample() {
+            sc.Flags = FlagsSampled
+        }
+    }
+
+    return &Span{
+        Name:        name,
+        Kind:        kind,
+        SpanContext: sc,
+        Parent:      parent,
+        StartTime:   time.Now(),
+        Attributes:  make(map[string]any),
+        tracer:      this,
+    }
+}
+
+// 关闭
+func (this *Tracer) Shutdown(ctx context.Context) error {
+    if this.exporter == nil {
+        return nil
+    }
+
+    return this.exporter.Shutdown(ctx)
+}
+
+// 导出
+func (this *Tracer) export(span *Span) {
+    if this.exporter != nil {
+        this.exporter.ExportSpan(span)
+    }
+}
+
+// 采样
+func (this *Tracer) sample() bool {
+    if this.sampleRate >= 1 {
+        return true
+    }
+
+    if this.sampleRate <= 0 {
+        return false
+    }
+
+    return rand.Float64() < this.sampleRate
+}
+
+// 设置默认
+func SetDefault(tracer *Tracer) {
+    defaultMu.Lock()
+    defer defaultMu.Unlock()
+
+    defaultTracer = tracer
+}
+
+// 默认
+func Default() *Tracer {
+    defaultMu.RLock()
+    defer defaultMu.RUnlock()
+
+    return defaultTracer
+}
+
+// 使用默认开始节点
+func Start(ctx context.Context, name string, kind ...SpanKind) (context.Context, *Span) {
+    spanKind := SpanKindInternal
+    if len(kind) > 0 {
+        spanKind = kind[0]
+    }
+
+    return Default().Start(ctx, name, spanKind)
+}
@@ -3,12 +3,17 @@ package validate
 import (
     "fmt"
     "strings"
+    "github.com/go-playground/locales/en"
     "github.com/go-playground/locales/zh"
     ut "github.com/go-playground/universal-translator"
     "github.com/go-playground/validator/v10"
+    enTranslations "github.com/go-playground/validator/v10/translations/en"
     zhTranslations "github.com/go-playground/validator/v10/translations/zh"
 )
 
+// 默认翻译语言
+const defaultTrans = "zh"
+
 var CustomValidator *customValidator
 
 // 所有验证器
@@ -21,22 +26,40 @@ func init() {
 
     validations = append(validations,
         // 国内手机号码
-        validationOfRegexp("phone", "^1[0-9]{10}$", "{0} 必须是手机号码"),
+        validationOfRegexp("phone", "^1[0-9]{10}$", map[string]string{
+            "zh": "{0} 必须是手机号码",
+            "en": "{0} must be a valid phone number",
+        }),
 
         // 常规用户名
-        validationOfRegexp("username", "^[a-zA-Z][a-zA-Z0-9_]{4,15}$", "{0} 必须只包含大小写字母, 数字, 下划线, 且长度为 4-15"),
+        validationOfRegexp("username", "^[a-zA-Z][a-zA-Z0-9_]{4,15}$", map[string]string{
+            "zh": "{0} 必须只包含大小写字母, 数字, 下划线, 且长度为 4-15",
+            "en": "{0} must contain only letters, numbers and underscores, with a length of 4-15",
+        }),
 
         // 标准域名
-        validationOfRegexp("domain", "[a-zA-Z0-9][-a-zA-Z0-9]{0,62}(/.[a-zA-Z0-9][-a-zA-Z0-9]{0,62})+/.?", "{0} 必须是标准域名"),
+        validationOfRegexp("domain", "[a-zA-Z0-9][-a-zA-Z0-9]{0,62}(/.[a-zA-Z0-9][-a-zA-Z0-9]{0,62})+/.?", map[string]string{
+            "zh": "{0} 必须是标准域名",
+            "en": "{0} must be a valid domain",
+        }),
 
         // 强密码
-        validationOfRegexp("strong_password", "^[a-zA-Z][a-zA-Z0-9_]{8,}$", "{0} 必须包含写字母和数字, 且长度为 8-16"),
+        validationOfRegexp("strong_password", "^[a-zA-Z][a-zA-Z0-9_]{8,}$", map[string]string{
+            "zh": "{0} 必须包含写字母和数字, 且长度为 8-16",
+            "en": "{0} must contain letters and numbers, with a length of 8-16",
+        }),
 
         // 中国邮政编码
-        validationOfRegexp("cn_postal_code", `[0-8][0-7]\d{4}`, "{0} 必须是中国邮政编码"),
+        validationOfRegexp("cn_postal_code", `[0-8][0-7]\d{4}`, map[string]string{
+            "zh": "{0} 必须是中国邮政编码",
+            "en": "{0} must be a valid China postal code",
+        }),
 
         // 中国大陆身份证号
-        validationOfRegexp("cn_id_number", `^\d{15}|\d{18}$`, "{0} 必须是中国身份证号码"),
+        validationOfRegexp("cn_id_number", `^\d{15}|\d{18}$`, map[string]string{
+            "zh": "{0} 必须是中国身份证号码",
+            "en": "{0} must be a valid China ID number",
+        }),
 
         // Example
 
@@ -80,34 +103,48 @@ func WithValidations(v Validation) {
 func New() (cv *customValidator, err error) {
     v := validator.New()
     local := zh.New()
-    uniTrans := ut.New(local, local)
+    uniTrans := ut.New(local, local, en.New())
 
-    defaultTrans := "zh"
-    translator, _ := uniTrans.GetTranslator(defaultTrans)
+    zhTrans, _ := uniTrans.GetTranslator("zh")
+    enTrans, _ := uniTrans.GetTranslator("en")
 
     // 批量注册参数验证表达式
     for i := range validations {
         validation := validations[i]
-        err = validation.register(v, translator)
+        err = validation.register(v, zhTrans)
+        if err != nil {
+            return
+        }
+
+        err = validation.registerTranslation(v, enTrans)
         if err != nil {
             return
         }
     }
 
     // registerTranslation chinese as default translators for validate.
-    err = zhTranslations.RegisterDefaultTranslations(v, translator)
+    err = zhTranslations.RegisterDefaultTranslations(v, zhTrans)
+    if err != nil {
+        return
+    }
 
+    err = enTranslations.RegisterDefaultTranslations(v, enTrans)
     if err != nil {
         return
     }
+
     cv = &customValidator{
         validate: v,
-        trans:    translator,
+        trans:    zhTrans,
+        uni:      uniTrans,
     }
 
     return
 }
 
+// 验证器
+type Validator = customValidator
+
 /**
  * 自定义验证器
  *
@@ -117,6 +154,32 @@ func New() (cv *customValidator, err error) {
 type customValidator struct {
     validate *validator.Validate
     trans    ut.Translator
+    uni      *ut.UniversalTranslator
+}
+
+/**
+ * 使用指定语言的默认错误提示，如 zh-CN, en-US
+ * 没有对应语言时使用中文
+ */
+func (this *customValidator) WithLocale(locale string) *customValidator {
+    base := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+    base, _, _ = strings.Cut(base, "-")
+
+    trans, found := this.uni.GetTranslator(base)
+    if !found {
+        trans, _ = this.uni.GetTranslator(defaultTrans)
+    }
+
+    return &customValidator{
+        validate: this.validate,
+        trans:    trans,
+        uni:      this.uni,
+    }
+}
+
+// 当前语言
+func (this *customValidator) Locale() string {
+    return this.trans.Locale()
 }
 
 /**
@@ -165,7 +228,7 @@ func (this *customValidator) Verify(
 
                 result[field + "." + tag] = str
             } else {
-                result[field + "." + tag] = "检测 " + field + " 的值的类型 " + tag + " 错误"
+                result[field + "." + tag] = this.defaultMessage(e, field, tag)
             }
         }
 
@@ -175,6 +238,27 @@ func (this *customValidator) Verify(
     return true, result
 }
 
+// 默认错误提示
+func (this *customValidator) defaultMessage(e validator.FieldError, field string, tag string) string {
+    msg := e.Translate(this.trans)
+
+    // 没有翻译
+    if msg == "" || msg == e.(error).Error() {
+        if this.trans.Locale() == defaultTrans {
+            return "检测 " + field + " 的值的类型 " + tag + " 错误"
+        }
+
+        return "the value of " + field + " failed on the " + tag + " check"
+    }
+
+    // map 验证时没有字段名称
+    if e.Field() == "" {
+        return field + msg
+    }
+
+    return msg
+}
+
 
 /**
  * 字段验证
@@ -236,7 +320,7 @@ func (this *customValidator) ValidateMap(
 
                         result[field + "." + tag] = str
                     } else {
-                        result[field + "." + tag] = "检测 " + field + " 的值的类型 " + tag + " 错误"
+                        result[field + "." + tag] = this.defaultMessage(e, field, tag)
                     }
                 }
             }
@@ -17,6 +17,8 @@ type Validation struct {
     tag string
     // 表示该标 Validate 的描述/解释
     translation string
+    // 各语言的描述，键为语言，如 zh, en
+    translations map[string]string
     // 是否覆盖已存在的验证器
     override bool
     // 用于验证字段的函数
@@ -43,8 +45,18 @@ func (this *Validation) register(v *validator.Validate, t ut.Translator) (err er
     return
 }
 
+// 获取对应语言的描述
+func (this *Validation) getTranslation(locale string) string {
+    if translation, ok := this.translations[locale]; ok {
+        return translation
+    }
+
+    return this.translation
+}
+
 // 以下方法支持
 func (this *Validation) registerTranslation(v *validator.Validate, t ut.Translator) (err error) {
+    translation := this.getTranslation(t.Locale())
 
     if this.translationFn != nil && this.registerFn != nil {
 
@@ -52,21 +64,21 @@ func (this *Validation) registerTranslation(v *validator.Validate, t ut.Translat
 
     } else if this.translationFn != nil && this.registerFn == nil {
 
-        err = v.RegisterTranslation(this.tag, t, registrationFunc(this.tag, this.translation, this.override), this.translationFn)
+        err = v.RegisterTranslation(this.tag, t, registrationFunc(this.tag, translation, this.override), this.translationFn)
 
     } else if this.translationFn == nil && this.registerFn != nil {
 
         err = v.RegisterTranslation(this.tag, t, this.registerFn, translateFunc)
 
     } else {
-        err = v.RegisterTranslation(this.tag, t, registrationFunc(this.tag, this.translation, this.override), translateFunc)
+        err = v.RegisterTranslation(this.tag, t, registrationFunc(this.tag, translation, this.override), translateFunc)
     }
 
     return
 }
 
 // 创建正则验证器
-func validationOfRegexp(tag string, regex string, translation string) Validation {
+func validationOfRegexp(tag string, regex string, translations map[string]string) Validation {
     re, err := regexp.Compile(regex)
     if err != nil {
         log.Print("创建正则自定义验证器: " + tag + " " + regex + " " + err.Error())
@@ -79,9 +91,10 @@ func validationOfRegexp(tag string, regex string, translation string) Validation
     }
 
     return Validation {
-        tag:         tag,
-        translation: translation,
-        validateFn:  fn,
+        tag:          tag,
+        translation:  translations[defaultTrans],
+        translations: translations,
+        validateFn:   fn,
     }
 }
 
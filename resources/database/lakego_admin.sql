@@ -9,7 +9,9 @@ CREATE TABLE `pre__action_log` (
   `time` int(10) DEFAULT NULL COMMENT '记录时间',
   `ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '0',
   `status` char(3) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '输出状态',
-  PRIMARY KEY (`id`)
+  `request_id` varchar(128) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  PRIMARY KEY (`id`),
+  KEY `request_id` (`request_id`)
 ) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='操作日志';
 
 DROP TABLE IF EXISTS `pre__admin`;
@@ -32,8 +34,10 @@ CREATE TABLE `pre__admin` (
   `update_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
   `add_time` int(10) DEFAULT NULL,
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
-  PRIMARY KEY (`id`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+  `deleted_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 DROP TABLE IF EXISTS `pre__attachment`;
 CREATE TABLE `pre__attachment` (
@@ -53,7 +57,9 @@ CREATE TABLE `pre__attachment` (
   `create_time` int(10) NOT NULL DEFAULT '0' COMMENT '上传时间',
   `add_time` int(10) DEFAULT '0' COMMENT '添加时间',
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
-  PRIMARY KEY (`id`)
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
 ) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='附件表';
 
 DROP TABLE IF EXISTS `pre__auth_group`;
@@ -68,15 +74,17 @@ CREATE TABLE `pre__auth_group` (
   `update_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
   `add_time` int(10) DEFAULT NULL,
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
-  PRIMARY KEY (`id`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+  `deleted_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 DROP TABLE IF EXISTS `pre__auth_group_access`;
 CREATE TABLE `pre__auth_group_access` (
   `admin_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '',
   `group_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '',
   PRIMARY KEY (`admin_id`,`group_id`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
 DROP TABLE IF EXISTS `pre__auth_rule`;
 CREATE TABLE `pre__auth_rule` (
@@ -93,16 +101,87 @@ CREATE TABLE `pre__auth_rule` (
   `update_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '0' COMMENT '更新ip',
   `add_time` int(10) DEFAULT '0' COMMENT '添加时间',
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
   PRIMARY KEY (`id`),
+  KEY `deleted_at` (`deleted_at`),
   KEY `module` (`status`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='规则表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='规则表';
 
 DROP TABLE IF EXISTS `pre__auth_rule_access`;
 CREATE TABLE `pre__auth_rule_access` (
   `group_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '0',
   `rule_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '0',
   PRIMARY KEY (`rule_id`,`group_id`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='用户组与权限关联表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='用户组与权限关联表';
+
+DROP TABLE IF EXISTS `pre__ca_authority`;
+CREATE TABLE `pre__ca_authority` (
+  `id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '',
+  `name` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '名称',
+  `subject` varchar(250) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '证书主题',
+  `key_type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '密钥类型',
+  `serial_number` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '证书序列号',
+  `cert` text COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '证书',
+  `private_key` text COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '加密私钥',
+  `crl_number` bigint(20) NOT NULL DEFAULT '0' COMMENT '吊销列表编号',
+  `not_before` int(10) NOT NULL DEFAULT '0' COMMENT '生效时间',
+  `not_after` int(10) NOT NULL DEFAULT '0' COMMENT '过期时间',
+  `add_time` int(10) NOT NULL DEFAULT '0' COMMENT '添加时间',
+  `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '添加ip',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name` (`name`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='证书签发机构';
+
+DROP TABLE IF EXISTS `pre__ca_cert`;
+CREATE TABLE `pre__ca_cert` (
+  `id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '',
+  `authority_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '签发机构id',
+  `serial_number` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '证书序列号',
+  `common_name` varchar(250) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '通用名称',
+  `profile` varchar(30) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '证书模板',
+  `key_type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '密钥类型',
+  `dns_names` text COLLATE utf8mb4_unicode_ci COMMENT 'DNS 名称',
+  `ip_addresses` text COLLATE utf8mb4_unicode_ci COMMENT 'IP 地址',
+  `csr` text COLLATE utf8mb4_unicode_ci COMMENT '证书请求',
+  `cert` text COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '证书',
+  `private_key` text COLLATE utf8mb4_unicode_ci COMMENT '加密私钥，使用证书请求签发时为空',
+  `renew_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '续期的原证书id',
+  `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '状态，1 正常，0 已吊销',
+  `revoke_reason` tinyint(2) NOT NULL DEFAULT '0' COMMENT '吊销原因',
+  `revoke_time` int(10) NOT NULL DEFAULT '0' COMMENT '吊销时间',
+  `not_before` int(10) NOT NULL DEFAULT '0' COMMENT '生效时间',
+  `not_after` int(10) NOT NULL DEFAULT '0' COMMENT '过期时间',
+  `add_time` int(10) NOT NULL DEFAULT '0' COMMENT '签发时间',
+  `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '签发ip',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `serial_number` (`serial_number`),
+  KEY `authority_id` (`authority_id`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='签发的证书';
+
+DROP TABLE IF EXISTS `pre__ca_log`;
+CREATE TABLE `pre__ca_log` (
+  `id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '',
+  `authority_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '签发机构id',
+  `cert_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '证书id',
+  `serial_number` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '证书序列号',
+  `action` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '动作',
+  `info` text COLLATE utf8mb4_unicode_ci COMMENT '内容信息',
+  `admin_id` char(36) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '管理员id，命令行操作时为空',
+  `add_time` int(10) NOT NULL DEFAULT '0' COMMENT '记录时间',
+  `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '记录ip',
+  PRIMARY KEY (`id`),
+  KEY `authority_id` (`authority_id`),
+  KEY `cert_id` (`cert_id`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='证书签发审计日志';
+
+DROP TABLE IF EXISTS `pre__captcha`;
+CREATE TABLE `pre__captcha` (
+  `id` varchar(50) CHARACTER SET utf8mb4 NOT NULL DEFAULT '' COMMENT '验证码id',
+  `value` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '验证码答案',
+  `expire_time` int(10) NOT NULL DEFAULT '0' COMMENT '过期时间',
+  PRIMARY KEY (`id`),
+  KEY `expire_time` (`expire_time`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='验证码表';
 
 DROP TABLE IF EXISTS `pre__rules`;
 CREATE TABLE `pre__rules` (
@@ -122,8 +201,8 @@ CREATE TABLE `pre__rules` (
   KEY `v3` (`v3`(191)),
   KEY `v4` (`v4`(191)),
   KEY `v5` (`v5`(191))
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='casbin权限表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='casbin权限表';
 
-INSERT INTO `pre__admin` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','lakego','8966aff5289184448a004af81373c8f9','gazqzd','lakego','lakego@admin.com','5acfcd19-3a4c-4a28-8386-ae877952fd11','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',0,1,0,'',1652759635,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1'),('642eb7b3-91ea-4808-bba6-f5f10938929a','admin','2a9b6b430ebe2f4257639e62ff9321bb','chNI7n','管理员','lakego-admin@admin.com','1f3cd4fb-f7e4-4b41-8663-167ca23ea5ab','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',1,1,0,'',1675937003,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1');
-INSERT INTO `pre__auth_group` VALUES ('277cbc81-be2c-4fab-9240-5feccb2c024c','0','管理员组','账号管理员组',105,1,1656389180,'127.0.0.1',1621431751,'127.0.0.1'),('bcf40e54-4802-45b4-b3e6-7021ec755083','0','超级管理员组','拥有全部管理权限',95,1,1652586071,'127.0.0.1',1621431751,'127.0.0.1');
+INSERT INTO `pre__admin` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','lakego','8966aff5289184448a004af81373c8f9','gazqzd','lakego','lakego@admin.com','5acfcd19-3a4c-4a28-8386-ae877952fd11','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',0,1,0,'',1652759635,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1',NULL),('642eb7b3-91ea-4808-bba6-f5f10938929a','admin','2a9b6b430ebe2f4257639e62ff9321bb','chNI7n','管理员','lakego-admin@admin.com','1f3cd4fb-f7e4-4b41-8663-167ca23ea5ab','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',1,1,0,'',1675937003,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1',NULL);
+INSERT INTO `pre__auth_group` VALUES ('277cbc81-be2c-4fab-9240-5feccb2c024c','0','管理员组','账号管理员组',105,1,1656389180,'127.0.0.1',1621431751,'127.0.0.1',NULL),('bcf40e54-4802-45b4-b3e6-7021ec755083','0','超级管理员组','拥有全部管理权限',95,1,1652586071,'127.0.0.1',1621431751,'127.0.0.1',NULL);
 INSERT INTO `pre__auth_group_access` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','277cbc81-be2c-4fab-9240-5feccb2c024c'),('642eb7b3-91ea-4808-bba6-f5f10938929a','277cbc81-be2c-4fab-9240-5feccb2c024c');
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- 旧版本数据表升级，只需执行安装版本之后新增的部分，执行前请先备份数据
+-- pre__ 需替换为实际的数据表前缀
+
+-- 操作日志记录请求ID
+ALTER TABLE `pre__action_log`
+  ADD `request_id` varchar(128) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  ADD KEY `request_id` (`request_id`);
+
+-- 回收站软删除字段
+ALTER TABLE `pre__admin`
+  ADD `deleted_at` datetime DEFAULT NULL,
+  ADD KEY `deleted_at` (`deleted_at`);
+ALTER TABLE `pre__attachment`
+  ADD `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
+  ADD KEY `deleted_at` (`deleted_at`);
+ALTER TABLE `pre__auth_group`
+  ADD `deleted_at` datetime DEFAULT NULL,
+  ADD KEY `deleted_at` (`deleted_at`);
+ALTER TABLE `pre__auth_rule`
+  ADD `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
+  ADD KEY `deleted_at` (`deleted_at`);
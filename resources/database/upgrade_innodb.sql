@@ -0,0 +1,10 @@
+-- 旧版本数据表升级为 InnoDB，批量操作及权限包导入需要事务支持
+-- MyISAM 不支持事务，失败时不能回滚，执行前请先备份数据
+-- pre__ 需替换为实际的数据表前缀
+
+ALTER TABLE `pre__admin` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_group` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_group_access` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_rule` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_rule_access` ENGINE=InnoDB;
+ALTER TABLE `pre__rules` ENGINE=InnoDB;